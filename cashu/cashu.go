@@ -470,6 +470,12 @@ func (t TokenV4) Serialize() (string, error) {
 
 type CashuErrCode int
 
+// Error makes the codes usable as sentinel errors. An Error matches
+// its code with errors.Is (i.e errors.Is(err, cashu.ProofAlreadyUsedErrCode))
+func (c CashuErrCode) Error() string {
+	return fmt.Sprintf("cashu error code %d", int(c))
+}
+
 // Error represents an error to be returned by the mint
 type Error struct {
 	Detail string       `json:"detail"`
@@ -484,6 +490,11 @@ func (e Error) Error() string {
 	return e.Detail
 }
 
+func (e Error) Is(target error) bool {
+	code, ok := target.(CashuErrCode)
+	return ok && code == e.Code
+}
+
 // Common error codes
 const (
 	StandardErrCode CashuErrCode = 10000
@@ -547,12 +558,17 @@ var (
 	LightningPaymentFailed       = Error{Detail: "Lightning payment failed", Code: LightningPaymentErrCode}
 	MeltQuoteAlreadyPaid         = Error{Detail: "quote already paid", Code: MeltQuoteAlreadyPaidErrCode}
 	MeltAmountExceededErr        = Error{Detail: "max amount for melting exceeded", Code: AmountLimitExceeded}
+	MeltAmountBelowMinErr        = Error{Detail: "amount below min for melting", Code: AmountLimitExceeded}
 	MeltQuoteForRequestExists    = Error{Detail: "melt quote for payment request already exists", Code: MeltQuoteErrCode}
 	InsufficientProofsAmount     = Error{
 		Detail: "amount of input proofs is below amount needed for transaction",
 		Code:   InsufficientProofAmountErrCode,
 	}
 	InactiveKeysetSignatureRequest = Error{Detail: "requested signature from inactive keyset", Code: InactiveKeysetErrCode}
+	MaxInputsExceededErr           = Error{Detail: "max number of inputs in request exceeded", Code: StandardErrCode}
+	MaxOutputsExceededErr          = Error{Detail: "max number of outputs in request exceeded", Code: StandardErrCode}
+	RequestBodyTooLargeErr         = Error{Detail: "request body is too large", Code: StandardErrCode}
+	MintShuttingDownErr            = Error{Detail: "mint is shutting down", Code: StandardErrCode}
 )
 
 // Given an amount, it returns list of amounts e.g 13 -> [1, 4, 8]
@@ -2,6 +2,8 @@ package cashu
 
 import (
 	"encoding/hex"
+	"errors"
+	"fmt"
 	"math"
 	"math/big"
 	"reflect"
@@ -482,3 +484,20 @@ func TestSerializeTokenV3(t *testing.T) {
 		}
 	}
 }
+
+func TestErrorCode(t *testing.T) {
+	err := fmt.Errorf("could not swap proofs: %w", ProofAlreadyUsedErr)
+	if !errors.Is(err, ProofAlreadyUsedErrCode) {
+		t.Fatalf("expected error to match code '%d'", ProofAlreadyUsedErrCode)
+	}
+	if errors.Is(err, UnknownKeysetErrCode) {
+		t.Fatalf("expected error to not match code '%d'", UnknownKeysetErrCode)
+	}
+	if !errors.Is(BuildCashuError("keyset inactive", InactiveKeysetErrCode), InactiveKeysetErrCode) {
+		t.Fatalf("expected error to match code '%d'", InactiveKeysetErrCode)
+	}
+	// errors with the same code are still different errors
+	if errors.Is(ProofAlreadyUsedErr, ProofPendingErr) {
+		t.Fatal("expected errors with different details to not match")
+	}
+}
@@ -49,9 +49,10 @@ func StringToState(state string) State {
 }
 
 type PostMintQuoteBolt11Request struct {
-	Amount uint64 `json:"amount"`
-	Unit   string `json:"unit"`
-	Pubkey string `json:"pubkey,omitempty"`
+	Amount      uint64 `json:"amount"`
+	Unit        string `json:"unit"`
+	Description string `json:"description,omitempty"`
+	Pubkey      string `json:"pubkey,omitempty"`
 }
 
 type PostMintQuoteBolt11Response struct {
@@ -44,15 +44,25 @@ func StringToState(state string) State {
 }
 
 type PostMeltQuoteBolt11Request struct {
-	Request string               `json:"request"`
-	Unit    string               `json:"unit"`
-	Options map[string]MppOption `json:"options,omitempty"`
+	Request string       `json:"request"`
+	Unit    string       `json:"unit"`
+	Options *MeltOptions `json:"options,omitempty"`
+}
+
+type MeltOptions struct {
+	Mpp        *MppOption        `json:"mpp,omitempty"`
+	Amountless *AmountlessOption `json:"amountless,omitempty"`
 }
 
 type MppOption struct {
 	AmountMsat uint64 `json:"amount"`
 }
 
+// AmountlessOption specifies the amount to pay for an invoice with no amount
+type AmountlessOption struct {
+	AmountMsat uint64 `json:"amount_msat"`
+}
+
 type PostMeltQuoteBolt11Response struct {
 	Quote      string                  `json:"quote"`
 	Request    string                  `json:"request"`
@@ -21,6 +21,15 @@ type MintInfo struct {
 	URLs            []string      `json:"urls,omitempty"`
 	Time            int64         `json:"time,omitempty"`
 	Nuts            Nuts          `json:"nuts"`
+	Limits          *Limits       `json:"limits,omitempty"`
+}
+
+// Limits are the max sizes of a request accepted by the mint.
+// A value of 0 means there is no limit
+type Limits struct {
+	MaxInputs       int   `json:"max_inputs,omitempty"`
+	MaxOutputs      int   `json:"max_outputs,omitempty"`
+	MaxRequestBytes int64 `json:"max_request_bytes,omitempty"`
 }
 
 type ContactInfo struct {
@@ -42,6 +51,7 @@ func (mi *MintInfo) UnmarshalJSON(data []byte) error {
 		URLs            []string        `json:"urls,omitempty"`
 		Time            int64           `json:"time,omitempty"`
 		Nuts            Nuts            `json:"nuts"`
+		Limits          *Limits         `json:"limits,omitempty"`
 	}
 
 	if err := json.Unmarshal(data, &tempInfo); err != nil {
@@ -58,6 +68,7 @@ func (mi *MintInfo) UnmarshalJSON(data []byte) error {
 	mi.URLs = tempInfo.URLs
 	mi.Time = tempInfo.Time
 	mi.Nuts = tempInfo.Nuts
+	mi.Limits = tempInfo.Limits
 	json.Unmarshal(tempInfo.Contact, &mi.Contact)
 
 	return nil
@@ -69,16 +80,30 @@ type NutSetting struct {
 }
 
 type MethodSetting struct {
-	Method    string `json:"method"`
-	Unit      string `json:"unit"`
-	MinAmount uint64 `json:"min_amount,omitempty"`
-	MaxAmount uint64 `json:"max_amount,omitempty"`
+	Method    string         `json:"method"`
+	Unit      string         `json:"unit"`
+	MinAmount uint64         `json:"min_amount,omitempty"`
+	MaxAmount uint64         `json:"max_amount,omitempty"`
+	Options   *MethodOptions `json:"options,omitempty"`
+}
+
+// MethodOptions signals support for optional fields in the requests of a method
+type MethodOptions struct {
+	Description bool `json:"description,omitempty"`
+	Amountless  bool `json:"amountless,omitempty"`
 }
 
 type Supported struct {
 	Supported bool `json:"supported"`
 }
 
+// Nut10Setting signals support for NUT-10 and
+// the kinds of well-known secrets the mint can verify
+type Nut10Setting struct {
+	Supported bool     `json:"supported"`
+	Kinds     []string `json:"kinds,omitempty"`
+}
+
 type Nut19Setting struct {
 	TTL             int              `json:"ttl"`
 	CachedEndpoints []CachedEndpoint `json:"cached_endpoints"`
@@ -95,7 +120,7 @@ type Nuts struct {
 	Nut07 Supported         `json:"7"`
 	Nut08 Supported         `json:"8"`
 	Nut09 Supported         `json:"9"`
-	Nut10 Supported         `json:"10"`
+	Nut10 Nut10Setting      `json:"10"`
 	Nut11 Supported         `json:"11"`
 	Nut12 Supported         `json:"12"`
 	Nut14 Supported         `json:"14"`
@@ -115,7 +140,7 @@ func (nuts *Nuts) UnmarshalJSON(data []byte) error {
 		Nut07 Supported         `json:"7"`
 		Nut08 Supported         `json:"8"`
 		Nut09 Supported         `json:"9"`
-		Nut10 Supported         `json:"10"`
+		Nut10 Nut10Setting      `json:"10"`
 		Nut11 Supported         `json:"11"`
 		Nut12 Supported         `json:"12"`
 		Nut14 Supported         `json:"14"`
@@ -8,23 +8,19 @@ import (
 	"fmt"
 )
 
-type SecretKind int
+// SecretKind is the kind of a well-known secret.
+// Kinds other than the ones defined here can be used
+// by mints and wallets that know how to handle them.
+type SecretKind string
 
 const (
-	AnyoneCanSpend SecretKind = iota
-	P2PK
-	HTLC
+	AnyoneCanSpend SecretKind = "anyonecanspend"
+	P2PK           SecretKind = "P2PK"
+	HTLC           SecretKind = "HTLC"
 )
 
 func (kind SecretKind) String() string {
-	switch kind {
-	case P2PK:
-		return "P2PK"
-	case HTLC:
-		return "HTLC"
-	default:
-		return "anyonecanspend"
-	}
+	return string(kind)
 }
 
 type WellKnownSecret struct {
@@ -67,15 +63,10 @@ func DeserializeSecret(serializedSecret string) (WellKnownSecret, error) {
 	if err := json.Unmarshal(rawJsonSecret[0], &kind); err != nil {
 		return WellKnownSecret{}, errors.New("invalid kind for secret")
 	}
-
-	switch kind {
-	case "P2PK":
-		secret.Kind = P2PK
-	case "HTLC":
-		secret.Kind = HTLC
-	default:
-		secret.Kind = AnyoneCanSpend
+	if len(kind) == 0 {
+		return WellKnownSecret{}, errors.New("invalid secret: empty kind")
 	}
+	secret.Kind = SecretKind(kind)
 
 	if err := json.Unmarshal(rawJsonSecret[1], &secret.Data); err != nil {
 		return WellKnownSecret{}, fmt.Errorf("invalid secret: %v", err)
@@ -99,7 +90,7 @@ func NewSecretFromSpendingCondition(spendingCondition SpendingCondition) (string
 	}
 	nonce := hex.EncodeToString(nonceBytes)
 
-	if spendingCondition.Kind != P2PK && spendingCondition.Kind != HTLC {
+	if len(spendingCondition.Kind) == 0 || spendingCondition.Kind == AnyoneCanSpend {
 		return "", fmt.Errorf("invalid NUT-10 kind '%s' to create new secret", spendingCondition.Kind)
 	}
 
@@ -73,6 +73,13 @@ func TestDeserializeSecret(t *testing.T) {
 			expectedData:  "033281c37677ea273eb7183b783067f5244933ef78d8c3f15b1a77cb246099c26e",
 			expectedTags:  [][]string{},
 		},
+		{
+			jsonSecret:    `["TOLLGATE", {"nonce":"da62796403af76c80cd6ce9153ed3746","data":"session","tags":[["duration","1800"]]}]`,
+			expectedKind:  SecretKind("TOLLGATE"),
+			expectedNonce: "da62796403af76c80cd6ce9153ed3746",
+			expectedData:  "session",
+			expectedTags:  [][]string{{"duration", "1800"}},
+		},
 	}
 
 	for _, test := range tests {
@@ -8,6 +8,7 @@ import (
 	"reflect"
 	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
@@ -220,6 +221,62 @@ func AddSignatureToOutputs(
 	return outputs, nil
 }
 
+// SigAllMessage returns the message to sign for a transaction with SIG_ALL inputs.
+// It is the concatenation of the secret and C of every input followed by the
+// amount and B_ of every output. For melts, the quote id is appended at the end.
+func SigAllMessage(inputs cashu.Proofs, outputs cashu.BlindedMessages, quoteId string) string {
+	var msg strings.Builder
+	for _, proof := range inputs {
+		msg.WriteString(proof.Secret)
+		msg.WriteString(proof.C)
+	}
+	for _, output := range outputs {
+		msg.WriteString(strconv.FormatUint(output.Amount, 10))
+		msg.WriteString(output.B_)
+	}
+	msg.WriteString(quoteId)
+	return msg.String()
+}
+
+// AddSigAllSignature signs the SIG_ALL message for the inputs, outputs and quote id
+// and returns a copy of the inputs with the signature added to the witness of the first one.
+// Signatures already in the witness are kept. The quote id is empty for swaps.
+func AddSigAllSignature(
+	inputs cashu.Proofs,
+	outputs cashu.BlindedMessages,
+	quoteId string,
+	signingKey *btcec.PrivateKey,
+) (cashu.Proofs, error) {
+	if len(inputs) == 0 {
+		return nil, cashu.NoProofsProvided
+	}
+
+	hash := sha256.Sum256([]byte(SigAllMessage(inputs, outputs, quoteId)))
+	signature, err := schnorr.Sign(signingKey, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	inputs = slices.Clone(inputs)
+	// witness can be either P2PK or HTLC so only the signatures field is updated
+	witness := make(map[string]any)
+	if len(inputs[0].Witness) > 0 {
+		if err := json.Unmarshal([]byte(inputs[0].Witness), &witness); err != nil {
+			return nil, InvalidWitness
+		}
+	}
+	signatures, _ := witness["signatures"].([]any)
+	witness["signatures"] = append(signatures, hex.EncodeToString(signature.Serialize()))
+
+	witnessBytes, err := json.Marshal(witness)
+	if err != nil {
+		return nil, err
+	}
+	inputs[0].Witness = string(witnessBytes)
+
+	return inputs, nil
+}
+
 // PublicKeys returns a list of public keys that can sign
 // a P2PK or HTLC proof
 func PublicKeys(secret nut10.WellKnownSecret) ([]*btcec.PublicKey, error) {
@@ -1,9 +1,12 @@
 package nut11
 
 import (
+	"crypto/sha256"
 	"encoding/hex"
+	"encoding/json"
 	"testing"
 
+	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut10"
 	"github.com/btcsuite/btcd/btcec/v2"
 )
@@ -94,3 +97,66 @@ func TestCanSign(t *testing.T) {
 		}
 	}
 }
+
+func TestSigAllMessage(t *testing.T) {
+	inputs := cashu.Proofs{
+		{Amount: 2, Secret: "secret1", C: "c1"},
+		{Amount: 8, Secret: "secret2", C: "c2"},
+	}
+	outputs := cashu.BlindedMessages{
+		{Amount: 1, B_: "b1"},
+		{Amount: 16, B_: "b2"},
+	}
+
+	msg := SigAllMessage(inputs, outputs, "quoteid")
+	expected := "secret1c1secret2c21b116b2quoteid"
+	if msg != expected {
+		t.Fatalf("expected message '%v' but got '%v'", expected, msg)
+	}
+
+	msg = SigAllMessage(inputs, nil, "")
+	expected = "secret1c1secret2c2"
+	if msg != expected {
+		t.Fatalf("expected message '%v' but got '%v'", expected, msg)
+	}
+}
+
+func TestAddSigAllSignature(t *testing.T) {
+	privateKey, _ := btcec.NewPrivateKey()
+
+	inputs := cashu.Proofs{
+		{Amount: 2, Secret: "secret1", C: "c1", Witness: `{"preimage":"abcd","signatures":["existing"]}`},
+		{Amount: 8, Secret: "secret2", C: "c2"},
+	}
+	signedInputs, err := AddSigAllSignature(inputs, nil, "quoteid", privateKey)
+	if err != nil {
+		t.Fatalf("unexpected error adding signature: %v", err)
+	}
+	// inputs passed are not modified
+	if inputs[0].Witness != `{"preimage":"abcd","signatures":["existing"]}` {
+		t.Fatalf("expected witness of inputs to not be modified but got '%v'", inputs[0].Witness)
+	}
+	inputs = signedInputs
+
+	var witness struct {
+		Preimage   string   `json:"preimage"`
+		Signatures []string `json:"signatures"`
+	}
+	if err := json.Unmarshal([]byte(inputs[0].Witness), &witness); err != nil {
+		t.Fatalf("invalid witness: %v", err)
+	}
+	if witness.Preimage != "abcd" {
+		t.Fatalf("expected preimage to be kept but got '%v'", witness.Preimage)
+	}
+	if len(witness.Signatures) != 2 || witness.Signatures[0] != "existing" {
+		t.Fatalf("expected existing signature to be kept but got '%v'", witness.Signatures)
+	}
+
+	hash := sha256.Sum256([]byte(SigAllMessage(inputs, nil, "quoteid")))
+	if !HasValidSignatures(hash[:], witness.Signatures, 1, []*btcec.PublicKey{privateKey.PubKey()}) {
+		t.Fatal("expected valid SIG_ALL signature")
+	}
+	if len(inputs[1].Witness) > 0 {
+		t.Fatal("expected signature only in the first input")
+	}
+}
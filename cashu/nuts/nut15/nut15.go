@@ -2,28 +2,27 @@ package nut15
 
 import (
 	"errors"
-	"fmt"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
-	"github.com/Origami74/gonuts-tollgate/wallet/client"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut06"
 )
 
 var (
 	ErrSplitTooShort = errors.New("length of split too short")
 )
 
-// IsMppSupported returns whether the mint supports NUT-15 for the specified unit
-func IsMppSupported(mint string, unit cashu.Unit) (bool, error) {
-	mintInfo, err := client.GetMintInfo(mint)
-	if err != nil {
-		return false, fmt.Errorf("error getting info from mint: %v", err)
+// IsMppSupported returns whether the mint info signals
+// support for NUT-15 for the specified unit
+func IsMppSupported(mintInfo nut06.MintInfo, unit cashu.Unit) bool {
+	if mintInfo.Nuts.Nut15 == nil {
+		return false
 	}
 
 	for _, method := range mintInfo.Nuts.Nut15.Methods {
 		if method.Unit == unit.String() {
-			return true, nil
+			return true
 		}
 	}
 
-	return false, nil
+	return false
 }
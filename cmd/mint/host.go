@@ -0,0 +1,168 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"net"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/mint"
+	"github.com/Origami74/gonuts-tollgate/mint/manager"
+	"github.com/joho/godotenv"
+)
+
+// variables that are not inherited by the tenants from the
+// environment of the host because they need to be different for each mint
+var tenantOnlyVariables = map[string]bool{
+	"MINT_DB_PATH":   true,
+	"MINT_GRPC_PORT": true,
+	"PUBSUB_SOCKET":  true,
+}
+
+// tenantsFromDir reads a tenant config from each .env file in the dir.
+// The name of the file (without the extension) is the name of the tenant.
+// Variables not set in the file are taken from the environment of the host.
+func tenantsFromDir(dir string) ([]mint.TenantConfig, error) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.env"))
+	if err != nil {
+		return nil, err
+	}
+	if len(files) == 0 {
+		return nil, fmt.Errorf("no tenant configs found in '%v'", dir)
+	}
+	sort.Strings(files)
+
+	// the db of each tenant is in its own dir under MINT_DB_PATH
+	basePath := os.Getenv("MINT_DB_PATH")
+	if len(basePath) == 0 {
+		homedir, err := os.UserHomeDir()
+		if err != nil {
+			return nil, err
+		}
+		basePath = filepath.Join(homedir, ".gonuts", "mint")
+	}
+
+	tenants := make([]mint.TenantConfig, 0, len(files))
+	for _, file := range files {
+		name := strings.TrimSuffix(filepath.Base(file), ".env")
+		variables, err := godotenv.Read(file)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config of tenant '%v': %v", name, err)
+		}
+		if _, ok := variables["MINT_DB_PATH"]; !ok {
+			variables["MINT_DB_PATH"] = filepath.Join(basePath, name)
+		}
+
+		lookupEnv := func(key string) (string, bool) {
+			if value, ok := variables[key]; ok {
+				return value, true
+			}
+			if tenantOnlyVariables[key] {
+				return "", false
+			}
+			return os.LookupEnv(key)
+		}
+		config, err := configFromEnv(lookupEnv)
+		if err != nil {
+			return nil, fmt.Errorf("error reading config of tenant '%v': %v", name, err)
+		}
+
+		var hostnames []string
+		for _, hostname := range strings.Split(variables["TENANT_HOSTNAMES"], ",") {
+			if hostname = strings.TrimSpace(hostname); len(hostname) > 0 {
+				hostnames = append(hostnames, hostname)
+			}
+		}
+		pathPrefix := variables["TENANT_PATH_PREFIX"]
+		// if no hostnames or prefix are set, serve the tenant under /<name>
+		if len(hostnames) == 0 && len(pathPrefix) == 0 {
+			pathPrefix = "/" + name
+		}
+
+		tenants = append(tenants, mint.TenantConfig{
+			Name:       name,
+			Hostnames:  hostnames,
+			PathPrefix: pathPrefix,
+			Config:     *config,
+		})
+	}
+	return tenants, nil
+}
+
+// runHost runs the mints of the tenants in the dir in a single process
+func runHost(tenantsDir string) {
+	tenants, err := tenantsFromDir(tenantsDir)
+	if err != nil {
+		log.Fatalf("error reading tenant configs: %v", err)
+	}
+
+	port, err := strconv.Atoi(os.Getenv("MINT_PORT"))
+	if err != nil {
+		port = 3338
+	}
+	var shutdownTimeout time.Duration
+	if shutdownTimeoutEnv, ok := os.LookupEnv("SHUTDOWN_TIMEOUT"); ok {
+		shutdownTimeout, err = time.ParseDuration(shutdownTimeoutEnv)
+		if err != nil || shutdownTimeout < 0 {
+			log.Fatalf("invalid SHUTDOWN_TIMEOUT: %v", shutdownTimeoutEnv)
+		}
+	}
+
+	host, err := mint.LoadHost(mint.HostConfig{Port: port, ShutdownTimeout: shutdownTimeout}, tenants)
+	if err != nil {
+		log.Fatalf("error loading mints: %v", err)
+	}
+
+	// the admin server is set up before listening for signals
+	// so that the shutdown goroutine does not race with its creation
+	var adminServer *manager.Server
+	if strings.ToLower(os.Getenv("ENABLE_ADMIN_SERVER")) == "true" {
+		adminServer, err = manager.SetupHostServer(host.Mints())
+		if err != nil {
+			log.Fatalf("error setting up admin server: %v\n", err)
+		}
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+
+	go func() {
+		<-c
+		if err := host.Shutdown(); err != nil {
+			log.Printf("error shutting down mints: %v", err)
+		}
+		if adminServer != nil {
+			adminServer.Shutdown()
+		}
+	}()
+
+	var wg sync.WaitGroup
+	if adminServer != nil {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := adminServer.Start(); err != nil && !errors.Is(err, net.ErrClosed) {
+				log.Fatalf("error running admin server: %v\n", err)
+			}
+		}()
+	}
+
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		if err := host.Start(); err != nil {
+			log.Fatalf("error running mint host: %v\n", err)
+		}
+	}()
+
+	wg.Wait()
+}
@@ -9,21 +9,33 @@ import (
 	"net"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut02"
+	"github.com/Origami74/gonuts-tollgate/mint"
 	"github.com/Origami74/gonuts-tollgate/mint/manager"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
 	"github.com/urfave/cli/v2"
 )
 
 const (
 	SOCKET_PATH = "/tmp/gonuts/gonuts-admin.sock"
 	KEYSET_FLAG = "keyset"
+	FROM_FLAG   = "from"
+	TO_FLAG     = "to"
+	TENANT_FLAG = "tenant"
 )
 
 func main() {
 	app := &cli.App{
 		Name:  "mint-cli",
 		Usage: "cli to interact with the Gonuts mint",
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  TENANT_FLAG,
+				Usage: "Tenant of the mint when running several mints in one process",
+			},
+		},
 		Commands: []*cli.Command{
 			{
 				Name:  "issued",
@@ -68,6 +80,38 @@ func main() {
 				},
 				Action: rotateKeyset,
 			},
+			{
+				Name:   "quotehistory",
+				Usage:  "Get history of expired quotes removed from the db",
+				Action: quoteHistory,
+			},
+			{
+				Name:  "fees",
+				Usage: "Get fees collected and paid by the mint",
+				Flags: []cli.Flag{
+					&cli.TimestampFlag{
+						Name:   FROM_FLAG,
+						Usage:  "Start date (YYYY-MM-DD) of the period",
+						Layout: time.DateOnly,
+					},
+					&cli.TimestampFlag{
+						Name:   TO_FLAG,
+						Usage:  "End date (YYYY-MM-DD) of the period, inclusive",
+						Layout: time.DateOnly,
+					},
+				},
+				Action: fees,
+			},
+			{
+				Name:   "solvency",
+				Usage:  "Compare ecash in circulation with the lightning backend balance",
+				Action: solvency,
+			},
+			{
+				Name:   "tenants",
+				Usage:  "List the tenants when running several mints in one process",
+				Action: listTenants,
+			},
 		},
 	}
 
@@ -76,7 +120,7 @@ func main() {
 	}
 }
 
-func sendRequest(method string, params []string) (*manager.Response, error) {
+func sendRequest(ctx *cli.Context, method string, params []string) (*manager.Response, error) {
 	conn, err := net.Dial("unix", SOCKET_PATH)
 	if err != nil {
 		return nil, err
@@ -88,6 +132,7 @@ func sendRequest(method string, params []string) (*manager.Response, error) {
 		Method:  method,
 		Params:  params,
 		Id:      rand.Int(),
+		Tenant:  ctx.String(TENANT_FLAG),
 	}
 
 	jsonReq, err := json.Marshal(req)
@@ -100,13 +145,8 @@ func sendRequest(method string, params []string) (*manager.Response, error) {
 		return nil, err
 	}
 
-	buf := make([]byte, 2048)
-	n, err := conn.Read(buf)
-	if err != nil {
-		return nil, err
-	}
 	var resp manager.Response
-	if err := json.Unmarshal(buf[:n], &resp); err != nil {
+	if err := json.NewDecoder(conn).Decode(&resp); err != nil {
 		return nil, err
 	}
 
@@ -124,7 +164,7 @@ func issuedEcash(ctx *cli.Context) error {
 		params = []string{keyset}
 	}
 
-	resp, err := sendRequest(manager.ISSUED_ECASH_REQUEST, params)
+	resp, err := sendRequest(ctx, manager.ISSUED_ECASH_REQUEST, params)
 	if err != nil {
 		return err
 	}
@@ -159,7 +199,7 @@ func redeemedEcash(ctx *cli.Context) error {
 		params = []string{keyset}
 	}
 
-	resp, err := sendRequest(manager.REDEEMED_ECASH_REQUEST, params)
+	resp, err := sendRequest(ctx, manager.REDEEMED_ECASH_REQUEST, params)
 	if err != nil {
 		return err
 	}
@@ -188,7 +228,7 @@ func redeemedEcash(ctx *cli.Context) error {
 }
 
 func totalBalance(ctx *cli.Context) error {
-	resp, err := sendRequest(manager.TOTAL_BALANCE, nil)
+	resp, err := sendRequest(ctx, manager.TOTAL_BALANCE, nil)
 	if err != nil {
 		return err
 	}
@@ -216,7 +256,7 @@ func totalBalance(ctx *cli.Context) error {
 }
 
 func listKeysets(ctx *cli.Context) error {
-	resp, err := sendRequest(manager.LIST_KEYSETS, nil)
+	resp, err := sendRequest(ctx, manager.LIST_KEYSETS, nil)
 	if err != nil {
 		return err
 	}
@@ -244,7 +284,7 @@ func rotateKeyset(ctx *cli.Context) error {
 	}
 	fee := ctx.Int("fee")
 
-	resp, err := sendRequest(manager.ROTATE_KEYSET, []string{strconv.Itoa(fee)})
+	resp, err := sendRequest(ctx, manager.ROTATE_KEYSET, []string{strconv.Itoa(fee)})
 	if err != nil {
 		return err
 	}
@@ -262,3 +302,118 @@ func rotateKeyset(ctx *cli.Context) error {
 
 	return nil
 }
+
+func quoteHistory(ctx *cli.Context) error {
+	resp, err := sendRequest(ctx, manager.QUOTE_HISTORY, nil)
+	if err != nil {
+		return err
+	}
+
+	var history []storage.QuoteHistory
+	if err := json.Unmarshal(resp.Result, &history); err != nil {
+		return err
+	}
+
+	if len(history) == 0 {
+		fmt.Println("No expired quotes have been removed")
+		return nil
+	}
+
+	fmt.Println("Expired quotes removed by day of expiry:")
+	for _, entry := range history {
+		fmt.Printf("\t%v %v quotes: %v (amount: %v)\n", entry.Day, entry.Kind, entry.Count, entry.Amount)
+	}
+
+	return nil
+}
+
+func fees(ctx *cli.Context) error {
+	from := time.Unix(0, 0)
+	if ctx.IsSet(FROM_FLAG) {
+		from = *ctx.Timestamp(FROM_FLAG)
+	}
+	to := time.Now()
+	if ctx.IsSet(TO_FLAG) {
+		// include the whole end day
+		to = ctx.Timestamp(TO_FLAG).AddDate(0, 0, 1).Add(-time.Second)
+	}
+	if to.Before(from) {
+		return errors.New("end of period is before the start")
+	}
+
+	params := []string{strconv.FormatInt(from.Unix(), 10), strconv.FormatInt(to.Unix(), 10)}
+	resp, err := sendRequest(ctx, manager.FEES, params)
+	if err != nil {
+		return err
+	}
+
+	var feesResponse manager.FeesResponse
+	if err := json.Unmarshal(resp.Result, &feesResponse); err != nil {
+		return err
+	}
+
+	operations := make(map[string]int)
+	for _, entry := range feesResponse.Entries {
+		operations[entry.Operation]++
+	}
+
+	fmt.Printf("Fees from %v to %v:\n", from.Format(time.DateTime), to.Format(time.DateTime))
+	fmt.Printf("\tswaps: %v\n", operations[storage.SwapOperation])
+	fmt.Printf("\tmelts: %v\n", operations[storage.MeltOperation])
+	fmt.Printf("\tinternal settlements: %v\n", operations[storage.InternalMeltOperation])
+	fmt.Printf("\nInput fees collected: %v\n", feesResponse.TotalInputFees)
+	fmt.Printf("Lightning fees paid: %v\n", feesResponse.TotalLightningFees)
+	fmt.Printf("Fee reserve kept: %v\n", feesResponse.TotalReserveKept)
+	fmt.Printf("\nProfit: %v\n", feesResponse.Profit)
+
+	return nil
+}
+
+func solvency(ctx *cli.Context) error {
+	resp, err := sendRequest(ctx, manager.SOLVENCY, nil)
+	if err != nil {
+		return err
+	}
+
+	var status mint.SolvencyStatus
+	if err := json.Unmarshal(resp.Result, &status); err != nil {
+		return err
+	}
+
+	fmt.Printf("Ecash in circulation: %v\n", status.Liabilities)
+	fmt.Printf("\nLightning backend balance: %v\n", status.Reserves.Total())
+	fmt.Printf("\tchannels: %v\n", status.Reserves.Channel)
+	fmt.Printf("\ton-chain: %v\n", status.Reserves.OnChain)
+	if status.Liabilities > 0 {
+		fmt.Printf("\nReserve ratio: %.4f\n", status.Ratio)
+	}
+	fmt.Printf("Solvent: %v\n", status.Solvent)
+	if status.MintingDisabled {
+		fmt.Println("\nMinting is disabled until the reserves are above the minimum ratio")
+	}
+
+	return nil
+}
+
+func listTenants(ctx *cli.Context) error {
+	resp, err := sendRequest(ctx, manager.LIST_TENANTS, nil)
+	if err != nil {
+		return err
+	}
+
+	var tenants []string
+	if err := json.Unmarshal(resp.Result, &tenants); err != nil {
+		return err
+	}
+
+	if len(tenants) == 0 {
+		fmt.Println("mint is not running several tenants")
+		return nil
+	}
+	fmt.Println("Tenants: ")
+	for _, tenant := range tenants {
+		fmt.Printf("\t%v\n", tenant)
+	}
+
+	return nil
+}
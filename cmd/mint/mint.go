@@ -14,20 +14,28 @@ import (
 	"strings"
 	"sync"
 	"syscall"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut06"
 	"github.com/Origami74/gonuts-tollgate/mint"
 	"github.com/Origami74/gonuts-tollgate/mint/lightning"
 	"github.com/Origami74/gonuts-tollgate/mint/manager"
+	"github.com/Origami74/gonuts-tollgate/mint/pubsub"
 	"github.com/joho/godotenv"
 	"github.com/lightningnetwork/lnd/macaroons"
 	"google.golang.org/grpc/credentials"
 	"gopkg.in/macaroon.v2"
 )
 
-func configFromEnv() (*mint.Config, error) {
+// configFromEnv reads the mint config from the variables returned by lookupEnv
+func configFromEnv(lookupEnv func(string) (string, bool)) (*mint.Config, error) {
+	getenv := func(key string) string {
+		value, _ := lookupEnv(key)
+		return value
+	}
+
 	var inputFeePpk uint = 0
-	if inputFeeEnv, ok := os.LookupEnv("INPUT_FEE_PPK"); ok {
+	if inputFeeEnv, ok := lookupEnv("INPUT_FEE_PPK"); ok {
 		fee, err := strconv.ParseUint(inputFeeEnv, 10, 16)
 		if err != nil {
 			return nil, fmt.Errorf("invalid INPUT_FEE_PPK: %v", err)
@@ -36,16 +44,24 @@ func configFromEnv() (*mint.Config, error) {
 	}
 
 	rotateKeyset := false
-	if strings.ToLower(os.Getenv("ROTATE_KEYSET")) == "true" {
+	if strings.ToLower(getenv("ROTATE_KEYSET")) == "true" {
 		rotateKeyset = true
 	}
 
-	port, err := strconv.Atoi(os.Getenv("MINT_PORT"))
+	port, err := strconv.Atoi(getenv("MINT_PORT"))
 	if err != nil {
 		port = 3338
 	}
 
-	mintPath := os.Getenv("MINT_DB_PATH")
+	grpcPort := 0
+	if grpcPortEnv, ok := lookupEnv("MINT_GRPC_PORT"); ok {
+		grpcPort, err = strconv.Atoi(grpcPortEnv)
+		if err != nil || grpcPort < 0 {
+			return nil, fmt.Errorf("invalid MINT_GRPC_PORT: %v", grpcPortEnv)
+		}
+	}
+
+	mintPath := getenv("MINT_DB_PATH")
 	// if MINT_DB_PATH is empty, use $HOME/.gonuts/mint
 	if len(mintPath) == 0 {
 		homedir, err := os.UserHomeDir()
@@ -56,7 +72,7 @@ func configFromEnv() (*mint.Config, error) {
 	}
 
 	mintLimits := mint.MintLimits{}
-	if maxBalanceEnv, ok := os.LookupEnv("MAX_BALANCE"); ok {
+	if maxBalanceEnv, ok := lookupEnv("MAX_BALANCE"); ok {
 		maxBalance, err := strconv.ParseUint(maxBalanceEnv, 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("invalid MAX_BALANCE: %v", err)
@@ -64,7 +80,7 @@ func configFromEnv() (*mint.Config, error) {
 		mintLimits.MaxBalance = maxBalance
 	}
 
-	if maxMintEnv, ok := os.LookupEnv("MINTING_MAX_AMOUNT"); ok {
+	if maxMintEnv, ok := lookupEnv("MINTING_MAX_AMOUNT"); ok {
 		maxMint, err := strconv.ParseUint(maxMintEnv, 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("invalid MINTING_MAX_AMOUNT: %v", err)
@@ -72,7 +88,7 @@ func configFromEnv() (*mint.Config, error) {
 		mintLimits.MintingSettings = mint.MintMethodSettings{MaxAmount: maxMint}
 	}
 
-	if maxMeltEnv, ok := os.LookupEnv("MELTING_MAX_AMOUNT"); ok {
+	if maxMeltEnv, ok := lookupEnv("MELTING_MAX_AMOUNT"); ok {
 		maxMelt, err := strconv.ParseUint(maxMeltEnv, 10, 64)
 		if err != nil {
 			return nil, fmt.Errorf("invalid MELTING_MAX_AMOUNT: %v", err)
@@ -80,14 +96,46 @@ func configFromEnv() (*mint.Config, error) {
 		mintLimits.MeltingSettings = mint.MeltMethodSettings{MaxAmount: maxMelt}
 	}
 
+	if maxInputsEnv, ok := lookupEnv("MAX_INPUTS"); ok {
+		maxInputs, err := strconv.Atoi(maxInputsEnv)
+		if err != nil || maxInputs < 0 {
+			return nil, fmt.Errorf("invalid MAX_INPUTS: %v", maxInputsEnv)
+		}
+		mintLimits.MaxInputs = maxInputs
+	}
+
+	if maxOutputsEnv, ok := lookupEnv("MAX_OUTPUTS"); ok {
+		maxOutputs, err := strconv.Atoi(maxOutputsEnv)
+		if err != nil || maxOutputs < 0 {
+			return nil, fmt.Errorf("invalid MAX_OUTPUTS: %v", maxOutputsEnv)
+		}
+		mintLimits.MaxOutputs = maxOutputs
+	}
+
+	if maxRequestBytesEnv, ok := lookupEnv("MAX_REQUEST_BYTES"); ok {
+		maxRequestBytes, err := strconv.ParseInt(maxRequestBytesEnv, 10, 64)
+		if err != nil || maxRequestBytes < 0 {
+			return nil, fmt.Errorf("invalid MAX_REQUEST_BYTES: %v", maxRequestBytesEnv)
+		}
+		mintLimits.MaxRequestBytes = maxRequestBytes
+	}
+
+	if minReserveRatioEnv, ok := lookupEnv("MIN_RESERVE_RATIO"); ok {
+		minReserveRatio, err := strconv.ParseFloat(minReserveRatioEnv, 64)
+		if err != nil || minReserveRatio < 0 {
+			return nil, fmt.Errorf("invalid MIN_RESERVE_RATIO: %v", minReserveRatioEnv)
+		}
+		mintLimits.MinReserveRatio = minReserveRatio
+	}
+
 	mintInfo := mint.MintInfo{
-		Name:            os.Getenv("MINT_NAME"),
-		Description:     os.Getenv("MINT_DESCRIPTION"),
-		LongDescription: os.Getenv("MINT_DESCRIPTION_LONG"),
-		Motd:            os.Getenv("MINT_MOTD"),
+		Name:            getenv("MINT_NAME"),
+		Description:     getenv("MINT_DESCRIPTION"),
+		LongDescription: getenv("MINT_DESCRIPTION_LONG"),
+		Motd:            getenv("MINT_MOTD"),
 	}
 
-	contact := os.Getenv("MINT_CONTACT_INFO")
+	contact := getenv("MINT_CONTACT_INFO")
 	var mintContactInfo []nut06.ContactInfo
 	if len(contact) > 0 {
 		var infoArr [][]string
@@ -102,15 +150,15 @@ func configFromEnv() (*mint.Config, error) {
 	}
 	mintInfo.Contact = mintContactInfo
 
-	if len(os.Getenv("MINT_ICON_URL")) > 0 {
-		iconURL, err := url.Parse(os.Getenv("MINT_ICON_URL"))
+	if len(getenv("MINT_ICON_URL")) > 0 {
+		iconURL, err := url.Parse(getenv("MINT_ICON_URL"))
 		if err != nil {
 			return nil, fmt.Errorf("invalid icon url: %v", err)
 		}
 		mintInfo.IconURL = iconURL.String()
 	}
 
-	urls := os.Getenv("MINT_URLS")
+	urls := getenv("MINT_URLS")
 	if len(urls) > 0 {
 		urlList := []string{}
 		if err := json.Unmarshal([]byte(urls), &urlList); err != nil {
@@ -126,17 +174,17 @@ func configFromEnv() (*mint.Config, error) {
 	}
 
 	var lightningClient lightning.Client
-	switch strings.ToUpper(os.Getenv("LIGHTNING_BACKEND")) {
+	switch strings.ToUpper(getenv("LIGHTNING_BACKEND")) {
 	case "LND":
-		host := os.Getenv("LND_GRPC_HOST")
+		host := getenv("LND_GRPC_HOST")
 		if host == "" {
 			return nil, errors.New("LND_GRPC_HOST cannot be empty")
 		}
-		certPath := os.Getenv("LND_CERT_PATH")
+		certPath := getenv("LND_CERT_PATH")
 		if certPath == "" {
 			return nil, errors.New("LND_CERT_PATH cannot be empty")
 		}
-		macaroonPath := os.Getenv("LND_MACAROON_PATH")
+		macaroonPath := getenv("LND_MACAROON_PATH")
 		if macaroonPath == "" {
 			return nil, errors.New("LND_MACAROON_PATH cannot be empty")
 		}
@@ -171,11 +219,11 @@ func configFromEnv() (*mint.Config, error) {
 		}
 
 	case "CLN":
-		restURL := os.Getenv("CLN_REST_URL")
+		restURL := getenv("CLN_REST_URL")
 		if restURL == "" {
 			return nil, errors.New("CLN_REST_URL cannot be empty")
 		}
-		runePath := os.Getenv("CLN_REST_RUNE_PATH")
+		runePath := getenv("CLN_REST_RUNE_PATH")
 		if runePath == "" {
 			return nil, errors.New("CLN_REST_RUNE_PATH cannot be empty")
 		}
@@ -202,32 +250,123 @@ func configFromEnv() (*mint.Config, error) {
 		return nil, errors.New("invalid lightning backend")
 	}
 
+	var feePolicy *lightning.FeePolicy
+	policy := lightning.DefaultFeePolicy
+	if feePercentEnv, ok := lookupEnv("FEE_PERCENT"); ok {
+		feePercent, err := strconv.ParseFloat(feePercentEnv, 64)
+		if err != nil || feePercent < 0 {
+			return nil, fmt.Errorf("invalid FEE_PERCENT: %v", feePercentEnv)
+		}
+		policy.Percent = feePercent
+		feePolicy = &policy
+	}
+	if feeMinEnv, ok := lookupEnv("FEE_MIN"); ok {
+		feeMin, err := strconv.ParseUint(feeMinEnv, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEE_MIN: %v", err)
+		}
+		policy.MinFee = feeMin
+		feePolicy = &policy
+	}
+	if feeMaxEnv, ok := lookupEnv("FEE_MAX"); ok {
+		feeMax, err := strconv.ParseUint(feeMaxEnv, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid FEE_MAX: %v", err)
+		}
+		policy.MaxFee = feeMax
+		feePolicy = &policy
+	}
+	if strings.ToLower(getenv("FEE_ROUTE_PROBING")) == "true" {
+		policy.RouteProbing = true
+		feePolicy = &policy
+	}
+	if probeMarginEnv, ok := lookupEnv("FEE_PROBE_MARGIN"); ok {
+		probeMargin, err := strconv.ParseFloat(probeMarginEnv, 64)
+		if err != nil || probeMargin < 1 {
+			return nil, fmt.Errorf("invalid FEE_PROBE_MARGIN: %v", probeMarginEnv)
+		}
+		policy.ProbeMargin = probeMargin
+		feePolicy = &policy
+	}
+	if policy.MaxFee > 0 && policy.MinFee > policy.MaxFee {
+		return nil, errors.New("FEE_MIN cannot be greater than FEE_MAX")
+	}
+
+	var quoteRetention time.Duration
+	if quoteRetentionEnv, ok := lookupEnv("QUOTE_RETENTION"); ok {
+		quoteRetention, err = time.ParseDuration(quoteRetentionEnv)
+		if err != nil || quoteRetention < 0 {
+			return nil, fmt.Errorf("invalid QUOTE_RETENTION: %v", quoteRetentionEnv)
+		}
+	}
+
+	concurrency := 0
+	if concurrencyEnv, ok := lookupEnv("CONCURRENCY"); ok {
+		concurrency, err = strconv.Atoi(concurrencyEnv)
+		if err != nil || concurrency < 0 {
+			return nil, fmt.Errorf("invalid CONCURRENCY: %v", concurrencyEnv)
+		}
+	}
+
+	var solvencyCheckInterval time.Duration
+	if solvencyCheckEnv, ok := lookupEnv("SOLVENCY_CHECK_INTERVAL"); ok {
+		solvencyCheckInterval, err = time.ParseDuration(solvencyCheckEnv)
+		if err != nil || solvencyCheckInterval < 0 {
+			return nil, fmt.Errorf("invalid SOLVENCY_CHECK_INTERVAL: %v", solvencyCheckEnv)
+		}
+	}
+
+	var shutdownTimeout time.Duration
+	if shutdownTimeoutEnv, ok := lookupEnv("SHUTDOWN_TIMEOUT"); ok {
+		shutdownTimeout, err = time.ParseDuration(shutdownTimeoutEnv)
+		if err != nil || shutdownTimeout < 0 {
+			return nil, fmt.Errorf("invalid SHUTDOWN_TIMEOUT: %v", shutdownTimeoutEnv)
+		}
+	}
+
 	enableMPP := false
-	if strings.ToLower(os.Getenv("ENABLE_MPP")) == "true" {
+	if strings.ToLower(getenv("ENABLE_MPP")) == "true" {
 		enableMPP = true
 	}
 
 	enableAdminServer := false
-	if strings.ToLower(os.Getenv("ENABLE_ADMIN_SERVER")) == "true" {
+	if strings.ToLower(getenv("ENABLE_ADMIN_SERVER")) == "true" {
 		enableAdminServer = true
 	}
 
+	// instances of the mint sharing the same db send the
+	// notifications to each other through the broker on this socket
+	var pubsubTransport pubsub.Transport
+	if socketPath := getenv("PUBSUB_SOCKET"); len(socketPath) > 0 {
+		pubsubTransport, err = pubsub.NewUnixTransport(socketPath)
+		if err != nil {
+			return nil, fmt.Errorf("error connecting to pubsub broker: %v", err)
+		}
+	}
+
 	logLevel := mint.Info
-	if strings.ToLower(os.Getenv("LOG")) == "debug" {
+	if strings.ToLower(getenv("LOG")) == "debug" {
 		logLevel = mint.Debug
 	}
 
 	return &mint.Config{
-		RotateKeyset:      rotateKeyset,
-		Port:              port,
-		MintPath:          mintPath,
-		InputFeePpk:       inputFeePpk,
-		MintInfo:          mintInfo,
-		Limits:            mintLimits,
-		LightningClient:   lightningClient,
-		EnableMPP:         enableMPP,
-		EnableAdminServer: enableAdminServer,
-		LogLevel:          logLevel,
+		RotateKeyset:          rotateKeyset,
+		Port:                  port,
+		GrpcPort:              grpcPort,
+		MintPath:              mintPath,
+		InputFeePpk:           inputFeePpk,
+		MintInfo:              mintInfo,
+		Limits:                mintLimits,
+		LightningClient:       lightningClient,
+		FeePolicy:             feePolicy,
+		QuoteRetention:        quoteRetention,
+		Concurrency:           concurrency,
+		SolvencyCheckInterval: solvencyCheckInterval,
+		ShutdownTimeout:       shutdownTimeout,
+		EnableMPP:             enableMPP,
+		EnableAdminServer:     enableAdminServer,
+		LogLevel:              logLevel,
+		PubSubTransport:       pubsubTransport,
 	}, nil
 }
 
@@ -235,7 +374,12 @@ func main() {
 	if err := godotenv.Load(); err != nil {
 		log.Fatal("error loading .env file")
 	}
-	mintConfig, err := configFromEnv()
+	if tenantsDir, ok := os.LookupEnv("MINT_TENANTS_DIR"); ok {
+		runHost(tenantsDir)
+		return
+	}
+
+	mintConfig, err := configFromEnv(os.LookupEnv)
 	if err != nil {
 		log.Fatalf("error reading config: %v", err)
 	}
@@ -244,7 +388,12 @@ func main() {
 	if err != nil {
 		log.Fatalf("error loading mint: %v", err)
 	}
-	serverConfig := mint.ServerConfig{Port: mintConfig.Port, MeltTimeout: mintConfig.MeltTimeout}
+	serverConfig := mint.ServerConfig{
+		Port:            mintConfig.Port,
+		GrpcPort:        mintConfig.GrpcPort,
+		MeltTimeout:     mintConfig.MeltTimeout,
+		ShutdownTimeout: mintConfig.ShutdownTimeout,
+	}
 
 	mintServer := mint.SetupMintServer(m, serverConfig)
 
@@ -0,0 +1,36 @@
+// pubsub-broker relays the notifications between instances of the
+// mint running on the same host and sharing the same db.
+// Each instance connects to it by setting PUBSUB_SOCKET to the socket path.
+package main
+
+import (
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/Origami74/gonuts-tollgate/mint/pubsub"
+)
+
+func main() {
+	socketPath := flag.String("socket", "/tmp/gonuts-pubsub.sock", "path of the unix socket to listen on")
+	flag.Parse()
+
+	broker, err := pubsub.NewBroker(*socketPath)
+	if err != nil {
+		log.Fatalf("error starting broker: %v", err)
+	}
+
+	c := make(chan os.Signal, 1)
+	signal.Notify(c, os.Interrupt, syscall.SIGTERM, syscall.SIGQUIT)
+	go func() {
+		<-c
+		broker.Close()
+	}()
+
+	log.Printf("pubsub broker listening on %v", *socketPath)
+	if err := broker.Serve(); err != nil {
+		log.Fatalf("error running broker: %v", err)
+	}
+}
@@ -2,10 +2,13 @@ package main
 
 import (
 	"bufio"
+	"context"
+	"encoding/csv"
 	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"log"
 	"net/url"
 	"os"
@@ -15,6 +18,7 @@ import (
 	"strconv"
 	"strings"
 	"syscall"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
@@ -22,12 +26,13 @@ import (
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut11"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut17"
 	"github.com/Origami74/gonuts-tollgate/wallet"
-	"github.com/Origami74/gonuts-tollgate/wallet/client"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
 	"github.com/Origami74/gonuts-tollgate/wallet/submanager"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/joho/godotenv"
 	decodepay "github.com/nbd-wtf/ln-decodepay"
 	"github.com/urfave/cli/v2"
+	"golang.org/x/term"
 )
 
 var nutw *wallet.Wallet
@@ -55,6 +60,23 @@ func walletConfig() (wallet.Config, error) {
 		mint = "http://127.0.0.1:3338"
 	}
 	config := wallet.Config{WalletPath: walletPath, CurrentMintURL: mint}
+	config.ClientConfig.ProxyURL = os.Getenv("MINT_PROXY")
+	if timeout := os.Getenv("MINT_TIMEOUT"); len(timeout) > 0 {
+		duration, err := time.ParseDuration(timeout)
+		if err != nil {
+			return wallet.Config{}, fmt.Errorf("invalid MINT_TIMEOUT: %v", err)
+		}
+		config.ClientConfig.Timeout = duration
+	}
+
+	switch strings.ToLower(os.Getenv("WALLET_STORAGE")) {
+	case "", "bolt":
+		config.StorageBackend = wallet.BoltStorage
+	case "sqlite":
+		config.StorageBackend = wallet.SQLiteStorage
+	default:
+		return wallet.Config{}, fmt.Errorf("invalid WALLET_STORAGE: %v", os.Getenv("WALLET_STORAGE"))
+	}
 
 	return config, nil
 }
@@ -90,6 +112,12 @@ func setupWallet(ctx *cli.Context) error {
 	if err != nil {
 		printErr(err)
 	}
+	if wallet.IsEncrypted(config.WalletPath) {
+		config.EncryptionPassphrase = readPassphrase("enter passphrase to unlock wallet: ")
+	} else if !wallet.Exists(config.WalletPath) && term.IsTerminal(int(os.Stdin.Fd())) {
+		fmt.Println("creating new wallet")
+		config.MnemonicPassphrase = readMnemonicPassphrase()
+	}
 
 	nutw, err = wallet.LoadWallet(config)
 	if err != nil {
@@ -110,12 +138,15 @@ func main() {
 			payCmd,
 			pendingCmd,
 			quotesCmd,
+			historyCmd,
+			sentCmd,
 			p2pkLockCmd,
 			mnemonicCmd,
 			restoreCmd,
 			currentMintCmd,
 			updateMintCmd,
 			decodeCmd,
+			changePassphraseCmd,
 		},
 	}
 
@@ -242,8 +273,9 @@ func receive(ctx *cli.Context) error {
 }
 
 const (
-	invoiceFlag = "invoice"
-	mintFlag    = "mint"
+	invoiceFlag     = "invoice"
+	mintFlag        = "mint"
+	descriptionFlag = "description"
 )
 
 var mintCmd = &cli.Command{
@@ -260,6 +292,10 @@ var mintCmd = &cli.Command{
 			Name:  mintFlag,
 			Usage: "Specify mint from which to request mint quote",
 		},
+		&cli.StringFlag{
+			Name:  descriptionFlag,
+			Usage: "Description to include in the invoice",
+		},
 	},
 	Action: mint,
 }
@@ -288,7 +324,7 @@ func mint(ctx *cli.Context) error {
 		mint = ctx.String(mintFlag)
 	}
 
-	err = requestMint(amount, mint)
+	err = requestMint(amount, mint, ctx.String(descriptionFlag))
 	if err != nil {
 		printErr(err)
 	}
@@ -296,15 +332,15 @@ func mint(ctx *cli.Context) error {
 	return nil
 }
 
-func requestMint(amount uint64, mintURL string) error {
-	mintResponse, err := nutw.RequestMint(amount, mintURL)
+func requestMint(amount uint64, mintURL, description string) error {
+	mintResponse, err := nutw.RequestMintWithDescription(amount, mintURL, description)
 	if err != nil {
 		return err
 	}
 
 	fmt.Printf("invoice: %v\n\n", mintResponse.Request)
 
-	subMananger, err := submanager.NewSubscriptionManager(mintURL)
+	subMananger, err := submanager.NewSubscriptionManager(context.Background(), nutw.MintClient(mintURL))
 	if err != nil {
 		fmt.Println("after paying the invoice you can redeem the ecash by doing 'nutw mint --invoice [invoice]'")
 		return nil
@@ -393,6 +429,8 @@ const (
 	noFeesFlag       = "no-fees"
 	legacyFlag       = "legacy"
 	includeDLEQFlag  = "include-dleq"
+	memoFlag         = "memo"
+	expiryFlag       = "expiry"
 )
 
 var sendCmd = &cli.Command{
@@ -448,6 +486,14 @@ var sendCmd = &cli.Command{
 			Usage:              "include DLEQ proofs",
 			DisableDefaultText: true,
 		},
+		&cli.StringFlag{
+			Name:  memoFlag,
+			Usage: "memo included in the token and saved with the sent token",
+		},
+		&cli.DurationFlag{
+			Name:  expiryFlag,
+			Usage: "reclaim the token if it has not been claimed after this time (e.g. 24h). Checked by the 'sent' command",
+		},
 	},
 	Action: send,
 }
@@ -527,10 +573,16 @@ func send(ctx *cli.Context) error {
 			}
 		}
 	} else {
-		proofsToSend, err = nutw.Send(sendAmount, selectedMint, includeFees)
+		options := wallet.SendOptions{
+			IncludeFees:  includeFees,
+			Memo:         ctx.String(memoFlag),
+			ReclaimAfter: ctx.Duration(expiryFlag),
+		}
+		sendResult, err := nutw.SendWithOptions(sendAmount, selectedMint, options)
 		if err != nil {
 			printErr(err)
 		}
+		proofsToSend = sendResult.Proofs
 	}
 
 	includeDLEQ := false
@@ -540,12 +592,16 @@ func send(ctx *cli.Context) error {
 
 	var token cashu.Token
 	if ctx.Bool(legacyFlag) {
-		token, _ = cashu.NewTokenV3(proofsToSend, selectedMint, cashu.Sat, includeDLEQ)
+		tokenV3, _ := cashu.NewTokenV3(proofsToSend, selectedMint, cashu.Sat, includeDLEQ)
+		tokenV3.Memo = ctx.String(memoFlag)
+		token = tokenV3
 	} else {
-		token, err = cashu.NewTokenV4(proofsToSend, selectedMint, cashu.Sat, includeDLEQ)
+		tokenV4, err := cashu.NewTokenV4(proofsToSend, selectedMint, cashu.Sat, includeDLEQ)
 		if err != nil {
 			printErr(fmt.Errorf("could not serialize token: %v", err))
 		}
+		tokenV4.Memo = ctx.String(memoFlag)
+		token = tokenV4
 	}
 
 	tokenString, err := token.Serialize()
@@ -559,6 +615,7 @@ func send(ctx *cli.Context) error {
 
 const (
 	multimintFlag = "multimint"
+	amountFlag    = "amount"
 )
 
 var payCmd = &cli.Command{
@@ -570,6 +627,10 @@ var payCmd = &cli.Command{
 			Name:  multimintFlag,
 			Usage: "pay invoice using funds from multiple mints",
 		},
+		&cli.Uint64Flag{
+			Name:  amountFlag,
+			Usage: "amount (in sats) to pay for an invoice with no amount",
+		},
 	},
 	Before: setupWallet,
 	Action: pay,
@@ -588,7 +649,18 @@ func pay(ctx *cli.Context) error {
 		printErr(fmt.Errorf("invalid invoice: %v", err))
 	}
 
+	amount := ctx.Uint64(amountFlag)
+	if bolt11.MSatoshi == 0 && amount == 0 {
+		printErr(errors.New("invoice has no amount. Specify the amount to pay with --amount"))
+	}
+	if bolt11.MSatoshi != 0 && amount > 0 {
+		printErr(errors.New("amount can only be specified for invoices with no amount"))
+	}
+
 	if ctx.Bool(multimintFlag) {
+		if bolt11.MSatoshi == 0 {
+			printErr(errors.New("multimint payment is not supported for invoices with no amount"))
+		}
 		balanceByMints := nutw.GetBalanceByMints()
 		mints := nutw.TrustedMints()
 		slices.Sort(mints)
@@ -675,7 +747,12 @@ func pay(ctx *cli.Context) error {
 	} else {
 		// do regular single mint payment if multimint not set
 		selectedMint := promptMintSelection("pay invoice")
-		meltQuote, err := nutw.RequestMeltQuote(invoice, selectedMint)
+		var meltQuote *nut05.PostMeltQuoteBolt11Response
+		if amount > 0 {
+			meltQuote, err = nutw.RequestAmountlessMeltQuote(invoice, selectedMint, amount)
+		} else {
+			meltQuote, err = nutw.RequestMeltQuote(invoice, selectedMint)
+		}
 		if err != nil {
 			printErr(err)
 		}
@@ -803,6 +880,157 @@ func quotes(ctx *cli.Context) error {
 	return nil
 }
 
+const (
+	typeFlag   = "type"
+	limitFlag  = "limit"
+	offsetFlag = "offset"
+	formatFlag = "format"
+)
+
+var historyCmd = &cli.Command{
+	Name:   "history",
+	Usage:  "list the operations done by the wallet, newest first",
+	Before: setupWallet,
+	Flags: []cli.Flag{
+		&cli.StringSliceFlag{
+			Name:  typeFlag,
+			Usage: "only list operations of type: send, receive, mint, melt or swap",
+		},
+		&cli.StringFlag{
+			Name:  mintFlag,
+			Usage: "only list operations with this mint",
+		},
+		&cli.IntFlag{
+			Name:  limitFlag,
+			Value: 20,
+			Usage: "max number of operations to list. 0 lists all",
+		},
+		&cli.IntFlag{
+			Name:  offsetFlag,
+			Usage: "number of operations to skip",
+		},
+		&cli.StringFlag{
+			Name:  formatFlag,
+			Value: "text",
+			Usage: "output format: text, csv or json",
+		},
+	},
+	Action: history,
+}
+
+func history(ctx *cli.Context) error {
+	filter := storage.HistoryFilter{
+		Mint:   ctx.String(mintFlag),
+		Limit:  ctx.Int(limitFlag),
+		Offset: ctx.Int(offsetFlag),
+	}
+	for _, historyType := range ctx.StringSlice(typeFlag) {
+		t, err := storage.ParseHistoryType(historyType)
+		if err != nil {
+			printErr(err)
+		}
+		filter.Types = append(filter.Types, t)
+	}
+
+	entries := nutw.History(filter)
+	switch ctx.String(formatFlag) {
+	case "json":
+		jsonEntries, err := json.MarshalIndent(entries, "", "  ")
+		if err != nil {
+			printErr(err)
+		}
+		fmt.Println(string(jsonEntries))
+	case "csv":
+		writer := csv.NewWriter(os.Stdout)
+		writer.Write([]string{"id", "type", "mint", "to_mint", "unit", "amount", "fee",
+			"token", "quote_id", "payment_request", "created_at"})
+		for _, entry := range entries {
+			writer.Write([]string{
+				entry.Id,
+				entry.Type.String(),
+				entry.Mint,
+				entry.ToMint,
+				entry.Unit,
+				strconv.FormatUint(entry.Amount, 10),
+				strconv.FormatUint(entry.Fee, 10),
+				entry.Token,
+				entry.QuoteId,
+				entry.PaymentRequest,
+				time.Unix(entry.CreatedAt, 0).UTC().Format(time.RFC3339),
+			})
+		}
+		writer.Flush()
+		if err := writer.Error(); err != nil {
+			printErr(err)
+		}
+	case "text":
+		if len(entries) == 0 {
+			fmt.Println("no history")
+			return nil
+		}
+		for _, entry := range entries {
+			mint := entry.Mint
+			if len(entry.ToMint) > 0 {
+				mint += " -> " + entry.ToMint
+			}
+			fmt.Printf("%v  %-7v %v %v (fee: %v)  %v\n",
+				time.Unix(entry.CreatedAt, 0).Format(time.DateTime),
+				entry.Type, entry.Amount, entry.Unit, entry.Fee, mint)
+		}
+	default:
+		printErr(fmt.Errorf("invalid format '%v'", ctx.String(formatFlag)))
+	}
+
+	return nil
+}
+
+var sentCmd = &cli.Command{
+	Name:   "sent",
+	Usage:  "list the tokens sent and whether they were claimed. Reclaims the ones that expired",
+	Before: setupWallet,
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:  reclaimFlag,
+			Usage: "reclaim the sent token with this id if it has not been claimed",
+		},
+	},
+	Action: sent,
+}
+
+func sent(ctx *cli.Context) error {
+	if ctx.IsSet(reclaimFlag) {
+		amountReclaimed, err := nutw.ReclaimSentToken(ctx.String(reclaimFlag))
+		if err != nil {
+			printErr(err)
+		}
+		fmt.Printf("%v sats reclaimed\n", amountReclaimed)
+		return nil
+	}
+
+	sentTokens, err := nutw.CheckSentTokens()
+	if err != nil {
+		printErr(err)
+	}
+	if len(sentTokens) == 0 {
+		fmt.Println("no tokens sent")
+		return nil
+	}
+
+	for _, sentToken := range sentTokens {
+		fmt.Printf("%v  %-9v %v sats  sent: %v", sentToken.Id, sentToken.State,
+			sentToken.Amount, time.Unix(sentToken.CreatedAt, 0).Format(time.DateTime))
+		if sentToken.Expiry > 0 && sentToken.State == storage.SentTokenPending {
+			fmt.Printf("  expires: %v", time.Unix(sentToken.Expiry, 0).Format(time.DateTime))
+		}
+		if len(sentToken.Memo) > 0 {
+			fmt.Printf("  memo: %v", sentToken.Memo)
+		}
+		fmt.Println()
+	}
+
+	return nil
+}
+
 var p2pkLockCmd = &cli.Command{
 	Name:   "p2pk-lock",
 	Usage:  "Retrieves a public key to which ecash can be locked",
@@ -830,6 +1058,9 @@ var mnemonicCmd = &cli.Command{
 func mnemonic(ctx *cli.Context) error {
 	mnemonic := nutw.Mnemonic()
 	fmt.Printf("mnemonic: %v\n", mnemonic)
+	if nutw.HasMnemonicPassphrase() {
+		fmt.Println("this wallet was created with a BIP39 passphrase. It is needed along with the mnemonic to restore the wallet")
+	}
 	return nil
 }
 
@@ -846,14 +1077,14 @@ func restore(ctx *cli.Context) error {
 	}
 	fmt.Printf("enter mnemonic: ")
 
-	reader := bufio.NewReader(os.Stdin)
-	mnemonic, err := reader.ReadString('\n')
+	mnemonic, err := stdinReader.ReadString('\n')
 	if err != nil {
 		log.Fatal("error reading input, please try again")
 	}
 	mnemonic = mnemonic[:len(mnemonic)-1]
+	config.MnemonicPassphrase = readPassphrase("enter BIP39 passphrase (leave empty if none): ")
 
-	amountRestored, err := wallet.Restore(config.WalletPath, mnemonic, []string{config.CurrentMintURL})
+	amountRestored, err := wallet.Restore(config, mnemonic, []string{config.CurrentMintURL})
 	if err != nil {
 		printErr(fmt.Errorf("error restoring wallet: %v", err))
 	}
@@ -940,7 +1171,7 @@ func updateMintURL(ctx *cli.Context) error {
 		printErr(fmt.Errorf("invalid URL: %v", err))
 	}
 
-	_, err = client.GetMintInfo(newURL)
+	_, err = nutw.MintClient(newURL).GetMintInfo(context.Background())
 	if err != nil {
 		printErr(fmt.Errorf("new provided URL does not point to a valid mint: %v", err))
 	}
@@ -1017,6 +1248,80 @@ func promptMintSelection(action string) string {
 	return selectedMint
 }
 
+var changePassphraseCmd = &cli.Command{
+	Name:   "change-passphrase",
+	Usage:  "Change the passphrase that encrypts the wallet. If the wallet is not encrypted, encrypt it",
+	Action: changePassphrase,
+}
+
+func changePassphrase(ctx *cli.Context) error {
+	config, err := walletConfig()
+	if err != nil {
+		printErr(err)
+	}
+
+	var oldPassphrase string
+	if wallet.IsEncrypted(config.WalletPath) {
+		oldPassphrase = readPassphrase("enter current passphrase: ")
+		config.EncryptionPassphrase = oldPassphrase
+	}
+	nutw, err = wallet.LoadWallet(config)
+	if err != nil {
+		printErr(err)
+	}
+
+	newPassphrase := readPassphrase("enter new passphrase: ")
+	if len(newPassphrase) == 0 {
+		printErr(errors.New("passphrase cannot be empty"))
+	}
+	if readPassphrase("confirm new passphrase: ") != newPassphrase {
+		printErr(errors.New("passphrases do not match"))
+	}
+
+	if err := nutw.ChangePassphrase(oldPassphrase, newPassphrase); err != nil {
+		printErr(fmt.Errorf("error changing passphrase: %v", err))
+	}
+	fmt.Println("passphrase changed")
+	return nil
+}
+
+// readMnemonicPassphrase asks for an optional BIP39 passphrase for a new wallet
+func readMnemonicPassphrase() string {
+	passphrase := readPassphrase("enter BIP39 passphrase for the seed (optional, leave empty for none): ")
+	if len(passphrase) == 0 {
+		return ""
+	}
+	if readPassphrase("confirm BIP39 passphrase: ") != passphrase {
+		printErr(errors.New("passphrases do not match"))
+	}
+	fmt.Println("the BIP39 passphrase is not stored. It will be needed along with the mnemonic to restore the wallet")
+	return passphrase
+}
+
+// stdin is read through a single reader so that input
+// buffered by one prompt is not lost for the next
+var stdinReader = bufio.NewReader(os.Stdin)
+
+// readPassphrase reads a passphrase from stdin without echoing it
+func readPassphrase(prompt string) string {
+	fmt.Print(prompt)
+	if term.IsTerminal(int(os.Stdin.Fd())) {
+		passphrase, err := term.ReadPassword(int(os.Stdin.Fd()))
+		fmt.Println()
+		if err != nil {
+			log.Fatal("error reading input, please try again")
+		}
+		return string(passphrase)
+	}
+
+	passphrase, err := stdinReader.ReadString('\n')
+	// an optional passphrase can be left out of piped input
+	if err != nil && !errors.Is(err, io.EOF) {
+		log.Fatal("error reading input, please try again")
+	}
+	return strings.TrimSuffix(passphrase, "\n")
+}
+
 func printErr(msg error) {
 	fmt.Println(msg.Error())
 	os.Exit(0)
@@ -324,3 +324,44 @@ func BenchmarkE2E(b *testing.B) {
 
 	result = C
 }
+
+// the parallel benchmarks show the throughput of the operations
+// when run by multiple goroutines (i.e the mint signing or verifying a batch)
+func BenchmarkSignBlindedMessageParallel(b *testing.B) {
+	bf, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	r := secp256k1.PrivKeyFromBytes(bf)
+
+	B_, _, _ := BlindMessage("test_message", r)
+
+	keyBytes, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	k := secp256k1.PrivKeyFromBytes(keyBytes)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			SignBlindedMessage(B_, k)
+		}
+	})
+}
+
+func BenchmarkVerifyParallel(b *testing.B) {
+	secret := "test_message"
+	rhex, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000002")
+	r := secp256k1.PrivKeyFromBytes(rhex)
+
+	B_, r, _ := BlindMessage(secret, r)
+
+	khex, _ := hex.DecodeString("0000000000000000000000000000000000000000000000000000000000000001")
+	k := secp256k1.PrivKeyFromBytes(khex)
+	K := k.PubKey()
+
+	C_ := SignBlindedMessage(B_, k)
+	C := UnblindSignature(C_, r, K)
+
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			if !Verify(secret, k, C) {
+				b.Error("invalid signature")
+			}
+		}
+	})
+}
@@ -4,7 +4,9 @@ import (
 	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut06"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut10"
 	"github.com/Origami74/gonuts-tollgate/mint/lightning"
+	"github.com/Origami74/gonuts-tollgate/mint/pubsub"
 )
 
 type LogLevel int
@@ -26,6 +28,31 @@ type Config struct {
 	EnableMPP         bool
 	EnableAdminServer bool
 	LogLevel          LogLevel
+	// policy used to calculate the fee reserve for melt quotes.
+	// If nil, the FeeReserve from the lightning client is used
+	FeePolicy *lightning.FeePolicy
+	// how long UNPAID quotes are kept after they expire before they are deleted
+	// and added to the quote history. If 0, expired quotes are never deleted
+	QuoteRetention time.Duration
+	// verifiers for custom NUT-10 secret kinds, in addition to P2PK and HTLC.
+	// Proofs locked to a kind without a verifier can be spent by anyone
+	SpendingConditions map[nut10.SecretKind]SpendingConditionVerifier
+	// max number of goroutines used to verify the proofs and sign
+	// the blinded messages of a single request. If 0, the number of CPUs is used
+	Concurrency int
+	// how often the outstanding ecash is compared with the balance
+	// of the lightning backend. If 0, the solvency check is disabled
+	SolvencyCheckInterval time.Duration
+	// max time to wait on shutdown for the payments of in-flight melts.
+	// If 0, a default of 30 seconds is used
+	ShutdownTimeout time.Duration
+	// port of the gRPC API. If 0, the gRPC API is not served
+	GrpcPort int
+	// transport to send notifications to the other instances of the mint
+	// sharing the db. If nil, the mint runs as a single instance.
+	// If set, NUT-19 cached responses are not advertised since
+	// the cache is kept in the memory of each instance
+	PubSubTransport pubsub.Transport
 	// NOTE: using this value for testing
 	MeltTimeout *time.Duration
 }
@@ -54,4 +81,14 @@ type MintLimits struct {
 	MaxBalance      uint64
 	MintingSettings MintMethodSettings
 	MeltingSettings MeltMethodSettings
+	// max number of proofs in a single request. 0 means no limit
+	MaxInputs int
+	// max number of blinded messages in a single request. 0 means no limit
+	MaxOutputs int
+	// max size in bytes of the body of a request. 0 means no limit
+	MaxRequestBytes int64
+	// minting is disabled while the balance of the lightning backend
+	// is below this ratio of the outstanding ecash (i.e 1 for fully backed).
+	// Only enforced if the solvency check is enabled. 0 means no limit
+	MinReserveRatio float64
 }
@@ -0,0 +1,48 @@
+package mint
+
+import (
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
+)
+
+// recordFeeEntry saves the entry in the fee ledger.
+// It is called after the operation completed so errors are only logged.
+func (m *Mint) recordFeeEntry(entry storage.FeeEntry) {
+	entry.CreatedAt = time.Now().Unix()
+	if err := m.db.SaveFeeEntry(entry); err != nil {
+		m.logErrorf("error saving %v fees in ledger: %v", entry.Operation, err)
+	}
+}
+
+// recordMeltFees adds an entry to the fee ledger for a paid melt quote.
+// It should be called after the change for the quote has been signed.
+func (m *Mint) recordMeltFees(meltQuote storage.MeltQuote, proofs cashu.Proofs, internal bool) {
+	inputFee := uint64(m.TransactionFees(proofs))
+	reserveKept, underflow := cashu.UnderflowSubUint64(
+		proofs.Amount(),
+		meltQuote.Amount+meltQuote.FeePaid+inputFee+meltQuote.Change.Amount(),
+	)
+	if underflow {
+		reserveKept = 0
+	}
+
+	operation := storage.MeltOperation
+	if internal {
+		operation = storage.InternalMeltOperation
+	}
+	m.recordFeeEntry(storage.FeeEntry{
+		Operation:    operation,
+		QuoteId:      meltQuote.Id,
+		InputFee:     inputFee,
+		FeeReserve:   meltQuote.FeeReserve,
+		LightningFee: meltQuote.FeePaid,
+		ReserveKept:  reserveKept,
+	})
+}
+
+// FeeEntries returns the entries in the fee ledger created between from and to
+func (m *Mint) FeeEntries(from, to time.Time) ([]storage.FeeEntry, error) {
+	return m.db.GetFeeEntries(from.Unix(), to.Unix())
+}
@@ -0,0 +1,272 @@
+package mint
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut01"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut03"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut17"
+	"github.com/Origami74/gonuts-tollgate/mint/mintrpc"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// max number of quotes in the filters of a subscription
+const maxSubscriptionFilters = 50
+
+// GrpcServer serves the mint API over gRPC.
+// It is backed by the same Mint methods as the REST API.
+type GrpcServer struct {
+	mintrpc.UnimplementedMintServiceServer
+
+	mint        *Mint
+	server      *grpc.Server
+	port        int
+	meltTimeout *time.Duration
+}
+
+func NewGrpcServer(m *Mint, port int, meltTimeout *time.Duration) *GrpcServer {
+	var opts []grpc.ServerOption
+	if m.limits.MaxRequestBytes > 0 {
+		opts = append(opts, grpc.MaxRecvMsgSize(int(m.limits.MaxRequestBytes)))
+	}
+
+	grpcServer := &GrpcServer{
+		mint:        m,
+		server:      grpc.NewServer(opts...),
+		port:        port,
+		meltTimeout: meltTimeout,
+	}
+	mintrpc.RegisterMintServiceServer(grpcServer.server, grpcServer)
+	return grpcServer
+}
+
+func (gs *GrpcServer) Start() error {
+	listener, err := net.Listen("tcp", fmt.Sprintf(":%v", gs.port))
+	if err != nil {
+		return err
+	}
+	return gs.Serve(listener)
+}
+
+func (gs *GrpcServer) Serve(listener net.Listener) error {
+	gs.mint.logger.Info("mint gRPC server listening on: " + listener.Addr().String())
+	err := gs.server.Serve(listener)
+	if err != nil && !errors.Is(err, grpc.ErrServerStopped) {
+		return err
+	}
+	return nil
+}
+
+// Shutdown waits for pending RPCs to finish until the ctx is done.
+// Open subscription streams are closed.
+func (gs *GrpcServer) Shutdown(ctx context.Context) {
+	stopped := make(chan struct{})
+	go func() {
+		gs.server.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		gs.server.Stop()
+	}
+}
+
+// rpcError logs internal errors from the db or the lightning backend
+// and returns a generic error in their place, like the REST API
+func (gs *GrpcServer) rpcError(method string, err error) error {
+	var cashuErr *cashu.Error
+	if errors.As(err, &cashuErr) {
+		if cashuErr.Code == cashu.LightningBackendErrCode || cashuErr.Code == cashu.DBErrCode {
+			gs.mint.logErrorf("error in gRPC %v: %v", method, err)
+			return mintrpc.ToStatus(cashu.StandardErr)
+		}
+	}
+	gs.mint.logDebugf("error in gRPC %v: %v", method, err)
+	return mintrpc.ToStatus(err)
+}
+
+func (gs *GrpcServer) Info(ctx context.Context, _ *mintrpc.Empty) (*mintrpc.MintInfo, error) {
+	mintInfo, err := gs.mint.RetrieveMintInfo()
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.InfoMethod, err)
+	}
+	return mintrpc.MintInfoToProto(mintInfo), nil
+}
+
+func (gs *GrpcServer) Keys(ctx context.Context, _ *mintrpc.Empty) (*mintrpc.KeysResponse, error) {
+	activeKeyset := gs.mint.GetActiveKeyset()
+	return mintrpc.KeysResponseToProto(nut01.GetKeysResponse{Keysets: []nut01.Keyset{activeKeyset}}), nil
+}
+
+func (gs *GrpcServer) KeysById(ctx context.Context, req *mintrpc.KeysetRequest) (*mintrpc.KeysResponse, error) {
+	keyset, err := gs.mint.GetKeysetById(req.Id)
+	if err != nil {
+		return nil, mintrpc.ToStatus(cashu.UnknownKeysetErr)
+	}
+	return mintrpc.KeysResponseToProto(nut01.GetKeysResponse{Keysets: []nut01.Keyset{keyset}}), nil
+}
+
+func (gs *GrpcServer) Keysets(ctx context.Context, _ *mintrpc.Empty) (*mintrpc.KeysetsResponse, error) {
+	return mintrpc.KeysetsResponseToProto(gs.mint.ListKeysets()), nil
+}
+
+func (gs *GrpcServer) MintQuote(ctx context.Context, req *mintrpc.MintQuoteRequest) (*mintrpc.MintQuoteResponse, error) {
+	mintQuote, err := gs.mint.RequestMintQuote(mintrpc.MintQuoteRequestFromProto(req))
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.MintQuoteMethod, err)
+	}
+	return mintrpc.MintQuoteResponseToProto(mintQuoteResponse(mintQuote)), nil
+}
+
+func (gs *GrpcServer) MintQuoteState(ctx context.Context, req *mintrpc.QuoteRequest) (*mintrpc.MintQuoteResponse, error) {
+	mintQuote, err := gs.mint.GetMintQuoteState(req.Quote)
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.MintQuoteStateMethod, err)
+	}
+	return mintrpc.MintQuoteResponseToProto(mintQuoteResponse(mintQuote)), nil
+}
+
+func (gs *GrpcServer) Mint(ctx context.Context, req *mintrpc.MintRequest) (*mintrpc.MintResponse, error) {
+	blindedSignatures, err := gs.mint.MintTokens(mintrpc.MintRequestFromProto(req))
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.MintMethod, err)
+	}
+	return mintrpc.MintResponseToProto(nut04.PostMintBolt11Response{Signatures: blindedSignatures}), nil
+}
+
+func (gs *GrpcServer) MeltQuote(ctx context.Context, req *mintrpc.MeltQuoteRequest) (*mintrpc.MeltQuoteResponse, error) {
+	meltQuote, err := gs.mint.RequestMeltQuote(mintrpc.MeltQuoteRequestFromProto(req))
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.MeltQuoteMethod, err)
+	}
+	return mintrpc.MeltQuoteResponseToProto(meltQuoteResponse(meltQuote)), nil
+}
+
+func (gs *GrpcServer) MeltQuoteState(ctx context.Context, req *mintrpc.QuoteRequest) (*mintrpc.MeltQuoteResponse, error) {
+	ctx, cancel := context.WithTimeout(ctx, time.Second*5)
+	defer cancel()
+
+	meltQuote, err := gs.mint.GetMeltQuoteState(ctx, req.Quote)
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.MeltQuoteStateMethod, err)
+	}
+	return mintrpc.MeltQuoteResponseToProto(meltQuoteResponse(meltQuote)), nil
+}
+
+func (gs *GrpcServer) Melt(ctx context.Context, req *mintrpc.MeltRequest) (*mintrpc.MeltQuoteResponse, error) {
+	timeout := time.Minute * 1
+	if gs.meltTimeout != nil {
+		timeout = *gs.meltTimeout
+	}
+	// payment should not be canceled if the client goes away
+	ctx, cancel := context.WithTimeout(context.WithoutCancel(ctx), timeout)
+	defer cancel()
+
+	meltQuote, err := gs.mint.MeltTokens(ctx, mintrpc.MeltRequestFromProto(req))
+	if err != nil {
+		var cashuErr *cashu.Error
+		if errors.As(err, &cashuErr) && cashuErr.Code == cashu.LightningBackendErrCode {
+			gs.mint.logErrorf("error in gRPC %v: %v", mintrpc.MeltMethod, err)
+			return nil, mintrpc.ToStatus(cashu.BuildCashuError("unable to send payment", cashu.StandardErrCode))
+		}
+		return nil, gs.rpcError(mintrpc.MeltMethod, err)
+	}
+	return mintrpc.MeltQuoteResponseToProto(meltQuoteResponse(meltQuote)), nil
+}
+
+func (gs *GrpcServer) Swap(ctx context.Context, req *mintrpc.SwapRequest) (*mintrpc.SwapResponse, error) {
+	swapRequest := mintrpc.SwapRequestFromProto(req)
+	blindedSignatures, err := gs.mint.Swap(swapRequest.Inputs, swapRequest.Outputs)
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.SwapMethod, err)
+	}
+	return mintrpc.SwapResponseToProto(nut03.PostSwapResponse{Signatures: blindedSignatures}), nil
+}
+
+func (gs *GrpcServer) CheckState(ctx context.Context, req *mintrpc.CheckStateRequest) (*mintrpc.CheckStateResponse, error) {
+	proofStates, err := gs.mint.ProofsStateCheck(req.Ys)
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.CheckStateMethod, err)
+	}
+	return mintrpc.CheckStateResponseToProto(nut07.PostCheckStateResponse{States: proofStates}), nil
+}
+
+func (gs *GrpcServer) Restore(ctx context.Context, req *mintrpc.RestoreRequest) (*mintrpc.RestoreResponse, error) {
+	restoreRequest := mintrpc.RestoreRequestFromProto(req)
+	blindedMessages, blindedSignatures, err := gs.mint.RestoreSignatures(restoreRequest.Outputs)
+	if err != nil {
+		return nil, gs.rpcError(mintrpc.RestoreMethod, err)
+	}
+	return mintrpc.RestoreResponseToProto(nut09.PostRestoreResponse{Outputs: blindedMessages, Signatures: blindedSignatures}), nil
+}
+
+// Subscribe sends the current state of the quotes in the filters
+// and then a notification for every state change until the client
+// cancels the stream or the mint shuts down.
+// Same as with websockets, only mint quote subscriptions are supported.
+func (gs *GrpcServer) Subscribe(req *mintrpc.SubscribeRequest, stream mintrpc.MintService_SubscribeServer) error {
+	if nut17.StringToKind(req.Kind) != nut17.Bolt11MintQuote {
+		return status.Errorf(codes.Unimplemented, "subscription kind '%v' not supported", req.Kind)
+	}
+	if len(req.Filters) > maxSubscriptionFilters {
+		return status.Error(codes.InvalidArgument, "too many filters")
+	}
+
+	quotes := make([]storage.MintQuote, len(req.Filters))
+	for i, quoteId := range req.Filters {
+		quote, err := gs.mint.db.GetMintQuote(quoteId)
+		if err != nil {
+			return status.Errorf(codes.NotFound, "quote %v does not exist", quoteId)
+		}
+		quotes[i] = quote
+	}
+
+	subClient := NewMintQuotesSubClient("", quotes, gs.mint.publisher)
+	defer subClient.Close()
+	notifications := subClient.Read()
+
+	sendQuoteState := func(quoteState nut04.PostMintQuoteBolt11Response) error {
+		return stream.Send(&mintrpc.Notification{
+			Kind:    req.Kind,
+			Payload: &mintrpc.Notification_MintQuote{MintQuote: mintrpc.MintQuoteResponseToProto(quoteState)},
+		})
+	}
+
+	for _, quote := range quotes {
+		if err := sendQuoteState(mintQuoteResponse(quote)); err != nil {
+			return err
+		}
+	}
+
+	for {
+		select {
+		case notification := <-notifications:
+			var quoteState nut04.PostMintQuoteBolt11Response
+			if err := json.Unmarshal(notification.Params.Payload, &quoteState); err != nil {
+				gs.mint.logErrorf("error decoding mint quote notification: %v", err)
+				continue
+			}
+			if err := sendQuoteState(quoteState); err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return nil
+		case <-gs.mint.ctx.Done():
+			return status.Error(codes.Unavailable, "mint is shutting down")
+		}
+	}
+}
@@ -0,0 +1,269 @@
+package mint
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// TenantConfig is the config of one of the mints run by a Host.
+// Requests are routed to the mint if their host is one of the Hostnames
+// or their path starts with the PathPrefix (i.e /tenant/v1/keys).
+type TenantConfig struct {
+	Name       string
+	Hostnames  []string
+	PathPrefix string
+	Config     Config
+}
+
+type HostConfig struct {
+	Port int
+	// max time to wait on shutdown for the in-flight melts of all the mints.
+	// If 0, a default of 30 seconds is used
+	ShutdownTimeout time.Duration
+}
+
+// Host runs several mints in one process. Each mint has its own
+// path, db, logger and limits but they are served from one http listener.
+type Host struct {
+	httpServer      *http.Server
+	tenants         []*tenant
+	byHostname      map[string]*tenant
+	shutdownTimeout time.Duration
+}
+
+type tenant struct {
+	name       string
+	pathPrefix string
+	mint       *Mint
+	server     *MintServer
+}
+
+// LoadHost loads the mints of the tenants and sets up the http server
+// that routes the requests to them.
+func LoadHost(config HostConfig, tenants []TenantConfig) (*Host, error) {
+	if len(tenants) == 0 {
+		return nil, errors.New("no tenants to host")
+	}
+	if err := validateTenants(tenants); err != nil {
+		return nil, err
+	}
+
+	host := &Host{
+		byHostname:      make(map[string]*tenant),
+		shutdownTimeout: config.ShutdownTimeout,
+	}
+	if host.shutdownTimeout <= 0 {
+		host.shutdownTimeout = defaultShutdownTimeout
+	}
+
+	for _, tenantConfig := range tenants {
+		m, err := LoadMint(tenantConfig.Config)
+		if err != nil {
+			host.shutdownMints()
+			return nil, fmt.Errorf("error loading mint for tenant '%v': %v", tenantConfig.Name, err)
+		}
+		serverConfig := ServerConfig{
+			GrpcPort:        tenantConfig.Config.GrpcPort,
+			MeltTimeout:     tenantConfig.Config.MeltTimeout,
+			ShutdownTimeout: host.shutdownTimeout,
+		}
+
+		t := &tenant{
+			name:       tenantConfig.Name,
+			pathPrefix: normalizePathPrefix(tenantConfig.PathPrefix),
+			mint:       m,
+			server:     SetupMintServer(m, serverConfig),
+		}
+		host.tenants = append(host.tenants, t)
+		for _, hostname := range tenantConfig.Hostnames {
+			host.byHostname[strings.ToLower(hostname)] = t
+		}
+	}
+
+	host.httpServer = &http.Server{
+		Addr:    ":" + strconv.Itoa(config.Port),
+		Handler: host,
+	}
+	return host, nil
+}
+
+func validateTenants(tenants []TenantConfig) error {
+	names := make(map[string]bool)
+	hostnames := make(map[string]bool)
+	prefixes := make(map[string]bool)
+	paths := make(map[string]bool)
+
+	for _, t := range tenants {
+		if len(t.Name) == 0 {
+			return errors.New("tenant name cannot be empty")
+		}
+		if names[t.Name] {
+			return fmt.Errorf("duplicate tenant '%v'", t.Name)
+		}
+		names[t.Name] = true
+
+		if len(t.Hostnames) == 0 && len(t.PathPrefix) == 0 {
+			return fmt.Errorf("tenant '%v' needs a hostname or a path prefix", t.Name)
+		}
+		for _, hostname := range t.Hostnames {
+			hostname = strings.ToLower(hostname)
+			if hostnames[hostname] {
+				return fmt.Errorf("hostname '%v' of tenant '%v' is already used", hostname, t.Name)
+			}
+			hostnames[hostname] = true
+		}
+		if len(t.PathPrefix) > 0 {
+			prefix := normalizePathPrefix(t.PathPrefix)
+			if prefix == "" || strings.HasPrefix(prefix, "/v1/") || prefix == "/v1" {
+				return fmt.Errorf("invalid path prefix '%v' for tenant '%v'", t.PathPrefix, t.Name)
+			}
+			if prefixes[prefix] {
+				return fmt.Errorf("path prefix '%v' of tenant '%v' is already used", prefix, t.Name)
+			}
+			prefixes[prefix] = true
+		}
+
+		// separate dbs are needed for each mint to have its own seed
+		path := filepath.Clean(t.Config.MintPath)
+		if len(t.Config.MintPath) == 0 {
+			return fmt.Errorf("mint path of tenant '%v' cannot be empty", t.Name)
+		}
+		if paths[path] {
+			return fmt.Errorf("mint path of tenant '%v' is already used by another tenant", t.Name)
+		}
+		paths[path] = true
+	}
+	return nil
+}
+
+// normalizePathPrefix returns the prefix with a leading slash and no trailing slash
+func normalizePathPrefix(prefix string) string {
+	prefix = strings.Trim(prefix, "/")
+	if len(prefix) == 0 {
+		return ""
+	}
+	return "/" + prefix
+}
+
+// Mints returns the mints of the host by tenant name
+func (h *Host) Mints() map[string]*Mint {
+	mints := make(map[string]*Mint, len(h.tenants))
+	for _, t := range h.tenants {
+		mints[t.name] = t.mint
+	}
+	return mints
+}
+
+// ServeHTTP routes the request to the mint of the tenant for its hostname.
+// If no tenant has the hostname, the longest matching path prefix is used
+// and removed from the path before it is handled by the mint.
+func (h *Host) ServeHTTP(rw http.ResponseWriter, req *http.Request) {
+	hostname := req.Host
+	if host, _, err := net.SplitHostPort(hostname); err == nil {
+		hostname = host
+	}
+	if t, ok := h.byHostname[strings.ToLower(hostname)]; ok {
+		t.server.httpServer.Handler.ServeHTTP(rw, req)
+		return
+	}
+
+	if t := h.tenantForPath(req.URL.Path); t != nil {
+		http.StripPrefix(t.pathPrefix, t.server.httpServer.Handler).ServeHTTP(rw, req)
+		return
+	}
+
+	http.NotFound(rw, req)
+}
+
+func (h *Host) tenantForPath(path string) *tenant {
+	var match *tenant
+	for _, t := range h.tenants {
+		if len(t.pathPrefix) == 0 {
+			continue
+		}
+		if path != t.pathPrefix && !strings.HasPrefix(path, t.pathPrefix+"/") {
+			continue
+		}
+		if match == nil || len(t.pathPrefix) > len(match.pathPrefix) {
+			match = t
+		}
+	}
+	return match
+}
+
+func (h *Host) Start() error {
+	listener, err := net.Listen("tcp", h.httpServer.Addr)
+	if err != nil {
+		return err
+	}
+	return h.Serve(listener)
+}
+
+// Serve starts the background tasks of each mint and serves the requests
+// for all of them from the listener
+func (h *Host) Serve(listener net.Listener) error {
+	for _, t := range h.tenants {
+		t.server.startBackground()
+		t.mint.logInfof("mint host listening on %v for tenant '%v'", listener.Addr(), t.name)
+	}
+
+	err := h.httpServer.Serve(listener)
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+// Shutdown drains all the mints in parallel, closes their websocket connections
+// and gRPC servers, shuts down the shared http server and then the mints.
+func (h *Host) Shutdown() error {
+	ctx, cancel := context.WithTimeout(context.Background(), h.shutdownTimeout)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	for _, t := range h.tenants {
+		wg.Add(1)
+		go func(t *tenant) {
+			defer wg.Done()
+			if err := t.mint.Drain(ctx); err != nil {
+				t.mint.logErrorf("error draining mint: %v", err)
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	var errs []error
+	for _, t := range h.tenants {
+		if err := t.server.websocketManager.Shutdown(); err != nil {
+			errs = append(errs, fmt.Errorf("tenant '%v': %v", t.name, err))
+		}
+		if t.server.grpcServer != nil {
+			t.server.grpcServer.Shutdown(ctx)
+		}
+	}
+	if err := h.httpServer.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("error shutting down http server: %v", err))
+	}
+	if err := h.shutdownMints(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+func (h *Host) shutdownMints() error {
+	var errs []error
+	for _, t := range h.tenants {
+		if err := t.mint.Shutdown(); err != nil {
+			errs = append(errs, fmt.Errorf("error shutting down mint of tenant '%v': %v", t.name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
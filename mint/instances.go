@@ -0,0 +1,154 @@
+package mint
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/mint/pubsub"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+)
+
+// names of the leases for the background jobs that
+// only one of the instances sharing the db should run
+const (
+	quoteCleanupJob  = "quote_cleanup"
+	solvencyCheckJob = "solvency_check"
+)
+
+const (
+	// how often the keysets are reloaded from the db in case
+	// a rotation by another instance was missed
+	keysetSyncInterval = time.Minute
+	// min time between reloads of the keysets when
+	// a request has a keyset id that is not in memory
+	unknownKeysetReloadInterval = time.Second
+)
+
+func newInstanceId() string {
+	id := make([]byte, 16)
+	rand.Read(id)
+	return hex.EncodeToString(id)
+}
+
+// isLeader returns true if this instance holds the lease to run the job.
+// It should be called every interval to renew the lease. If the
+// instance stops renewing it, another one takes over after 2 intervals.
+func (m *Mint) isLeader(job string, interval time.Duration) bool {
+	acquired, err := m.db.AcquireLease(job, m.instanceId, 2*interval)
+	if err != nil {
+		m.logErrorf("could not acquire lease for job '%v': %v", job, err)
+		return false
+	}
+	return acquired
+}
+
+// releaseLeases lets other instances take over the
+// background jobs right away when this one shuts down
+func (m *Mint) releaseLeases() {
+	for _, job := range []string{quoteCleanupJob, solvencyCheckJob} {
+		if err := m.db.ReleaseLease(job, m.instanceId); err != nil {
+			m.logErrorf("could not release lease for job '%v': %v", job, err)
+		}
+	}
+}
+
+// runKeysetSync loads the keysets rotated by other instances of the mint
+// until the mint context is canceled. It should be called in a different goroutine.
+// Since rotations published while disconnected from the other instances are lost,
+// keysets are also reloaded after reconnecting and every keysetSyncInterval.
+func (m *Mint) runKeysetSync() {
+	rotations := m.publisher.Subscribe(KEYSET_TOPIC)
+	reconnections := m.publisher.Subscribe(pubsub.ReconnectedTopic)
+	defer func() {
+		m.unsubscribe(rotations, KEYSET_TOPIC)
+		m.unsubscribe(reconnections, pubsub.ReconnectedTopic)
+	}()
+
+	ticker := time.NewTicker(keysetSyncInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-m.ctx.Done():
+			return
+		case msg := <-rotations.GetMessages():
+			keysetId := string(msg.Payload())
+			if keysetId == m.getActiveKeyset().Id {
+				continue
+			}
+			m.logInfof("keyset '%v' was rotated by another instance. Loading keysets from db", keysetId)
+		case <-reconnections.GetMessages():
+			m.logInfof("reconnected to other instances. Loading keysets from db")
+		case <-ticker.C:
+		}
+
+		if err := m.loadKeysets(); err != nil {
+			m.logErrorf("error loading keysets from db: %v", err)
+		}
+	}
+}
+
+// unsubscribe removes the subscriber from the topic and closes it
+func (m *Mint) unsubscribe(subscriber *pubsub.Subscriber, topic string) {
+	m.publisher.Unsubscribe(subscriber, topic)
+	// drain messages being delivered so that closing does not block
+	go func() {
+		for range subscriber.GetMessages() {
+		}
+	}()
+	subscriber.Close()
+}
+
+// loadKeysets adds the keysets in the db that are not in memory
+// and updates which one is active
+func (m *Mint) loadKeysets() error {
+	m.keysetsMu.Lock()
+	defer m.keysetsMu.Unlock()
+	m.keysetsLoadedAt = time.Now()
+
+	seed, err := m.db.GetSeed()
+	if err != nil {
+		return err
+	}
+	master, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return err
+	}
+
+	dbKeysets, err := m.db.GetKeysets()
+	if err != nil {
+		return fmt.Errorf("error reading keysets from db: %v", err)
+	}
+
+	var activeKeyset *crypto.MintKeyset
+	for _, dbkeyset := range dbKeysets {
+		keyset, ok := m.keysets[dbkeyset.Id]
+		if !ok {
+			newKeyset, err := crypto.GenerateKeyset(
+				master,
+				dbkeyset.DerivationPathIdx,
+				dbkeyset.InputFeePpk,
+				dbkeyset.Active,
+			)
+			if err != nil {
+				return err
+			}
+			keyset = *newKeyset
+		}
+		keyset.Active = dbkeyset.Active
+		m.keysets[keyset.Id] = keyset
+		if keyset.Active {
+			activeKeyset = &keyset
+		}
+	}
+
+	if activeKeyset != nil {
+		m.activeKeyset = activeKeyset
+		m.logInfof("setting active keyset '%v' with fee %v", activeKeyset.Id, activeKeyset.InputFeePpk)
+	}
+	return nil
+}
@@ -53,10 +53,17 @@ func (m *Mint) checkInvoicePaid(ctx context.Context, quoteId string) {
 	case invoice := <-updateChan:
 		if invoice.Settled {
 			m.logInfof("received update from invoice sub. Invoice for mint quote '%v' is PAID", mintQuote.Id)
-			mintQuote.State = nut04.Paid
-			if err := m.db.UpdateMintQuoteState(mintQuote.Id, mintQuote.State); err != nil {
+			// the quote could have been marked as paid (or issued)
+			// by a state check in this or another instance of the mint
+			set, err := m.db.CompareAndSetMintQuoteState(mintQuote.Id, nut04.Unpaid, nut04.Paid)
+			if err != nil {
 				m.logErrorf("could not mark mint quote '%v' as PAID in db: %v", mintQuote.Id, err)
+				return
 			}
+			if !set {
+				return
+			}
+			mintQuote.State = nut04.Paid
 			jsonQuote, _ := json.Marshal(mintQuote)
 			m.publisher.Publish(BOLT11_MINT_QUOTE_TOPIC, jsonQuote)
 		}
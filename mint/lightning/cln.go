@@ -76,13 +76,17 @@ func (cln *CLNClient) ConnectionStatus() error {
 	return nil
 }
 
-func (cln *CLNClient) CreateInvoice(amount uint64) (Invoice, error) {
+func (cln *CLNClient) CreateInvoice(amount uint64, description string) (Invoice, error) {
 	r := rand.New(rand.NewPCG(uint64(time.Now().UnixMicro()), uint64(time.Now().UnixMilli())))
 
+	// description is required by CLN
+	if len(description) == 0 {
+		description = "Cashu Lightning Invoice"
+	}
 	body := map[string]interface{}{
 		"amount_msat": amount * 1000,
 		"label":       time.Now().Unix() + int64(r.Int()),
-		"description": "Cashu Lightning Invoice",
+		"description": description,
 		"expiry":      InvoiceExpiryTime,
 	}
 
@@ -174,10 +178,32 @@ func (cln *CLNClient) InvoiceStatus(hash string) (Invoice, error) {
 }
 
 func (cln *CLNClient) SendPayment(ctx context.Context, request string, maxFee uint64) (PaymentStatus, error) {
+	return cln.sendPayment(ctx, request, 0, maxFee)
+}
+
+func (cln *CLNClient) PayAmountless(
+	ctx context.Context,
+	request string,
+	amountMsat uint64,
+	maxFee uint64,
+) (PaymentStatus, error) {
+	return cln.sendPayment(ctx, request, amountMsat, maxFee)
+}
+
+// sendPayment pays the request. amountMsat is only set for invoices with no amount
+func (cln *CLNClient) sendPayment(
+	ctx context.Context,
+	request string,
+	amountMsat uint64,
+	maxFee uint64,
+) (PaymentStatus, error) {
 	body := map[string]interface{}{
 		"bolt11": request,
 		"maxfee": maxFee * 1000,
 	}
+	if amountMsat > 0 {
+		body["amount_msat"] = amountMsat
+	}
 
 	resp, err := cln.Post(ctx, cln.config.RestURL+"/v1/pay", body)
 	if err != nil {
@@ -199,8 +225,10 @@ func (cln *CLNClient) SendPayment(ctx context.Context, request string, maxFee ui
 	}
 
 	var response struct {
-		Preimage string `json:"payment_preimage"`
-		Status   string `json:"status"`
+		Preimage       string `json:"payment_preimage"`
+		Status         string `json:"status"`
+		AmountMsat     uint64 `json:"amount_msat"`
+		AmountSentMsat uint64 `json:"amount_sent_msat"`
 	}
 	if err := json.Unmarshal(bodyBytes, &response); err != nil {
 		return PaymentStatus{PaymentStatus: Pending}, err
@@ -219,6 +247,7 @@ func (cln *CLNClient) SendPayment(ctx context.Context, request string, maxFee ui
 	return PaymentStatus{
 		Preimage:      response.Preimage,
 		PaymentStatus: status,
+		Fee:           clnFeePaid(response.AmountSentMsat, response.AmountMsat),
 	}, nil
 }
 
@@ -255,8 +284,10 @@ func (cln *CLNClient) PayPartialAmount(
 	}
 
 	var response struct {
-		Preimage string `json:"payment_preimage"`
-		Status   string `json:"status"`
+		Preimage       string `json:"payment_preimage"`
+		Status         string `json:"status"`
+		AmountMsat     uint64 `json:"amount_msat"`
+		AmountSentMsat uint64 `json:"amount_sent_msat"`
 	}
 	if err := json.Unmarshal(bodyBytes, &response); err != nil {
 		return PaymentStatus{}, fmt.Errorf("failed to parse response: %w", err)
@@ -275,6 +306,7 @@ func (cln *CLNClient) PayPartialAmount(
 	return PaymentStatus{
 		Preimage:      response.Preimage,
 		PaymentStatus: status,
+		Fee:           clnFeePaid(response.AmountSentMsat, response.AmountMsat),
 	}, nil
 }
 
@@ -304,6 +336,8 @@ func (cln *CLNClient) OutgoingPaymentStatus(ctx context.Context, paymentHash str
 			PaymentHash     string `json:"payment_hash"`
 			Status          string `json:"status"`
 			PaymentPreimage string `json:"preimage,omitempty"`
+			AmountMsat      uint64 `json:"amount_msat"`
+			AmountSentMsat  uint64 `json:"amount_sent_msat"`
 		} `json:"pays"`
 	}
 	if err := json.Unmarshal(bodyBytes, &listPaysResponse); err != nil {
@@ -316,7 +350,11 @@ func (cln *CLNClient) OutgoingPaymentStatus(ctx context.Context, paymentHash str
 	payment := listPaysResponse.Pays[0]
 	switch payment.Status {
 	case "complete":
-		return PaymentStatus{PaymentStatus: Succeeded, Preimage: payment.PaymentPreimage}, nil
+		return PaymentStatus{
+			PaymentStatus: Succeeded,
+			Preimage:      payment.PaymentPreimage,
+			Fee:           clnFeePaid(payment.AmountSentMsat, payment.AmountMsat),
+		}, nil
 	case "failed":
 		return PaymentStatus{PaymentStatus: Failed}, nil
 	default:
@@ -328,6 +366,196 @@ func (cln *CLNClient) FeeReserve(amount uint64) uint64 {
 	return uint64(math.Ceil(float64(amount) * FeePercent))
 }
 
+// EstimateRouteFee gets a route to the destination of the request
+// and returns the fees of that route
+func (cln *CLNClient) EstimateRouteFee(ctx context.Context, request string, amountMsat uint64) (uint64, error) {
+	resp, err := cln.Post(ctx, cln.config.RestURL+"/v1/decodepay", map[string]string{"bolt11": request})
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var errRes ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &errRes); err != nil {
+			return 0, err
+		}
+		return 0, errors.New(errRes.Message)
+	}
+
+	var decodeResponse struct {
+		Payee string `json:"payee"`
+	}
+	if err := json.Unmarshal(bodyBytes, &decodeResponse); err != nil {
+		return 0, err
+	}
+
+	body := map[string]interface{}{
+		"id":          decodeResponse.Payee,
+		"amount_msat": amountMsat,
+		"riskfactor":  10,
+	}
+	routeResp, err := cln.Post(ctx, cln.config.RestURL+"/v1/getroute", body)
+	if err != nil {
+		return 0, err
+	}
+	defer routeResp.Body.Close()
+
+	bodyBytes, err = io.ReadAll(routeResp.Body)
+	if err != nil {
+		return 0, err
+	}
+
+	if routeResp.StatusCode != http.StatusOK && routeResp.StatusCode != http.StatusCreated {
+		var errRes ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &errRes); err != nil {
+			return 0, err
+		}
+		return 0, errors.New(errRes.Message)
+	}
+
+	var routeResponse struct {
+		Route []struct {
+			AmountMsat uint64 `json:"amount_msat"`
+		} `json:"route"`
+	}
+	if err := json.Unmarshal(bodyBytes, &routeResponse); err != nil {
+		return 0, err
+	}
+	if len(routeResponse.Route) == 0 {
+		return 0, errors.New("no route found")
+	}
+
+	// amount sent to the first hop includes the fees for the whole route
+	return clnFeePaid(routeResponse.Route[0].AmountMsat, amountMsat), nil
+}
+
+// clnFeePaid returns the fee in sats from the amount sent and the amount received
+func clnFeePaid(amountSentMsat, amountMsat uint64) uint64 {
+	if amountSentMsat <= amountMsat {
+		return 0
+	}
+	return msatToSatCeil(amountSentMsat - amountMsat)
+}
+
+// CancelInvoice deletes the unpaid invoice from CLN
+func (cln *CLNClient) CancelInvoice(ctx context.Context, paymentHash string) error {
+	body := map[string]string{"payment_hash": paymentHash}
+
+	resp, err := cln.Post(ctx, cln.config.RestURL+"/v1/listinvoices", body)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var errRes ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &errRes); err != nil {
+			return err
+		}
+		return errors.New(errRes.Message)
+	}
+
+	var response struct {
+		Invoices []struct {
+			Label  string `json:"label"`
+			Status string `json:"status"`
+		} `json:"invoices"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return err
+	}
+	if len(response.Invoices) == 0 {
+		return fmt.Errorf("invoice not found")
+	}
+	invoice := response.Invoices[0]
+	if invoice.Status == "paid" {
+		return errors.New("cannot cancel paid invoice")
+	}
+
+	delBody := map[string]string{"label": invoice.Label, "status": invoice.Status}
+	delResp, err := cln.Post(ctx, cln.config.RestURL+"/v1/delinvoice", delBody)
+	if err != nil {
+		return err
+	}
+	defer delResp.Body.Close()
+
+	delBodyBytes, err := io.ReadAll(delResp.Body)
+	if err != nil {
+		return err
+	}
+
+	if delResp.StatusCode != http.StatusOK && delResp.StatusCode != http.StatusCreated {
+		var errRes ErrorResponse
+		if err := json.Unmarshal(delBodyBytes, &errRes); err != nil {
+			return err
+		}
+		return errors.New(errRes.Message)
+	}
+
+	return nil
+}
+
+// Balance adds the funds in normal channels and confirmed outputs from listfunds
+func (cln *CLNClient) Balance(ctx context.Context) (Balance, error) {
+	resp, err := cln.Post(ctx, cln.config.RestURL+"/v1/listfunds", nil)
+	if err != nil {
+		return Balance{}, err
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Balance{}, err
+	}
+
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusCreated {
+		var errRes ErrorResponse
+		if err := json.Unmarshal(bodyBytes, &errRes); err != nil {
+			return Balance{}, err
+		}
+		return Balance{}, errors.New(errRes.Message)
+	}
+
+	var response struct {
+		Outputs []struct {
+			AmountMsat uint64 `json:"amount_msat"`
+			Status     string `json:"status"`
+		} `json:"outputs"`
+		Channels []struct {
+			OurAmountMsat uint64 `json:"our_amount_msat"`
+			State         string `json:"state"`
+		} `json:"channels"`
+	}
+	if err := json.Unmarshal(bodyBytes, &response); err != nil {
+		return Balance{}, err
+	}
+
+	var channelMsat, onchainMsat uint64
+	for _, channel := range response.Channels {
+		if channel.State == "CHANNELD_NORMAL" {
+			channelMsat += channel.OurAmountMsat
+		}
+	}
+	for _, output := range response.Outputs {
+		if output.Status == "confirmed" {
+			onchainMsat += output.AmountMsat
+		}
+	}
+
+	return Balance{Channel: channelMsat / 1000, OnChain: onchainMsat / 1000}, nil
+}
+
 func (cln *CLNClient) SubscribeInvoice(ctx context.Context, paymentHash string) (InvoiceSubscriptionClient, error) {
 	body := map[string]string{"payment_hash": paymentHash}
 
@@ -47,12 +47,14 @@ func (i *FakeBackendInvoice) ToInvoice() Invoice {
 type FakeBackend struct {
 	Invoices     []FakeBackendInvoice
 	PaymentDelay int64
+	// balance returned by Balance
+	NodeBalance Balance
 }
 
 func (fb *FakeBackend) ConnectionStatus() error { return nil }
 
-func (fb *FakeBackend) CreateInvoice(amount uint64) (Invoice, error) {
-	req, preimage, paymentHash, err := CreateFakeInvoice(amount, false)
+func (fb *FakeBackend) CreateInvoice(amount uint64, description string) (Invoice, error) {
+	req, preimage, paymentHash, err := createFakeInvoice(amount, description)
 	if err != nil {
 		return Invoice{}, err
 	}
@@ -86,7 +88,21 @@ func (fb *FakeBackend) SendPayment(ctx context.Context, request string, maxFee u
 	if err != nil {
 		return PaymentStatus{}, fmt.Errorf("error decoding invoice: %v", err)
 	}
+	return fb.pay(invoice, uint64(invoice.MSatoshi)), nil
+}
 
+func (fb *FakeBackend) PayAmountless(ctx context.Context, request string, amountMsat, maxFee uint64) (PaymentStatus, error) {
+	invoice, err := decodepay.Decodepay(request)
+	if err != nil {
+		return PaymentStatus{}, fmt.Errorf("error decoding invoice: %v", err)
+	}
+	if invoice.MSatoshi != 0 {
+		return PaymentStatus{PaymentStatus: Failed}, errors.New("invoice has an amount")
+	}
+	return fb.pay(invoice, amountMsat), nil
+}
+
+func (fb *FakeBackend) pay(invoice decodepay.Bolt11, amountMsat uint64) PaymentStatus {
 	status := Succeeded
 	if invoice.Description == FailPaymentDescription {
 		status = Failed
@@ -100,14 +116,14 @@ func (fb *FakeBackend) SendPayment(ctx context.Context, request string, maxFee u
 		PaymentHash: invoice.PaymentHash,
 		Preimage:    FakePreimage,
 		Status:      status,
-		Amount:      uint64(invoice.MSatoshi) * 1000,
+		Amount:      amountMsat / 1000,
 	}
 	fb.Invoices = append(fb.Invoices, outgoingPayment)
 
 	return PaymentStatus{
 		Preimage:      FakePreimage,
 		PaymentStatus: status,
-	}, nil
+	}
 }
 
 func (fb *FakeBackend) PayPartialAmount(ctx context.Context, request string, amountMsat, maxFee uint64) (PaymentStatus, error) {
@@ -115,28 +131,7 @@ func (fb *FakeBackend) PayPartialAmount(ctx context.Context, request string, amo
 	if err != nil {
 		return PaymentStatus{}, fmt.Errorf("error decoding invoice: %v", err)
 	}
-
-	status := Succeeded
-	if invoice.Description == FailPaymentDescription {
-		status = Failed
-	} else if fb.PaymentDelay > 0 {
-		if time.Now().Unix() < int64(invoice.CreatedAt)+fb.PaymentDelay {
-			status = Pending
-		}
-	}
-
-	outgoingPayment := FakeBackendInvoice{
-		PaymentHash: invoice.PaymentHash,
-		Preimage:    FakePreimage,
-		Status:      status,
-		Amount:      uint64(invoice.MSatoshi) * 1000,
-	}
-	fb.Invoices = append(fb.Invoices, outgoingPayment)
-
-	return PaymentStatus{
-		Preimage:      FakePreimage,
-		PaymentStatus: status,
-	}, nil
+	return fb.pay(invoice, amountMsat), nil
 }
 
 func (fb *FakeBackend) OutgoingPaymentStatus(ctx context.Context, hash string) (PaymentStatus, error) {
@@ -157,6 +152,21 @@ func (fb *FakeBackend) FeeReserve(amount uint64) uint64 {
 	return 0
 }
 
+func (fb *FakeBackend) CancelInvoice(ctx context.Context, paymentHash string) error {
+	invoiceIdx := slices.IndexFunc(fb.Invoices, func(i FakeBackendInvoice) bool {
+		return i.PaymentHash == paymentHash
+	})
+	if invoiceIdx == -1 {
+		return errors.New("invoice does not exist")
+	}
+	fb.Invoices = slices.Delete(fb.Invoices, invoiceIdx, invoiceIdx+1)
+	return nil
+}
+
+func (fb *FakeBackend) Balance(ctx context.Context) (Balance, error) {
+	return fb.NodeBalance, nil
+}
+
 func (fb *FakeBackend) SubscribeInvoice(ctx context.Context, paymentHash string) (InvoiceSubscriptionClient, error) {
 	return &FakeInvoiceSub{
 		paymentHash: paymentHash,
@@ -191,6 +201,14 @@ func (fb *FakeBackend) SetInvoiceStatus(hash string, status State) {
 }
 
 func CreateFakeInvoice(amount uint64, failPayment bool) (string, string, string, error) {
+	description := "test"
+	if failPayment {
+		description = FailPaymentDescription
+	}
+	return createFakeInvoice(amount, description)
+}
+
+func createFakeInvoice(amount uint64, description string) (string, string, string, error) {
 	var random [32]byte
 	_, err := rand.Read(random[:])
 	if err != nil {
@@ -200,18 +218,12 @@ func CreateFakeInvoice(amount uint64, failPayment bool) (string, string, string,
 	paymentHash := sha256.Sum256(random[:])
 	hash := hex.EncodeToString(paymentHash[:])
 
-	description := "test"
-	if failPayment {
-		description = FailPaymentDescription
+	options := []func(*zpay32.Invoice){zpay32.Description(description)}
+	// if amount is 0, create invoice with no amount
+	if amount > 0 {
+		options = append(options, zpay32.Amount(lnwire.MilliSatoshi(amount*1000)))
 	}
-
-	invoice, err := zpay32.NewInvoice(
-		&chaincfg.SigNetParams,
-		paymentHash,
-		time.Now(),
-		zpay32.Amount(lnwire.MilliSatoshi(amount*1000)),
-		zpay32.Description(description),
-	)
+	invoice, err := zpay32.NewInvoice(&chaincfg.SigNetParams, paymentHash, time.Now(), options...)
 	if err != nil {
 		return "", "", "", err
 	}
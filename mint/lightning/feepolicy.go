@@ -0,0 +1,83 @@
+package lightning
+
+import (
+	"context"
+	"math"
+)
+
+// FeePolicy defines how the fee reserve for a melt quote is calculated.
+// All amounts are in sats.
+type FeePolicy struct {
+	// percentage of the amount to reserve for routing fees (i.e 0.01 is 1%)
+	Percent float64
+	// minimum fee reserve regardless of amount
+	MinFee uint64
+	// maximum fee reserve. 0 means no maximum
+	MaxFee uint64
+	// if true, the backend will be asked to find a route to the destination
+	// and the fee of that route will be used as the reserve if it is higher
+	// than the percentage. This is only used if the backend implements RouteFeeEstimator
+	RouteProbing bool
+	// multiplier for the fee of the probed route in case the fee of the route
+	// used for the payment is higher (i.e 1.5). 0 means the fee is used as is
+	ProbeMargin float64
+}
+
+// DefaultFeePolicy reserves FeePercent of the amount with no minimum or maximum
+var DefaultFeePolicy = FeePolicy{Percent: FeePercent}
+
+// RouteFeeEstimator is implemented by backends that can estimate
+// the routing fee to pay a request by probing for a route.
+type RouteFeeEstimator interface {
+	// EstimateRouteFee returns the estimated fee in sats
+	// to pay amountMsat to the destination in the request
+	EstimateRouteFee(ctx context.Context, request string, amountMsat uint64) (uint64, error)
+}
+
+// FeeReserve returns the fee reserve for the amount using the percentage
+// and bounded by the minimum and maximum in the policy.
+func (policy FeePolicy) FeeReserve(amount uint64) uint64 {
+	fee := uint64(math.Ceil(float64(amount) * policy.Percent))
+	return policy.bound(fee)
+}
+
+// EstimateFeeReserve returns the fee reserve to pay amountMsat for the request.
+// If route probing is enabled and the client can estimate route fees, the estimate
+// with the margin is used if it is higher than FeeReserve.
+// Otherwise, or if probing fails, it is FeeReserve.
+func (policy FeePolicy) EstimateFeeReserve(
+	ctx context.Context,
+	client Client,
+	request string,
+	amountMsat uint64,
+) uint64 {
+	feeReserve := policy.FeeReserve(amountMsat / 1000)
+	if policy.RouteProbing {
+		if estimator, ok := client.(RouteFeeEstimator); ok {
+			fee, err := estimator.EstimateRouteFee(ctx, request, amountMsat)
+			if err == nil {
+				if policy.ProbeMargin > 0 {
+					fee = uint64(math.Ceil(float64(fee) * policy.ProbeMargin))
+				}
+				return policy.bound(max(fee, feeReserve))
+			}
+		}
+	}
+	return feeReserve
+}
+
+func (policy FeePolicy) bound(fee uint64) uint64 {
+	if fee < policy.MinFee {
+		fee = policy.MinFee
+	}
+	if policy.MaxFee > 0 && fee > policy.MaxFee {
+		fee = policy.MaxFee
+	}
+	return fee
+}
+
+// msatToSatCeil converts msat to sat rounding up
+// so that fees are never underestimated
+func msatToSatCeil(msat uint64) uint64 {
+	return (msat + 999) / 1000
+}
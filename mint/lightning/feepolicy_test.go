@@ -0,0 +1,110 @@
+package lightning
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestFeeReserve(t *testing.T) {
+	tests := []struct {
+		policy   FeePolicy
+		amount   uint64
+		expected uint64
+	}{
+		{policy: DefaultFeePolicy, amount: 1000, expected: 10},
+		{policy: DefaultFeePolicy, amount: 1001, expected: 11},
+		{policy: DefaultFeePolicy, amount: 0, expected: 0},
+		{policy: FeePolicy{Percent: 0.01, MinFee: 2}, amount: 10, expected: 2},
+		{policy: FeePolicy{Percent: 0.01, MinFee: 2}, amount: 10000, expected: 100},
+		{policy: FeePolicy{Percent: 0.01, MaxFee: 50}, amount: 10000, expected: 50},
+		{policy: FeePolicy{Percent: 0.01, MinFee: 2, MaxFee: 50}, amount: 2100, expected: 21},
+		{policy: FeePolicy{MinFee: 5}, amount: 2100, expected: 5},
+	}
+
+	for _, test := range tests {
+		fee := test.policy.FeeReserve(test.amount)
+		if fee != test.expected {
+			t.Fatalf("expected fee reserve of '%v' but got '%v'", test.expected, fee)
+		}
+	}
+}
+
+type fakeEstimator struct {
+	FakeBackend
+	fee uint64
+	err error
+}
+
+func (fe *fakeEstimator) EstimateRouteFee(ctx context.Context, request string, amountMsat uint64) (uint64, error) {
+	return fe.fee, fe.err
+}
+
+func TestEstimateFeeReserve(t *testing.T) {
+	tests := []struct {
+		policy   FeePolicy
+		client   Client
+		expected uint64
+	}{
+		// probing disabled uses percentage
+		{
+			policy:   FeePolicy{Percent: 0.01},
+			client:   &fakeEstimator{fee: 3},
+			expected: 100,
+		},
+		{
+			policy:   FeePolicy{Percent: 0.01, RouteProbing: true},
+			client:   &fakeEstimator{fee: 300},
+			expected: 300,
+		},
+		// estimate with margin
+		{
+			policy:   FeePolicy{Percent: 0.01, RouteProbing: true, ProbeMargin: 1.5},
+			client:   &fakeEstimator{fee: 101},
+			expected: 152,
+		},
+		// percentage is used if it is higher than the estimate
+		{
+			policy:   FeePolicy{Percent: 0.01, RouteProbing: true},
+			client:   &fakeEstimator{fee: 3},
+			expected: 100,
+		},
+		// estimate of zero from a direct channel does not leave the reserve empty
+		{
+			policy:   FeePolicy{Percent: 0.01, RouteProbing: true, ProbeMargin: 1.5},
+			client:   &fakeEstimator{fee: 0},
+			expected: 100,
+		},
+		// estimate is bounded by min
+		{
+			policy:   FeePolicy{MinFee: 5, RouteProbing: true},
+			client:   &fakeEstimator{fee: 0},
+			expected: 5,
+		},
+		// estimate is bounded by max
+		{
+			policy:   FeePolicy{Percent: 0.01, MaxFee: 200, RouteProbing: true},
+			client:   &fakeEstimator{fee: 300},
+			expected: 200,
+		},
+		// fallback to percentage if probing fails
+		{
+			policy:   FeePolicy{Percent: 0.01, RouteProbing: true},
+			client:   &fakeEstimator{err: errors.New("no route found")},
+			expected: 100,
+		},
+		// fallback to percentage if client can't estimate
+		{
+			policy:   FeePolicy{Percent: 0.01, RouteProbing: true},
+			client:   &FakeBackend{},
+			expected: 100,
+		},
+	}
+
+	for _, test := range tests {
+		fee := test.policy.EstimateFeeReserve(context.Background(), test.client, "", 10000*1000)
+		if fee != test.expected {
+			t.Fatalf("expected fee reserve of '%v' but got '%v'", test.expected, fee)
+		}
+	}
+}
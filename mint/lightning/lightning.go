@@ -8,19 +8,31 @@ import (
 // Client interface to interact with a Lightning backend
 type Client interface {
 	ConnectionStatus() error
-	CreateInvoice(amount uint64) (Invoice, error)
+	CreateInvoice(amount uint64, description string) (Invoice, error)
 	InvoiceStatus(hash string) (Invoice, error)
 	SendPayment(ctx context.Context, request string, maxFee uint64) (PaymentStatus, error)
 	PayPartialAmount(ctx context.Context, request string, amountMsat uint64, maxFee uint64) (PaymentStatus, error)
+	// PayAmountless pays amountMsat to an invoice that does not specify an amount
+	PayAmountless(ctx context.Context, request string, amountMsat uint64, maxFee uint64) (PaymentStatus, error)
 	OutgoingPaymentStatus(ctx context.Context, hash string) (PaymentStatus, error)
 	FeeReserve(amount uint64) uint64
 	SubscribeInvoice(ctx context.Context, paymentHash string) (InvoiceSubscriptionClient, error)
+	// Balance returns the funds available in the node
+	Balance(ctx context.Context) (Balance, error)
+}
+
+// InvoiceCanceler is implemented by backends that can cancel
+// or delete an invoice that has not been paid
+type InvoiceCanceler interface {
+	CancelInvoice(ctx context.Context, paymentHash string) error
 }
 
 const (
 	// 1 hour
 	InvoiceExpiryTime         = 3600
 	FeePercent        float64 = 0.01
+	// max length in bytes of the description in a BOLT11 invoice
+	MaxDescriptionLength = 639
 )
 
 var (
@@ -36,6 +48,18 @@ type Invoice struct {
 	Expiry         uint64
 }
 
+// Balance of the node in sats
+type Balance struct {
+	// local balance in open channels
+	Channel uint64 `json:"channel"`
+	// confirmed on-chain balance
+	OnChain uint64 `json:"onchain"`
+}
+
+func (b Balance) Total() uint64 {
+	return b.Channel + b.OnChain
+}
+
 type State int
 
 const (
@@ -48,6 +72,8 @@ type PaymentStatus struct {
 	Preimage             string
 	PaymentStatus        State
 	PaymentFailureReason string
+	// routing fee paid in sats
+	Fee uint64
 }
 
 // InvoiceSubscriptionClient subscribes to get updates on the status of an invoice
@@ -60,9 +60,10 @@ func (lnd *LndClient) ConnectionStatus() error {
 	return nil
 }
 
-func (lnd *LndClient) CreateInvoice(amount uint64) (Invoice, error) {
+func (lnd *LndClient) CreateInvoice(amount uint64, description string) (Invoice, error) {
 	invoiceRequest := lnrpc.Invoice{
 		Value:  int64(amount),
+		Memo:   description,
 		Expiry: InvoiceExpiryTime,
 	}
 
@@ -107,9 +108,29 @@ func (lnd *LndClient) InvoiceStatus(hash string) (Invoice, error) {
 }
 
 func (lnd *LndClient) SendPayment(ctx context.Context, request string, maxFee uint64) (PaymentStatus, error) {
+	return lnd.sendPayment(ctx, request, 0, maxFee)
+}
+
+func (lnd *LndClient) PayAmountless(
+	ctx context.Context,
+	request string,
+	amountMsat uint64,
+	maxFee uint64,
+) (PaymentStatus, error) {
+	return lnd.sendPayment(ctx, request, amountMsat, maxFee)
+}
+
+// sendPayment pays the request. amountMsat is only set for invoices with no amount
+func (lnd *LndClient) sendPayment(
+	ctx context.Context,
+	request string,
+	amountMsat uint64,
+	maxFee uint64,
+) (PaymentStatus, error) {
 	feeLimit := &lnrpc.FeeLimit{Limit: &lnrpc.FeeLimit_Fixed{Fixed: int64(maxFee)}}
 	sendPaymentRequest := lnrpc.SendRequest{
 		PaymentRequest: request,
+		AmtMsat:        int64(amountMsat),
 		FeeLimit:       feeLimit,
 	}
 	sendPaymentResponse, err := lnd.grpcClient.SendPaymentSync(ctx, &sendPaymentRequest)
@@ -129,6 +150,9 @@ func (lnd *LndClient) SendPayment(ctx context.Context, request string, maxFee ui
 
 	preimage := hex.EncodeToString(sendPaymentResponse.PaymentPreimage)
 	paymentResponse := PaymentStatus{Preimage: preimage, PaymentStatus: Succeeded}
+	if sendPaymentResponse.PaymentRoute != nil {
+		paymentResponse.Fee = msatToSatCeil(uint64(sendPaymentResponse.PaymentRoute.TotalFeesMsat))
+	}
 	return paymentResponse, nil
 }
 
@@ -180,7 +204,11 @@ func (lnd *LndClient) PayPartialAmount(
 	switch htlcAttempt.Status {
 	case lnrpc.HTLCAttempt_SUCCEEDED:
 		preimage := hex.EncodeToString(htlcAttempt.Preimage)
-		paymentResponse := PaymentStatus{Preimage: preimage, PaymentStatus: Succeeded}
+		paymentResponse := PaymentStatus{
+			Preimage:      preimage,
+			PaymentStatus: Succeeded,
+			Fee:           msatToSatCeil(uint64(route.TotalFeesMsat)),
+		}
 		return paymentResponse, nil
 	case lnrpc.HTLCAttempt_FAILED:
 		err := "payment failed"
@@ -232,7 +260,11 @@ func (lnd *LndClient) OutgoingPaymentStatus(ctx context.Context, hash string) (P
 		return PaymentStatus{PaymentStatus: Pending}, nil
 	}
 	if payment.Status == lnrpc.Payment_SUCCEEDED {
-		return PaymentStatus{PaymentStatus: Succeeded, Preimage: payment.PaymentPreimage}, nil
+		return PaymentStatus{
+			PaymentStatus: Succeeded,
+			Preimage:      payment.PaymentPreimage,
+			Fee:           msatToSatCeil(uint64(payment.FeeMsat)),
+		}, nil
 	}
 
 	return PaymentStatus{PaymentStatus: Failed}, errors.New("unknown")
@@ -243,6 +275,58 @@ func (lnd *LndClient) FeeReserve(amount uint64) uint64 {
 	return uint64(fee)
 }
 
+// EstimateRouteFee queries a route to the destination of the request
+// and returns the total fees of that route
+func (lnd *LndClient) EstimateRouteFee(ctx context.Context, request string, amountMsat uint64) (uint64, error) {
+	payReq, err := lnd.grpcClient.DecodePayReq(ctx, &lnrpc.PayReqString{PayReq: request})
+	if err != nil {
+		return 0, err
+	}
+
+	queryRoutesRequest := lnrpc.QueryRoutesRequest{
+		PubKey:  payReq.Destination,
+		AmtMsat: int64(amountMsat),
+	}
+	queryRoutesResponse, err := lnd.grpcClient.QueryRoutes(ctx, &queryRoutesRequest)
+	if err != nil {
+		return 0, err
+	}
+	if len(queryRoutesResponse.Routes) < 1 {
+		return 0, errors.New("no routes found")
+	}
+
+	return msatToSatCeil(uint64(queryRoutesResponse.Routes[0].TotalFeesMsat)), nil
+}
+
+func (lnd *LndClient) CancelInvoice(ctx context.Context, paymentHash string) error {
+	hash, err := hex.DecodeString(paymentHash)
+	if err != nil {
+		return err
+	}
+	_, err = lnd.invoicesClient.CancelInvoice(ctx, &invoicesrpc.CancelInvoiceMsg{PaymentHash: hash})
+	return err
+}
+
+func (lnd *LndClient) Balance(ctx context.Context) (Balance, error) {
+	channelBalance, err := lnd.grpcClient.ChannelBalance(ctx, &lnrpc.ChannelBalanceRequest{})
+	if err != nil {
+		return Balance{}, err
+	}
+	walletBalance, err := lnd.grpcClient.WalletBalance(ctx, &lnrpc.WalletBalanceRequest{})
+	if err != nil {
+		return Balance{}, err
+	}
+
+	var balance Balance
+	if channelBalance.LocalBalance != nil {
+		balance.Channel = channelBalance.LocalBalance.Sat
+	}
+	if walletBalance.ConfirmedBalance > 0 {
+		balance.OnChain = uint64(walletBalance.ConfirmedBalance)
+	}
+	return balance, nil
+}
+
 func (lnd *LndClient) SubscribeInvoice(ctx context.Context, paymentHash string) (InvoiceSubscriptionClient, error) {
 	hash, err := hex.DecodeString(paymentHash)
 	if err != nil {
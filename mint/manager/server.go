@@ -6,10 +6,13 @@ import (
 	"net"
 	"os"
 	"path/filepath"
+	"slices"
 	"strconv"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/mint"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
 )
 
 const (
@@ -22,15 +25,31 @@ const (
 	TOTAL_BALANCE          = "total_balance"
 	LIST_KEYSETS           = "list_keysets"
 	ROTATE_KEYSET          = "rotate_keyset"
+	QUOTE_HISTORY          = "quote_history"
+	FEES                   = "fees"
+	SOLVENCY               = "solvency"
+	LIST_TENANTS           = "list_tenants"
 )
 
 type Server struct {
-	mint      *mint.Mint
+	mint *mint.Mint
+	// mints by tenant name when running a multi-tenant host
+	mints     map[string]*mint.Mint
 	listener  net.Listener
 	socketDir string
 }
 
 func SetupServer(mint *mint.Mint) (*Server, error) {
+	return setupServer(mint, nil)
+}
+
+// SetupHostServer sets up the admin server for the mints of a multi-tenant host.
+// Requests need to specify the tenant of the mint they are for.
+func SetupHostServer(mints map[string]*mint.Mint) (*Server, error) {
+	return setupServer(nil, mints)
+}
+
+func setupServer(mint *mint.Mint, mints map[string]*mint.Mint) (*Server, error) {
 	if err := os.MkdirAll(socketdir, 0700); err != nil {
 		return nil, err
 	}
@@ -47,6 +66,7 @@ func SetupServer(mint *mint.Mint) (*Server, error) {
 
 	return &Server{
 		mint:      mint,
+		mints:     mints,
 		listener:  listener,
 		socketDir: socketdir,
 	}, nil
@@ -85,6 +105,8 @@ type Request struct {
 	Method  string   `json:"method"`
 	Params  []string `json:"params,omitempty"`
 	Id      int      `json:"id"`
+	// name of the tenant when the mint is run in a multi-tenant host
+	Tenant string `json:"tenant,omitempty"`
 }
 
 type Response struct {
@@ -180,33 +202,97 @@ type TotalBalanceResponse struct {
 	TotalInCirculation uint64                `json:"total_circulation"`
 }
 
+// FeesResponse has the entries in the fee ledger for a period of time
+// and the totals of the fees collected and paid
+type FeesResponse struct {
+	Entries            []storage.FeeEntry `json:"entries"`
+	TotalInputFees     uint64             `json:"total_input_fees"`
+	TotalLightningFees uint64             `json:"total_lightning_fees"`
+	TotalReserveKept   uint64             `json:"total_reserve_kept"`
+	// input fees + fee reserve kept from melts
+	Profit uint64 `json:"profit"`
+}
+
+// mintForRequest returns the mint of the tenant in the request
+func (s *Server) mintForRequest(req Request) (*mint.Mint, *Error) {
+	if s.mints == nil {
+		if len(req.Tenant) > 0 {
+			return nil, &Error{-32000, "mint is not running in a multi-tenant host"}
+		}
+		return s.mint, nil
+	}
+
+	if len(req.Tenant) == 0 {
+		return nil, &Error{-32000, "tenant not specified"}
+	}
+	m, ok := s.mints[req.Tenant]
+	if !ok {
+		return nil, &Error{-32000, fmt.Sprintf("unknown tenant '%v'", req.Tenant)}
+	}
+	return m, nil
+}
+
 func (s *Server) processRequest(req Request) (Response, *Error) {
+	if req.Method == LIST_TENANTS {
+		tenants := make([]string, 0, len(s.mints))
+		for tenant := range s.mints {
+			tenants = append(tenants, tenant)
+		}
+		slices.Sort(tenants)
+		result, _ := json.Marshal(tenants)
+		return NewResponse(result, req.Id), nil
+	}
+
+	m, jsonErr := s.mintForRequest(req)
+	if jsonErr != nil {
+		return Response{}, jsonErr
+	}
+
 	switch req.Method {
 	case ISSUED_ECASH_REQUEST:
-		return s.handleIssuedEcashReq(req)
+		return s.handleIssuedEcashReq(m, req)
 
 	case REDEEMED_ECASH_REQUEST:
-		return s.handleRedeemedEcashRequest(req)
+		return s.handleRedeemedEcashRequest(m, req)
 
 	case TOTAL_BALANCE:
-		return s.handleTotalBalanceRequest(req)
+		return s.handleTotalBalanceRequest(m, req)
 
 	case LIST_KEYSETS:
-		keysets := s.mint.ListKeysets()
+		keysets := m.ListKeysets()
 		result, _ := json.Marshal(keysets)
 		return NewResponse(result, req.Id), nil
 
 	case ROTATE_KEYSET:
-		return s.handleRotateKeyset(req)
+		return s.handleRotateKeyset(m, req)
+
+	case QUOTE_HISTORY:
+		history, err := m.QuoteHistory()
+		if err != nil {
+			return Response{}, &Error{Code: -32000, Message: err.Error()}
+		}
+		result, _ := json.Marshal(history)
+		return NewResponse(result, req.Id), nil
+
+	case FEES:
+		return s.handleFeesRequest(m, req)
+
+	case SOLVENCY:
+		status, err := m.CheckSolvency()
+		if err != nil {
+			return Response{}, &Error{-32000, err.Error()}
+		}
+		result, _ := json.Marshal(status)
+		return NewResponse(result, req.Id), nil
 
 	default:
 		return Response{}, &Error{Code: -32601, Message: "invalid method"}
 	}
 }
 
-func (s *Server) handleIssuedEcashReq(req Request) (Response, *Error) {
+func (s *Server) handleIssuedEcashReq(m *mint.Mint, req Request) (Response, *Error) {
 	if len(req.Params) > 0 {
-		issuedEcashMap, err := s.mint.IssuedEcash()
+		issuedEcashMap, err := m.IssuedEcash()
 		if err != nil {
 			return Response{}, &Error{Code: -32000, Message: err.Error()}
 		}
@@ -223,7 +309,7 @@ func (s *Server) handleIssuedEcashReq(req Request) (Response, *Error) {
 		result, _ := json.Marshal(issuedByKeyset)
 		return NewResponse(result, req.Id), nil
 	} else {
-		issuedEcash, err := s.issuedEcash()
+		issuedEcash, err := s.issuedEcash(m)
 		if err != nil {
 			return Response{}, &Error{Code: -32000, Message: err.Error()}
 		}
@@ -232,9 +318,9 @@ func (s *Server) handleIssuedEcashReq(req Request) (Response, *Error) {
 	}
 }
 
-func (s *Server) handleRedeemedEcashRequest(req Request) (Response, *Error) {
+func (s *Server) handleRedeemedEcashRequest(m *mint.Mint, req Request) (Response, *Error) {
 	if len(req.Params) > 0 {
-		redeemedEcashMap, err := s.mint.RedeemedEcash()
+		redeemedEcashMap, err := m.RedeemedEcash()
 		if err != nil {
 			return Response{}, &Error{Code: -32000, Message: err.Error()}
 		}
@@ -251,7 +337,7 @@ func (s *Server) handleRedeemedEcashRequest(req Request) (Response, *Error) {
 		result, _ := json.Marshal(redeemedByKeyset)
 		return NewResponse(result, req.Id), nil
 	} else {
-		redeemedEcash, err := s.redeemedEcash()
+		redeemedEcash, err := s.redeemedEcash(m)
 		if err != nil {
 			return Response{}, &Error{Code: -32000, Message: err.Error()}
 		}
@@ -260,13 +346,13 @@ func (s *Server) handleRedeemedEcashRequest(req Request) (Response, *Error) {
 	}
 }
 
-func (s *Server) handleTotalBalanceRequest(req Request) (Response, *Error) {
-	issuedEcash, err := s.issuedEcash()
+func (s *Server) handleTotalBalanceRequest(m *mint.Mint, req Request) (Response, *Error) {
+	issuedEcash, err := s.issuedEcash(m)
 	if err != nil {
 		return Response{}, &Error{Code: -32000, Message: err.Error()}
 	}
 
-	redeemedEcash, err := s.redeemedEcash()
+	redeemedEcash, err := s.redeemedEcash(m)
 	if err != nil {
 		return Response{}, &Error{Code: -32000, Message: err.Error()}
 	}
@@ -280,7 +366,7 @@ func (s *Server) handleTotalBalanceRequest(req Request) (Response, *Error) {
 	return NewResponse(result, req.Id), nil
 }
 
-func (s *Server) handleRotateKeyset(req Request) (Response, *Error) {
+func (s *Server) handleRotateKeyset(m *mint.Mint, req Request) (Response, *Error) {
 	if len(req.Params) < 1 {
 		return Response{}, &Error{-32000, "fee not included"}
 	} else {
@@ -291,7 +377,7 @@ func (s *Server) handleRotateKeyset(req Request) (Response, *Error) {
 			return Response{}, &Error{-32000, "invalid fee"}
 		}
 
-		newKeyset, err := s.mint.RotateKeyset(uint(keysetFee))
+		newKeyset, err := m.RotateKeyset(uint(keysetFee))
 		if err != nil {
 			return Response{}, &Error{-32000, err.Error()}
 		}
@@ -301,8 +387,44 @@ func (s *Server) handleRotateKeyset(req Request) (Response, *Error) {
 	}
 }
 
-func (s *Server) issuedEcash() (IssuedEcashResponse, error) {
-	issuedEcashMap, err := s.mint.IssuedEcash()
+// params are the optional start and end (unix timestamps) of the period
+func (s *Server) handleFeesRequest(m *mint.Mint, req Request) (Response, *Error) {
+	from := time.Unix(0, 0)
+	to := time.Now()
+	if len(req.Params) > 0 {
+		timestamp, err := strconv.ParseInt(req.Params[0], 10, 64)
+		if err != nil {
+			return Response{}, &Error{-32000, "invalid start of period"}
+		}
+		from = time.Unix(timestamp, 0)
+	}
+	if len(req.Params) > 1 {
+		timestamp, err := strconv.ParseInt(req.Params[1], 10, 64)
+		if err != nil {
+			return Response{}, &Error{-32000, "invalid end of period"}
+		}
+		to = time.Unix(timestamp, 0)
+	}
+
+	entries, err := m.FeeEntries(from, to)
+	if err != nil {
+		return Response{}, &Error{-32000, err.Error()}
+	}
+
+	fees := FeesResponse{Entries: entries}
+	for _, entry := range entries {
+		fees.TotalInputFees += entry.InputFee
+		fees.TotalLightningFees += entry.LightningFee
+		fees.TotalReserveKept += entry.ReserveKept
+	}
+	fees.Profit = fees.TotalInputFees + fees.TotalReserveKept
+
+	result, _ := json.Marshal(fees)
+	return NewResponse(result, req.Id), nil
+}
+
+func (s *Server) issuedEcash(m *mint.Mint) (IssuedEcashResponse, error) {
+	issuedEcashMap, err := m.IssuedEcash()
 	if err != nil {
 		return IssuedEcashResponse{}, fmt.Errorf("unable to get issued ecash from db: %v", err)
 	}
@@ -318,8 +440,8 @@ func (s *Server) issuedEcash() (IssuedEcashResponse, error) {
 	return issuedEcash, nil
 }
 
-func (s *Server) redeemedEcash() (RedeemedEcashResponse, error) {
-	redeemedEcashMap, err := s.mint.RedeemedEcash()
+func (s *Server) redeemedEcash(m *mint.Mint) (RedeemedEcashResponse, error) {
+	redeemedEcashMap, err := m.RedeemedEcash()
 	if err != nil {
 		return RedeemedEcashResponse{}, fmt.Errorf("unable to get redeemed ecash from db: %v", err)
 	}
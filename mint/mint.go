@@ -15,6 +15,7 @@ import (
 	"reflect"
 	"runtime"
 	"slices"
+	"sync"
 	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
@@ -26,7 +27,6 @@ import (
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut10"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut11"
-	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut14"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut17"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut20"
 	"github.com/Origami74/gonuts-tollgate/crypto"
@@ -56,12 +56,32 @@ type Mint struct {
 
 	// map of all keysets (both active and inactive)
 	keysets map[string]crypto.MintKeyset
+	// guards activeKeyset and keysets, which can change
+	// when keysets are rotated by this or another instance
+	keysetsMu sync.RWMutex
+	// last time the keysets were loaded from the db
+	keysetsLoadedAt time.Time
 
 	lightningClient lightning.Client
+	feePolicy       *lightning.FeePolicy
 	mintInfo        nut06.MintInfo
 	limits          MintLimits
 	logger          *slog.Logger
 	mppEnabled      bool
+	quoteRetention  time.Duration
+	// max number of goroutines used to verify proofs
+	// and sign blinded messages of a single request
+	concurrency int
+	// verifiers for the spending conditions of NUT-10 locked proofs by kind
+	spendingConditions map[nut10.SecretKind]SpendingConditionVerifier
+	// result of the last solvency check
+	solvency *solvencyMonitor
+	// melts in progress that the shutdown waits for
+	drain *drainState
+	// identifies this instance when several share the db
+	instanceId string
+	// whether other instances of the mint share the db
+	multiInstance bool
 
 	publisher *pubsub.PubSub
 	ctx       context.Context
@@ -116,11 +136,21 @@ func LoadMint(config Config) (*Mint, error) {
 		limits:     config.Limits,
 		logger:     logger,
 		mppEnabled: config.EnableMPP,
-		publisher:  pubsub.NewPubSub(),
+		feePolicy:  config.FeePolicy,
 		ctx:        ctx,
 		cancel:     cancel,
 	}
 
+	mint.instanceId = newInstanceId()
+	mint.multiInstance = config.PubSubTransport != nil
+	if config.PubSubTransport != nil {
+		mint.publisher = pubsub.NewPubSubWithTransport(config.PubSubTransport, func(err error) {
+			mint.logErrorf("error sending notification to other instances: %v", err)
+		})
+	} else {
+		mint.publisher = pubsub.NewPubSub()
+	}
+
 	// if no keysets stored, just create a new one
 	if len(dbKeysets) == 0 {
 		keyset, err := crypto.GenerateKeyset(master, 0, config.InputFeePpk, true)
@@ -176,8 +206,47 @@ func LoadMint(config Config) (*Mint, error) {
 		return nil, fmt.Errorf("can't connect to lightning backend: %v", err)
 	}
 	mint.lightningClient = config.LightningClient
+
+	mint.spendingConditions = builtinSpendingConditions()
+	for kind, verifier := range config.SpendingConditions {
+		if len(kind) == 0 || kind == nut10.AnyoneCanSpend {
+			return nil, fmt.Errorf("invalid NUT-10 kind '%v'", kind)
+		}
+		if _, ok := mint.spendingConditions[kind]; ok {
+			return nil, fmt.Errorf("verifier for NUT-10 kind '%v' is already registered", kind)
+		}
+		mint.spendingConditions[kind] = verifier
+	}
 	mint.SetMintInfo(config.MintInfo)
 
+	mint.concurrency = config.Concurrency
+	if mint.concurrency <= 0 {
+		mint.concurrency = runtime.NumCPU()
+	}
+
+	mint.quoteRetention = config.QuoteRetention
+	if mint.quoteRetention > 0 {
+		go mint.runQuoteCleanup()
+	}
+
+	mint.drain = newDrainState()
+	// get the quotes left pending before accepting new melts
+	pendingMeltQuotes, err := db.GetPendingMeltQuotes()
+	if err != nil {
+		return nil, fmt.Errorf("error getting pending melt quotes: %v", err)
+	}
+	go mint.checkPendingMeltQuotes(pendingMeltQuotes)
+
+	mint.solvency = &solvencyMonitor{}
+	if config.SolvencyCheckInterval > 0 {
+		go mint.runSolvencyCheck(config.SolvencyCheckInterval)
+	}
+
+	// keysets rotated by other instances are loaded when they publish the rotation
+	if config.PubSubTransport != nil {
+		go mint.runKeysetSync()
+	}
+
 	return mint, nil
 }
 
@@ -231,6 +300,13 @@ func (m *Mint) logErrorf(format string, args ...any) {
 	_ = m.logger.Handler().Handle(context.Background(), r)
 }
 
+func (m *Mint) logWarnf(format string, args ...any) {
+	var pcs [1]uintptr
+	runtime.Callers(2, pcs[:])
+	r := slog.NewRecord(time.Now(), slog.LevelWarn, fmt.Sprintf(format, args...), pcs[0])
+	_ = m.logger.Handler().Handle(context.Background(), r)
+}
+
 func (m *Mint) logDebugf(format string, args ...any) {
 	if !m.logger.Enabled(context.Background(), slog.LevelDebug) {
 		return
@@ -242,8 +318,22 @@ func (m *Mint) logDebugf(format string, args ...any) {
 	_ = m.logger.Handler().Handle(context.Background(), r)
 }
 
+// Shutdown cancels the payments of the melts still in progress
+// and closes the db. Drain should be called before to let them resolve.
 func (m *Mint) Shutdown() error {
+	m.drain.stopAccepting()
 	m.cancel()
+
+	// give canceled melts time to save their state before closing the db
+	ctx, cancel := context.WithTimeout(context.Background(), meltCancelGracePeriod)
+	defer cancel()
+	if err := m.drain.wait(ctx); err != nil {
+		m.logErrorf("melt quotes %v still in progress when closing the db", m.drain.inflightQuotes())
+	}
+	m.releaseLeases()
+	if err := m.publisher.Close(); err != nil {
+		m.logErrorf("error closing pubsub transport: %v", err)
+	}
 	return m.db.Close()
 }
 
@@ -252,6 +342,10 @@ func (m *Mint) Shutdown() error {
 // The request to mint a token is explained in
 // NUT-04 here: https://github.com/cashubtc/nuts/blob/main/04.md.
 func (m *Mint) RequestMintQuote(mintQuoteRequest nut04.PostMintQuoteBolt11Request) (storage.MintQuote, error) {
+	if err := m.checkDraining(); err != nil {
+		return storage.MintQuote{}, err
+	}
+
 	// only support sat unit
 	if mintQuoteRequest.Unit != cashu.Sat.String() {
 		errmsg := fmt.Sprintf("unit '%v' not supported", mintQuoteRequest.Unit)
@@ -273,6 +367,11 @@ func (m *Mint) RequestMintQuote(mintQuoteRequest nut04.PostMintQuoteBolt11Reques
 		}
 	}
 
+	if len(mintQuoteRequest.Description) > lightning.MaxDescriptionLength {
+		errmsg := fmt.Sprintf("description cannot be longer than %v bytes", lightning.MaxDescriptionLength)
+		return storage.MintQuote{}, cashu.BuildCashuError(errmsg, cashu.StandardErrCode)
+	}
+
 	// check limits
 	requestAmount := mintQuoteRequest.Amount
 	if m.limits.MintingSettings.MaxAmount > 0 {
@@ -290,10 +389,13 @@ func (m *Mint) RequestMintQuote(mintQuoteRequest nut04.PostMintQuoteBolt11Reques
 			return storage.MintQuote{}, cashu.MintingDisabled
 		}
 	}
+	if m.solvency.mintingDisabled() {
+		return storage.MintQuote{}, cashu.MintingDisabled
+	}
 
 	// get an invoice from the lightning backend
 	m.logInfof("requesting invoice from lightning backend for %v sats", requestAmount)
-	invoice, err := m.requestInvoice(requestAmount)
+	invoice, err := m.requestInvoice(requestAmount, mintQuoteRequest.Description)
 	if err != nil {
 		errmsg := fmt.Sprintf("could not generate invoice: %v", err)
 		return storage.MintQuote{}, cashu.BuildCashuError(errmsg, cashu.LightningBackendErrCode)
@@ -312,6 +414,7 @@ func (m *Mint) RequestMintQuote(mintQuoteRequest nut04.PostMintQuoteBolt11Reques
 		State:          nut04.Unpaid,
 		Expiry:         uint64(time.Now().Add(time.Second * time.Duration(invoice.Expiry)).Unix()),
 		Pubkey:         publicKey,
+		Description:    mintQuoteRequest.Description,
 	}
 
 	err = m.db.SaveMintQuote(mintQuote)
@@ -344,12 +447,16 @@ func (m *Mint) GetMintQuoteState(quoteId string) (storage.MintQuote, error) {
 
 		if status.Settled {
 			m.logInfof("mint quote '%v' with invoice payment hash '%v' was paid", mintQuote.Id, mintQuote.PaymentHash)
-			mintQuote.State = nut04.Paid
-			err := m.db.UpdateMintQuoteState(mintQuote.Id, mintQuote.State)
+			set, err := m.db.CompareAndSetMintQuoteState(mintQuote.Id, nut04.Unpaid, nut04.Paid)
 			if err != nil {
 				errmsg := fmt.Sprintf("error updating mint quote in db: %v", err)
 				return storage.MintQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 			}
+			// state was changed by another request since it was read
+			if !set {
+				return m.GetMintQuoteState(quoteId)
+			}
+			mintQuote.State = nut04.Paid
 
 			jsonQuote, _ := json.Marshal(mintQuote)
 			m.publisher.Publish(BOLT11_MINT_QUOTE_TOPIC, jsonQuote)
@@ -362,6 +469,10 @@ func (m *Mint) GetMintQuoteState(quoteId string) (storage.MintQuote, error) {
 // MintTokens verifies whether the mint quote with id has been paid and proceeds to
 // sign the blindedMessages and return the BlindedSignatures if it was paid.
 func (m *Mint) MintTokens(mintTokensRequest nut04.PostMintBolt11Request) (cashu.BlindedSignatures, error) {
+	if err := m.checkRequestLimits(0, len(mintTokensRequest.Outputs)); err != nil {
+		return nil, err
+	}
+
 	mintQuote, err := m.GetMintQuoteState(mintTokensRequest.Quote)
 	if err != nil {
 		return nil, err
@@ -377,14 +488,18 @@ func (m *Mint) MintTokens(mintTokensRequest nut04.PostMintBolt11Request) (cashu.
 	case nut04.Pending:
 		return nil, cashu.QuotePending
 	case nut04.Paid:
-		err := func() error {
-			// set quote as pending while validating blinded messages and signing
-			err = m.db.UpdateMintQuoteState(mintQuote.Id, nut04.Pending)
-			if err != nil {
-				errmsg := fmt.Sprintf("error mint quote state: %v", err)
-				return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
-			}
+		// set quote as pending while validating blinded messages and signing.
+		// Only one request, in any instance of the mint, can do it
+		set, err := m.db.CompareAndSetMintQuoteState(mintQuote.Id, nut04.Paid, nut04.Pending)
+		if err != nil {
+			errmsg := fmt.Sprintf("error mint quote state: %v", err)
+			return nil, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+		}
+		if !set {
+			return nil, cashu.QuotePending
+		}
 
+		err = func() error {
 			blindedMessages := mintTokensRequest.Outputs
 			blindedMessagesAmount, err := blindedMessages.AmountChecked()
 			if err != nil {
@@ -480,6 +595,10 @@ func (m *Mint) MintTokens(mintTokensRequest nut04.PostMintBolt11Request) (cashu.
 // the proofs that were used as input.
 // It returns the BlindedSignatures.
 func (m *Mint) Swap(proofs cashu.Proofs, blindedMessages cashu.BlindedMessages) (cashu.BlindedSignatures, error) {
+	if err := m.checkRequestLimits(len(proofs), len(blindedMessages)); err != nil {
+		return nil, err
+	}
+
 	var proofsAmount uint64
 	Ys := make([]string, len(proofs))
 	for i, proof := range proofs {
@@ -529,12 +648,10 @@ func (m *Mint) Swap(proofs cashu.Proofs, blindedMessages cashu.BlindedMessages)
 		return nil, cashu.BlindedMessageAlreadySigned
 	}
 
-	// if sig all, verify signatures in blinded messages
-	if nut11.ProofsSigAll(proofs) {
-		m.logDebugf("locked proofs have SIG_ALL flag. Verifying blinded messages")
-		if err := verifyBlindedMessages(proofs, blindedMessages); err != nil {
-			return nil, err
-		}
+	// verify blinded messages if required by the spending conditions
+	// in the proofs (i.e signatures of the SIG_ALL message)
+	if err := m.verifyBlindedMessages(proofs, blindedMessages, ""); err != nil {
+		return nil, err
 	}
 
 	// if verification complete, sign blinded messages
@@ -543,8 +660,12 @@ func (m *Mint) Swap(proofs cashu.Proofs, blindedMessages cashu.BlindedMessages)
 		return nil, err
 	}
 
-	// invalidate proofs after signing blinded messages
+	// invalidate proofs after signing blinded messages. Signatures are
+	// not returned if the proofs were spent by a concurrent request
 	if err := m.db.SaveProofs(proofs); err != nil {
+		if stateErr := proofStateErr(err); stateErr != nil {
+			return nil, stateErr
+		}
 		errmsg := fmt.Sprintf("error invalidating proofs. Could not save proofs to db: %v", err)
 		return nil, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 	}
@@ -552,6 +673,9 @@ func (m *Mint) Swap(proofs cashu.Proofs, blindedMessages cashu.BlindedMessages)
 		errmsg := fmt.Sprintf("error saving blind signatures: %v", err)
 		return nil, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 	}
+	if fees > 0 {
+		m.recordFeeEntry(storage.FeeEntry{Operation: storage.SwapOperation, InputFee: fees})
+	}
 
 	m.publishProofsStateChanges(proofs, nut07.Spent)
 
@@ -561,6 +685,10 @@ func (m *Mint) Swap(proofs cashu.Proofs, blindedMessages cashu.BlindedMessages)
 // RequestMeltQuote will process a request to melt tokens and return a MeltQuote.
 // A melt is requested by a wallet to request the mint to pay an invoice.
 func (m *Mint) RequestMeltQuote(meltQuoteRequest nut05.PostMeltQuoteBolt11Request) (storage.MeltQuote, error) {
+	if err := m.checkDraining(); err != nil {
+		return storage.MeltQuote{}, err
+	}
+
 	if meltQuoteRequest.Unit != cashu.Sat.String() {
 		errmsg := fmt.Sprintf("unit '%v' not supported", meltQuoteRequest.Unit)
 		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.UnitErrCode)
@@ -573,10 +701,38 @@ func (m *Mint) RequestMeltQuote(meltQuoteRequest nut05.PostMeltQuoteBolt11Reques
 		errmsg := fmt.Sprintf("invalid invoice: %v", err)
 		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.MeltQuoteErrCode)
 	}
-	if bolt11.MSatoshi == 0 {
+
+	var options nut05.MeltOptions
+	if meltQuoteRequest.Options != nil {
+		options = *meltQuoteRequest.Options
+	}
+
+	isAmountless := false
+	invoiceMsatAmount := uint64(bolt11.MSatoshi)
+	if options.Amountless != nil {
+		if bolt11.MSatoshi != 0 && options.Amountless.AmountMsat != invoiceMsatAmount {
+			return storage.MeltQuote{},
+				cashu.BuildCashuError("amountless amount does not match amount in invoice", cashu.MeltQuoteErrCode)
+		}
+		if bolt11.MSatoshi == 0 {
+			if options.Amountless.AmountMsat < 1000 {
+				return storage.MeltQuote{},
+					cashu.BuildCashuError("amountless amount must be at least 1 sat", cashu.MeltQuoteErrCode)
+			}
+			// quote amounts are in sats so the amount paid
+			// has to be the same as the one quoted
+			if options.Amountless.AmountMsat%1000 != 0 {
+				return storage.MeltQuote{},
+					cashu.BuildCashuError("amountless amount must be a whole number of sats", cashu.MeltQuoteErrCode)
+			}
+			isAmountless = true
+			invoiceMsatAmount = options.Amountless.AmountMsat
+		}
+	}
+	if invoiceMsatAmount == 0 {
 		return storage.MeltQuote{}, cashu.BuildCashuError("invoice has no amount", cashu.MeltQuoteErrCode)
 	}
-	invoiceSatAmount := uint64(bolt11.MSatoshi) / 1000
+	invoiceSatAmount := invoiceMsatAmount / 1000
 	quoteAmount := invoiceSatAmount
 
 	// check if a mint quote exists with the same invoice.
@@ -588,41 +744,53 @@ func (m *Mint) RequestMeltQuote(meltQuoteRequest nut05.PostMeltQuoteBolt11Reques
 
 	isMpp := false
 	var amountMsat uint64 = 0
+	if isAmountless {
+		amountMsat = invoiceMsatAmount
+	}
+	// amount in msat that will be sent to the destination
+	paymentAmountMsat := invoiceMsatAmount
 	// check mpp option
-	if len(meltQuoteRequest.Options) > 0 {
-		mpp, ok := meltQuoteRequest.Options["mpp"]
-		if ok {
-			if m.mppEnabled {
-				// if this is an internal invoice, reject MPP request
-				if isInternal {
-					return storage.MeltQuote{},
-						cashu.BuildCashuError("mpp for internal invoice is not allowed", cashu.MeltQuoteErrCode)
-				}
+	if options.Mpp != nil {
+		if m.mppEnabled {
+			// if this is an internal invoice, reject MPP request
+			if isInternal {
+				return storage.MeltQuote{},
+					cashu.BuildCashuError("mpp for internal invoice is not allowed", cashu.MeltQuoteErrCode)
+			}
+			if isAmountless {
+				return storage.MeltQuote{},
+					cashu.BuildCashuError("mpp for amountless invoice is not allowed", cashu.MeltQuoteErrCode)
+			}
 
-				// check mpp msat amount is less than invoice amount
-				if mpp.AmountMsat >= uint64(bolt11.MSatoshi) {
-					return storage.MeltQuote{},
-						cashu.BuildCashuError("mpp amount is not less than amount in invoice",
-							cashu.MeltQuoteErrCode)
-				}
-				isMpp = true
-				amountMsat = mpp.AmountMsat
-				quoteAmount = amountMsat / 1000
-				m.logInfof("got melt quote request to pay partial amount '%v' of invoice with amount '%v'",
-					quoteAmount, invoiceSatAmount)
-			} else {
+			// check mpp msat amount is less than invoice amount
+			if options.Mpp.AmountMsat >= uint64(bolt11.MSatoshi) {
 				return storage.MeltQuote{},
-					cashu.BuildCashuError("MPP is not supported", cashu.MeltQuoteErrCode)
+					cashu.BuildCashuError("mpp amount is not less than amount in invoice",
+						cashu.MeltQuoteErrCode)
 			}
+			isMpp = true
+			amountMsat = options.Mpp.AmountMsat
+			paymentAmountMsat = amountMsat
+			quoteAmount = amountMsat / 1000
+			m.logInfof("got melt quote request to pay partial amount '%v' of invoice with amount '%v'",
+				quoteAmount, invoiceSatAmount)
+		} else {
+			return storage.MeltQuote{},
+				cashu.BuildCashuError("MPP is not supported", cashu.MeltQuoteErrCode)
 		}
 	}
 
-	// check melt limit
+	// check melt limits
 	if m.limits.MeltingSettings.MaxAmount > 0 {
 		if quoteAmount > m.limits.MeltingSettings.MaxAmount {
 			return storage.MeltQuote{}, cashu.MeltAmountExceededErr
 		}
 	}
+	// the min amount only applies to the amount chosen
+	// by the wallet for amountless invoices
+	if isAmountless && quoteAmount < m.limits.MeltingSettings.MinAmount {
+		return storage.MeltQuote{}, cashu.MeltAmountBelowMinErr
+	}
 
 	// check if a melt quote for the invoice already exists
 	quote, _ := m.db.GetMeltQuoteByPaymentRequest(request)
@@ -635,14 +803,15 @@ func (m *Mint) RequestMeltQuote(meltQuoteRequest nut05.PostMeltQuoteBolt11Reques
 		m.logErrorf("error generating random quote id: %v", err)
 		return storage.MeltQuote{}, cashu.StandardErr
 	}
-	// Fee reserve that is required by the mint
-	fee := m.lightningClient.FeeReserve(quoteAmount)
 	// if mint quote exists with same invoice, it can be
 	// settled internally so set the fee to 0
+	var fee uint64 = 0
 	if isInternal {
 		m.logDebugf(`in melt quote request found mint quote with same invoice. 
 		Setting fee reserve to 0 because quotes can be settled internally.`)
-		fee = 0
+	} else {
+		// Fee reserve that is required by the mint
+		fee = m.feeReserve(request, paymentAmountMsat)
 	}
 	meltQuote := storage.MeltQuote{
 		Id:             quoteId,
@@ -654,6 +823,7 @@ func (m *Mint) RequestMeltQuote(meltQuoteRequest nut05.PostMeltQuoteBolt11Reques
 		Expiry:         uint64(time.Now().Add(time.Minute * QuoteExpiryMins).Unix()),
 		IsMpp:          isMpp,
 		AmountMsat:     amountMsat,
+		IsAmountless:   isAmountless,
 	}
 
 	m.logInfof("got melt quote request for invoice of amount '%v'. Setting fee reserve to %v",
@@ -694,51 +864,212 @@ func (m *Mint) GetMeltQuoteState(ctx context.Context, quoteId string) (storage.M
 			m.logInfof("payment %v succeded. setting melt quote '%v' to paid and invalidating proofs",
 				meltQuote.PaymentHash, meltQuote.Id)
 
-			proofs, err := m.removePendingProofsForQuote(meltQuote.Id)
+			resolved, err := m.resolvePendingMeltQuote(meltQuote.Id, paymentStatus.Preimage, nut05.Paid)
 			if err != nil {
-				errmsg := fmt.Sprintf("error removing pending proofs for quote: %v", err)
-				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+				return storage.MeltQuote{}, err
 			}
-			err = m.db.SaveProofs(proofs)
-			if err != nil {
-				errmsg := fmt.Sprintf("error invalidating proofs. Could not save proofs to db: %v", err)
-				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+			if !resolved {
+				return m.GetMeltQuoteState(ctx, quoteId)
 			}
-
 			meltQuote.State = nut05.Paid
 			meltQuote.Preimage = paymentStatus.Preimage
-			err = m.db.UpdateMeltQuote(meltQuote.Id, paymentStatus.Preimage, nut05.Paid)
+
+			proofs, Ys, err := m.pendingProofsForQuote(meltQuote.Id)
 			if err != nil {
-				errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
+				errmsg := fmt.Sprintf("error reading pending proofs for quote: %v", err)
+				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+			}
+			if err := m.db.SettlePendingProofs(Ys); err != nil {
+				errmsg := fmt.Sprintf("error invalidating proofs. Could not save proofs to db: %v", err)
 				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 			}
+
+			if err := m.recordFeePaid(&meltQuote, paymentStatus.Fee); err != nil {
+				return storage.MeltQuote{}, err
+			}
+			if err := m.signMeltChange(&meltQuote, proofs); err != nil {
+				return storage.MeltQuote{}, err
+			}
+			m.recordMeltFees(meltQuote, proofs, false)
 			m.publishProofsStateChanges(proofs, nut07.Spent)
 
 		case lightning.Failed:
 			m.logInfof("payment %v failed with error: %v. Setting melt quote '%v' to unpaid and removing proofs from pending",
 				meltQuote.PaymentHash, paymentStatus.PaymentFailureReason, meltQuote.Id)
 
-			meltQuote.State = nut05.Unpaid
-			err = m.db.UpdateMeltQuote(meltQuote.Id, "", meltQuote.State)
+			resolved, err := m.resolvePendingMeltQuote(meltQuote.Id, "", nut05.Unpaid)
 			if err != nil {
-				errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
-				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+				return storage.MeltQuote{}, err
+			}
+			if !resolved {
+				return m.GetMeltQuoteState(ctx, quoteId)
+			}
+			meltQuote.State = nut05.Unpaid
+			_, Ys, err := m.pendingProofsForQuote(meltQuote.Id)
+			if err == nil {
+				err = m.db.RemovePendingProofs(Ys)
 			}
-			_, err = m.removePendingProofsForQuote(meltQuote.Id)
 			if err != nil {
 				errmsg := fmt.Sprintf("error removing pending proofs for quote: %v", err)
 				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 			}
+			if err := m.db.DeleteMeltChange(meltQuote.Id); err != nil {
+				errmsg := fmt.Sprintf("error removing blank outputs for melt quote: %v", err)
+				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+			}
+		}
+	} else if meltQuote.State == nut05.Paid {
+		change, err := m.db.GetMeltChangeSignatures(meltQuote.Id)
+		if err != nil {
+			errmsg := fmt.Sprintf("error getting change for melt quote: %v", err)
+			return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 		}
+		meltQuote.Change = change
 	}
 
 	return meltQuote, nil
 }
 
-func (m *Mint) removePendingProofsForQuote(quoteId string) (cashu.Proofs, error) {
+// feeReserve returns the fee reserve required to pay amountMsat of the request.
+// If no fee policy was set in the config, the reserve from the lightning client is used.
+func (m *Mint) feeReserve(request string, amountMsat uint64) uint64 {
+	if m.feePolicy == nil {
+		return m.lightningClient.FeeReserve(amountMsat / 1000)
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, time.Second*10)
+	defer cancel()
+	return m.feePolicy.EstimateFeeReserve(ctx, m.lightningClient, request, amountMsat)
+}
+
+// recordFeePaid saves the lightning fee paid for the melt quote
+// and logs if it was more than the reserve
+func (m *Mint) recordFeePaid(meltQuote *storage.MeltQuote, feePaid uint64) error {
+	if feePaid > meltQuote.FeeReserve {
+		m.logErrorf("fee paid '%v' for melt quote '%v' is greater than fee reserve '%v'",
+			feePaid, meltQuote.Id, meltQuote.FeeReserve)
+	}
+
+	meltQuote.FeePaid = feePaid
+	if err := m.db.UpdateMeltQuoteFeePaid(meltQuote.Id, feePaid); err != nil {
+		errmsg := fmt.Sprintf("error saving fee paid for melt quote: %v", err)
+		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+	return nil
+}
+
+// verifyChangeOutputs verifies the blank outputs provided in a melt request
+// can be signed if there is change to return
+func (m *Mint) verifyChangeOutputs(outputs cashu.BlindedMessages) error {
+	if cashu.CheckDuplicateBlindedMessages(outputs) {
+		return cashu.DuplicateOutputs
+	}
+
+	activeKeyset := m.getActiveKeyset()
+	B_s := make([]string, len(outputs))
+	for i, output := range outputs {
+		if _, ok := m.getKeyset(output.Id); !ok {
+			return cashu.UnknownKeysetErr
+		}
+		if output.Id != activeKeyset.Id {
+			return cashu.InactiveKeysetSignatureRequest
+		}
+		B_bytes, err := hex.DecodeString(output.B_)
+		if err != nil {
+			errmsg := fmt.Sprintf("invalid B_: %v", err)
+			return cashu.BuildCashuError(errmsg, cashu.StandardErrCode)
+		}
+		if _, err := btcec.ParsePubKey(B_bytes); err != nil {
+			return cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+		}
+		B_s[i] = output.B_
+	}
+
+	sigs, err := m.db.GetBlindSignatures(B_s)
+	if err != nil {
+		errmsg := fmt.Sprintf("error getting blind signatures from db: %v", err)
+		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+	if len(sigs) > 0 {
+		return cashu.BlindedMessageAlreadySigned
+	}
+
+	// outputs stored as change for another melt cannot be used again
+	changeOutputs, err := m.db.GetMeltChangeOutputs(B_s)
+	if err != nil {
+		errmsg := fmt.Sprintf("error getting blank outputs from db: %v", err)
+		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+	if len(changeOutputs) > 0 {
+		return cashu.BlindedMessageAlreadySigned
+	}
+
+	return nil
+}
+
+// signMeltChange signs the blank outputs stored for the melt quote with
+// the amount of inputs - amount - fee paid - input fees (NUT-08).
+// The outputs signed and their signatures are saved in a single transaction
+// so that the stored blank outputs are kept if any of it fails.
+func (m *Mint) signMeltChange(meltQuote *storage.MeltQuote, proofs cashu.Proofs) error {
+	outputs, err := m.db.GetMeltChange(meltQuote.Id)
+	if err != nil {
+		errmsg := fmt.Sprintf("error getting blank outputs for melt quote: %v", err)
+		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+	if len(outputs) == 0 {
+		return nil
+	}
+
+	var changeOutputs cashu.BlindedMessages
+	var B_s []string
+	inputFees := uint64(m.TransactionFees(proofs))
+	overpaid, underflow := cashu.UnderflowSubUint64(
+		proofs.Amount(),
+		meltQuote.Amount+meltQuote.FeePaid+inputFees,
+	)
+	if !underflow && overpaid > 0 {
+		split := cashu.AmountSplit(overpaid)
+		// if there are not enough outputs, sign the largest amounts
+		if len(split) > len(outputs) {
+			split = split[len(split)-len(outputs):]
+		}
+		changeOutputs = make(cashu.BlindedMessages, len(split))
+		B_s = make([]string, len(split))
+		for i, amount := range split {
+			changeOutputs[i] = cashu.BlindedMessage{Amount: amount, B_: outputs[i].B_, Id: outputs[i].Id}
+			B_s[i] = outputs[i].B_
+		}
+	}
+
+	var change cashu.BlindedSignatures
+	if len(changeOutputs) > 0 {
+		change, err = m.signBlindedMessages(changeOutputs)
+		if err != nil {
+			return err
+		}
+	}
+
+	// the stored outputs are replaced with the ones signed so that
+	// the unsigned can be used by the wallet in another request
+	if err := m.db.ReplaceMeltChange(meltQuote.Id, changeOutputs, B_s, change); err != nil {
+		errmsg := fmt.Sprintf("error saving change for melt quote: %v", err)
+		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+
+	if len(change) > 0 {
+		m.logInfof("returning change of '%v' for overpaid fees in melt quote '%v'", overpaid, meltQuote.Id)
+		meltQuote.Change = change
+	}
+	return nil
+}
+
+// pendingProofsForQuote returns the proofs pending for the
+// melt quote and their Ys
+func (m *Mint) pendingProofsForQuote(quoteId string) (cashu.Proofs, []string, error) {
 	dbproofs, err := m.db.GetPendingProofsByQuote(quoteId)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	proofs := make(cashu.Proofs, len(dbproofs))
@@ -756,18 +1087,27 @@ func (m *Mint) removePendingProofsForQuote(quoteId string) (cashu.Proofs, error)
 		proofs[i] = proof
 	}
 
-	err = m.db.RemovePendingProofs(Ys)
-	if err != nil {
-		return nil, err
-	}
-
-	return proofs, nil
+	return proofs, Ys, nil
 }
 
 // MeltTokens verifies whether proofs provided are valid
 // and proceeds to attempt payment.
 func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltBolt11Request) (storage.MeltQuote, error) {
+	// the shutdown waits for the melt to finish. Payment is canceled
+	// if it does not resolve before the mint context is canceled
+	if !m.drain.enter(meltTokensRequest.Quote) {
+		return storage.MeltQuote{}, cashu.MintShuttingDownErr
+	}
+	defer m.drain.exit(meltTokensRequest.Quote)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stop := context.AfterFunc(m.ctx, cancel)
+	defer stop()
+
 	proofs := meltTokensRequest.Inputs
+	if err := m.checkRequestLimits(len(proofs), len(meltTokensRequest.Outputs)); err != nil {
+		return storage.MeltQuote{}, err
+	}
 
 	var proofsAmount uint64
 	Ys := make([]string, len(proofs))
@@ -804,22 +1144,56 @@ func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltB
 		return storage.MeltQuote{}, cashu.InsufficientProofsAmount
 	}
 
-	if nut11.ProofsSigAll(proofs) {
-		return storage.MeltQuote{}, nut11.SigAllOnlySwap
+	// NUT-08 blank outputs to return overpaid lightning fees
+	outputs := meltTokensRequest.Outputs
+	if len(outputs) > 0 {
+		if err := m.verifyChangeOutputs(outputs); err != nil {
+			return storage.MeltQuote{}, err
+		}
+	}
+	// verify spending conditions that include the outputs and quote
+	// (i.e signatures of the SIG_ALL message)
+	if err := m.verifyBlindedMessages(proofs, outputs, meltQuote.Id); err != nil {
+		return storage.MeltQuote{}, err
 	}
 
+	// set quote as pending. Only one request, in any instance of the mint, can do it
+	set, err := m.db.CompareAndSetMeltQuote(meltQuote.Id, "", nut05.Unpaid, nut05.Pending)
+	if err != nil {
+		errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
+		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+	if !set {
+		return storage.MeltQuote{}, cashu.QuotePending
+	}
+	meltQuote.State = nut05.Pending
+
 	m.logInfof("verified proofs in melt tokens request. Setting proofs as pending before attempting payment.")
 	// set proofs as pending before trying to make payment
 	err = m.db.AddPendingProofs(proofs, meltQuote.Id)
 	if err != nil {
+		if _, err := m.db.CompareAndSetMeltQuote(meltQuote.Id, "", nut05.Pending, nut05.Unpaid); err != nil {
+			m.logErrorf("could not set melt quote '%v' back to unpaid: %v", meltQuote.Id, err)
+		}
+		if stateErr := proofStateErr(err); stateErr != nil {
+			return storage.MeltQuote{}, stateErr
+		}
 		errmsg := fmt.Sprintf("error setting proofs as pending in db: %v", err)
 		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 	}
-	meltQuote.State = nut05.Pending
-	err = m.db.UpdateMeltQuote(meltQuote.Id, "", nut05.Pending)
-	if err != nil {
-		errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
-		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	// save blank outputs so change can be returned
+	// if the payment is pending and settles later
+	if len(outputs) > 0 {
+		if err := m.db.SaveMeltChange(meltQuote.Id, outputs); err != nil {
+			if _, err := m.db.CompareAndSetMeltQuote(meltQuote.Id, "", nut05.Pending, nut05.Unpaid); err != nil {
+				m.logErrorf("could not set melt quote '%v' back to unpaid: %v", meltQuote.Id, err)
+			}
+			if err := m.db.RemovePendingProofs(Ys); err != nil {
+				m.logErrorf("could not remove pending proofs for melt quote '%v': %v", meltQuote.Id, err)
+			}
+			errmsg := fmt.Sprintf("error saving blank outputs for melt quote: %v", err)
+			return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+		}
 	}
 
 	// before asking backend to send payment, check if quotes can be settled
@@ -827,36 +1201,52 @@ func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltB
 	mintQuote, err := m.db.GetMintQuoteByPaymentHash(meltQuote.PaymentHash)
 	if err == nil {
 		m.logDebugf("quotes '%v' and '%v' have same invoice so settling them internally", meltQuote.Id, mintQuote.Id)
-		meltQuote, err = m.settleQuotesInternally(mintQuote, meltQuote)
+		settledQuote, err := m.settleQuotesInternally(mintQuote, meltQuote)
 		if err != nil {
+			if errors.Is(err, invoiceAlreadyPaidErr) {
+				// nothing was paid so the melt can be reverted
+				if _, err := m.resolvePendingMeltQuote(meltQuote.Id, "", nut05.Unpaid); err != nil {
+					m.logErrorf("could not set melt quote '%v' back to unpaid: %v", meltQuote.Id, err)
+				} else if err := m.db.RemovePendingProofs(Ys); err != nil {
+					m.logErrorf("could not remove pending proofs for melt quote '%v': %v", meltQuote.Id, err)
+				}
+			}
 			return storage.MeltQuote{}, err
 		}
-		err := m.db.RemovePendingProofs(Ys)
-		if err != nil {
-			errmsg := fmt.Sprintf("error removing pending proofs: %v", err)
-			return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+		meltQuote = settledQuote
+		if err := m.settleProofs(Ys, proofs); err != nil {
+			return storage.MeltQuote{}, err
 		}
-		err = m.db.SaveProofs(proofs)
-		if err != nil {
-			errmsg := fmt.Sprintf("error invalidating proofs. Could not save proofs to db: %v", err)
-			return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+		if err := m.signMeltChange(&meltQuote, proofs); err != nil {
+			return storage.MeltQuote{}, err
 		}
-		m.publishProofsStateChanges(proofs, nut07.Spent)
+		m.recordMeltFees(meltQuote, proofs, true)
 	} else {
 		var sendPaymentResponse lightning.PaymentStatus
-		// if melt is MPP, pay partial amount. If not, send full payment
-		if meltQuote.IsMpp {
+		// if melt is MPP, pay partial amount. If amountless, pay the amount in the quote.
+		// Otherwise, send full payment
+		switch {
+		case meltQuote.IsMpp:
 			m.logInfof("attempting MPP payment of amount '%v' for invoice '%v'",
 				meltQuote.Amount, meltQuote.InvoiceRequest)
 			sendPaymentResponse, err = m.lightningClient.PayPartialAmount(
 				ctx,
 				meltQuote.InvoiceRequest,
 				meltQuote.AmountMsat,
-				m.lightningClient.FeeReserve(meltQuote.AmountMsat/1000),
+				meltQuote.FeeReserve,
 			)
-		} else {
+		case meltQuote.IsAmountless:
+			m.logInfof("attempting payment of amount '%v' for amountless invoice '%v'",
+				meltQuote.Amount, meltQuote.InvoiceRequest)
+			sendPaymentResponse, err = m.lightningClient.PayAmountless(
+				ctx,
+				meltQuote.InvoiceRequest,
+				meltQuote.AmountMsat,
+				meltQuote.FeeReserve,
+			)
+		default:
 			m.logInfof("attempting to pay invoice: %v", meltQuote.InvoiceRequest)
-			sendPaymentResponse, err = m.lightningClient.SendPayment(ctx, meltQuote.InvoiceRequest, meltQuote.Amount)
+			sendPaymentResponse, err = m.lightningClient.SendPayment(ctx, meltQuote.InvoiceRequest, meltQuote.FeeReserve)
 		}
 		if err != nil {
 			// if SendPayment failed do not return yet, an extra check will be done
@@ -870,17 +1260,26 @@ func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltB
 			// if payment succeeded:
 			// - unset pending proofs and mark them as spent by adding them to the db
 			// - mark melt quote as paid
+			resolved, err := m.resolvePendingMeltQuote(meltQuote.Id, sendPaymentResponse.Preimage, nut05.Paid)
+			if err != nil {
+				return storage.MeltQuote{}, err
+			}
+			if !resolved {
+				return m.GetMeltQuoteState(ctx, meltQuote.Id)
+			}
 			meltQuote.State = nut05.Paid
 			meltQuote.Preimage = sendPaymentResponse.Preimage
 			err = m.settleProofs(Ys, proofs)
 			if err != nil {
 				return storage.MeltQuote{}, err
 			}
-			err = m.db.UpdateMeltQuote(meltQuote.Id, sendPaymentResponse.Preimage, nut05.Paid)
-			if err != nil {
-				errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
-				return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+			if err := m.recordFeePaid(&meltQuote, sendPaymentResponse.Fee); err != nil {
+				return storage.MeltQuote{}, err
+			}
+			if err := m.signMeltChange(&meltQuote, proofs); err != nil {
+				return storage.MeltQuote{}, err
 			}
+			m.recordMeltFees(meltQuote, proofs, false)
 
 		case lightning.Pending:
 			// if payment is pending, leave quote and proofs as pending and return
@@ -895,17 +1294,23 @@ func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltB
 				m.logInfof("no outgoing payment found with hash: %v. Removing pending proofs and marking quote '%v' as unpaid",
 					meltQuote.PaymentHash, meltQuote.Id)
 
-				meltQuote.State = nut05.Unpaid
-				err = m.db.UpdateMeltQuote(meltQuote.Id, "", meltQuote.State)
+				resolved, err := m.resolvePendingMeltQuote(meltQuote.Id, "", nut05.Unpaid)
 				if err != nil {
-					errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
-					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+					return storage.MeltQuote{}, err
 				}
+				if !resolved {
+					return m.GetMeltQuoteState(ctx, meltQuote.Id)
+				}
+				meltQuote.State = nut05.Unpaid
 				err = m.db.RemovePendingProofs(Ys)
 				if err != nil {
 					errmsg := fmt.Sprintf("error removing proofs from pending: %v", err)
 					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 				}
+				if err := m.db.DeleteMeltChange(meltQuote.Id); err != nil {
+					errmsg := fmt.Sprintf("error removing blank outputs for melt quote: %v", err)
+					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+				}
 				return meltQuote, nil
 			}
 			if err != nil {
@@ -921,31 +1326,46 @@ func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltB
 				m.logInfof("payment failed with error: %v. Removing pending proofs and marking quote '%v' as unpaid",
 					paymentStatus.PaymentFailureReason, meltQuote.Id)
 
-				meltQuote.State = nut05.Unpaid
-				err = m.db.UpdateMeltQuote(meltQuote.Id, "", meltQuote.State)
+				resolved, err := m.resolvePendingMeltQuote(meltQuote.Id, "", nut05.Unpaid)
 				if err != nil {
-					errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
-					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+					return storage.MeltQuote{}, err
 				}
+				if !resolved {
+					return m.GetMeltQuoteState(ctx, meltQuote.Id)
+				}
+				meltQuote.State = nut05.Unpaid
 				err = m.db.RemovePendingProofs(Ys)
 				if err != nil {
 					errmsg := fmt.Sprintf("error removing proofs from pending: %v", err)
 					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 				}
+				if err := m.db.DeleteMeltChange(meltQuote.Id); err != nil {
+					errmsg := fmt.Sprintf("error removing blank outputs for melt quote: %v", err)
+					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+				}
 				return meltQuote, nil
 			case lightning.Succeeded:
 				m.logInfof("succesfully paid invoice with hash '%v' for melt quote '%v'", meltQuote.PaymentHash, meltQuote.Id)
-				err = m.settleProofs(Ys, proofs)
+				resolved, err := m.resolvePendingMeltQuote(meltQuote.Id, paymentStatus.Preimage, nut05.Paid)
 				if err != nil {
 					return storage.MeltQuote{}, err
 				}
+				if !resolved {
+					return m.GetMeltQuoteState(ctx, meltQuote.Id)
+				}
 				meltQuote.State = nut05.Paid
 				meltQuote.Preimage = paymentStatus.Preimage
-				err = m.db.UpdateMeltQuote(meltQuote.Id, paymentStatus.Preimage, nut05.Paid)
+				err = m.settleProofs(Ys, proofs)
 				if err != nil {
-					errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
-					return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+					return storage.MeltQuote{}, err
+				}
+				if err := m.recordFeePaid(&meltQuote, paymentStatus.Fee); err != nil {
+					return storage.MeltQuote{}, err
+				}
+				if err := m.signMeltChange(&meltQuote, proofs); err != nil {
+					return storage.MeltQuote{}, err
 				}
+				m.recordMeltFees(meltQuote, proofs, false)
 			}
 		}
 	}
@@ -953,6 +1373,10 @@ func (m *Mint) MeltTokens(ctx context.Context, meltTokensRequest nut05.PostMeltB
 	return meltQuote, nil
 }
 
+// returned when settling a melt internally if the ecash for the
+// mint quote of the invoice is being or was already issued
+var invoiceAlreadyPaidErr = cashu.BuildCashuError("invoice already paid", cashu.LightningPaymentErrCode)
+
 // if a pair of mint and melt quotes have the same invoice,
 // settle them internally and update in db
 func (m *Mint) settleQuotesInternally(
@@ -966,19 +1390,30 @@ func (m *Mint) settleQuotesInternally(
 		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.LightningBackendErrCode)
 	}
 
-	meltQuote.State = nut05.Paid
-	meltQuote.Preimage = invoice.Preimage
-	err = m.db.UpdateMeltQuote(meltQuote.Id, meltQuote.Preimage, meltQuote.State)
+	// mark mint quote request as paid. If the ecash for it is being
+	// or was already issued, the melt is not settled
+	set, err := m.db.CompareAndSetMintQuoteState(mintQuote.Id, nut04.Unpaid, nut04.Paid)
 	if err != nil {
-		errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
+		errmsg := fmt.Sprintf("error updating mint quote state: %v", err)
 		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 	}
-
-	// mark mint quote request as paid
+	if !set {
+		mintQuote, err = m.db.GetMintQuote(mintQuote.Id)
+		if err != nil {
+			errmsg := fmt.Sprintf("error getting mint quote: %v", err)
+			return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+		}
+		if mintQuote.State != nut04.Paid {
+			return storage.MeltQuote{}, invoiceAlreadyPaidErr
+		}
+	}
 	mintQuote.State = nut04.Paid
-	err = m.db.UpdateMintQuoteState(mintQuote.Id, mintQuote.State)
+
+	meltQuote.State = nut05.Paid
+	meltQuote.Preimage = invoice.Preimage
+	err = m.db.UpdateMeltQuote(meltQuote.Id, meltQuote.Preimage, meltQuote.State)
 	if err != nil {
-		errmsg := fmt.Sprintf("error updating mint quote state: %v", err)
+		errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
 		return storage.MeltQuote{}, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
 	}
 	jsonQuote, _ := json.Marshal(mintQuote)
@@ -987,15 +1422,34 @@ func (m *Mint) settleQuotesInternally(
 	return meltQuote, nil
 }
 
+// resolvePendingMeltQuote moves the melt quote from PENDING to the state.
+// It returns false if the quote was already moved by another request
+// (i.e a state check in another instance of the mint).
+func (m *Mint) resolvePendingMeltQuote(quoteId, preimage string, state nut05.State) (bool, error) {
+	resolved, err := m.db.CompareAndSetMeltQuote(quoteId, preimage, nut05.Pending, state)
+	if err != nil {
+		errmsg := fmt.Sprintf("error updating melt quote state: %v", err)
+		return false, cashu.BuildCashuError(errmsg, cashu.DBErrCode)
+	}
+	return resolved, nil
+}
+
+// proofStateErr returns the cashu error if saving the proofs failed
+// because they were already spent or pending. Otherwise it returns nil
+func proofStateErr(err error) error {
+	switch {
+	case errors.Is(err, storage.ErrProofSpent):
+		return cashu.ProofAlreadyUsedErr
+	case errors.Is(err, storage.ErrProofPending):
+		return cashu.ProofPendingErr
+	}
+	return nil
+}
+
 // settleProofs will remove the proofs from the pending table
 // and mark them as spent by adding them to the used proofs table
 func (m *Mint) settleProofs(Ys []string, proofs cashu.Proofs) error {
-	err := m.db.RemovePendingProofs(Ys)
-	if err != nil {
-		errmsg := fmt.Sprintf("error removing pending proofs: %v", err)
-		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
-	}
-	err = m.db.SaveProofs(proofs)
+	err := m.db.SettlePendingProofs(Ys)
 	if err != nil {
 		errmsg := fmt.Sprintf("error invalidating proofs. Could not save proofs to db: %v", err)
 		return cashu.BuildCashuError(errmsg, cashu.DBErrCode)
@@ -1077,6 +1531,10 @@ func (m *Mint) ProofsStateCheck(Ys []string) ([]nut07.ProofState, error) {
 }
 
 func (m *Mint) RestoreSignatures(blindedMessages cashu.BlindedMessages) (cashu.BlindedMessages, cashu.BlindedSignatures, error) {
+	if err := m.checkRequestLimits(0, len(blindedMessages)); err != nil {
+		return nil, nil, err
+	}
+
 	outputs := make(cashu.BlindedMessages, 0, len(blindedMessages))
 	signatures := make(cashu.BlindedSignatures, 0, len(blindedMessages))
 
@@ -1096,6 +1554,18 @@ func (m *Mint) RestoreSignatures(blindedMessages cashu.BlindedMessages) (cashu.B
 	return outputs, signatures, nil
 }
 
+// checkRequestLimits checks the number of inputs and outputs
+// in a request against the limits configured for the mint
+func (m *Mint) checkRequestLimits(inputs, outputs int) error {
+	if m.limits.MaxInputs > 0 && inputs > m.limits.MaxInputs {
+		return cashu.MaxInputsExceededErr
+	}
+	if m.limits.MaxOutputs > 0 && outputs > m.limits.MaxOutputs {
+		return cashu.MaxOutputsExceededErr
+	}
+	return nil
+}
+
 func (m *Mint) verifyProofs(proofs cashu.Proofs, Ys []string) error {
 	if len(proofs) == 0 {
 		return cashu.NoProofsProvided
@@ -1129,76 +1599,111 @@ func (m *Mint) verifyProofs(proofs cashu.Proofs, Ys []string) error {
 		return cashu.DuplicateProofs
 	}
 
-	for _, proof := range proofs {
-		if len(proof.Secret) > cashu.MAX_SECRET_LENGTH {
-			return cashu.SecretTooLongErr
-		}
+	// verify the proofs in parallel since each check is independent
+	return runParallel(len(proofs), m.concurrency, func(i int) error {
+		return m.verifyProof(proofs[i])
+	})
+}
 
-		// check that id in the proof matches id of any
-		// of the mint's keyset
-		var k *secp256k1.PrivateKey
-		if keyset, ok := m.keysets[proof.Id]; !ok {
-			return cashu.UnknownKeysetErr
+// verifyProof checks the spending conditions and signature of a single proof.
+// It does not check whether the proof has already been spent
+func (m *Mint) verifyProof(proof cashu.Proof) error {
+	if len(proof.Secret) > cashu.MAX_SECRET_LENGTH {
+		return cashu.SecretTooLongErr
+	}
+
+	// check that id in the proof matches id of any
+	// of the mint's keyset
+	var k *secp256k1.PrivateKey
+	if keyset, ok := m.getKeyset(proof.Id); !ok {
+		return cashu.UnknownKeysetErr
+	} else {
+		if key, ok := keyset.Keys[proof.Amount]; ok {
+			k = key.PrivateKey
 		} else {
-			if key, ok := keyset.Keys[proof.Amount]; ok {
-				k = key.PrivateKey
-			} else {
-				return cashu.InvalidProofErr
-			}
+			return cashu.InvalidProofErr
 		}
+	}
 
-		// if P2PK locked proof, verify valid witness
-		nut10Secret, err := nut10.DeserializeSecret(proof.Secret)
-		if err == nil {
-			if nut10Secret.Kind == nut10.P2PK {
-				if err := nut11.VerifyP2PKLockedProof(proof, nut10Secret); err != nil {
-					return err
-				}
-				m.logDebugf("verified P2PK locked proof")
-			} else if nut10Secret.Kind == nut10.HTLC {
-				if err := nut14.VerifyHTLCProof(proof, nut10Secret); err != nil {
-					return err
-				}
-				m.logDebugf("verified HTLC proof")
+	// if locked proof of a known kind, verify valid witness
+	nut10Secret, err := nut10.DeserializeSecret(proof.Secret)
+	if err == nil {
+		if verifier, ok := m.spendingConditions[nut10Secret.Kind]; ok {
+			if err := verifier.VerifyProof(proof, nut10Secret); err != nil {
+				return err
 			}
+			m.logDebugf("verified %v locked proof", nut10Secret.Kind)
 		}
+	}
 
-		Cbytes, err := hex.DecodeString(proof.C)
-		if err != nil {
-			errmsg := fmt.Sprintf("invalid C: %v", err)
-			return cashu.BuildCashuError(errmsg, cashu.StandardErrCode)
-		}
+	Cbytes, err := hex.DecodeString(proof.C)
+	if err != nil {
+		errmsg := fmt.Sprintf("invalid C: %v", err)
+		return cashu.BuildCashuError(errmsg, cashu.StandardErrCode)
+	}
 
-		C, err := secp256k1.ParsePubKey(Cbytes)
+	C, err := secp256k1.ParsePubKey(Cbytes)
+	if err != nil {
+		return cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+	}
+
+	if !crypto.Verify(proof.Secret, k, C) {
+		return cashu.InvalidProofErr
+	}
+	return nil
+}
+
+// verifyBlindedMessages runs the checks on the outputs for the spending
+// conditions of the inputs if the verifier of their kind defines them.
+// quoteId is only set for melts
+func (m *Mint) verifyBlindedMessages(
+	proofs cashu.Proofs,
+	blindedMessages cashu.BlindedMessages,
+	quoteId string,
+) error {
+	kinds := make(map[nut10.SecretKind]bool)
+	for _, proof := range proofs {
+		secret, err := nut10.DeserializeSecret(proof.Secret)
 		if err != nil {
-			return cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+			continue
+		}
+		if kinds[secret.Kind] {
+			continue
 		}
+		kinds[secret.Kind] = true
 
-		if !crypto.Verify(proof.Secret, k, C) {
-			return cashu.InvalidProofErr
+		verifier, ok := m.spendingConditions[secret.Kind]
+		if !ok {
+			continue
+		}
+		if outputsVerifier, ok := verifier.(OutputsVerifier); ok {
+			if err := outputsVerifier.VerifyOutputs(proofs, blindedMessages, quoteId); err != nil {
+				return err
+			}
 		}
 	}
 	return nil
 }
 
-// verifyBlindedMessages used to verify blinded messages are signed when SIG_ALL flag
-// is present in either a P2PK or HTLC locked proofs
-func verifyBlindedMessages(proofs cashu.Proofs, blindedMessages cashu.BlindedMessages) error {
+// sigAllConditions checks that all the proofs have the SIG_ALL flag and the same
+// spending conditions. It returns the secret of the first proof, the public keys
+// that can sign and the number of signatures required
+func sigAllConditions(proofs cashu.Proofs) (nut10.WellKnownSecret, []*secp256k1.PublicKey, int, error) {
 	secret, err := nut10.DeserializeSecret(proofs[0].Secret)
 	if err != nil {
-		return cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+		return nut10.WellKnownSecret{}, nil, 0, cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
 	}
 
 	// pubkeys will hold list of public keys that can sign
 	pubkeys, err := nut11.PublicKeys(secret)
 	if err != nil {
-		return err
+		return nut10.WellKnownSecret{}, nil, 0, err
 	}
 
 	signaturesRequired := 1
 	p2pkTags, err := nut11.ParseP2PKTags(secret.Data.Tags)
 	if err != nil {
-		return err
+		return nut10.WellKnownSecret{}, nil, 0, err
 	}
 	if p2pkTags.NSigs > 0 {
 		signaturesRequired = p2pkTags.NSigs
@@ -1208,17 +1713,17 @@ func verifyBlindedMessages(proofs cashu.Proofs, blindedMessages cashu.BlindedMes
 	for _, proof := range proofs {
 		secret, err := nut10.DeserializeSecret(proof.Secret)
 		if err != nil {
-			return cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+			return nut10.WellKnownSecret{}, nil, 0, cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
 		}
 		// all flags need to be SIG_ALL
 		if !nut11.IsSigAll(secret) {
-			return nut11.AllSigAllFlagsErr
+			return nut10.WellKnownSecret{}, nil, 0, nut11.AllSigAllFlagsErr
 		}
 
 		currentSignaturesRequired := 1
 		p2pkTags, err := nut11.ParseP2PKTags(secret.Data.Tags)
 		if err != nil {
-			return err
+			return nut10.WellKnownSecret{}, nil, 0, err
 		}
 		if p2pkTags.NSigs > 0 {
 			currentSignaturesRequired = p2pkTags.NSigs
@@ -1226,67 +1731,45 @@ func verifyBlindedMessages(proofs cashu.Proofs, blindedMessages cashu.BlindedMes
 
 		currentKeys, err := nut11.PublicKeys(secret)
 		if err != nil {
-			return err
+			return nut10.WellKnownSecret{}, nil, 0, err
 		}
 
 		// list of valid keys should be the same
 		// across all proofs
 		if !reflect.DeepEqual(pubkeys, currentKeys) {
-			return nut11.SigAllKeysMustBeEqualErr
+			return nut10.WellKnownSecret{}, nil, 0, nut11.SigAllKeysMustBeEqualErr
 		}
 
 		// all n_sigs must be same
 		if signaturesRequired != currentSignaturesRequired {
-			return nut11.NSigsMustBeEqualErr
+			return nut10.WellKnownSecret{}, nil, 0, nut11.NSigsMustBeEqualErr
 		}
 	}
 
-	for _, bm := range blindedMessages {
-		B_bytes, err := hex.DecodeString(bm.B_)
-		if err != nil {
-			return cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
-		}
-		hash := sha256.Sum256(B_bytes)
-
-		var signatures []string
-		switch secret.Kind {
-		case nut10.P2PK:
-			var witness nut11.P2PKWitness
-			if err := json.Unmarshal([]byte(bm.Witness), &witness); err != nil {
-				return nut11.InvalidWitness
-			}
-			signatures = witness.Signatures
-		case nut10.HTLC:
-			var witness nut14.HTLCWitness
-			if err := json.Unmarshal([]byte(bm.Witness), &witness); err != nil {
-				return nut11.InvalidWitness
-			}
+	return secret, pubkeys, signaturesRequired, nil
+}
 
-			// verify valid preimage
-			preimageBytes, err := hex.DecodeString(witness.Preimage)
-			if err != nil {
-				return nut14.InvalidPreimageErr
-			}
-			hashBytes := sha256.Sum256(preimageBytes)
-			hash := hex.EncodeToString(hashBytes[:])
+// verifySigAll verifies that the witness of the first proof has enough signatures
+// of the SIG_ALL message for the inputs, outputs and quote id of the swap or melt.
+// quoteId is empty for swaps
+func verifySigAll(proofs cashu.Proofs, outputs cashu.BlindedMessages, quoteId string) error {
+	_, pubkeys, signaturesRequired, err := sigAllConditions(proofs)
+	if err != nil {
+		return err
+	}
 
-			if len(secret.Data.Data) != 64 {
-				return nut14.InvalidHashErr
-			}
-			if hash != secret.Data.Data {
-				return nut14.InvalidPreimageErr
-			}
-			signatures = witness.Signatures
-		default:
-			return nut11.InvalidKindErr
-		}
+	// both P2PK and HTLC witnesses have the signatures in the same field
+	var witness nut11.P2PKWitness
+	if err := json.Unmarshal([]byte(proofs[0].Witness), &witness); err != nil {
+		return nut11.InvalidWitness
+	}
+	if nut11.DuplicateSignatures(witness.Signatures) {
+		return nut11.DuplicateSignaturesErr
+	}
 
-		if nut11.DuplicateSignatures(signatures) {
-			return nut11.DuplicateSignaturesErr
-		}
-		if !nut11.HasValidSignatures(hash[:], signatures, signaturesRequired, pubkeys) {
-			return nut11.NotEnoughSignaturesErr
-		}
+	hash := sha256.Sum256([]byte(nut11.SigAllMessage(proofs, outputs, quoteId)))
+	if !nut11.HasValidSignatures(hash[:], witness.Signatures, signaturesRequired, pubkeys) {
+		return nut11.NotEnoughSignaturesErr
 	}
 
 	return nil
@@ -1296,55 +1779,69 @@ func verifyBlindedMessages(proofs cashu.Proofs, blindedMessages cashu.BlindedMes
 func (m *Mint) signBlindedMessages(blindedMessages cashu.BlindedMessages) (cashu.BlindedSignatures, error) {
 	blindedSignatures := make(cashu.BlindedSignatures, len(blindedMessages))
 
-	for i, msg := range blindedMessages {
-		if _, ok := m.keysets[msg.Id]; !ok {
-			return nil, cashu.UnknownKeysetErr
-		}
-		var k *secp256k1.PrivateKey
-		if msg.Id != m.activeKeyset.Id {
-			return nil, cashu.InactiveKeysetSignatureRequest
-		} else {
-			if key, ok := m.activeKeyset.Keys[msg.Amount]; ok {
-				k = key.PrivateKey
-			} else {
-				return nil, cashu.InvalidBlindedMessageAmount
-			}
-		}
-
-		B_bytes, err := hex.DecodeString(msg.B_)
-		if err != nil {
-			errmsg := fmt.Sprintf("invalid B_: %v", err)
-			return nil, cashu.BuildCashuError(errmsg, cashu.StandardErrCode)
-		}
-		B_, err := btcec.ParsePubKey(B_bytes)
+	err := runParallel(len(blindedMessages), m.concurrency, func(i int) error {
+		blindedSignature, err := m.signBlindedMessage(blindedMessages[i])
 		if err != nil {
-			return nil, cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+			return err
 		}
+		blindedSignatures[i] = blindedSignature
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
 
-		C_ := crypto.SignBlindedMessage(B_, k)
-		C_hex := hex.EncodeToString(C_.SerializeCompressed())
-
-		// DLEQ proof
-		e, s := crypto.GenerateDLEQ(k, B_, C_)
+	return blindedSignatures, nil
+}
 
-		blindedSignature := cashu.BlindedSignature{
-			Amount: msg.Amount,
-			C_:     C_hex,
-			Id:     m.activeKeyset.Id,
-			DLEQ: &cashu.DLEQProof{
-				E: hex.EncodeToString(e.Serialize()),
-				S: hex.EncodeToString(s.Serialize()),
-			},
+// signBlindedMessage signs a single blinded message with the key
+// of the active keyset and adds a DLEQ proof to the signature
+func (m *Mint) signBlindedMessage(msg cashu.BlindedMessage) (cashu.BlindedSignature, error) {
+	if _, ok := m.getKeyset(msg.Id); !ok {
+		return cashu.BlindedSignature{}, cashu.UnknownKeysetErr
+	}
+	activeKeyset := m.getActiveKeyset()
+	var k *secp256k1.PrivateKey
+	if msg.Id != activeKeyset.Id {
+		return cashu.BlindedSignature{}, cashu.InactiveKeysetSignatureRequest
+	} else {
+		if key, ok := activeKeyset.Keys[msg.Amount]; ok {
+			k = key.PrivateKey
+		} else {
+			return cashu.BlindedSignature{}, cashu.InvalidBlindedMessageAmount
 		}
-		blindedSignatures[i] = blindedSignature
 	}
 
-	return blindedSignatures, nil
+	B_bytes, err := hex.DecodeString(msg.B_)
+	if err != nil {
+		errmsg := fmt.Sprintf("invalid B_: %v", err)
+		return cashu.BlindedSignature{}, cashu.BuildCashuError(errmsg, cashu.StandardErrCode)
+	}
+	B_, err := btcec.ParsePubKey(B_bytes)
+	if err != nil {
+		return cashu.BlindedSignature{}, cashu.BuildCashuError(err.Error(), cashu.StandardErrCode)
+	}
+
+	C_ := crypto.SignBlindedMessage(B_, k)
+	C_hex := hex.EncodeToString(C_.SerializeCompressed())
+
+	// DLEQ proof
+	e, s := crypto.GenerateDLEQ(k, B_, C_)
+
+	return cashu.BlindedSignature{
+		Amount: msg.Amount,
+		C_:     C_hex,
+		Id:     activeKeyset.Id,
+		DLEQ: &cashu.DLEQProof{
+			E: hex.EncodeToString(e.Serialize()),
+			S: hex.EncodeToString(s.Serialize()),
+		},
+	}, nil
 }
 
-// requestInvoice requests an invoice from the Lightning backend for the given amount
-func (m *Mint) requestInvoice(amount uint64) (*lightning.Invoice, error) {
-	invoice, err := m.lightningClient.CreateInvoice(amount)
+// requestInvoice requests an invoice from the Lightning backend for the given amount and description
+func (m *Mint) requestInvoice(amount uint64, description string) (*lightning.Invoice, error) {
+	invoice, err := m.lightningClient.CreateInvoice(amount, description)
 	if err != nil {
 		return nil, err
 	}
@@ -1352,6 +1849,9 @@ func (m *Mint) requestInvoice(amount uint64) (*lightning.Invoice, error) {
 }
 
 func (m *Mint) TransactionFees(inputs cashu.Proofs) uint {
+	m.keysetsMu.RLock()
+	defer m.keysetsMu.RUnlock()
+
 	var fees uint = 0
 	for _, proof := range inputs {
 		// note: not checking that proof id is from valid keyset
@@ -1362,6 +1862,9 @@ func (m *Mint) TransactionFees(inputs cashu.Proofs) uint {
 }
 
 func (m *Mint) ListKeysets() nut02.GetKeysetsResponse {
+	m.keysetsMu.RLock()
+	defer m.keysetsMu.RUnlock()
+
 	keysets := make([]nut02.Keyset, len(m.keysets))
 	i := 0
 	for _, keyset := range m.keysets {
@@ -1378,15 +1881,16 @@ func (m *Mint) ListKeysets() nut02.GetKeysetsResponse {
 }
 
 func (m *Mint) GetActiveKeyset() nut01.Keyset {
+	activeKeyset := m.getActiveKeyset()
 	return nut01.Keyset{
-		Id:   m.activeKeyset.Id,
-		Unit: m.activeKeyset.Unit,
-		Keys: m.activeKeyset.PublicKeys(),
+		Id:   activeKeyset.Id,
+		Unit: activeKeyset.Unit,
+		Keys: activeKeyset.PublicKeys(),
 	}
 }
 
 func (m *Mint) GetKeysetById(id string) (nut01.Keyset, error) {
-	keyset, ok := m.keysets[id]
+	keyset, ok := m.getKeyset(id)
 	if !ok {
 		return nut01.Keyset{}, cashu.UnknownKeysetErr
 	}
@@ -1397,7 +1901,58 @@ func (m *Mint) GetKeysetById(id string) (nut01.Keyset, error) {
 	}, nil
 }
 
+// getActiveKeyset returns the keyset currently used to sign outputs.
+// The returned keyset is not modified when keysets are rotated.
+func (m *Mint) getActiveKeyset() *crypto.MintKeyset {
+	m.keysetsMu.RLock()
+	defer m.keysetsMu.RUnlock()
+	return m.activeKeyset
+}
+
+// getKeyset returns the keyset with the id, whether it is active or not.
+// When running with other instances, the keysets are reloaded from the db
+// if the id is unknown in case it was created by another instance.
+func (m *Mint) getKeyset(id string) (crypto.MintKeyset, bool) {
+	m.keysetsMu.RLock()
+	keyset, ok := m.keysets[id]
+	reload := !ok && m.multiInstance && time.Since(m.keysetsLoadedAt) > unknownKeysetReloadInterval
+	m.keysetsMu.RUnlock()
+	if !reload {
+		return keyset, ok
+	}
+
+	if err := m.loadKeysets(); err != nil {
+		m.logErrorf("error loading keysets from db: %v", err)
+		return crypto.MintKeyset{}, false
+	}
+	m.keysetsMu.RLock()
+	defer m.keysetsMu.RUnlock()
+	keyset, ok = m.keysets[id]
+	return keyset, ok
+}
+
 func (m *Mint) RotateKeyset(fee uint) (*nut02.Keyset, error) {
+	newKeyset, err := m.rotateKeyset(fee)
+	if err != nil {
+		return nil, err
+	}
+	m.logInfof("setting new keyset %v to active", newKeyset.Id)
+	m.publisher.Publish(KEYSET_TOPIC, []byte(newKeyset.Id))
+
+	return &nut02.Keyset{
+		Id:          newKeyset.Id,
+		Unit:        newKeyset.Unit,
+		Active:      newKeyset.Active,
+		InputFeePpk: newKeyset.InputFeePpk,
+	}, nil
+}
+
+// rotateKeyset deactivates the current keyset and generates
+// a new active one, holding the keysets lock while it does
+func (m *Mint) rotateKeyset(fee uint) (*crypto.MintKeyset, error) {
+	m.keysetsMu.Lock()
+	defer m.keysetsMu.Unlock()
+
 	seed, err := m.db.GetSeed()
 	if err != nil {
 		return nil, err
@@ -1408,7 +1963,9 @@ func (m *Mint) RotateKeyset(fee uint) (*nut02.Keyset, error) {
 		return nil, err
 	}
 
-	currentActiveKeyset := m.activeKeyset
+	// copy it so that readers holding the previous
+	// active keyset do not see it change
+	currentActiveKeyset := *m.activeKeyset
 
 	newDerivationPathIdx := currentActiveKeyset.DerivationPathIdx + 1
 	newKeyset, err := crypto.GenerateKeyset(
@@ -1424,7 +1981,7 @@ func (m *Mint) RotateKeyset(fee uint) (*nut02.Keyset, error) {
 
 	// deactivate previous one and change it in db
 	currentActiveKeyset.Active = false
-	m.keysets[currentActiveKeyset.Id] = *currentActiveKeyset
+	m.keysets[currentActiveKeyset.Id] = currentActiveKeyset
 	if err := m.db.UpdateKeysetActive(currentActiveKeyset.Id, false); err != nil {
 		return nil, fmt.Errorf("could not update active state of keyset in db: %v", err)
 	}
@@ -1444,14 +2001,7 @@ func (m *Mint) RotateKeyset(fee uint) (*nut02.Keyset, error) {
 	if err := m.db.SaveKeyset(activeDbKeyset); err != nil {
 		return nil, fmt.Errorf("error saving new active keyset: %v", err)
 	}
-	m.logInfof("setting new keyset %v to active", newKeyset.Id)
-
-	return &nut02.Keyset{
-		Id:          newKeyset.Id,
-		Unit:        newKeyset.Unit,
-		Active:      newKeyset.Active,
-		InputFeePpk: newKeyset.InputFeePpk,
-	}, nil
+	return newKeyset, nil
 }
 
 func (m *Mint) IssuedEcash() (map[string]uint64, error) {
@@ -1494,6 +2044,7 @@ func (m *Mint) SetMintInfo(mintInfo MintInfo) {
 					Unit:      cashu.Sat.String(),
 					MinAmount: m.limits.MintingSettings.MinAmount,
 					MaxAmount: m.limits.MintingSettings.MaxAmount,
+					Options:   &nut06.MethodOptions{Description: true},
 				},
 			},
 			Disabled: false,
@@ -1505,14 +2056,15 @@ func (m *Mint) SetMintInfo(mintInfo MintInfo) {
 					Unit:      cashu.Sat.String(),
 					MinAmount: m.limits.MeltingSettings.MinAmount,
 					MaxAmount: m.limits.MeltingSettings.MaxAmount,
+					Options:   &nut06.MethodOptions{Amountless: true},
 				},
 			},
 			Disabled: false,
 		},
 		Nut07: nut06.Supported{Supported: true},
-		Nut08: nut06.Supported{Supported: false},
+		Nut08: nut06.Supported{Supported: true},
 		Nut09: nut06.Supported{Supported: true},
-		Nut10: nut06.Supported{Supported: true},
+		Nut10: nut06.Nut10Setting{Supported: true, Kinds: m.supportedKinds()},
 		Nut11: nut06.Supported{Supported: true},
 		Nut12: nut06.Supported{Supported: true},
 		Nut14: nut06.Supported{Supported: true},
@@ -1527,14 +2079,20 @@ func (m *Mint) SetMintInfo(mintInfo MintInfo) {
 				},
 			},
 		},
-		Nut19: nut06.Nut19Setting{
+		Nut20: nut06.Supported{Supported: true},
+	}
+
+	// the responses are cached in the memory of each instance, so a request
+	// retried against another instance sharing the db would not be found
+	// in its cache. NUT-19 is only advertised when running as a single instance
+	if !m.multiInstance {
+		nuts.Nut19 = nut06.Nut19Setting{
 			TTL: CACHE_ITEM_TTL,
 			CachedEndpoints: []nut06.CachedEndpoint{
 				{Method: "POST", Path: "/v1/mint/bolt11"},
 				{Method: "POST", Path: "/v1/swap"},
 			},
-		},
-		Nut20: nut06.Supported{Supported: true},
+		}
 	}
 
 	if m.mppEnabled {
@@ -1557,10 +2115,17 @@ func (m *Mint) SetMintInfo(mintInfo MintInfo) {
 		Time:            time.Now().Unix(),
 		Nuts:            nuts,
 	}
+	if m.limits.MaxInputs > 0 || m.limits.MaxOutputs > 0 || m.limits.MaxRequestBytes > 0 {
+		info.Limits = &nut06.Limits{
+			MaxInputs:       m.limits.MaxInputs,
+			MaxOutputs:      m.limits.MaxOutputs,
+			MaxRequestBytes: m.limits.MaxRequestBytes,
+		}
+	}
 	m.mintInfo = info
 }
 
-func (m Mint) RetrieveMintInfo() (nut06.MintInfo, error) {
+func (m *Mint) RetrieveMintInfo() (nut06.MintInfo, error) {
 	seed, err := m.db.GetSeed()
 	if err != nil {
 		return nut06.MintInfo{}, err
@@ -1586,6 +2151,9 @@ func (m Mint) RetrieveMintInfo() (nut06.MintInfo, error) {
 			mintingDisabled = true
 		}
 	}
+	if m.solvency.mintingDisabled() {
+		mintingDisabled = true
+	}
 	nut04 := m.mintInfo.Nuts.Nut04
 	nut04.Disabled = mintingDisabled
 	m.mintInfo.Nuts.Nut04 = nut04
@@ -37,6 +37,7 @@ import (
 	btcdocker "github.com/elnosh/btc-docker-test"
 	"github.com/elnosh/btc-docker-test/cln"
 	"github.com/elnosh/btc-docker-test/lnd"
+	decodepay "github.com/nbd-wtf/ln-decodepay"
 )
 
 var (
@@ -210,6 +211,35 @@ func TestRequestMintQuote(t *testing.T) {
 	if !strings.Contains(cashuErr.Detail, invalidPubkeyErr) {
 		t.Fatalf("expected error '%v' but got '%v' instead", invalidPubkeyErr, cashuErr.Detail)
 	}
+
+	// test description is included in invoice
+	description := "TollGate session - 30 min"
+	mintQuoteRequest = nut04.PostMintQuoteBolt11Request{
+		Amount:      mintAmount,
+		Unit:        cashu.Sat.String(),
+		Description: description,
+	}
+	mintQuote, err := testMint.RequestMintQuote(mintQuoteRequest)
+	if err != nil {
+		t.Fatalf("error requesting mint quote: %v", err)
+	}
+	if mintQuote.Description != description {
+		t.Fatalf("expected description '%v' but got '%v'", description, mintQuote.Description)
+	}
+	bolt11, err := decodepay.Decodepay(mintQuote.PaymentRequest)
+	if err != nil {
+		t.Fatalf("error decoding invoice: %v", err)
+	}
+	if bolt11.Description != description {
+		t.Fatalf("expected invoice description '%v' but got '%v'", description, bolt11.Description)
+	}
+
+	// test description too long
+	mintQuoteRequest.Description = strings.Repeat("a", lightning.MaxDescriptionLength+1)
+	_, err = testMint.RequestMintQuote(mintQuoteRequest)
+	if err == nil {
+		t.Fatal("expected error requesting mint quote with description too long")
+	}
 }
 
 func TestMintQuoteState(t *testing.T) {
@@ -770,7 +800,7 @@ func TestMelt(t *testing.T) {
 
 	// test failed lightning payment
 	// create invoice from node for which there is no route so payment fails
-	noRouteInvoice, err := lightningClient3.CreateInvoice(2000)
+	noRouteInvoice, err := lightningClient3.CreateInvoice(2000, "")
 	if err != nil {
 		t.Fatalf("error creating invoice: %v", err)
 	}
@@ -844,6 +874,220 @@ func TestMelt(t *testing.T) {
 	}
 }
 
+func TestMeltChange(t *testing.T) {
+	invoice, err := node2.CreateInvoice(10000)
+	if err != nil {
+		t.Fatalf("error creating invoice: %v", err)
+	}
+
+	meltQuoteRequest := nut05.PostMeltQuoteBolt11Request{Request: invoice.PaymentRequest, Unit: cashu.Sat.String()}
+	meltQuote, err := testMint.RequestMeltQuote(meltQuoteRequest)
+	if err != nil {
+		t.Fatalf("got unexpected error in melt request: %v", err)
+	}
+
+	validProofs, err := testutils.GetValidProofsForAmount(meltQuote.Amount+meltQuote.FeeReserve, testMint, node2)
+	if err != nil {
+		t.Fatalf("error generating valid proofs: %v", err)
+	}
+
+	keyset := testMint.GetActiveKeyset()
+	numBlankOutputs := int(math.Max(math.Ceil(math.Log2(float64(meltQuote.FeeReserve))), 1))
+	blankOutputs, secrets, rs, err := testutils.CreateBlindedMessages(uint64(1<<numBlankOutputs)-1, keyset.Id)
+	if err != nil {
+		t.Fatalf("error creating blinded messages: %v", err)
+	}
+
+	// test blank outputs from inactive keyset
+	invalidOutputs := make(cashu.BlindedMessages, len(blankOutputs))
+	copy(invalidOutputs, blankOutputs)
+	invalidOutputs[0].Id = "00f93f8d7fe8ae0b"
+	meltTokensRequest := nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: validProofs, Outputs: invalidOutputs}
+	_, err = testMint.MeltTokens(ctx, meltTokensRequest)
+	if !errors.Is(err, cashu.UnknownKeysetErr) {
+		t.Fatalf("expected error '%v' but got '%v' instead", cashu.UnknownKeysetErr, err)
+	}
+
+	meltTokensRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: validProofs, Outputs: blankOutputs}
+	melt, err := testMint.MeltTokens(ctx, meltTokensRequest)
+	if err != nil {
+		t.Fatalf("got unexpected error in melt: %v", err)
+	}
+	if melt.State != nut05.Paid {
+		t.Fatal("got unexpected unpaid melt quote")
+	}
+
+	// direct channel to node2 so no routing fees are expected
+	expectedChange := meltQuote.FeeReserve - melt.FeePaid
+	if melt.Change.Amount() != expectedChange {
+		t.Fatalf("expected change of '%v' but got '%v'", expectedChange, melt.Change.Amount())
+	}
+
+	changeLen := len(melt.Change)
+	changeProofs, err := testutils.ConstructProofs(melt.Change, secrets[:changeLen], rs[:changeLen], keyset)
+	if err != nil {
+		t.Fatalf("error constructing proofs from change: %v", err)
+	}
+
+	// change should be spendable
+	swapOutputs, _, _, err := testutils.CreateBlindedMessages(changeProofs.Amount(), keyset.Id)
+	if err != nil {
+		t.Fatalf("error creating blinded messages: %v", err)
+	}
+	if _, err := testMint.Swap(changeProofs, swapOutputs); err != nil {
+		t.Fatalf("unexpected error swapping change proofs: %v", err)
+	}
+
+	// quote state should return the same change
+	quoteState, err := testMint.GetMeltQuoteState(ctx, meltQuote.Id)
+	if err != nil {
+		t.Fatalf("unexpected error getting melt quote state: %v", err)
+	}
+	if !reflect.DeepEqual(quoteState.Change, melt.Change) {
+		t.Fatal("change in melt quote state does not match change returned in melt")
+	}
+
+	// test internal quote returns overpaid amount as change
+	var mintAmount uint64 = 2000
+	mintQuoteRequest := nut04.PostMintQuoteBolt11Request{Amount: mintAmount, Unit: cashu.Sat.String()}
+	mintQuoteResponse, err := testMint.RequestMintQuote(mintQuoteRequest)
+	if err != nil {
+		t.Fatalf("error requesting mint quote: %v", err)
+	}
+
+	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{Request: mintQuoteResponse.PaymentRequest, Unit: cashu.Sat.String()}
+	meltQuote, err = testMint.RequestMeltQuote(meltQuoteRequest)
+	if err != nil {
+		t.Fatalf("got unexpected error in melt request: %v", err)
+	}
+
+	validProofs, err = testutils.GetValidProofsForAmount(mintAmount+100, testMint, node2)
+	if err != nil {
+		t.Fatalf("error generating valid proofs: %v", err)
+	}
+	blankOutputs, _, _, err = testutils.CreateBlindedMessages(127, keyset.Id)
+	if err != nil {
+		t.Fatalf("error creating blinded messages: %v", err)
+	}
+
+	meltTokensRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: validProofs, Outputs: blankOutputs}
+	melt, err = testMint.MeltTokens(ctx, meltTokensRequest)
+	if err != nil {
+		t.Fatalf("got unexpected error in melt: %v", err)
+	}
+	if melt.Change.Amount() != 100 {
+		t.Fatalf("expected change of '%v' but got '%v'", 100, melt.Change.Amount())
+	}
+}
+
+func TestAmountlessMelt(t *testing.T) {
+	// invoice with no amount
+	invoice, err := node2.CreateInvoice(0)
+	if err != nil {
+		t.Fatalf("error creating invoice: %v", err)
+	}
+
+	meltQuoteRequest := nut05.PostMeltQuoteBolt11Request{Request: invoice.PaymentRequest, Unit: cashu.Sat.String()}
+	_, err = testMint.RequestMeltQuote(meltQuoteRequest)
+	if err == nil {
+		t.Fatal("expected error requesting melt quote for invoice with no amount")
+	}
+
+	meltQuoteRequest.Options = &nut05.MeltOptions{Amountless: &nut05.AmountlessOption{AmountMsat: 0}}
+	_, err = testMint.RequestMeltQuote(meltQuoteRequest)
+	if err == nil {
+		t.Fatal("expected error requesting melt quote with amountless amount of 0")
+	}
+
+	meltQuoteRequest.Options = &nut05.MeltOptions{Amountless: &nut05.AmountlessOption{AmountMsat: 3000500}}
+	_, err = testMint.RequestMeltQuote(meltQuoteRequest)
+	if err == nil {
+		t.Fatal("expected error requesting melt quote with amountless amount that is not a whole number of sats")
+	}
+
+	var amount uint64 = 3000
+	meltQuoteRequest.Options = &nut05.MeltOptions{Amountless: &nut05.AmountlessOption{AmountMsat: amount * 1000}}
+	meltQuote, err := testMint.RequestMeltQuote(meltQuoteRequest)
+	if err != nil {
+		t.Fatalf("got unexpected error in melt request: %v", err)
+	}
+	if meltQuote.Amount != amount {
+		t.Fatalf("expected melt quote amount of '%v' but got '%v'", amount, meltQuote.Amount)
+	}
+
+	validProofs, err := testutils.GetValidProofsForAmount(amount+meltQuote.FeeReserve, testMint, node2)
+	if err != nil {
+		t.Fatalf("error generating valid proofs: %v", err)
+	}
+
+	meltTokensRequest := nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: validProofs}
+	melt, err := testMint.MeltTokens(ctx, meltTokensRequest)
+	if err != nil {
+		t.Fatalf("got unexpected error in melt: %v", err)
+	}
+	if melt.State != nut05.Paid {
+		t.Fatal("got unexpected unpaid melt quote")
+	}
+
+	// amountless option with amount different than amount in invoice
+	invoiceWithAmount, err := node2.CreateInvoice(2000)
+	if err != nil {
+		t.Fatalf("error creating invoice: %v", err)
+	}
+	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
+		Request: invoiceWithAmount.PaymentRequest,
+		Unit:    cashu.Sat.String(),
+		Options: &nut05.MeltOptions{Amountless: &nut05.AmountlessOption{AmountMsat: 1000 * 1000}},
+	}
+	_, err = testMint.RequestMeltQuote(meltQuoteRequest)
+	if err == nil {
+		t.Fatal("expected error requesting melt quote with amount different than invoice")
+	}
+
+	// amountless amount over melting limit
+	limitsMintPath := filepath.Join(".", "amountlesslimitsmint")
+	limitsMint, err := testutils.CreateTestMint(
+		lightningClient1,
+		limitsMintPath,
+		0,
+		mint.MintLimits{MeltingSettings: mint.MeltMethodSettings{MinAmount: 10, MaxAmount: 500}},
+	)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(limitsMintPath)
+
+	invoice, err = node2.CreateInvoice(0)
+	if err != nil {
+		t.Fatalf("error creating invoice: %v", err)
+	}
+	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
+		Request: invoice.PaymentRequest,
+		Unit:    cashu.Sat.String(),
+		Options: &nut05.MeltOptions{Amountless: &nut05.AmountlessOption{AmountMsat: 1000 * 1000}},
+	}
+	_, err = limitsMint.RequestMeltQuote(meltQuoteRequest)
+	if !errors.Is(err, cashu.MeltAmountExceededErr) {
+		t.Fatalf("expected error '%v' but got '%v' instead", cashu.MeltAmountExceededErr, err)
+	}
+
+	meltQuoteRequest.Options = &nut05.MeltOptions{Amountless: &nut05.AmountlessOption{AmountMsat: 5 * 1000}}
+	_, err = limitsMint.RequestMeltQuote(meltQuoteRequest)
+	if !errors.Is(err, cashu.MeltAmountBelowMinErr) {
+		t.Fatalf("expected error '%v' but got '%v' instead", cashu.MeltAmountBelowMinErr, err)
+	}
+
+	// min amount is not checked for invoices with an amount
+	invoice, err = node2.CreateInvoice(5)
+	if err != nil {
+		t.Fatalf("error creating invoice: %v", err)
+	}
+	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{Request: invoice.PaymentRequest, Unit: cashu.Sat.String()}
+	if _, err := limitsMint.RequestMeltQuote(meltQuoteRequest); err != nil {
+		t.Fatalf("unexpected error requesting melt quote: %v", err)
+	}
+}
+
 func TestMPPMelt(t *testing.T) {
 	var lightningClient4 lightning.Client
 	switch *backend {
@@ -895,7 +1139,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest := nut05.PostMeltQuoteBolt11Request{
 		Request: paymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 6000 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 6000 * 1000}},
 	}
 	meltQuote1, err := testMint.RequestMeltQuote(meltQuoteRequest)
 	if err != nil {
@@ -905,7 +1149,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
 		Request: paymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 4000 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 4000 * 1000}},
 	}
 	meltQuote2, err := testMppMint.RequestMeltQuote(meltQuoteRequest)
 	if err != nil {
@@ -951,7 +1195,7 @@ func TestMPPMelt(t *testing.T) {
 	}
 
 	// MPP will fail because there is no route
-	noRouteInvoice, err := lightningClient4.CreateInvoice(10000)
+	noRouteInvoice, err := lightningClient4.CreateInvoice(10000, "")
 	if err != nil {
 		t.Fatalf("error creating invoice: %v", err)
 	}
@@ -960,7 +1204,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
 		Request: noRoutePaymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 6000 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 6000 * 1000}},
 	}
 	meltQuote1, err = testMint.RequestMeltQuote(meltQuoteRequest)
 	if err != nil {
@@ -970,7 +1214,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
 		Request: noRoutePaymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 4000 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 4000 * 1000}},
 	}
 	meltQuote2, err = testMppMint.RequestMeltQuote(meltQuoteRequest)
 	if err != nil {
@@ -1008,7 +1252,7 @@ func TestMPPMelt(t *testing.T) {
 	}
 
 	// test err on mpp amount over invoice amount
-	newInvoice, err := lightningClient4.CreateInvoice(10000)
+	newInvoice, err := lightningClient4.CreateInvoice(10000, "")
 	if err != nil {
 		t.Fatalf("error creating invoice: %v", err)
 	}
@@ -1016,7 +1260,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
 		Request: newInvoice.PaymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 10100 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 10100 * 1000}},
 	}
 	meltQuote1, err = testMint.RequestMeltQuote(meltQuoteRequest)
 	if err == nil {
@@ -1042,7 +1286,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
 		Request: hodlInvoice.PaymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 2000 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 2000 * 1000}},
 	}
 	meltQuote, err := testMint.RequestMeltQuote(meltQuoteRequest)
 	if err != nil {
@@ -1093,7 +1337,7 @@ func TestMPPMelt(t *testing.T) {
 	meltQuoteRequest = nut05.PostMeltQuoteBolt11Request{
 		Request: mintQuote.PaymentRequest,
 		Unit:    cashu.Sat.String(),
-		Options: map[string]nut05.MppOption{"mpp": {AmountMsat: 6000 * 1000}},
+		Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: 6000 * 1000}},
 	}
 	meltQuote1, err = testMint.RequestMeltQuote(meltQuoteRequest)
 	expectedErrMsg = "mpp for internal invoice is not allowed"
@@ -1154,7 +1398,11 @@ func TestPendingProofs(t *testing.T) {
 	meltContext, cancel := context.WithTimeout(context.Background(), time.Second*5)
 	defer cancel()
 
-	meltTokensRequest := nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: validProofs}
+	blankOutputs, _, _, err := testutils.CreateBlindedMessages(3, testMint.GetActiveKeyset().Id)
+	if err != nil {
+		t.Fatalf("error creating blinded messages: %v", err)
+	}
+	meltTokensRequest := nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: validProofs, Outputs: blankOutputs}
 	melt, err := testMint.MeltTokens(meltContext, meltTokensRequest)
 	if err != nil {
 		t.Fatalf("got unexpected error in melt: %v", err)
@@ -1194,6 +1442,32 @@ func TestPendingProofs(t *testing.T) {
 		t.Fatalf("expected error '%v' but got '%v' instead", cashu.QuotePending, err)
 	}
 
+	// blank outputs stored for the pending melt cannot be used in another melt
+	invoice, err := node2.CreateInvoice(1000)
+	if err != nil {
+		t.Fatalf("error creating invoice: %v", err)
+	}
+	otherMeltQuote, err := testMint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{Request: invoice.PaymentRequest, Unit: cashu.Sat.String()})
+	if err != nil {
+		t.Fatalf("got unexpected error in melt request: %v", err)
+	}
+	otherProofs, err := testutils.GetValidProofsForAmount(otherMeltQuote.Amount+otherMeltQuote.FeeReserve, testMint, node2)
+	if err != nil {
+		t.Fatalf("error generating valid proofs: %v", err)
+	}
+	meltTokensRequest = nut05.PostMeltBolt11Request{Quote: otherMeltQuote.Id, Inputs: otherProofs, Outputs: blankOutputs}
+	_, err = testMint.MeltTokens(ctx, meltTokensRequest)
+	if !errors.Is(err, cashu.BlindedMessageAlreadySigned) {
+		t.Fatalf("expected error '%v' but got '%v' instead", cashu.BlindedMessageAlreadySigned, err)
+	}
+	otherMeltQuote, err = testMint.GetMeltQuoteState(ctx, otherMeltQuote.Id)
+	if err != nil {
+		t.Fatalf("unexpected error getting melt quote state: %v", err)
+	}
+	if otherMeltQuote.State != nut05.Unpaid {
+		t.Fatalf("expected melt quote with state of '%s' but got '%s' instead", nut05.Unpaid, otherMeltQuote.State)
+	}
+
 	// try to use currently pending proofs in another op.
 	// swap should return err saying proofs are pending
 	blindedMessages, _, _, _ := testutils.CreateBlindedMessages(validProofs.Amount(), testMint.GetActiveKeyset().Id)
@@ -1684,17 +1958,17 @@ func TestNUT11P2PK(t *testing.T) {
 		t.Fatalf("expected error '%v' but got '%v' instead", nut11.NotEnoughSignaturesErr, err)
 	}
 
-	signingKeys := []*btcec.PrivateKey{key1, key2}
-	// enough signatures but blinded messages not signed
-	signedProofs, _ = testutils.AddP2PKWitnessToInputs(multisigProofs, signingKeys)
+	// enough signatures of the inputs but not of the SIG_ALL message
+	signedProofs, _ = testutils.AddP2PKWitnessToInputs(multisigProofs, []*btcec.PrivateKey{key1, key2})
 	_, err = testMint.Swap(signedProofs, blindedMessages)
-	if !errors.Is(err, nut11.InvalidWitness) {
-		t.Fatalf("expected error '%v' but got '%v' instead", nut11.InvalidWitness, err)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v' instead", nut11.NotEnoughSignaturesErr, err)
 	}
 
-	// inputs and outputs with valid signatures
-	signedBlindedMessages, _ := testutils.AddP2PKWitnessToOutputs(blindedMessages, signingKeys)
-	_, err = testMint.Swap(signedProofs, signedBlindedMessages)
+	// SIG_ALL message signed by both keys
+	signedProofs, _ = nut11.AddSigAllSignature(signedProofs, blindedMessages, "", key1)
+	signedProofs, _ = nut11.AddSigAllSignature(signedProofs, blindedMessages, "", key2)
+	_, err = testMint.Swap(signedProofs, blindedMessages)
 	if err != nil {
 		t.Fatalf("unexpected error in swap: %v", err)
 	}
@@ -1793,7 +2067,7 @@ func TestNUT11P2PK(t *testing.T) {
 		t.Fatalf("unexpected error melting: %v", err)
 	}
 
-	// test melt with SIG_ALL fails
+	// test melt with SIG_ALL
 	tags = nut11.P2PKTags{
 		Sigflag: nut11.SIGALL,
 	}
@@ -1816,10 +2090,18 @@ func TestNUT11P2PK(t *testing.T) {
 		t.Fatalf("got unexpected error in melt request: %v", err)
 	}
 
+	// melt without signature on the SIG_ALL message should fail
+	meltTokensRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: signedProofs}
+	_, err = testMint.MeltTokens(ctx, meltTokensRequest)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v' instead", nut11.NotEnoughSignaturesErr, err)
+	}
+
+	signedProofs, _ = nut11.AddSigAllSignature(signedProofs, nil, meltQuote.Id, lock)
 	meltTokensRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: signedProofs}
 	_, err = testMint.MeltTokens(ctx, meltTokensRequest)
-	if !errors.Is(err, nut11.SigAllOnlySwap) {
-		t.Fatalf("expected error '%v' but got '%v' instead", nut11.SigAllOnlySwap, err)
+	if err != nil {
+		t.Fatalf("unexpected error melting SIG_ALL proofs: %v", err)
 	}
 }
 
@@ -1984,14 +2266,13 @@ func TestHTLC(t *testing.T) {
 
 	// test only inputs signed
 	proofs, _ = testutils.AddHTLCWitnessToInputs(lockedProofs, preimage, signingKey)
-	blindedMessages, _ = testutils.AddHTLCWitnessToOutputs(blindedMessages, preimage, nil)
 	_, err = testMint.Swap(proofs, blindedMessages)
 	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
 		t.Fatalf("expected error '%v' but got '%v' instead", nut11.NotEnoughSignaturesErr, err)
 	}
 
-	// add signatures to outputs for SIG_ALL
-	blindedMessages, err = testutils.AddHTLCWitnessToOutputs(blindedMessages, preimage, signingKey)
+	// sign SIG_ALL message
+	proofs, _ = nut11.AddSigAllSignature(proofs, blindedMessages, "", signingKey)
 	_, err = testMint.Swap(proofs, blindedMessages)
 	if err != nil {
 		t.Fatalf("got unexpected error swapping HTLC proofs: %v", err)
@@ -2082,7 +2363,7 @@ func TestHTLC(t *testing.T) {
 		t.Fatalf("unexpected error melting: %v", err)
 	}
 
-	// test melt with SIG_ALL fails
+	// test melt with SIG_ALL and no pubkeys to sign fails
 	tags = nut11.P2PKTags{
 		Sigflag: nut11.SIGALL,
 	}
@@ -2109,7 +2390,7 @@ func TestHTLC(t *testing.T) {
 
 	meltTokensRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: lockedProofs}
 	_, err = testMint.MeltTokens(ctx, meltTokensRequest)
-	if !errors.Is(err, nut11.SigAllOnlySwap) {
-		t.Fatalf("expected error '%v' but got '%v' instead", nut11.SigAllOnlySwap, err)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v' instead", nut11.NotEnoughSignaturesErr, err)
 	}
 }
@@ -1,10 +1,30 @@
 package mint
 
 import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
 	"os"
+	"path/filepath"
+	"reflect"
+	"runtime"
+	"slices"
 	"testing"
+	"time"
 
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut10"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut11"
+	"github.com/Origami74/gonuts-tollgate/crypto"
 	"github.com/Origami74/gonuts-tollgate/mint/lightning"
+	"github.com/Origami74/gonuts-tollgate/mint/pubsub"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 )
 
 func TestKeysetRotations(t *testing.T) {
@@ -97,3 +117,964 @@ func TestKeysetRotations(t *testing.T) {
 		t.Fatalf("expected fee of '%v' but got '%v'", 200, mint.activeKeyset.InputFeePpk)
 	}
 }
+
+type tollgateVerifier struct{}
+
+func (tollgateVerifier) VerifyProof(proof cashu.Proof, secret nut10.WellKnownSecret) error {
+	if proof.Witness != secret.Data.Data {
+		return errors.New("invalid session witness")
+	}
+	return nil
+}
+
+func (tollgateVerifier) VerifyOutputs(inputs cashu.Proofs, outputs cashu.BlindedMessages, quoteId string) error {
+	if len(outputs) > 1 {
+		return errors.New("session can only be swapped to a single output")
+	}
+	return nil
+}
+
+func TestCustomSpendingCondition(t *testing.T) {
+	tollgateKind := nut10.SecretKind("TOLLGATE")
+	testMintPath := "./testmintspendingcondition"
+	config := Config{
+		MintPath:           testMintPath,
+		LightningClient:    &lightning.FakeBackend{},
+		LogLevel:           Disable,
+		SpendingConditions: map[nut10.SecretKind]SpendingConditionVerifier{tollgateKind: tollgateVerifier{}},
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	expectedKinds := []string{"HTLC", "P2PK", "TOLLGATE"}
+	if !reflect.DeepEqual(mint.mintInfo.Nuts.Nut10.Kinds, expectedKinds) {
+		t.Fatalf("expected supported kinds '%v' but got '%v'", expectedKinds, mint.mintInfo.Nuts.Nut10.Kinds)
+	}
+
+	newProof := func(amount uint64, witness string) cashu.Proof {
+		proof := lockedProof(t, mint, amount, nut10.SpendingCondition{Kind: tollgateKind, Data: "session-key"})
+		proof.Witness = witness
+		return proof
+	}
+	newOutputs := func(amounts ...uint64) cashu.BlindedMessages {
+		outputs := make(cashu.BlindedMessages, len(amounts))
+		for i, amount := range amounts {
+			r, err := secp256k1.GeneratePrivateKey()
+			if err != nil {
+				t.Fatalf("unexpected error generating r: %v", err)
+			}
+			B_, _, err := crypto.BlindMessage(hex.EncodeToString([]byte{byte(i), 1, 2, 3}), r)
+			if err != nil {
+				t.Fatalf("unexpected error blinding message: %v", err)
+			}
+			outputs[i] = cashu.NewBlindedMessage(mint.activeKeyset.Id, amount, B_)
+		}
+		return outputs
+	}
+
+	// invalid witness
+	_, err = mint.Swap(cashu.Proofs{newProof(8, "wrong")}, newOutputs(8))
+	if err == nil || err.Error() != "invalid session witness" {
+		t.Fatalf("expected error 'invalid session witness' but got '%v'", err)
+	}
+
+	// outputs rejected by verifier
+	_, err = mint.Swap(cashu.Proofs{newProof(8, "session-key")}, newOutputs(4, 4))
+	if err == nil || err.Error() != "session can only be swapped to a single output" {
+		t.Fatalf("expected error 'session can only be swapped to a single output' but got '%v'", err)
+	}
+
+	_, err = mint.Swap(cashu.Proofs{newProof(8, "session-key")}, newOutputs(8))
+	if err != nil {
+		t.Fatalf("unexpected error in swap: %v", err)
+	}
+
+	// can't register verifier for built-in kind
+	config.SpendingConditions = map[nut10.SecretKind]SpendingConditionVerifier{nut10.P2PK: tollgateVerifier{}}
+	if _, err := LoadMint(config); err == nil {
+		t.Fatal("expected error registering verifier for P2PK")
+	}
+}
+
+func TestSigAllMelt(t *testing.T) {
+	testMintPath := "./testmintsigallmelt"
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: &lightning.FakeBackend{},
+		LogLevel:        Disable,
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	lockKey, _ := btcec.NewPrivateKey()
+	spendingCondition := nut10.SpendingCondition{
+		Kind: nut10.P2PK,
+		Data: hex.EncodeToString(lockKey.PubKey().SerializeCompressed()),
+		Tags: nut11.SerializeP2PKTags(nut11.P2PKTags{Sigflag: nut11.SIGALL}),
+	}
+	newMeltQuote := func() storage.MeltQuote {
+		invoice, _, _, err := lightning.CreateFakeInvoice(12, false)
+		if err != nil {
+			t.Fatalf("unexpected error creating invoice: %v", err)
+		}
+		meltQuote, err := mint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{
+			Request: invoice,
+			Unit:    cashu.Sat.String(),
+		})
+		if err != nil {
+			t.Fatalf("unexpected error requesting melt quote: %v", err)
+		}
+		return meltQuote
+	}
+
+	proofs := cashu.Proofs{
+		lockedProof(t, mint, 8, spendingCondition),
+		lockedProof(t, mint, 4, spendingCondition),
+	}
+	proofs, _ = nut11.AddSignatureToInputs(proofs, lockKey)
+
+	// no signature for the SIG_ALL message
+	meltQuote := newMeltQuote()
+	meltRequest := nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: proofs}
+	_, err = mint.MeltTokens(context.Background(), meltRequest)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v'", nut11.NotEnoughSignaturesErr, err)
+	}
+
+	// signature for a different quote
+	otherQuote := newMeltQuote()
+	signedProofs, _ := nut11.AddSigAllSignature(slices.Clone(proofs), nil, otherQuote.Id, lockKey)
+	meltRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: signedProofs}
+	_, err = mint.MeltTokens(context.Background(), meltRequest)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v'", nut11.NotEnoughSignaturesErr, err)
+	}
+
+	signedProofs, _ = nut11.AddSigAllSignature(slices.Clone(proofs), nil, meltQuote.Id, lockKey)
+	meltRequest = nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: signedProofs}
+	meltResponse, err := mint.MeltTokens(context.Background(), meltRequest)
+	if err != nil {
+		t.Fatalf("unexpected error melting SIG_ALL proofs: %v", err)
+	}
+	if meltResponse.State != nut05.Paid {
+		t.Fatalf("expected melt quote state '%v' but got '%v'", nut05.Paid, meltResponse.State)
+	}
+}
+
+func TestSigAllSwap(t *testing.T) {
+	testMintPath := "./testmintsigallswap"
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: &lightning.FakeBackend{},
+		LogLevel:        Disable,
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	lockKey, _ := btcec.NewPrivateKey()
+	spendingCondition := nut10.SpendingCondition{
+		Kind: nut10.P2PK,
+		Data: hex.EncodeToString(lockKey.PubKey().SerializeCompressed()),
+		Tags: nut11.SerializeP2PKTags(nut11.P2PKTags{Sigflag: nut11.SIGALL}),
+	}
+	proofs := cashu.Proofs{
+		lockedProof(t, mint, 8, spendingCondition),
+		lockedProof(t, mint, 4, spendingCondition),
+	}
+	proofs, _ = nut11.AddSignatureToInputs(proofs, lockKey)
+	blindedMessages := make(cashu.BlindedMessages, 3)
+	for i, amount := range []uint64{8, 2, 2} {
+		r, _ := secp256k1.GeneratePrivateKey()
+		B_, _, _ := crypto.BlindMessage(fmt.Sprintf("sigallswap%v", i), r)
+		blindedMessages[i] = cashu.NewBlindedMessage(mint.activeKeyset.Id, amount, B_)
+	}
+
+	// signature for different outputs
+	signedProofs, _ := nut11.AddSigAllSignature(proofs, blindedMessages[:2], "", lockKey)
+	_, err = mint.Swap(signedProofs, blindedMessages)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v'", nut11.NotEnoughSignaturesErr, err)
+	}
+
+	// signature of the melt message for the same inputs and outputs
+	signedProofs, _ = nut11.AddSigAllSignature(proofs, blindedMessages, "quoteid", lockKey)
+	_, err = mint.Swap(signedProofs, blindedMessages)
+	if !errors.Is(err, nut11.NotEnoughSignaturesErr) {
+		t.Fatalf("expected error '%v' but got '%v'", nut11.NotEnoughSignaturesErr, err)
+	}
+
+	signedProofs, _ = nut11.AddSigAllSignature(proofs, blindedMessages, "", lockKey)
+	if _, err := mint.Swap(signedProofs, blindedMessages); err != nil {
+		t.Fatalf("unexpected error swapping SIG_ALL proofs: %v", err)
+	}
+}
+
+// lockedProof signs a proof with the mint keys directly
+// to avoid going through minting
+func lockedProof(
+	t testing.TB,
+	mint *Mint,
+	amount uint64,
+	spendingCondition nut10.SpendingCondition,
+) cashu.Proof {
+	secret, err := nut10.NewSecretFromSpendingCondition(spendingCondition)
+	if err != nil {
+		t.Fatalf("unexpected error creating secret: %v", err)
+	}
+	Y, err := crypto.HashToCurve([]byte(secret))
+	if err != nil {
+		t.Fatalf("unexpected error hashing secret: %v", err)
+	}
+	C := crypto.SignBlindedMessage(Y, mint.activeKeyset.Keys[amount].PrivateKey)
+	return cashu.Proof{
+		Amount: amount,
+		Id:     mint.activeKeyset.Id,
+		Secret: secret,
+		C:      hex.EncodeToString(C.SerializeCompressed()),
+	}
+}
+
+func TestRunParallel(t *testing.T) {
+	n := 100
+	visited := make([]bool, n)
+	err := runParallel(n, 8, func(i int) error {
+		visited[i] = true
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for i, ok := range visited {
+		if !ok {
+			t.Fatalf("index %v was not visited", i)
+		}
+	}
+
+	failing := map[int]bool{30: true, 70: true, 90: true}
+	for _, workers := range []int{1, 4, 16} {
+		err := runParallel(n, workers, func(i int) error {
+			if failing[i] {
+				return fmt.Errorf("failed at %v", i)
+			}
+			return nil
+		})
+		expectedErr := "failed at 30"
+		if err == nil || err.Error() != expectedErr {
+			t.Fatalf("expected error '%v' with %v workers but got '%v'", expectedErr, workers, err)
+		}
+	}
+}
+
+func TestParallelVerifyAndSign(t *testing.T) {
+	testMintPath := "./testmintparallel"
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: &lightning.FakeBackend{},
+		LogLevel:        Disable,
+		Concurrency:     4,
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	proofs, Ys := validProofs(t, mint, 20)
+	if err := mint.verifyProofs(proofs, Ys); err != nil {
+		t.Fatalf("unexpected error verifying proofs: %v", err)
+	}
+
+	// error for the lowest failing index should be returned
+	proofs[3].C = proofs[4].C
+	proofs[7].Id = "00deadbeef"
+	if err := mint.verifyProofs(proofs, Ys); !errors.Is(err, cashu.InvalidProofErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.InvalidProofErr, err)
+	}
+
+	blindedMessages := make(cashu.BlindedMessages, 20)
+	for i := range blindedMessages {
+		r, _ := secp256k1.GeneratePrivateKey()
+		B_, _, _ := crypto.BlindMessage(fmt.Sprintf("secret%v", i), r)
+		blindedMessages[i] = cashu.NewBlindedMessage(mint.activeKeyset.Id, 1, B_)
+	}
+	signatures, err := mint.signBlindedMessages(blindedMessages)
+	if err != nil {
+		t.Fatalf("unexpected error signing blinded messages: %v", err)
+	}
+	for i, signature := range signatures {
+		if signature.C_ == "" || signature.Amount != blindedMessages[i].Amount {
+			t.Fatalf("invalid signature at index %v", i)
+		}
+	}
+
+	blindedMessages[2].Amount = 3
+	blindedMessages[5].Id = "00deadbeef"
+	_, err = mint.signBlindedMessages(blindedMessages)
+	if !errors.Is(err, cashu.InvalidBlindedMessageAmount) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.InvalidBlindedMessageAmount, err)
+	}
+}
+
+func BenchmarkVerifyProofs(b *testing.B) {
+	for _, concurrency := range benchmarkConcurrency() {
+		b.Run(fmt.Sprintf("concurrency=%v", concurrency), func(b *testing.B) {
+			testMintPath := "./benchmintverify"
+			config := Config{
+				MintPath:        testMintPath,
+				LightningClient: &lightning.FakeBackend{},
+				LogLevel:        Disable,
+				Concurrency:     concurrency,
+			}
+			defer os.RemoveAll(testMintPath)
+
+			mint, err := LoadMint(config)
+			if err != nil {
+				b.Fatalf("unexpected error loading mint: %v", err)
+			}
+			proofs, Ys := validProofs(b, mint, 100)
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if err := mint.verifyProofs(proofs, Ys); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkSignBlindedMessages(b *testing.B) {
+	for _, concurrency := range benchmarkConcurrency() {
+		b.Run(fmt.Sprintf("concurrency=%v", concurrency), func(b *testing.B) {
+			testMintPath := "./benchmintsign"
+			config := Config{
+				MintPath:        testMintPath,
+				LightningClient: &lightning.FakeBackend{},
+				LogLevel:        Disable,
+				Concurrency:     concurrency,
+			}
+			defer os.RemoveAll(testMintPath)
+
+			mint, err := LoadMint(config)
+			if err != nil {
+				b.Fatalf("unexpected error loading mint: %v", err)
+			}
+			blindedMessages := make(cashu.BlindedMessages, 100)
+			for i := range blindedMessages {
+				r, _ := secp256k1.GeneratePrivateKey()
+				B_, _, _ := crypto.BlindMessage(fmt.Sprintf("secret%v", i), r)
+				blindedMessages[i] = cashu.NewBlindedMessage(mint.activeKeyset.Id, 1, B_)
+			}
+
+			b.ResetTimer()
+			for n := 0; n < b.N; n++ {
+				if _, err := mint.signBlindedMessages(blindedMessages); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// benchmarkConcurrency returns the concurrency settings to compare in benchmarks
+func benchmarkConcurrency() []int {
+	concurrency := []int{1}
+	if runtime.NumCPU() > 1 {
+		concurrency = append(concurrency, runtime.NumCPU())
+	}
+	return concurrency
+}
+
+// validProofs creates n proofs of amount 1 signed with the mint keys
+// and returns them with their Ys
+func validProofs(t testing.TB, mint *Mint, n int) (cashu.Proofs, []string) {
+	proofs := make(cashu.Proofs, n)
+	Ys := make([]string, n)
+	for i := range proofs {
+		secret := fmt.Sprintf("secret%v", i)
+		Y, err := crypto.HashToCurve([]byte(secret))
+		if err != nil {
+			t.Fatalf("unexpected error hashing secret: %v", err)
+		}
+		C := crypto.SignBlindedMessage(Y, mint.activeKeyset.Keys[1].PrivateKey)
+		proofs[i] = cashu.Proof{
+			Amount: 1,
+			Id:     mint.activeKeyset.Id,
+			Secret: secret,
+			C:      hex.EncodeToString(C.SerializeCompressed()),
+		}
+		Ys[i] = hex.EncodeToString(Y.SerializeCompressed())
+	}
+	return proofs, Ys
+}
+
+func TestRequestLimits(t *testing.T) {
+	testMintPath := "./testmintrequestlimits"
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: &lightning.FakeBackend{},
+		LogLevel:        Disable,
+		Limits: MintLimits{
+			MaxInputs:       3,
+			MaxOutputs:      3,
+			MaxRequestBytes: 1024,
+		},
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	mintInfo, err := mint.RetrieveMintInfo()
+	if err != nil {
+		t.Fatalf("unexpected error getting mint info: %v", err)
+	}
+	limits := mintInfo.Limits
+	if limits == nil || limits.MaxInputs != 3 || limits.MaxOutputs != 3 || limits.MaxRequestBytes != 1024 {
+		t.Fatalf("unexpected limits in mint info: %+v", limits)
+	}
+
+	blindedMessages := make(cashu.BlindedMessages, 4)
+	for i := range blindedMessages {
+		r, _ := secp256k1.GeneratePrivateKey()
+		B_, _, _ := crypto.BlindMessage(fmt.Sprintf("secret%v", i), r)
+		blindedMessages[i] = cashu.NewBlindedMessage(mint.activeKeyset.Id, 1, B_)
+	}
+
+	proofs, _ := validProofs(t, mint, 4)
+	_, err = mint.Swap(proofs, blindedMessages[:3])
+	if !errors.Is(err, cashu.MaxInputsExceededErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MaxInputsExceededErr, err)
+	}
+
+	_, err = mint.Swap(proofs[:3], blindedMessages)
+	if !errors.Is(err, cashu.MaxOutputsExceededErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MaxOutputsExceededErr, err)
+	}
+
+	_, err = mint.Swap(proofs[:3], blindedMessages[:3])
+	if err != nil {
+		t.Fatalf("unexpected error in swap: %v", err)
+	}
+
+	_, _, err = mint.RestoreSignatures(blindedMessages)
+	if !errors.Is(err, cashu.MaxOutputsExceededErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MaxOutputsExceededErr, err)
+	}
+
+	meltRequest := nut05.PostMeltBolt11Request{Quote: "quote", Inputs: proofs}
+	_, err = mint.MeltTokens(context.Background(), meltRequest)
+	if !errors.Is(err, cashu.MaxInputsExceededErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MaxInputsExceededErr, err)
+	}
+}
+
+func TestFeeLedger(t *testing.T) {
+	testMintPath := "./testmintfeeledger"
+	config := Config{
+		MintPath:        testMintPath,
+		InputFeePpk:     100,
+		LightningClient: &lightning.FakeBackend{},
+		FeePolicy:       &lightning.FeePolicy{MinFee: 2},
+		LogLevel:        Disable,
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	secretIdx := 0
+	newProofs := func(amounts ...uint64) cashu.Proofs {
+		proofs := make(cashu.Proofs, len(amounts))
+		for i, amount := range amounts {
+			secret := fmt.Sprintf("feeledger%v", secretIdx)
+			secretIdx++
+			Y, _ := crypto.HashToCurve([]byte(secret))
+			C := crypto.SignBlindedMessage(Y, mint.activeKeyset.Keys[amount].PrivateKey)
+			proofs[i] = cashu.Proof{
+				Amount: amount,
+				Id:     mint.activeKeyset.Id,
+				Secret: secret,
+				C:      hex.EncodeToString(C.SerializeCompressed()),
+			}
+		}
+		return proofs
+	}
+
+	// swap 13 with fee of 1
+	blindedMessages := make(cashu.BlindedMessages, 2)
+	for i, amount := range []uint64{8, 4} {
+		r, _ := secp256k1.GeneratePrivateKey()
+		B_, _, _ := crypto.BlindMessage(fmt.Sprintf("feeledgeroutput%v", i), r)
+		blindedMessages[i] = cashu.NewBlindedMessage(mint.activeKeyset.Id, amount, B_)
+	}
+	if _, err := mint.Swap(newProofs(8, 4, 1), blindedMessages); err != nil {
+		t.Fatalf("unexpected error in swap: %v", err)
+	}
+
+	// melt 10 with fee reserve of 2 and input fee of 1
+	invoice, _, _, _ := lightning.CreateFakeInvoice(10, false)
+	meltQuote, err := mint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{
+		Request: invoice,
+		Unit:    cashu.Sat.String(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error requesting melt quote: %v", err)
+	}
+	meltRequest := nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: newProofs(8, 4, 1)}
+	if _, err := mint.MeltTokens(context.Background(), meltRequest); err != nil {
+		t.Fatalf("unexpected error melting: %v", err)
+	}
+
+	// internal melt of 5 with input fee of 1
+	mintQuote, err := mint.RequestMintQuote(nut04.PostMintQuoteBolt11Request{Amount: 5, Unit: cashu.Sat.String()})
+	if err != nil {
+		t.Fatalf("unexpected error requesting mint quote: %v", err)
+	}
+	internalQuote, err := mint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{
+		Request: mintQuote.PaymentRequest,
+		Unit:    cashu.Sat.String(),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error requesting melt quote: %v", err)
+	}
+	meltRequest = nut05.PostMeltBolt11Request{Quote: internalQuote.Id, Inputs: newProofs(4, 2)}
+	if _, err := mint.MeltTokens(context.Background(), meltRequest); err != nil {
+		t.Fatalf("unexpected error melting: %v", err)
+	}
+
+	entries, err := mint.FeeEntries(time.Unix(0, 0), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error getting fee entries: %v", err)
+	}
+	expectedEntries := []storage.FeeEntry{
+		{Operation: storage.SwapOperation, InputFee: 1},
+		{Operation: storage.MeltOperation, QuoteId: meltQuote.Id, InputFee: 1, FeeReserve: 2, ReserveKept: 2},
+		{Operation: storage.InternalMeltOperation, QuoteId: internalQuote.Id, InputFee: 1},
+	}
+	if len(entries) != len(expectedEntries) {
+		t.Fatalf("expected '%v' fee entries but got '%v'", len(expectedEntries), len(entries))
+	}
+	for i, entry := range entries {
+		entry.CreatedAt = 0
+		if entry != expectedEntries[i] {
+			t.Fatalf("expected fee entry '%+v' but got '%+v'", expectedEntries[i], entry)
+		}
+	}
+}
+
+func TestSolvencyCheck(t *testing.T) {
+	testMintPath := "./testmintsolvency"
+	fakeBackend := &lightning.FakeBackend{NodeBalance: lightning.Balance{Channel: 20, OnChain: 10}}
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: fakeBackend,
+		LogLevel:        Disable,
+		Limits:          MintLimits{MinReserveRatio: 1},
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	mintQuote, err := mint.RequestMintQuote(nut04.PostMintQuoteBolt11Request{Amount: 64, Unit: cashu.Sat.String()})
+	if err != nil {
+		t.Fatalf("unexpected error requesting mint quote: %v", err)
+	}
+	r, _ := secp256k1.GeneratePrivateKey()
+	B_, _, _ := crypto.BlindMessage("solvency", r)
+	mintRequest := nut04.PostMintBolt11Request{
+		Quote:   mintQuote.Id,
+		Outputs: cashu.BlindedMessages{cashu.NewBlindedMessage(mint.activeKeyset.Id, 64, B_)},
+	}
+	if _, err := mint.MintTokens(mintRequest); err != nil {
+		t.Fatalf("unexpected error minting tokens: %v", err)
+	}
+
+	subscriber := mint.SubscribeSolvency()
+	defer mint.UnsubscribeSolvency(subscriber)
+
+	status, err := mint.CheckSolvency()
+	if err != nil {
+		t.Fatalf("unexpected error checking solvency: %v", err)
+	}
+	if status.Liabilities != 64 || status.Reserves.Total() != 30 {
+		t.Fatalf("unexpected solvency status: %+v", status)
+	}
+	if status.Solvent || !status.MintingDisabled {
+		t.Fatalf("expected mint to be insolvent with minting disabled but got: %+v", status)
+	}
+
+	select {
+	case msg := <-subscriber.GetMessages():
+		var published SolvencyStatus
+		if err := json.Unmarshal(msg.Payload(), &published); err != nil {
+			t.Fatalf("unexpected error decoding solvency event: %v", err)
+		}
+		if published != status {
+			t.Fatalf("expected solvency event '%+v' but got '%+v'", status, published)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("solvency event was not published")
+	}
+
+	_, err = mint.RequestMintQuote(nut04.PostMintQuoteBolt11Request{Amount: 10, Unit: cashu.Sat.String()})
+	if !errors.Is(err, cashu.MintingDisabled) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MintingDisabled, err)
+	}
+	mintInfo, err := mint.RetrieveMintInfo()
+	if err != nil {
+		t.Fatalf("unexpected error getting mint info: %v", err)
+	}
+	if !mintInfo.Nuts.Nut04.Disabled {
+		t.Fatal("expected minting to be disabled in mint info")
+	}
+
+	fakeBackend.NodeBalance.OnChain = 100
+	status, err = mint.CheckSolvency()
+	if err != nil {
+		t.Fatalf("unexpected error checking solvency: %v", err)
+	}
+	if !status.Solvent || status.MintingDisabled {
+		t.Fatalf("expected mint to be solvent with minting enabled but got: %+v", status)
+	}
+	if _, err := mint.RequestMintQuote(nut04.PostMintQuoteBolt11Request{Amount: 10, Unit: cashu.Sat.String()}); err != nil {
+		t.Fatalf("unexpected error requesting mint quote: %v", err)
+	}
+}
+
+// blockingBackend blocks outgoing payments until released or canceled
+type blockingBackend struct {
+	*lightning.FakeBackend
+	started chan struct{}
+	release chan struct{}
+}
+
+func (b *blockingBackend) SendPayment(ctx context.Context, request string, maxFee uint64) (lightning.PaymentStatus, error) {
+	b.started <- struct{}{}
+	select {
+	case <-b.release:
+		return b.FakeBackend.SendPayment(ctx, request, maxFee)
+	case <-ctx.Done():
+		return lightning.PaymentStatus{}, ctx.Err()
+	}
+}
+
+func TestDrainInFlightMelts(t *testing.T) {
+	testMintPath := "./testmintdrain"
+	backend := &blockingBackend{
+		FakeBackend: &lightning.FakeBackend{},
+		started:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: backend,
+		LogLevel:        Disable,
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	invoice, _, _, _ := lightning.CreateFakeInvoice(10, false)
+	meltQuote, err := mint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{Request: invoice, Unit: cashu.Sat.String()})
+	if err != nil {
+		t.Fatalf("unexpected error requesting melt quote: %v", err)
+	}
+	proofs, _ := validProofs(t, mint, 10)
+
+	type meltResult struct {
+		quote storage.MeltQuote
+		err   error
+	}
+	meltDone := make(chan meltResult)
+	go func() {
+		quote, err := mint.MeltTokens(context.Background(), nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: proofs})
+		meltDone <- meltResult{quote, err}
+	}()
+	<-backend.started
+
+	// payment is blocked so drain should time out
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := mint.Drain(ctx); err == nil {
+		t.Fatal("expected error draining mint with melt in flight")
+	}
+
+	_, err = mint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{Request: invoice, Unit: cashu.Sat.String()})
+	if !errors.Is(err, cashu.MintShuttingDownErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MintShuttingDownErr, err)
+	}
+	_, err = mint.RequestMintQuote(nut04.PostMintQuoteBolt11Request{Amount: 10, Unit: cashu.Sat.String()})
+	if !errors.Is(err, cashu.MintShuttingDownErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MintShuttingDownErr, err)
+	}
+
+	close(backend.release)
+	ctx, cancel = context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := mint.Drain(ctx); err != nil {
+		t.Fatalf("unexpected error draining mint: %v", err)
+	}
+
+	result := <-meltDone
+	if result.err != nil {
+		t.Fatalf("unexpected error melting: %v", result.err)
+	}
+	if result.quote.State != nut05.Paid {
+		t.Fatalf("expected melt quote state '%v' but got '%v'", nut05.Paid, result.quote.State)
+	}
+
+	if err := mint.Shutdown(); err != nil {
+		t.Fatalf("unexpected error shutting down mint: %v", err)
+	}
+}
+
+func TestDrainSameQuote(t *testing.T) {
+	drain := newDrainState()
+	drain.enter("quote")
+	drain.enter("quote")
+
+	// melt rejected for the quote exits while the other is still paying
+	drain.exit("quote")
+	if quoteIds := drain.inflightQuotes(); len(quoteIds) != 1 || quoteIds[0] != "quote" {
+		t.Fatalf("expected quote to still be in flight but got %v", quoteIds)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	if err := drain.wait(ctx); err == nil {
+		t.Fatal("expected drain to wait for the melt still in flight")
+	}
+
+	drain.exit("quote")
+	if quoteIds := drain.inflightQuotes(); len(quoteIds) != 0 {
+		t.Fatalf("expected no quotes in flight but got %v", quoteIds)
+	}
+	if err := drain.wait(context.Background()); err != nil {
+		t.Fatalf("unexpected error waiting for drain: %v", err)
+	}
+}
+
+func TestShutdownLeavesMeltPending(t *testing.T) {
+	testMintPath := "./testmintshutdownpending"
+	backend := &blockingBackend{
+		FakeBackend: &lightning.FakeBackend{},
+		started:     make(chan struct{}),
+		release:     make(chan struct{}),
+	}
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: backend,
+		LogLevel:        Disable,
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	invoice, _, paymentHash, _ := lightning.CreateFakeInvoice(10, false)
+	meltQuote, err := mint.RequestMeltQuote(nut05.PostMeltQuoteBolt11Request{Request: invoice, Unit: cashu.Sat.String()})
+	if err != nil {
+		t.Fatalf("unexpected error requesting melt quote: %v", err)
+	}
+	proofs, _ := validProofs(t, mint, 10)
+
+	meltDone := make(chan storage.MeltQuote)
+	go func() {
+		quote, _ := mint.MeltTokens(context.Background(), nut05.PostMeltBolt11Request{Quote: meltQuote.Id, Inputs: proofs})
+		meltDone <- quote
+	}()
+	<-backend.started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	mint.Drain(ctx)
+	if err := mint.Shutdown(); err != nil {
+		t.Fatalf("unexpected error shutting down mint: %v", err)
+	}
+	if quote := <-meltDone; quote.State != nut05.Pending {
+		t.Fatalf("expected melt quote state '%v' but got '%v'", nut05.Pending, quote.State)
+	}
+
+	// payment went through after the mint was stopped
+	config.LightningClient = &lightning.FakeBackend{
+		Invoices: []lightning.FakeBackendInvoice{{PaymentHash: paymentHash, Status: lightning.Succeeded}},
+	}
+	mint, err = LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+	defer mint.Shutdown()
+
+	pendingQuotes, err := mint.db.GetPendingMeltQuotes()
+	if err != nil {
+		t.Fatalf("unexpected error getting pending melt quotes: %v", err)
+	}
+	if len(pendingQuotes) != 1 || pendingQuotes[0].Id != meltQuote.Id {
+		t.Fatalf("expected melt quote '%v' to be pending but got %+v", meltQuote.Id, pendingQuotes)
+	}
+	mint.checkPendingMeltQuotes(pendingQuotes)
+	quote, err := mint.db.GetMeltQuote(meltQuote.Id)
+	if err != nil {
+		t.Fatalf("unexpected error getting melt quote: %v", err)
+	}
+	if quote.State != nut05.Paid {
+		t.Fatalf("expected melt quote state '%v' but got '%v'", nut05.Paid, quote.State)
+	}
+}
+
+func TestMultipleInstances(t *testing.T) {
+	testMintPath := "./testmintinstances"
+	defer os.RemoveAll(testMintPath)
+
+	socketPath := filepath.Join(t.TempDir(), "broker.sock")
+	broker, err := pubsub.NewBroker(socketPath)
+	if err != nil {
+		t.Fatalf("unexpected error starting broker: %v", err)
+	}
+	go broker.Serve()
+	defer broker.Close()
+
+	fakeBackend := &lightning.FakeBackend{}
+	loadInstance := func() *Mint {
+		transport, err := pubsub.NewUnixTransport(socketPath)
+		if err != nil {
+			t.Fatalf("unexpected error connecting to broker: %v", err)
+		}
+		config := Config{
+			MintPath:        testMintPath,
+			LightningClient: fakeBackend,
+			LogLevel:        Disable,
+			PubSubTransport: transport,
+		}
+		mint, err := LoadMint(config)
+		if err != nil {
+			t.Fatalf("unexpected error loading mint: %v", err)
+		}
+		return mint
+	}
+	mint1 := loadInstance()
+	defer mint1.Shutdown()
+	mint2 := loadInstance()
+	defer mint2.Shutdown()
+
+	// responses are not cached in the shared db so NUT-19 is not advertised
+	mintInfo, err := mint1.RetrieveMintInfo()
+	if err != nil {
+		t.Fatalf("unexpected error getting mint info: %v", err)
+	}
+	if len(mintInfo.Nuts.Nut19.CachedEndpoints) > 0 {
+		t.Fatalf("expected no NUT-19 cached endpoints but got %v", mintInfo.Nuts.Nut19.CachedEndpoints)
+	}
+
+	newOutputs := func(n int) cashu.BlindedMessages {
+		outputs := make(cashu.BlindedMessages, n)
+		for i := range outputs {
+			r, _ := secp256k1.GeneratePrivateKey()
+			B_, _, _ := crypto.BlindMessage(hex.EncodeToString(r.Serialize()), r)
+			outputs[i] = cashu.NewBlindedMessage(mint1.activeKeyset.Id, 1, B_)
+		}
+		return outputs
+	}
+
+	// same proofs swapped on both instances at once
+	proofs, _ := validProofs(t, mint1, 4)
+	errs := make(chan error, 2)
+	for _, mint := range []*Mint{mint1, mint2} {
+		go func(mint *Mint, outputs cashu.BlindedMessages) {
+			_, err := mint.Swap(proofs, outputs)
+			errs <- err
+		}(mint, newOutputs(4))
+	}
+	var succeeded int
+	for range 2 {
+		err := <-errs
+		if err == nil {
+			succeeded++
+			continue
+		}
+		if !errors.Is(err, cashu.ProofAlreadyUsedErr) && !errors.Is(err, cashu.ProofPendingErr) {
+			t.Fatalf("expected proof already used or pending error but got '%v'", err)
+		}
+	}
+	if succeeded != 1 {
+		t.Fatalf("expected exactly one swap to succeed but %v did", succeeded)
+	}
+
+	// only one instance runs the background jobs
+	if !mint1.isLeader(quoteCleanupJob, time.Minute) {
+		t.Fatal("expected first instance to acquire the lease")
+	}
+	if mint2.isLeader(quoteCleanupJob, time.Minute) {
+		t.Fatal("expected second instance to not acquire the lease held by the first")
+	}
+
+	// mint quote notifications reach subscribers on the other instance
+	subscriber := mint2.publisher.Subscribe(BOLT11_MINT_QUOTE_TOPIC)
+	defer mint2.publisher.Unsubscribe(subscriber, BOLT11_MINT_QUOTE_TOPIC)
+	mint1.publisher.Publish(BOLT11_MINT_QUOTE_TOPIC, []byte("quote"))
+	select {
+	case msg := <-subscriber.GetMessages():
+		if string(msg.Payload()) != "quote" {
+			t.Fatalf("expected payload 'quote' but got '%s'", msg.Payload())
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("notification was not received by the other instance")
+	}
+
+	// keyset rotated on one instance is loaded by the other
+	newKeyset, err := mint1.RotateKeyset(100)
+	if err != nil {
+		t.Fatalf("unexpected error rotating keyset: %v", err)
+	}
+	deadline := time.Now().Add(2 * time.Second)
+	for mint2.GetActiveKeyset().Id != newKeyset.Id {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected active keyset '%v' in second instance but got '%v'",
+				newKeyset.Id, mint2.GetActiveKeyset().Id)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// keyset rotated while disconnected is loaded when a request uses it
+	broker.Close()
+	time.Sleep(100 * time.Millisecond)
+	newKeyset, err = mint1.RotateKeyset(100)
+	if err != nil {
+		t.Fatalf("unexpected error rotating keyset: %v", err)
+	}
+	mint2.keysetsMu.Lock()
+	mint2.keysetsLoadedAt = time.Time{}
+	mint2.keysetsMu.Unlock()
+	if _, err := mint2.GetKeysetById(newKeyset.Id); err != nil {
+		t.Fatalf("expected second instance to load unknown keyset from db but got error: %v", err)
+	}
+	if mint2.GetActiveKeyset().Id != newKeyset.Id {
+		t.Fatalf("expected active keyset '%v' in second instance but got '%v'",
+			newKeyset.Id, mint2.GetActiveKeyset().Id)
+	}
+}
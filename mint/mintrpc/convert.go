@@ -0,0 +1,554 @@
+package mintrpc
+
+import (
+	"encoding/hex"
+	"fmt"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut01"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut02"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut03"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut06"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut17"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// The functions in this file convert between the protobuf messages
+// and the types of the nuts packages used by the mint and the wallet.
+
+func MintInfoToProto(info nut06.MintInfo) *MintInfo {
+	contact := make([]*ContactInfo, len(info.Contact))
+	for i, c := range info.Contact {
+		contact[i] = &ContactInfo{Method: c.Method, Info: c.Info}
+	}
+
+	var limits *Limits
+	if info.Limits != nil {
+		limits = &Limits{
+			MaxInputs:       int64(info.Limits.MaxInputs),
+			MaxOutputs:      int64(info.Limits.MaxOutputs),
+			MaxRequestBytes: info.Limits.MaxRequestBytes,
+		}
+	}
+
+	return &MintInfo{
+		Name:            info.Name,
+		Pubkey:          info.Pubkey,
+		Version:         info.Version,
+		Description:     info.Description,
+		DescriptionLong: info.LongDescription,
+		Contact:         contact,
+		Motd:            info.Motd,
+		IconUrl:         info.IconURL,
+		Urls:            info.URLs,
+		Time:            info.Time,
+		Nuts:            nutsToProto(info.Nuts),
+		Limits:          limits,
+	}
+}
+
+func MintInfoFromProto(info *MintInfo) nut06.MintInfo {
+	var contact []nut06.ContactInfo
+	for _, c := range info.GetContact() {
+		contact = append(contact, nut06.ContactInfo{Method: c.GetMethod(), Info: c.GetInfo()})
+	}
+
+	var limits *nut06.Limits
+	if info.GetLimits() != nil {
+		limits = &nut06.Limits{
+			MaxInputs:       int(info.Limits.GetMaxInputs()),
+			MaxOutputs:      int(info.Limits.GetMaxOutputs()),
+			MaxRequestBytes: info.Limits.GetMaxRequestBytes(),
+		}
+	}
+
+	return nut06.MintInfo{
+		Name:            info.GetName(),
+		Pubkey:          info.GetPubkey(),
+		Version:         info.GetVersion(),
+		Description:     info.GetDescription(),
+		LongDescription: info.GetDescriptionLong(),
+		Contact:         contact,
+		Motd:            info.GetMotd(),
+		IconURL:         info.GetIconUrl(),
+		URLs:            info.GetUrls(),
+		Time:            info.GetTime(),
+		Nuts:            nutsFromProto(info.GetNuts()),
+		Limits:          limits,
+	}
+}
+
+func nutsToProto(nuts nut06.Nuts) *Nuts {
+	var nut15 *NutSetting
+	if nuts.Nut15 != nil {
+		nut15 = nutSettingToProto(*nuts.Nut15)
+	}
+
+	nut17Methods := make([]*SubscriptionMethod, len(nuts.Nut17.Supported))
+	for i, method := range nuts.Nut17.Supported {
+		nut17Methods[i] = &SubscriptionMethod{Method: method.Method, Unit: method.Unit, Commands: method.Commands}
+	}
+
+	cachedEndpoints := make([]*CachedEndpoint, len(nuts.Nut19.CachedEndpoints))
+	for i, endpoint := range nuts.Nut19.CachedEndpoints {
+		cachedEndpoints[i] = &CachedEndpoint{Method: endpoint.Method, Path: endpoint.Path}
+	}
+
+	return &Nuts{
+		Nut04: nutSettingToProto(nuts.Nut04),
+		Nut05: nutSettingToProto(nuts.Nut05),
+		Nut07: &Supported{Supported: nuts.Nut07.Supported},
+		Nut08: &Supported{Supported: nuts.Nut08.Supported},
+		Nut09: &Supported{Supported: nuts.Nut09.Supported},
+		Nut10: &Nut10Setting{Supported: nuts.Nut10.Supported, Kinds: nuts.Nut10.Kinds},
+		Nut11: &Supported{Supported: nuts.Nut11.Supported},
+		Nut12: &Supported{Supported: nuts.Nut12.Supported},
+		Nut14: &Supported{Supported: nuts.Nut14.Supported},
+		Nut15: nut15,
+		Nut17: &Nut17Setting{Supported: nut17Methods},
+		Nut19: &Nut19Setting{Ttl: int64(nuts.Nut19.TTL), CachedEndpoints: cachedEndpoints},
+		Nut20: &Supported{Supported: nuts.Nut20.Supported},
+	}
+}
+
+func nutsFromProto(nuts *Nuts) nut06.Nuts {
+	var nut15 *nut06.NutSetting
+	if nuts.GetNut15() != nil {
+		setting := nutSettingFromProto(nuts.Nut15)
+		nut15 = &setting
+	}
+
+	var nut17Methods []nut17.SupportedMethod
+	for _, method := range nuts.GetNut17().GetSupported() {
+		nut17Methods = append(nut17Methods, nut17.SupportedMethod{
+			Method:   method.GetMethod(),
+			Unit:     method.GetUnit(),
+			Commands: method.GetCommands(),
+		})
+	}
+
+	var cachedEndpoints []nut06.CachedEndpoint
+	for _, endpoint := range nuts.GetNut19().GetCachedEndpoints() {
+		cachedEndpoints = append(cachedEndpoints, nut06.CachedEndpoint{Method: endpoint.GetMethod(), Path: endpoint.GetPath()})
+	}
+
+	return nut06.Nuts{
+		Nut04: nutSettingFromProto(nuts.GetNut04()),
+		Nut05: nutSettingFromProto(nuts.GetNut05()),
+		Nut07: nut06.Supported{Supported: nuts.GetNut07().GetSupported()},
+		Nut08: nut06.Supported{Supported: nuts.GetNut08().GetSupported()},
+		Nut09: nut06.Supported{Supported: nuts.GetNut09().GetSupported()},
+		Nut10: nut06.Nut10Setting{Supported: nuts.GetNut10().GetSupported(), Kinds: nuts.GetNut10().GetKinds()},
+		Nut11: nut06.Supported{Supported: nuts.GetNut11().GetSupported()},
+		Nut12: nut06.Supported{Supported: nuts.GetNut12().GetSupported()},
+		Nut14: nut06.Supported{Supported: nuts.GetNut14().GetSupported()},
+		Nut15: nut15,
+		Nut17: nut17.InfoSetting{Supported: nut17Methods},
+		Nut19: nut06.Nut19Setting{TTL: int(nuts.GetNut19().GetTtl()), CachedEndpoints: cachedEndpoints},
+		Nut20: nut06.Supported{Supported: nuts.GetNut20().GetSupported()},
+	}
+}
+
+func nutSettingToProto(setting nut06.NutSetting) *NutSetting {
+	methods := make([]*MethodSetting, len(setting.Methods))
+	for i, method := range setting.Methods {
+		var options *MethodOptions
+		if method.Options != nil {
+			options = &MethodOptions{Description: method.Options.Description, Amountless: method.Options.Amountless}
+		}
+		methods[i] = &MethodSetting{
+			Method:    method.Method,
+			Unit:      method.Unit,
+			MinAmount: method.MinAmount,
+			MaxAmount: method.MaxAmount,
+			Options:   options,
+		}
+	}
+	return &NutSetting{Methods: methods, Disabled: setting.Disabled}
+}
+
+func nutSettingFromProto(setting *NutSetting) nut06.NutSetting {
+	methods := []nut06.MethodSetting{}
+	for _, method := range setting.GetMethods() {
+		var options *nut06.MethodOptions
+		if method.GetOptions() != nil {
+			options = &nut06.MethodOptions{
+				Description: method.Options.GetDescription(),
+				Amountless:  method.Options.GetAmountless(),
+			}
+		}
+		methods = append(methods, nut06.MethodSetting{
+			Method:    method.GetMethod(),
+			Unit:      method.GetUnit(),
+			MinAmount: method.GetMinAmount(),
+			MaxAmount: method.GetMaxAmount(),
+			Options:   options,
+		})
+	}
+	return nut06.NutSetting{Methods: methods, Disabled: setting.GetDisabled()}
+}
+
+func KeysResponseToProto(response nut01.GetKeysResponse) *KeysResponse {
+	keysets := make([]*Keyset, len(response.Keysets))
+	for i, keyset := range response.Keysets {
+		keys := make(map[uint64]string, len(keyset.Keys))
+		for amount, key := range keyset.Keys {
+			keys[amount] = hex.EncodeToString(key.SerializeCompressed())
+		}
+		keysets[i] = &Keyset{Id: keyset.Id, Unit: keyset.Unit, Keys: keys}
+	}
+	return &KeysResponse{Keysets: keysets}
+}
+
+func KeysResponseFromProto(response *KeysResponse) (nut01.GetKeysResponse, error) {
+	keysets := make([]nut01.Keyset, len(response.GetKeysets()))
+	for i, keyset := range response.GetKeysets() {
+		keys := make(crypto.PublicKeys, len(keyset.GetKeys()))
+		for amount, key := range keyset.GetKeys() {
+			keyBytes, err := hex.DecodeString(key)
+			if err != nil {
+				return nut01.GetKeysResponse{}, err
+			}
+			publicKey, err := secp256k1.ParsePubKey(keyBytes)
+			if err != nil {
+				return nut01.GetKeysResponse{}, fmt.Errorf("invalid public key: %v", err)
+			}
+			keys[amount] = publicKey
+		}
+		keysets[i] = nut01.Keyset{Id: keyset.GetId(), Unit: keyset.GetUnit(), Keys: keys}
+	}
+	return nut01.GetKeysResponse{Keysets: keysets}, nil
+}
+
+func KeysetsResponseToProto(response nut02.GetKeysetsResponse) *KeysetsResponse {
+	keysets := make([]*KeysetInfo, len(response.Keysets))
+	for i, keyset := range response.Keysets {
+		keysets[i] = &KeysetInfo{
+			Id:          keyset.Id,
+			Unit:        keyset.Unit,
+			Active:      keyset.Active,
+			InputFeePpk: uint64(keyset.InputFeePpk),
+		}
+	}
+	return &KeysetsResponse{Keysets: keysets}
+}
+
+func KeysetsResponseFromProto(response *KeysetsResponse) nut02.GetKeysetsResponse {
+	keysets := make([]nut02.Keyset, len(response.GetKeysets()))
+	for i, keyset := range response.GetKeysets() {
+		keysets[i] = nut02.Keyset{
+			Id:          keyset.GetId(),
+			Unit:        keyset.GetUnit(),
+			Active:      keyset.GetActive(),
+			InputFeePpk: uint(keyset.GetInputFeePpk()),
+		}
+	}
+	return nut02.GetKeysetsResponse{Keysets: keysets}
+}
+
+func MintQuoteRequestToProto(request nut04.PostMintQuoteBolt11Request) *MintQuoteRequest {
+	return &MintQuoteRequest{
+		Amount:      request.Amount,
+		Unit:        request.Unit,
+		Description: request.Description,
+		Pubkey:      request.Pubkey,
+	}
+}
+
+func MintQuoteRequestFromProto(request *MintQuoteRequest) nut04.PostMintQuoteBolt11Request {
+	return nut04.PostMintQuoteBolt11Request{
+		Amount:      request.GetAmount(),
+		Unit:        request.GetUnit(),
+		Description: request.GetDescription(),
+		Pubkey:      request.GetPubkey(),
+	}
+}
+
+func MintQuoteResponseToProto(response nut04.PostMintQuoteBolt11Response) *MintQuoteResponse {
+	return &MintQuoteResponse{
+		Quote:   response.Quote,
+		Request: response.Request,
+		Amount:  response.Amount,
+		Unit:    response.Unit,
+		State:   MintQuoteState(response.State),
+		Expiry:  response.Expiry,
+		Pubkey:  response.Pubkey,
+	}
+}
+
+func MintQuoteResponseFromProto(response *MintQuoteResponse) nut04.PostMintQuoteBolt11Response {
+	return nut04.PostMintQuoteBolt11Response{
+		Quote:   response.GetQuote(),
+		Request: response.GetRequest(),
+		Amount:  response.GetAmount(),
+		Unit:    response.GetUnit(),
+		State:   nut04.State(response.GetState()),
+		Expiry:  response.GetExpiry(),
+		Pubkey:  response.GetPubkey(),
+	}
+}
+
+func MintRequestToProto(request nut04.PostMintBolt11Request) *MintRequest {
+	return &MintRequest{
+		Quote:     request.Quote,
+		Outputs:   blindedMessagesToProto(request.Outputs),
+		Signature: request.Signature,
+	}
+}
+
+func MintRequestFromProto(request *MintRequest) nut04.PostMintBolt11Request {
+	return nut04.PostMintBolt11Request{
+		Quote:     request.GetQuote(),
+		Outputs:   blindedMessagesFromProto(request.GetOutputs()),
+		Signature: request.GetSignature(),
+	}
+}
+
+func MintResponseToProto(response nut04.PostMintBolt11Response) *MintResponse {
+	return &MintResponse{Signatures: blindedSignaturesToProto(response.Signatures)}
+}
+
+func MintResponseFromProto(response *MintResponse) nut04.PostMintBolt11Response {
+	return nut04.PostMintBolt11Response{Signatures: blindedSignaturesFromProto(response.GetSignatures())}
+}
+
+func MeltQuoteRequestToProto(request nut05.PostMeltQuoteBolt11Request) *MeltQuoteRequest {
+	var options *MeltOptions
+	if request.Options != nil {
+		options = &MeltOptions{}
+		if request.Options.Mpp != nil {
+			options.Mpp = &MppOption{AmountMsat: request.Options.Mpp.AmountMsat}
+		}
+		if request.Options.Amountless != nil {
+			options.Amountless = &AmountlessOption{AmountMsat: request.Options.Amountless.AmountMsat}
+		}
+	}
+	return &MeltQuoteRequest{Request: request.Request, Unit: request.Unit, Options: options}
+}
+
+func MeltQuoteRequestFromProto(request *MeltQuoteRequest) nut05.PostMeltQuoteBolt11Request {
+	var options *nut05.MeltOptions
+	if request.GetOptions() != nil {
+		options = &nut05.MeltOptions{}
+		if request.Options.GetMpp() != nil {
+			options.Mpp = &nut05.MppOption{AmountMsat: request.Options.Mpp.GetAmountMsat()}
+		}
+		if request.Options.GetAmountless() != nil {
+			options.Amountless = &nut05.AmountlessOption{AmountMsat: request.Options.Amountless.GetAmountMsat()}
+		}
+	}
+	return nut05.PostMeltQuoteBolt11Request{Request: request.GetRequest(), Unit: request.GetUnit(), Options: options}
+}
+
+func MeltQuoteResponseToProto(response nut05.PostMeltQuoteBolt11Response) *MeltQuoteResponse {
+	return &MeltQuoteResponse{
+		Quote:           response.Quote,
+		Request:         response.Request,
+		Amount:          response.Amount,
+		Unit:            response.Unit,
+		FeeReserve:      response.FeeReserve,
+		State:           MeltQuoteState(response.State),
+		Expiry:          response.Expiry,
+		PaymentPreimage: response.Preimage,
+		Change:          blindedSignaturesToProto(response.Change),
+	}
+}
+
+func MeltQuoteResponseFromProto(response *MeltQuoteResponse) nut05.PostMeltQuoteBolt11Response {
+	return nut05.PostMeltQuoteBolt11Response{
+		Quote:      response.GetQuote(),
+		Request:    response.GetRequest(),
+		Amount:     response.GetAmount(),
+		Unit:       response.GetUnit(),
+		FeeReserve: response.GetFeeReserve(),
+		State:      nut05.State(response.GetState()),
+		Expiry:     response.GetExpiry(),
+		Preimage:   response.GetPaymentPreimage(),
+		Change:     blindedSignaturesFromProto(response.GetChange()),
+	}
+}
+
+func MeltRequestToProto(request nut05.PostMeltBolt11Request) *MeltRequest {
+	return &MeltRequest{
+		Quote:   request.Quote,
+		Inputs:  proofsToProto(request.Inputs),
+		Outputs: blindedMessagesToProto(request.Outputs),
+	}
+}
+
+func MeltRequestFromProto(request *MeltRequest) nut05.PostMeltBolt11Request {
+	return nut05.PostMeltBolt11Request{
+		Quote:   request.GetQuote(),
+		Inputs:  proofsFromProto(request.GetInputs()),
+		Outputs: blindedMessagesFromProto(request.GetOutputs()),
+	}
+}
+
+func SwapRequestToProto(request nut03.PostSwapRequest) *SwapRequest {
+	return &SwapRequest{
+		Inputs:  proofsToProto(request.Inputs),
+		Outputs: blindedMessagesToProto(request.Outputs),
+	}
+}
+
+func SwapRequestFromProto(request *SwapRequest) nut03.PostSwapRequest {
+	return nut03.PostSwapRequest{
+		Inputs:  proofsFromProto(request.GetInputs()),
+		Outputs: blindedMessagesFromProto(request.GetOutputs()),
+	}
+}
+
+func SwapResponseToProto(response nut03.PostSwapResponse) *SwapResponse {
+	return &SwapResponse{Signatures: blindedSignaturesToProto(response.Signatures)}
+}
+
+func SwapResponseFromProto(response *SwapResponse) nut03.PostSwapResponse {
+	return nut03.PostSwapResponse{Signatures: blindedSignaturesFromProto(response.GetSignatures())}
+}
+
+func CheckStateRequestToProto(request nut07.PostCheckStateRequest) *CheckStateRequest {
+	return &CheckStateRequest{Ys: request.Ys}
+}
+
+func CheckStateRequestFromProto(request *CheckStateRequest) nut07.PostCheckStateRequest {
+	return nut07.PostCheckStateRequest{Ys: request.GetYs()}
+}
+
+func CheckStateResponseToProto(response nut07.PostCheckStateResponse) *CheckStateResponse {
+	states := make([]*ProofState, len(response.States))
+	for i, state := range response.States {
+		states[i] = &ProofState{Y: state.Y, State: ProofSpendState(state.State), Witness: state.Witness}
+	}
+	return &CheckStateResponse{States: states}
+}
+
+func CheckStateResponseFromProto(response *CheckStateResponse) nut07.PostCheckStateResponse {
+	states := make([]nut07.ProofState, len(response.GetStates()))
+	for i, state := range response.GetStates() {
+		states[i] = nut07.ProofState{Y: state.GetY(), State: nut07.State(state.GetState()), Witness: state.GetWitness()}
+	}
+	return nut07.PostCheckStateResponse{States: states}
+}
+
+func RestoreRequestToProto(request nut09.PostRestoreRequest) *RestoreRequest {
+	return &RestoreRequest{Outputs: blindedMessagesToProto(request.Outputs)}
+}
+
+func RestoreRequestFromProto(request *RestoreRequest) nut09.PostRestoreRequest {
+	return nut09.PostRestoreRequest{Outputs: blindedMessagesFromProto(request.GetOutputs())}
+}
+
+func RestoreResponseToProto(response nut09.PostRestoreResponse) *RestoreResponse {
+	return &RestoreResponse{
+		Outputs:    blindedMessagesToProto(response.Outputs),
+		Signatures: blindedSignaturesToProto(response.Signatures),
+	}
+}
+
+func RestoreResponseFromProto(response *RestoreResponse) nut09.PostRestoreResponse {
+	return nut09.PostRestoreResponse{
+		Outputs:    blindedMessagesFromProto(response.GetOutputs()),
+		Signatures: blindedSignaturesFromProto(response.GetSignatures()),
+	}
+}
+
+func proofsToProto(proofs cashu.Proofs) []*Proof {
+	protoProofs := make([]*Proof, len(proofs))
+	for i, proof := range proofs {
+		protoProofs[i] = &Proof{
+			Amount:  proof.Amount,
+			Id:      proof.Id,
+			Secret:  proof.Secret,
+			C:       proof.C,
+			Witness: proof.Witness,
+			Dleq:    dleqToProto(proof.DLEQ),
+		}
+	}
+	return protoProofs
+}
+
+func proofsFromProto(protoProofs []*Proof) cashu.Proofs {
+	proofs := make(cashu.Proofs, len(protoProofs))
+	for i, proof := range protoProofs {
+		proofs[i] = cashu.Proof{
+			Amount:  proof.GetAmount(),
+			Id:      proof.GetId(),
+			Secret:  proof.GetSecret(),
+			C:       proof.GetC(),
+			Witness: proof.GetWitness(),
+			DLEQ:    dleqFromProto(proof.GetDleq()),
+		}
+	}
+	return proofs
+}
+
+func blindedMessagesToProto(messages cashu.BlindedMessages) []*BlindedMessage {
+	protoMessages := make([]*BlindedMessage, len(messages))
+	for i, message := range messages {
+		protoMessages[i] = &BlindedMessage{
+			Amount:  message.Amount,
+			B_:      message.B_,
+			Id:      message.Id,
+			Witness: message.Witness,
+		}
+	}
+	return protoMessages
+}
+
+func blindedMessagesFromProto(protoMessages []*BlindedMessage) cashu.BlindedMessages {
+	messages := make(cashu.BlindedMessages, len(protoMessages))
+	for i, message := range protoMessages {
+		messages[i] = cashu.BlindedMessage{
+			Amount:  message.GetAmount(),
+			B_:      message.GetB_(),
+			Id:      message.GetId(),
+			Witness: message.GetWitness(),
+		}
+	}
+	return messages
+}
+
+func blindedSignaturesToProto(signatures cashu.BlindedSignatures) []*BlindedSignature {
+	protoSignatures := make([]*BlindedSignature, len(signatures))
+	for i, signature := range signatures {
+		protoSignatures[i] = &BlindedSignature{
+			Amount: signature.Amount,
+			C_:     signature.C_,
+			Id:     signature.Id,
+			Dleq:   dleqToProto(signature.DLEQ),
+		}
+	}
+	return protoSignatures
+}
+
+func blindedSignaturesFromProto(protoSignatures []*BlindedSignature) cashu.BlindedSignatures {
+	signatures := make(cashu.BlindedSignatures, len(protoSignatures))
+	for i, signature := range protoSignatures {
+		signatures[i] = cashu.BlindedSignature{
+			Amount: signature.GetAmount(),
+			C_:     signature.GetC_(),
+			Id:     signature.GetId(),
+			DLEQ:   dleqFromProto(signature.GetDleq()),
+		}
+	}
+	return signatures
+}
+
+func dleqToProto(dleq *cashu.DLEQProof) *DLEQProof {
+	if dleq == nil {
+		return nil
+	}
+	return &DLEQProof{E: dleq.E, S: dleq.S, R: dleq.R}
+}
+
+func dleqFromProto(dleq *DLEQProof) *cashu.DLEQProof {
+	if dleq == nil {
+		return nil
+	}
+	return &cashu.DLEQProof{E: dleq.GetE(), S: dleq.GetS(), R: dleq.GetR()}
+}
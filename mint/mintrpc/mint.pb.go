@@ -0,0 +1,3988 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.30.0
+// 	protoc        (unknown)
+// source: mint.proto
+
+package mintrpc
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// values are the same as the ones of nut04.State
+type MintQuoteState int32
+
+const (
+	MintQuoteState_MINT_QUOTE_STATE_UNPAID  MintQuoteState = 0
+	MintQuoteState_MINT_QUOTE_STATE_PAID    MintQuoteState = 1
+	MintQuoteState_MINT_QUOTE_STATE_ISSUED  MintQuoteState = 2
+	MintQuoteState_MINT_QUOTE_STATE_PENDING MintQuoteState = 3
+	MintQuoteState_MINT_QUOTE_STATE_UNKNOWN MintQuoteState = 4
+)
+
+// Enum value maps for MintQuoteState.
+var (
+	MintQuoteState_name = map[int32]string{
+		0: "MINT_QUOTE_STATE_UNPAID",
+		1: "MINT_QUOTE_STATE_PAID",
+		2: "MINT_QUOTE_STATE_ISSUED",
+		3: "MINT_QUOTE_STATE_PENDING",
+		4: "MINT_QUOTE_STATE_UNKNOWN",
+	}
+	MintQuoteState_value = map[string]int32{
+		"MINT_QUOTE_STATE_UNPAID":  0,
+		"MINT_QUOTE_STATE_PAID":    1,
+		"MINT_QUOTE_STATE_ISSUED":  2,
+		"MINT_QUOTE_STATE_PENDING": 3,
+		"MINT_QUOTE_STATE_UNKNOWN": 4,
+	}
+)
+
+func (x MintQuoteState) Enum() *MintQuoteState {
+	p := new(MintQuoteState)
+	*p = x
+	return p
+}
+
+func (x MintQuoteState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MintQuoteState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mint_proto_enumTypes[0].Descriptor()
+}
+
+func (MintQuoteState) Type() protoreflect.EnumType {
+	return &file_mint_proto_enumTypes[0]
+}
+
+func (x MintQuoteState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MintQuoteState.Descriptor instead.
+func (MintQuoteState) EnumDescriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{0}
+}
+
+// values are the same as the ones of nut05.State
+type MeltQuoteState int32
+
+const (
+	MeltQuoteState_MELT_QUOTE_STATE_UNPAID  MeltQuoteState = 0
+	MeltQuoteState_MELT_QUOTE_STATE_PENDING MeltQuoteState = 1
+	MeltQuoteState_MELT_QUOTE_STATE_PAID    MeltQuoteState = 2
+	MeltQuoteState_MELT_QUOTE_STATE_UNKNOWN MeltQuoteState = 3
+)
+
+// Enum value maps for MeltQuoteState.
+var (
+	MeltQuoteState_name = map[int32]string{
+		0: "MELT_QUOTE_STATE_UNPAID",
+		1: "MELT_QUOTE_STATE_PENDING",
+		2: "MELT_QUOTE_STATE_PAID",
+		3: "MELT_QUOTE_STATE_UNKNOWN",
+	}
+	MeltQuoteState_value = map[string]int32{
+		"MELT_QUOTE_STATE_UNPAID":  0,
+		"MELT_QUOTE_STATE_PENDING": 1,
+		"MELT_QUOTE_STATE_PAID":    2,
+		"MELT_QUOTE_STATE_UNKNOWN": 3,
+	}
+)
+
+func (x MeltQuoteState) Enum() *MeltQuoteState {
+	p := new(MeltQuoteState)
+	*p = x
+	return p
+}
+
+func (x MeltQuoteState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MeltQuoteState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mint_proto_enumTypes[1].Descriptor()
+}
+
+func (MeltQuoteState) Type() protoreflect.EnumType {
+	return &file_mint_proto_enumTypes[1]
+}
+
+func (x MeltQuoteState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MeltQuoteState.Descriptor instead.
+func (MeltQuoteState) EnumDescriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{1}
+}
+
+// values are the same as the ones of nut07.State
+type ProofSpendState int32
+
+const (
+	ProofSpendState_PROOF_SPEND_STATE_UNSPENT ProofSpendState = 0
+	ProofSpendState_PROOF_SPEND_STATE_PENDING ProofSpendState = 1
+	ProofSpendState_PROOF_SPEND_STATE_SPENT   ProofSpendState = 2
+	ProofSpendState_PROOF_SPEND_STATE_UNKNOWN ProofSpendState = 3
+)
+
+// Enum value maps for ProofSpendState.
+var (
+	ProofSpendState_name = map[int32]string{
+		0: "PROOF_SPEND_STATE_UNSPENT",
+		1: "PROOF_SPEND_STATE_PENDING",
+		2: "PROOF_SPEND_STATE_SPENT",
+		3: "PROOF_SPEND_STATE_UNKNOWN",
+	}
+	ProofSpendState_value = map[string]int32{
+		"PROOF_SPEND_STATE_UNSPENT": 0,
+		"PROOF_SPEND_STATE_PENDING": 1,
+		"PROOF_SPEND_STATE_SPENT":   2,
+		"PROOF_SPEND_STATE_UNKNOWN": 3,
+	}
+)
+
+func (x ProofSpendState) Enum() *ProofSpendState {
+	p := new(ProofSpendState)
+	*p = x
+	return p
+}
+
+func (x ProofSpendState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ProofSpendState) Descriptor() protoreflect.EnumDescriptor {
+	return file_mint_proto_enumTypes[2].Descriptor()
+}
+
+func (ProofSpendState) Type() protoreflect.EnumType {
+	return &file_mint_proto_enumTypes[2]
+}
+
+func (x ProofSpendState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ProofSpendState.Descriptor instead.
+func (ProofSpendState) EnumDescriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{2}
+}
+
+type Empty struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+}
+
+func (x *Empty) Reset() {
+	*x = Empty{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[0]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Empty) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Empty) ProtoMessage() {}
+
+func (x *Empty) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[0]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Empty.ProtoReflect.Descriptor instead.
+func (*Empty) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{0}
+}
+
+type KeysetRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+}
+
+func (x *KeysetRequest) Reset() {
+	*x = KeysetRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[1]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysetRequest) ProtoMessage() {}
+
+func (x *KeysetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[1]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysetRequest.ProtoReflect.Descriptor instead.
+func (*KeysetRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *KeysetRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type QuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quote string `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+}
+
+func (x *QuoteRequest) Reset() {
+	*x = QuoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[2]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *QuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QuoteRequest) ProtoMessage() {}
+
+func (x *QuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[2]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QuoteRequest.ProtoReflect.Descriptor instead.
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *QuoteRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+type MintInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Name            string         `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Pubkey          string         `protobuf:"bytes,2,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+	Version         string         `protobuf:"bytes,3,opt,name=version,proto3" json:"version,omitempty"`
+	Description     string         `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	DescriptionLong string         `protobuf:"bytes,5,opt,name=description_long,json=descriptionLong,proto3" json:"description_long,omitempty"`
+	Contact         []*ContactInfo `protobuf:"bytes,6,rep,name=contact,proto3" json:"contact,omitempty"`
+	Motd            string         `protobuf:"bytes,7,opt,name=motd,proto3" json:"motd,omitempty"`
+	IconUrl         string         `protobuf:"bytes,8,opt,name=icon_url,json=iconUrl,proto3" json:"icon_url,omitempty"`
+	Urls            []string       `protobuf:"bytes,9,rep,name=urls,proto3" json:"urls,omitempty"`
+	Time            int64          `protobuf:"varint,10,opt,name=time,proto3" json:"time,omitempty"`
+	Nuts            *Nuts          `protobuf:"bytes,11,opt,name=nuts,proto3" json:"nuts,omitempty"`
+	Limits          *Limits        `protobuf:"bytes,12,opt,name=limits,proto3" json:"limits,omitempty"`
+}
+
+func (x *MintInfo) Reset() {
+	*x = MintInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[3]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintInfo) ProtoMessage() {}
+
+func (x *MintInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[3]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintInfo.ProtoReflect.Descriptor instead.
+func (*MintInfo) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MintInfo) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *MintInfo) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+func (x *MintInfo) GetVersion() string {
+	if x != nil {
+		return x.Version
+	}
+	return ""
+}
+
+func (x *MintInfo) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MintInfo) GetDescriptionLong() string {
+	if x != nil {
+		return x.DescriptionLong
+	}
+	return ""
+}
+
+func (x *MintInfo) GetContact() []*ContactInfo {
+	if x != nil {
+		return x.Contact
+	}
+	return nil
+}
+
+func (x *MintInfo) GetMotd() string {
+	if x != nil {
+		return x.Motd
+	}
+	return ""
+}
+
+func (x *MintInfo) GetIconUrl() string {
+	if x != nil {
+		return x.IconUrl
+	}
+	return ""
+}
+
+func (x *MintInfo) GetUrls() []string {
+	if x != nil {
+		return x.Urls
+	}
+	return nil
+}
+
+func (x *MintInfo) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+func (x *MintInfo) GetNuts() *Nuts {
+	if x != nil {
+		return x.Nuts
+	}
+	return nil
+}
+
+func (x *MintInfo) GetLimits() *Limits {
+	if x != nil {
+		return x.Limits
+	}
+	return nil
+}
+
+type ContactInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Info   string `protobuf:"bytes,2,opt,name=info,proto3" json:"info,omitempty"`
+}
+
+func (x *ContactInfo) Reset() {
+	*x = ContactInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[4]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ContactInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ContactInfo) ProtoMessage() {}
+
+func (x *ContactInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[4]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ContactInfo.ProtoReflect.Descriptor instead.
+func (*ContactInfo) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ContactInfo) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *ContactInfo) GetInfo() string {
+	if x != nil {
+		return x.Info
+	}
+	return ""
+}
+
+type Limits struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	MaxInputs       int64 `protobuf:"varint,1,opt,name=max_inputs,json=maxInputs,proto3" json:"max_inputs,omitempty"`
+	MaxOutputs      int64 `protobuf:"varint,2,opt,name=max_outputs,json=maxOutputs,proto3" json:"max_outputs,omitempty"`
+	MaxRequestBytes int64 `protobuf:"varint,3,opt,name=max_request_bytes,json=maxRequestBytes,proto3" json:"max_request_bytes,omitempty"`
+}
+
+func (x *Limits) Reset() {
+	*x = Limits{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[5]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Limits) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Limits) ProtoMessage() {}
+
+func (x *Limits) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[5]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Limits.ProtoReflect.Descriptor instead.
+func (*Limits) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *Limits) GetMaxInputs() int64 {
+	if x != nil {
+		return x.MaxInputs
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxOutputs() int64 {
+	if x != nil {
+		return x.MaxOutputs
+	}
+	return 0
+}
+
+func (x *Limits) GetMaxRequestBytes() int64 {
+	if x != nil {
+		return x.MaxRequestBytes
+	}
+	return 0
+}
+
+// Nuts has the settings of the supported NUTs.
+// Field numbers are the numbers of the NUTs.
+type Nuts struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Nut04 *NutSetting   `protobuf:"bytes,4,opt,name=nut04,proto3" json:"nut04,omitempty"`
+	Nut05 *NutSetting   `protobuf:"bytes,5,opt,name=nut05,proto3" json:"nut05,omitempty"`
+	Nut07 *Supported    `protobuf:"bytes,7,opt,name=nut07,proto3" json:"nut07,omitempty"`
+	Nut08 *Supported    `protobuf:"bytes,8,opt,name=nut08,proto3" json:"nut08,omitempty"`
+	Nut09 *Supported    `protobuf:"bytes,9,opt,name=nut09,proto3" json:"nut09,omitempty"`
+	Nut10 *Nut10Setting `protobuf:"bytes,10,opt,name=nut10,proto3" json:"nut10,omitempty"`
+	Nut11 *Supported    `protobuf:"bytes,11,opt,name=nut11,proto3" json:"nut11,omitempty"`
+	Nut12 *Supported    `protobuf:"bytes,12,opt,name=nut12,proto3" json:"nut12,omitempty"`
+	Nut14 *Supported    `protobuf:"bytes,14,opt,name=nut14,proto3" json:"nut14,omitempty"`
+	Nut15 *NutSetting   `protobuf:"bytes,15,opt,name=nut15,proto3" json:"nut15,omitempty"`
+	Nut17 *Nut17Setting `protobuf:"bytes,17,opt,name=nut17,proto3" json:"nut17,omitempty"`
+	Nut19 *Nut19Setting `protobuf:"bytes,19,opt,name=nut19,proto3" json:"nut19,omitempty"`
+	Nut20 *Supported    `protobuf:"bytes,20,opt,name=nut20,proto3" json:"nut20,omitempty"`
+}
+
+func (x *Nuts) Reset() {
+	*x = Nuts{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[6]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nuts) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nuts) ProtoMessage() {}
+
+func (x *Nuts) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[6]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nuts.ProtoReflect.Descriptor instead.
+func (*Nuts) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *Nuts) GetNut04() *NutSetting {
+	if x != nil {
+		return x.Nut04
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut05() *NutSetting {
+	if x != nil {
+		return x.Nut05
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut07() *Supported {
+	if x != nil {
+		return x.Nut07
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut08() *Supported {
+	if x != nil {
+		return x.Nut08
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut09() *Supported {
+	if x != nil {
+		return x.Nut09
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut10() *Nut10Setting {
+	if x != nil {
+		return x.Nut10
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut11() *Supported {
+	if x != nil {
+		return x.Nut11
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut12() *Supported {
+	if x != nil {
+		return x.Nut12
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut14() *Supported {
+	if x != nil {
+		return x.Nut14
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut15() *NutSetting {
+	if x != nil {
+		return x.Nut15
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut17() *Nut17Setting {
+	if x != nil {
+		return x.Nut17
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut19() *Nut19Setting {
+	if x != nil {
+		return x.Nut19
+	}
+	return nil
+}
+
+func (x *Nuts) GetNut20() *Supported {
+	if x != nil {
+		return x.Nut20
+	}
+	return nil
+}
+
+type NutSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Methods  []*MethodSetting `protobuf:"bytes,1,rep,name=methods,proto3" json:"methods,omitempty"`
+	Disabled bool             `protobuf:"varint,2,opt,name=disabled,proto3" json:"disabled,omitempty"`
+}
+
+func (x *NutSetting) Reset() {
+	*x = NutSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[7]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *NutSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NutSetting) ProtoMessage() {}
+
+func (x *NutSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[7]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NutSetting.ProtoReflect.Descriptor instead.
+func (*NutSetting) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *NutSetting) GetMethods() []*MethodSetting {
+	if x != nil {
+		return x.Methods
+	}
+	return nil
+}
+
+func (x *NutSetting) GetDisabled() bool {
+	if x != nil {
+		return x.Disabled
+	}
+	return false
+}
+
+type MethodSetting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method    string         `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Unit      string         `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	MinAmount uint64         `protobuf:"varint,3,opt,name=min_amount,json=minAmount,proto3" json:"min_amount,omitempty"`
+	MaxAmount uint64         `protobuf:"varint,4,opt,name=max_amount,json=maxAmount,proto3" json:"max_amount,omitempty"`
+	Options   *MethodOptions `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *MethodSetting) Reset() {
+	*x = MethodSetting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[8]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MethodSetting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodSetting) ProtoMessage() {}
+
+func (x *MethodSetting) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[8]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodSetting.ProtoReflect.Descriptor instead.
+func (*MethodSetting) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *MethodSetting) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *MethodSetting) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MethodSetting) GetMinAmount() uint64 {
+	if x != nil {
+		return x.MinAmount
+	}
+	return 0
+}
+
+func (x *MethodSetting) GetMaxAmount() uint64 {
+	if x != nil {
+		return x.MaxAmount
+	}
+	return 0
+}
+
+func (x *MethodSetting) GetOptions() *MethodOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type MethodOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Description bool `protobuf:"varint,1,opt,name=description,proto3" json:"description,omitempty"`
+	Amountless  bool `protobuf:"varint,2,opt,name=amountless,proto3" json:"amountless,omitempty"`
+}
+
+func (x *MethodOptions) Reset() {
+	*x = MethodOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[9]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MethodOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MethodOptions) ProtoMessage() {}
+
+func (x *MethodOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[9]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MethodOptions.ProtoReflect.Descriptor instead.
+func (*MethodOptions) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *MethodOptions) GetDescription() bool {
+	if x != nil {
+		return x.Description
+	}
+	return false
+}
+
+func (x *MethodOptions) GetAmountless() bool {
+	if x != nil {
+		return x.Amountless
+	}
+	return false
+}
+
+type Supported struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Supported bool `protobuf:"varint,1,opt,name=supported,proto3" json:"supported,omitempty"`
+}
+
+func (x *Supported) Reset() {
+	*x = Supported{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[10]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Supported) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Supported) ProtoMessage() {}
+
+func (x *Supported) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[10]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Supported.ProtoReflect.Descriptor instead.
+func (*Supported) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *Supported) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+type Nut10Setting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Supported bool     `protobuf:"varint,1,opt,name=supported,proto3" json:"supported,omitempty"`
+	Kinds     []string `protobuf:"bytes,2,rep,name=kinds,proto3" json:"kinds,omitempty"`
+}
+
+func (x *Nut10Setting) Reset() {
+	*x = Nut10Setting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[11]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nut10Setting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nut10Setting) ProtoMessage() {}
+
+func (x *Nut10Setting) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[11]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nut10Setting.ProtoReflect.Descriptor instead.
+func (*Nut10Setting) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *Nut10Setting) GetSupported() bool {
+	if x != nil {
+		return x.Supported
+	}
+	return false
+}
+
+func (x *Nut10Setting) GetKinds() []string {
+	if x != nil {
+		return x.Kinds
+	}
+	return nil
+}
+
+type Nut17Setting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Supported []*SubscriptionMethod `protobuf:"bytes,1,rep,name=supported,proto3" json:"supported,omitempty"`
+}
+
+func (x *Nut17Setting) Reset() {
+	*x = Nut17Setting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[12]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nut17Setting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nut17Setting) ProtoMessage() {}
+
+func (x *Nut17Setting) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[12]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nut17Setting.ProtoReflect.Descriptor instead.
+func (*Nut17Setting) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *Nut17Setting) GetSupported() []*SubscriptionMethod {
+	if x != nil {
+		return x.Supported
+	}
+	return nil
+}
+
+type SubscriptionMethod struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method   string   `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Unit     string   `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Commands []string `protobuf:"bytes,3,rep,name=commands,proto3" json:"commands,omitempty"`
+}
+
+func (x *SubscriptionMethod) Reset() {
+	*x = SubscriptionMethod{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[13]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscriptionMethod) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscriptionMethod) ProtoMessage() {}
+
+func (x *SubscriptionMethod) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[13]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscriptionMethod.ProtoReflect.Descriptor instead.
+func (*SubscriptionMethod) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *SubscriptionMethod) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *SubscriptionMethod) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *SubscriptionMethod) GetCommands() []string {
+	if x != nil {
+		return x.Commands
+	}
+	return nil
+}
+
+type Nut19Setting struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ttl             int64             `protobuf:"varint,1,opt,name=ttl,proto3" json:"ttl,omitempty"`
+	CachedEndpoints []*CachedEndpoint `protobuf:"bytes,2,rep,name=cached_endpoints,json=cachedEndpoints,proto3" json:"cached_endpoints,omitempty"`
+}
+
+func (x *Nut19Setting) Reset() {
+	*x = Nut19Setting{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[14]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Nut19Setting) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Nut19Setting) ProtoMessage() {}
+
+func (x *Nut19Setting) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[14]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Nut19Setting.ProtoReflect.Descriptor instead.
+func (*Nut19Setting) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *Nut19Setting) GetTtl() int64 {
+	if x != nil {
+		return x.Ttl
+	}
+	return 0
+}
+
+func (x *Nut19Setting) GetCachedEndpoints() []*CachedEndpoint {
+	if x != nil {
+		return x.CachedEndpoints
+	}
+	return nil
+}
+
+type CachedEndpoint struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Method string `protobuf:"bytes,1,opt,name=method,proto3" json:"method,omitempty"`
+	Path   string `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+}
+
+func (x *CachedEndpoint) Reset() {
+	*x = CachedEndpoint{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[15]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CachedEndpoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CachedEndpoint) ProtoMessage() {}
+
+func (x *CachedEndpoint) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[15]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CachedEndpoint.ProtoReflect.Descriptor instead.
+func (*CachedEndpoint) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *CachedEndpoint) GetMethod() string {
+	if x != nil {
+		return x.Method
+	}
+	return ""
+}
+
+func (x *CachedEndpoint) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type Keyset struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id   string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Unit string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	// hex encoded public keys by amount
+	Keys map[uint64]string `protobuf:"bytes,3,rep,name=keys,proto3" json:"keys,omitempty" protobuf_key:"varint,1,opt,name=key,proto3" protobuf_val:"bytes,2,opt,name=value,proto3"`
+}
+
+func (x *Keyset) Reset() {
+	*x = Keyset{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[16]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Keyset) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Keyset) ProtoMessage() {}
+
+func (x *Keyset) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[16]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Keyset.ProtoReflect.Descriptor instead.
+func (*Keyset) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *Keyset) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Keyset) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *Keyset) GetKeys() map[uint64]string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type KeysResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keysets []*Keyset `protobuf:"bytes,1,rep,name=keysets,proto3" json:"keysets,omitempty"`
+}
+
+func (x *KeysResponse) Reset() {
+	*x = KeysResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[17]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysResponse) ProtoMessage() {}
+
+func (x *KeysResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[17]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysResponse.ProtoReflect.Descriptor instead.
+func (*KeysResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *KeysResponse) GetKeysets() []*Keyset {
+	if x != nil {
+		return x.Keysets
+	}
+	return nil
+}
+
+type KeysetInfo struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Id          string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Unit        string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Active      bool   `protobuf:"varint,3,opt,name=active,proto3" json:"active,omitempty"`
+	InputFeePpk uint64 `protobuf:"varint,4,opt,name=input_fee_ppk,json=inputFeePpk,proto3" json:"input_fee_ppk,omitempty"`
+}
+
+func (x *KeysetInfo) Reset() {
+	*x = KeysetInfo{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[18]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysetInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysetInfo) ProtoMessage() {}
+
+func (x *KeysetInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[18]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysetInfo.ProtoReflect.Descriptor instead.
+func (*KeysetInfo) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *KeysetInfo) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *KeysetInfo) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *KeysetInfo) GetActive() bool {
+	if x != nil {
+		return x.Active
+	}
+	return false
+}
+
+func (x *KeysetInfo) GetInputFeePpk() uint64 {
+	if x != nil {
+		return x.InputFeePpk
+	}
+	return 0
+}
+
+type KeysetsResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Keysets []*KeysetInfo `protobuf:"bytes,1,rep,name=keysets,proto3" json:"keysets,omitempty"`
+}
+
+func (x *KeysetsResponse) Reset() {
+	*x = KeysetsResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[19]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *KeysetsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*KeysetsResponse) ProtoMessage() {}
+
+func (x *KeysetsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[19]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use KeysetsResponse.ProtoReflect.Descriptor instead.
+func (*KeysetsResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *KeysetsResponse) GetKeysets() []*KeysetInfo {
+	if x != nil {
+		return x.Keysets
+	}
+	return nil
+}
+
+type BlindedMessage struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount  uint64 `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	B_      string `protobuf:"bytes,2,opt,name=b_,json=b,proto3" json:"b_,omitempty"`
+	Id      string `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Witness string `protobuf:"bytes,4,opt,name=witness,proto3" json:"witness,omitempty"`
+}
+
+func (x *BlindedMessage) Reset() {
+	*x = BlindedMessage{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[20]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlindedMessage) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlindedMessage) ProtoMessage() {}
+
+func (x *BlindedMessage) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[20]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlindedMessage.ProtoReflect.Descriptor instead.
+func (*BlindedMessage) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *BlindedMessage) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BlindedMessage) GetB_() string {
+	if x != nil {
+		return x.B_
+	}
+	return ""
+}
+
+func (x *BlindedMessage) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BlindedMessage) GetWitness() string {
+	if x != nil {
+		return x.Witness
+	}
+	return ""
+}
+
+type BlindedSignature struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount uint64     `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	C_     string     `protobuf:"bytes,2,opt,name=c_,json=c,proto3" json:"c_,omitempty"`
+	Id     string     `protobuf:"bytes,3,opt,name=id,proto3" json:"id,omitempty"`
+	Dleq   *DLEQProof `protobuf:"bytes,4,opt,name=dleq,proto3" json:"dleq,omitempty"`
+}
+
+func (x *BlindedSignature) Reset() {
+	*x = BlindedSignature{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[21]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *BlindedSignature) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BlindedSignature) ProtoMessage() {}
+
+func (x *BlindedSignature) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[21]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BlindedSignature.ProtoReflect.Descriptor instead.
+func (*BlindedSignature) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *BlindedSignature) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *BlindedSignature) GetC_() string {
+	if x != nil {
+		return x.C_
+	}
+	return ""
+}
+
+func (x *BlindedSignature) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BlindedSignature) GetDleq() *DLEQProof {
+	if x != nil {
+		return x.Dleq
+	}
+	return nil
+}
+
+type Proof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount  uint64     `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Id      string     `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Secret  string     `protobuf:"bytes,3,opt,name=secret,proto3" json:"secret,omitempty"`
+	C       string     `protobuf:"bytes,4,opt,name=c,proto3" json:"c,omitempty"`
+	Witness string     `protobuf:"bytes,5,opt,name=witness,proto3" json:"witness,omitempty"`
+	Dleq    *DLEQProof `protobuf:"bytes,6,opt,name=dleq,proto3" json:"dleq,omitempty"`
+}
+
+func (x *Proof) Reset() {
+	*x = Proof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[22]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Proof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Proof) ProtoMessage() {}
+
+func (x *Proof) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[22]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Proof.ProtoReflect.Descriptor instead.
+func (*Proof) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *Proof) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *Proof) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *Proof) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *Proof) GetC() string {
+	if x != nil {
+		return x.C
+	}
+	return ""
+}
+
+func (x *Proof) GetWitness() string {
+	if x != nil {
+		return x.Witness
+	}
+	return ""
+}
+
+func (x *Proof) GetDleq() *DLEQProof {
+	if x != nil {
+		return x.Dleq
+	}
+	return nil
+}
+
+type DLEQProof struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	E string `protobuf:"bytes,1,opt,name=e,proto3" json:"e,omitempty"`
+	S string `protobuf:"bytes,2,opt,name=s,proto3" json:"s,omitempty"`
+	R string `protobuf:"bytes,3,opt,name=r,proto3" json:"r,omitempty"`
+}
+
+func (x *DLEQProof) Reset() {
+	*x = DLEQProof{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[23]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *DLEQProof) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DLEQProof) ProtoMessage() {}
+
+func (x *DLEQProof) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[23]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DLEQProof.ProtoReflect.Descriptor instead.
+func (*DLEQProof) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *DLEQProof) GetE() string {
+	if x != nil {
+		return x.E
+	}
+	return ""
+}
+
+func (x *DLEQProof) GetS() string {
+	if x != nil {
+		return x.S
+	}
+	return ""
+}
+
+func (x *DLEQProof) GetR() string {
+	if x != nil {
+		return x.R
+	}
+	return ""
+}
+
+type SwapRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Inputs  []*Proof          `protobuf:"bytes,1,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs []*BlindedMessage `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+}
+
+func (x *SwapRequest) Reset() {
+	*x = SwapRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[24]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapRequest) ProtoMessage() {}
+
+func (x *SwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[24]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapRequest.ProtoReflect.Descriptor instead.
+func (*SwapRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SwapRequest) GetInputs() []*Proof {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *SwapRequest) GetOutputs() []*BlindedMessage {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type SwapResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signatures []*BlindedSignature `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *SwapResponse) Reset() {
+	*x = SwapResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[25]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SwapResponse) ProtoMessage() {}
+
+func (x *SwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[25]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SwapResponse.ProtoReflect.Descriptor instead.
+func (*SwapResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *SwapResponse) GetSignatures() []*BlindedSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type MintQuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Amount      uint64 `protobuf:"varint,1,opt,name=amount,proto3" json:"amount,omitempty"`
+	Unit        string `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Description string `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Pubkey      string `protobuf:"bytes,4,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+}
+
+func (x *MintQuoteRequest) Reset() {
+	*x = MintQuoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[26]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintQuoteRequest) ProtoMessage() {}
+
+func (x *MintQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[26]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintQuoteRequest.ProtoReflect.Descriptor instead.
+func (*MintQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *MintQuoteRequest) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *MintQuoteRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MintQuoteRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+func (x *MintQuoteRequest) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+type MintQuoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quote   string         `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Request string         `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Amount  uint64         `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Unit    string         `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	State   MintQuoteState `protobuf:"varint,5,opt,name=state,proto3,enum=cashu.v1.MintQuoteState" json:"state,omitempty"`
+	Expiry  uint64         `protobuf:"varint,6,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	Pubkey  string         `protobuf:"bytes,7,opt,name=pubkey,proto3" json:"pubkey,omitempty"`
+}
+
+func (x *MintQuoteResponse) Reset() {
+	*x = MintQuoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[27]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintQuoteResponse) ProtoMessage() {}
+
+func (x *MintQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[27]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintQuoteResponse.ProtoReflect.Descriptor instead.
+func (*MintQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *MintQuoteResponse) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *MintQuoteResponse) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *MintQuoteResponse) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *MintQuoteResponse) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MintQuoteResponse) GetState() MintQuoteState {
+	if x != nil {
+		return x.State
+	}
+	return MintQuoteState_MINT_QUOTE_STATE_UNPAID
+}
+
+func (x *MintQuoteResponse) GetExpiry() uint64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+func (x *MintQuoteResponse) GetPubkey() string {
+	if x != nil {
+		return x.Pubkey
+	}
+	return ""
+}
+
+type MintRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quote     string            `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Outputs   []*BlindedMessage `protobuf:"bytes,2,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Signature string            `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+}
+
+func (x *MintRequest) Reset() {
+	*x = MintRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[28]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintRequest) ProtoMessage() {}
+
+func (x *MintRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[28]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintRequest.ProtoReflect.Descriptor instead.
+func (*MintRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *MintRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *MintRequest) GetOutputs() []*BlindedMessage {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *MintRequest) GetSignature() string {
+	if x != nil {
+		return x.Signature
+	}
+	return ""
+}
+
+type MintResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Signatures []*BlindedSignature `protobuf:"bytes,1,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *MintResponse) Reset() {
+	*x = MintResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[29]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MintResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MintResponse) ProtoMessage() {}
+
+func (x *MintResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[29]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MintResponse.ProtoReflect.Descriptor instead.
+func (*MintResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MintResponse) GetSignatures() []*BlindedSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type MeltQuoteRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Request string       `protobuf:"bytes,1,opt,name=request,proto3" json:"request,omitempty"`
+	Unit    string       `protobuf:"bytes,2,opt,name=unit,proto3" json:"unit,omitempty"`
+	Options *MeltOptions `protobuf:"bytes,3,opt,name=options,proto3" json:"options,omitempty"`
+}
+
+func (x *MeltQuoteRequest) Reset() {
+	*x = MeltQuoteRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[30]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeltQuoteRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeltQuoteRequest) ProtoMessage() {}
+
+func (x *MeltQuoteRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[30]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeltQuoteRequest.ProtoReflect.Descriptor instead.
+func (*MeltQuoteRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *MeltQuoteRequest) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *MeltQuoteRequest) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MeltQuoteRequest) GetOptions() *MeltOptions {
+	if x != nil {
+		return x.Options
+	}
+	return nil
+}
+
+type MeltOptions struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Mpp        *MppOption        `protobuf:"bytes,1,opt,name=mpp,proto3" json:"mpp,omitempty"`
+	Amountless *AmountlessOption `protobuf:"bytes,2,opt,name=amountless,proto3" json:"amountless,omitempty"`
+}
+
+func (x *MeltOptions) Reset() {
+	*x = MeltOptions{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[31]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeltOptions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeltOptions) ProtoMessage() {}
+
+func (x *MeltOptions) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[31]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeltOptions.ProtoReflect.Descriptor instead.
+func (*MeltOptions) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *MeltOptions) GetMpp() *MppOption {
+	if x != nil {
+		return x.Mpp
+	}
+	return nil
+}
+
+func (x *MeltOptions) GetAmountless() *AmountlessOption {
+	if x != nil {
+		return x.Amountless
+	}
+	return nil
+}
+
+type MppOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AmountMsat uint64 `protobuf:"varint,1,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+}
+
+func (x *MppOption) Reset() {
+	*x = MppOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[32]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MppOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MppOption) ProtoMessage() {}
+
+func (x *MppOption) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[32]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MppOption.ProtoReflect.Descriptor instead.
+func (*MppOption) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *MppOption) GetAmountMsat() uint64 {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return 0
+}
+
+type AmountlessOption struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	AmountMsat uint64 `protobuf:"varint,1,opt,name=amount_msat,json=amountMsat,proto3" json:"amount_msat,omitempty"`
+}
+
+func (x *AmountlessOption) Reset() {
+	*x = AmountlessOption{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[33]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *AmountlessOption) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AmountlessOption) ProtoMessage() {}
+
+func (x *AmountlessOption) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[33]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AmountlessOption.ProtoReflect.Descriptor instead.
+func (*AmountlessOption) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *AmountlessOption) GetAmountMsat() uint64 {
+	if x != nil {
+		return x.AmountMsat
+	}
+	return 0
+}
+
+type MeltQuoteResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quote           string              `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Request         string              `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Amount          uint64              `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Unit            string              `protobuf:"bytes,4,opt,name=unit,proto3" json:"unit,omitempty"`
+	FeeReserve      uint64              `protobuf:"varint,5,opt,name=fee_reserve,json=feeReserve,proto3" json:"fee_reserve,omitempty"`
+	State           MeltQuoteState      `protobuf:"varint,6,opt,name=state,proto3,enum=cashu.v1.MeltQuoteState" json:"state,omitempty"`
+	Expiry          uint64              `protobuf:"varint,7,opt,name=expiry,proto3" json:"expiry,omitempty"`
+	PaymentPreimage string              `protobuf:"bytes,8,opt,name=payment_preimage,json=paymentPreimage,proto3" json:"payment_preimage,omitempty"`
+	Change          []*BlindedSignature `protobuf:"bytes,9,rep,name=change,proto3" json:"change,omitempty"`
+}
+
+func (x *MeltQuoteResponse) Reset() {
+	*x = MeltQuoteResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[34]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeltQuoteResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeltQuoteResponse) ProtoMessage() {}
+
+func (x *MeltQuoteResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[34]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeltQuoteResponse.ProtoReflect.Descriptor instead.
+func (*MeltQuoteResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *MeltQuoteResponse) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *MeltQuoteResponse) GetRequest() string {
+	if x != nil {
+		return x.Request
+	}
+	return ""
+}
+
+func (x *MeltQuoteResponse) GetAmount() uint64 {
+	if x != nil {
+		return x.Amount
+	}
+	return 0
+}
+
+func (x *MeltQuoteResponse) GetUnit() string {
+	if x != nil {
+		return x.Unit
+	}
+	return ""
+}
+
+func (x *MeltQuoteResponse) GetFeeReserve() uint64 {
+	if x != nil {
+		return x.FeeReserve
+	}
+	return 0
+}
+
+func (x *MeltQuoteResponse) GetState() MeltQuoteState {
+	if x != nil {
+		return x.State
+	}
+	return MeltQuoteState_MELT_QUOTE_STATE_UNPAID
+}
+
+func (x *MeltQuoteResponse) GetExpiry() uint64 {
+	if x != nil {
+		return x.Expiry
+	}
+	return 0
+}
+
+func (x *MeltQuoteResponse) GetPaymentPreimage() string {
+	if x != nil {
+		return x.PaymentPreimage
+	}
+	return ""
+}
+
+func (x *MeltQuoteResponse) GetChange() []*BlindedSignature {
+	if x != nil {
+		return x.Change
+	}
+	return nil
+}
+
+type MeltRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Quote   string            `protobuf:"bytes,1,opt,name=quote,proto3" json:"quote,omitempty"`
+	Inputs  []*Proof          `protobuf:"bytes,2,rep,name=inputs,proto3" json:"inputs,omitempty"`
+	Outputs []*BlindedMessage `protobuf:"bytes,3,rep,name=outputs,proto3" json:"outputs,omitempty"`
+}
+
+func (x *MeltRequest) Reset() {
+	*x = MeltRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[35]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *MeltRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MeltRequest) ProtoMessage() {}
+
+func (x *MeltRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[35]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MeltRequest.ProtoReflect.Descriptor instead.
+func (*MeltRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *MeltRequest) GetQuote() string {
+	if x != nil {
+		return x.Quote
+	}
+	return ""
+}
+
+func (x *MeltRequest) GetInputs() []*Proof {
+	if x != nil {
+		return x.Inputs
+	}
+	return nil
+}
+
+func (x *MeltRequest) GetOutputs() []*BlindedMessage {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type CheckStateRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Ys []string `protobuf:"bytes,1,rep,name=ys,proto3" json:"ys,omitempty"`
+}
+
+func (x *CheckStateRequest) Reset() {
+	*x = CheckStateRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[36]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckStateRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStateRequest) ProtoMessage() {}
+
+func (x *CheckStateRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[36]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStateRequest.ProtoReflect.Descriptor instead.
+func (*CheckStateRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *CheckStateRequest) GetYs() []string {
+	if x != nil {
+		return x.Ys
+	}
+	return nil
+}
+
+type CheckStateResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	States []*ProofState `protobuf:"bytes,1,rep,name=states,proto3" json:"states,omitempty"`
+}
+
+func (x *CheckStateResponse) Reset() {
+	*x = CheckStateResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[37]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *CheckStateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckStateResponse) ProtoMessage() {}
+
+func (x *CheckStateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[37]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckStateResponse.ProtoReflect.Descriptor instead.
+func (*CheckStateResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *CheckStateResponse) GetStates() []*ProofState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+type ProofState struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Y       string          `protobuf:"bytes,1,opt,name=y,proto3" json:"y,omitempty"`
+	State   ProofSpendState `protobuf:"varint,2,opt,name=state,proto3,enum=cashu.v1.ProofSpendState" json:"state,omitempty"`
+	Witness string          `protobuf:"bytes,3,opt,name=witness,proto3" json:"witness,omitempty"`
+}
+
+func (x *ProofState) Reset() {
+	*x = ProofState{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[38]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *ProofState) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ProofState) ProtoMessage() {}
+
+func (x *ProofState) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[38]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ProofState.ProtoReflect.Descriptor instead.
+func (*ProofState) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ProofState) GetY() string {
+	if x != nil {
+		return x.Y
+	}
+	return ""
+}
+
+func (x *ProofState) GetState() ProofSpendState {
+	if x != nil {
+		return x.State
+	}
+	return ProofSpendState_PROOF_SPEND_STATE_UNSPENT
+}
+
+func (x *ProofState) GetWitness() string {
+	if x != nil {
+		return x.Witness
+	}
+	return ""
+}
+
+type RestoreRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Outputs []*BlindedMessage `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+}
+
+func (x *RestoreRequest) Reset() {
+	*x = RestoreRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[39]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreRequest) ProtoMessage() {}
+
+func (x *RestoreRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[39]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreRequest.ProtoReflect.Descriptor instead.
+func (*RestoreRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *RestoreRequest) GetOutputs() []*BlindedMessage {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+type RestoreResponse struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Outputs    []*BlindedMessage   `protobuf:"bytes,1,rep,name=outputs,proto3" json:"outputs,omitempty"`
+	Signatures []*BlindedSignature `protobuf:"bytes,2,rep,name=signatures,proto3" json:"signatures,omitempty"`
+}
+
+func (x *RestoreResponse) Reset() {
+	*x = RestoreResponse{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[40]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *RestoreResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreResponse) ProtoMessage() {}
+
+func (x *RestoreResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[40]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreResponse.ProtoReflect.Descriptor instead.
+func (*RestoreResponse) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *RestoreResponse) GetOutputs() []*BlindedMessage {
+	if x != nil {
+		return x.Outputs
+	}
+	return nil
+}
+
+func (x *RestoreResponse) GetSignatures() []*BlindedSignature {
+	if x != nil {
+		return x.Signatures
+	}
+	return nil
+}
+
+type SubscribeRequest struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	// kind of the NUT-17 subscription. Only bolt11_mint_quote is supported
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// ids of the quotes
+	Filters []string `protobuf:"bytes,2,rep,name=filters,proto3" json:"filters,omitempty"`
+}
+
+func (x *SubscribeRequest) Reset() {
+	*x = SubscribeRequest{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[41]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *SubscribeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SubscribeRequest) ProtoMessage() {}
+
+func (x *SubscribeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[41]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SubscribeRequest.ProtoReflect.Descriptor instead.
+func (*SubscribeRequest) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *SubscribeRequest) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (x *SubscribeRequest) GetFilters() []string {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+type Notification struct {
+	state         protoimpl.MessageState
+	sizeCache     protoimpl.SizeCache
+	unknownFields protoimpl.UnknownFields
+
+	Kind string `protobuf:"bytes,1,opt,name=kind,proto3" json:"kind,omitempty"`
+	// Types that are assignable to Payload:
+	//	*Notification_MintQuote
+	Payload isNotification_Payload `protobuf_oneof:"payload"`
+}
+
+func (x *Notification) Reset() {
+	*x = Notification{}
+	if protoimpl.UnsafeEnabled {
+		mi := &file_mint_proto_msgTypes[42]
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		ms.StoreMessageInfo(mi)
+	}
+}
+
+func (x *Notification) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Notification) ProtoMessage() {}
+
+func (x *Notification) ProtoReflect() protoreflect.Message {
+	mi := &file_mint_proto_msgTypes[42]
+	if protoimpl.UnsafeEnabled && x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Notification.ProtoReflect.Descriptor instead.
+func (*Notification) Descriptor() ([]byte, []int) {
+	return file_mint_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *Notification) GetKind() string {
+	if x != nil {
+		return x.Kind
+	}
+	return ""
+}
+
+func (m *Notification) GetPayload() isNotification_Payload {
+	if m != nil {
+		return m.Payload
+	}
+	return nil
+}
+
+func (x *Notification) GetMintQuote() *MintQuoteResponse {
+	if x, ok := x.GetPayload().(*Notification_MintQuote); ok {
+		return x.MintQuote
+	}
+	return nil
+}
+
+type isNotification_Payload interface {
+	isNotification_Payload()
+}
+
+type Notification_MintQuote struct {
+	MintQuote *MintQuoteResponse `protobuf:"bytes,2,opt,name=mint_quote,json=mintQuote,proto3,oneof"`
+}
+
+func (*Notification_MintQuote) isNotification_Payload() {}
+
+var File_mint_proto protoreflect.FileDescriptor
+
+var file_mint_proto_rawDesc = []byte{
+	0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x74, 0x2e, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x12, 0x08, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x22, 0x07, 0x0a, 0x05, 0x45, 0x6d, 0x70, 0x74, 0x79, 0x22,
+	0x1f, 0x0a, 0x0d, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x22, 0x24, 0x0a, 0x0c, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x22, 0xf3, 0x02, 0x0a, 0x08, 0x4d, 0x69, 0x6e, 0x74, 0x49,
+	0x6e, 0x66, 0x6f, 0x12, 0x12, 0x0a, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x6e, 0x61, 0x6d, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65,
+	0x79, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x12,
+	0x18, 0x0a, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x07, 0x76, 0x65, 0x72, 0x73, 0x69, 0x6f, 0x6e, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73,
+	0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b,
+	0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x29, 0x0a, 0x10, 0x64,
+	0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x5f, 0x6c, 0x6f, 0x6e, 0x67, 0x18,
+	0x05, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0f, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69,
+	0x6f, 0x6e, 0x4c, 0x6f, 0x6e, 0x67, 0x12, 0x2f, 0x0a, 0x07, 0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63,
+	0x74, 0x18, 0x06, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07,
+	0x63, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6d, 0x6f, 0x74, 0x64, 0x18,
+	0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6d, 0x6f, 0x74, 0x64, 0x12, 0x19, 0x0a, 0x08, 0x69,
+	0x63, 0x6f, 0x6e, 0x5f, 0x75, 0x72, 0x6c, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x69,
+	0x63, 0x6f, 0x6e, 0x55, 0x72, 0x6c, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x18, 0x09,
+	0x20, 0x03, 0x28, 0x09, 0x52, 0x04, 0x75, 0x72, 0x6c, 0x73, 0x12, 0x12, 0x0a, 0x04, 0x74, 0x69,
+	0x6d, 0x65, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x03, 0x52, 0x04, 0x74, 0x69, 0x6d, 0x65, 0x12, 0x22,
+	0x0a, 0x04, 0x6e, 0x75, 0x74, 0x73, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x0e, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x74, 0x73, 0x52, 0x04, 0x6e, 0x75,
+	0x74, 0x73, 0x12, 0x28, 0x0a, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x18, 0x0c, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4c, 0x69,
+	0x6d, 0x69, 0x74, 0x73, 0x52, 0x06, 0x6c, 0x69, 0x6d, 0x69, 0x74, 0x73, 0x22, 0x39, 0x0a, 0x0b,
+	0x43, 0x6f, 0x6e, 0x74, 0x61, 0x63, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x16, 0x0a, 0x06, 0x6d,
+	0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74,
+	0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x18, 0x02, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x04, 0x69, 0x6e, 0x66, 0x6f, 0x22, 0x74, 0x0a, 0x06, 0x4c, 0x69, 0x6d, 0x69, 0x74,
+	0x73, 0x12, 0x1d, 0x0a, 0x0a, 0x6d, 0x61, 0x78, 0x5f, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x09, 0x6d, 0x61, 0x78, 0x49, 0x6e, 0x70, 0x75, 0x74, 0x73,
+	0x12, 0x1f, 0x0a, 0x0b, 0x6d, 0x61, 0x78, 0x5f, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0a, 0x6d, 0x61, 0x78, 0x4f, 0x75, 0x74, 0x70, 0x75, 0x74,
+	0x73, 0x12, 0x2a, 0x0a, 0x11, 0x6d, 0x61, 0x78, 0x5f, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x5f, 0x62, 0x79, 0x74, 0x65, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x03, 0x52, 0x0f, 0x6d, 0x61,
+	0x78, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x42, 0x79, 0x74, 0x65, 0x73, 0x22, 0xc1, 0x04,
+	0x0a, 0x04, 0x4e, 0x75, 0x74, 0x73, 0x12, 0x2a, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x34, 0x18,
+	0x04, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x4e, 0x75, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x6e, 0x75, 0x74,
+	0x30, 0x34, 0x12, 0x2a, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x35, 0x18, 0x05, 0x20, 0x01, 0x28,
+	0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x74,
+	0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x35, 0x12, 0x29,
+	0x0a, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x37, 0x18, 0x07, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e,
+	0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74,
+	0x65, 0x64, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x37, 0x12, 0x29, 0x0a, 0x05, 0x6e, 0x75, 0x74,
+	0x30, 0x38, 0x18, 0x08, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x05, 0x6e,
+	0x75, 0x74, 0x30, 0x38, 0x12, 0x29, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x39, 0x18, 0x09, 0x20,
+	0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x30, 0x39, 0x12,
+	0x2c, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x30, 0x18, 0x0a, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16,
+	0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x74, 0x31, 0x30, 0x53,
+	0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x30, 0x12, 0x29, 0x0a,
+	0x05, 0x6e, 0x75, 0x74, 0x31, 0x31, 0x18, 0x0b, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65,
+	0x64, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x31, 0x12, 0x29, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x31,
+	0x32, 0x18, 0x0c, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x75,
+	0x74, 0x31, 0x32, 0x12, 0x29, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x34, 0x18, 0x0e, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75,
+	0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x34, 0x12, 0x2a,
+	0x0a, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x35, 0x18, 0x0f, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x14, 0x2e,
+	0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x74, 0x53, 0x65, 0x74, 0x74,
+	0x69, 0x6e, 0x67, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x35, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x75,
+	0x74, 0x31, 0x37, 0x18, 0x11, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x75, 0x74, 0x31, 0x37, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e,
+	0x67, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x31, 0x37, 0x12, 0x2c, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x31,
+	0x39, 0x18, 0x13, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x4e, 0x75, 0x74, 0x31, 0x39, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52,
+	0x05, 0x6e, 0x75, 0x74, 0x31, 0x39, 0x12, 0x29, 0x0a, 0x05, 0x6e, 0x75, 0x74, 0x32, 0x30, 0x18,
+	0x14, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x52, 0x05, 0x6e, 0x75, 0x74, 0x32,
+	0x30, 0x22, 0x5b, 0x0a, 0x0a, 0x4e, 0x75, 0x74, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x31, 0x0a, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b,
+	0x32, 0x17, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x68,
+	0x6f, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x52, 0x07, 0x6d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x73, 0x12, 0x1a, 0x0a, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x08, 0x64, 0x69, 0x73, 0x61, 0x62, 0x6c, 0x65, 0x64, 0x22, 0xac,
+	0x01, 0x0a, 0x0d, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67,
+	0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74,
+	0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x1d, 0x0a, 0x0a,
+	0x6d, 0x69, 0x6e, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x09, 0x6d, 0x69, 0x6e, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x1d, 0x0a, 0x0a, 0x6d,
+	0x61, 0x78, 0x5f, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x09, 0x6d, 0x61, 0x78, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x31, 0x0a, 0x07, 0x6f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x17, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x51, 0x0a,
+	0x0d, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x20,
+	0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x08, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e,
+	0x12, 0x1e, 0x0a, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x6c, 0x65, 0x73, 0x73, 0x18, 0x02,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x6c, 0x65, 0x73, 0x73,
+	0x22, 0x29, 0x0a, 0x09, 0x53, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x1c, 0x0a,
+	0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08,
+	0x52, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x22, 0x42, 0x0a, 0x0c, 0x4e,
+	0x75, 0x74, 0x31, 0x30, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x1c, 0x0a, 0x09, 0x73,
+	0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x08, 0x52, 0x09,
+	0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x12, 0x14, 0x0a, 0x05, 0x6b, 0x69, 0x6e,
+	0x64, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x05, 0x6b, 0x69, 0x6e, 0x64, 0x73, 0x22,
+	0x4a, 0x0a, 0x0c, 0x4e, 0x75, 0x74, 0x31, 0x37, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12,
+	0x3a, 0x0a, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x18, 0x01, 0x20, 0x03,
+	0x28, 0x0b, 0x32, 0x1c, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x75,
+	0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f, 0x64,
+	0x52, 0x09, 0x73, 0x75, 0x70, 0x70, 0x6f, 0x72, 0x74, 0x65, 0x64, 0x22, 0x5c, 0x0a, 0x12, 0x53,
+	0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x4d, 0x65, 0x74, 0x68, 0x6f,
+	0x64, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28,
+	0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x1a, 0x0a,
+	0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x09, 0x52,
+	0x08, 0x63, 0x6f, 0x6d, 0x6d, 0x61, 0x6e, 0x64, 0x73, 0x22, 0x65, 0x0a, 0x0c, 0x4e, 0x75, 0x74,
+	0x31, 0x39, 0x53, 0x65, 0x74, 0x74, 0x69, 0x6e, 0x67, 0x12, 0x10, 0x0a, 0x03, 0x74, 0x74, 0x6c,
+	0x18, 0x01, 0x20, 0x01, 0x28, 0x03, 0x52, 0x03, 0x74, 0x74, 0x6c, 0x12, 0x43, 0x0a, 0x10, 0x63,
+	0x61, 0x63, 0x68, 0x65, 0x64, 0x5f, 0x65, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x52,
+	0x0f, 0x63, 0x61, 0x63, 0x68, 0x65, 0x64, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69, 0x6e, 0x74, 0x73,
+	0x22, 0x3c, 0x0a, 0x0e, 0x43, 0x61, 0x63, 0x68, 0x65, 0x64, 0x45, 0x6e, 0x64, 0x70, 0x6f, 0x69,
+	0x6e, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x06, 0x6d, 0x65, 0x74, 0x68, 0x6f, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x70, 0x61,
+	0x74, 0x68, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x70, 0x61, 0x74, 0x68, 0x22, 0x95,
+	0x01, 0x0a, 0x06, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69,
+	0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x2e, 0x0a,
+	0x04, 0x6b, 0x65, 0x79, 0x73, 0x18, 0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x2e, 0x4b, 0x65,
+	0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x52, 0x04, 0x6b, 0x65, 0x79, 0x73, 0x1a, 0x37, 0x0a,
+	0x09, 0x4b, 0x65, 0x79, 0x73, 0x45, 0x6e, 0x74, 0x72, 0x79, 0x12, 0x10, 0x0a, 0x03, 0x6b, 0x65,
+	0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x03, 0x6b, 0x65, 0x79, 0x12, 0x14, 0x0a, 0x05,
+	0x76, 0x61, 0x6c, 0x75, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x76, 0x61, 0x6c,
+	0x75, 0x65, 0x3a, 0x02, 0x38, 0x01, 0x22, 0x3a, 0x0a, 0x0c, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x2a, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x73, 0x65, 0x74,
+	0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x10, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e,
+	0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x73, 0x65,
+	0x74, 0x73, 0x22, 0x6c, 0x0a, 0x0a, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f,
+	0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x75, 0x6e, 0x69, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x08, 0x52, 0x06, 0x61, 0x63, 0x74, 0x69, 0x76, 0x65, 0x12, 0x22, 0x0a, 0x0d,
+	0x69, 0x6e, 0x70, 0x75, 0x74, 0x5f, 0x66, 0x65, 0x65, 0x5f, 0x70, 0x70, 0x6b, 0x18, 0x04, 0x20,
+	0x01, 0x28, 0x04, 0x52, 0x0b, 0x69, 0x6e, 0x70, 0x75, 0x74, 0x46, 0x65, 0x65, 0x50, 0x70, 0x6b,
+	0x22, 0x41, 0x0a, 0x0f, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2e, 0x0a, 0x07, 0x6b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x73, 0x18, 0x01,
+	0x20, 0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e,
+	0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x52, 0x07, 0x6b, 0x65, 0x79, 0x73,
+	0x65, 0x74, 0x73, 0x22, 0x61, 0x0a, 0x0e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18,
+	0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0d, 0x0a,
+	0x02, 0x62, 0x5f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x62, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x18, 0x0a, 0x07,
+	0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x77,
+	0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x72, 0x0a, 0x10, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65,
+	0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x12, 0x0d, 0x0a, 0x02, 0x63, 0x5f, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01,
+	0x63, 0x12, 0x0e, 0x0a, 0x02, 0x69, 0x64, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69,
+	0x64, 0x12, 0x27, 0x0a, 0x04, 0x64, 0x6c, 0x65, 0x71, 0x18, 0x04, 0x20, 0x01, 0x28, 0x0b, 0x32,
+	0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4c, 0x45, 0x51, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x52, 0x04, 0x64, 0x6c, 0x65, 0x71, 0x22, 0x98, 0x01, 0x0a, 0x05, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x0e, 0x0a, 0x02,
+	0x69, 0x64, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x02, 0x69, 0x64, 0x12, 0x16, 0x0a, 0x06,
+	0x73, 0x65, 0x63, 0x72, 0x65, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x73, 0x65,
+	0x63, 0x72, 0x65, 0x74, 0x12, 0x0c, 0x0a, 0x01, 0x63, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x01, 0x63, 0x12, 0x18, 0x0a, 0x07, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x05, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x07, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x12, 0x27, 0x0a, 0x04,
+	0x64, 0x6c, 0x65, 0x71, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73,
+	0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x44, 0x4c, 0x45, 0x51, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52,
+	0x04, 0x64, 0x6c, 0x65, 0x71, 0x22, 0x35, 0x0a, 0x09, 0x44, 0x4c, 0x45, 0x51, 0x50, 0x72, 0x6f,
+	0x6f, 0x66, 0x12, 0x0c, 0x0a, 0x01, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x65,
+	0x12, 0x0c, 0x0a, 0x01, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x73, 0x12, 0x0c,
+	0x0a, 0x01, 0x72, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x72, 0x22, 0x6a, 0x0a, 0x0b,
+	0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x27, 0x0a, 0x06, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x4a, 0x0a, 0x0c, 0x53, 0x77, 0x61, 0x70,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e,
+	0x61, 0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x53,
+	0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x22, 0x78, 0x0a, 0x10, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04,
+	0x75, 0x6e, 0x69, 0x74, 0x12, 0x20, 0x0a, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72, 0x69, 0x70, 0x74,
+	0x69, 0x6f, 0x6e, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x0b, 0x64, 0x65, 0x73, 0x63, 0x72,
+	0x69, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79,
+	0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79, 0x22, 0xcf,
+	0x01, 0x0a, 0x11, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70,
+	0x6f, 0x6e, 0x73, 0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20,
+	0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x71,
+	0x75, 0x65, 0x73, 0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03,
+	0x20, 0x01, 0x28, 0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04,
+	0x75, 0x6e, 0x69, 0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74,
+	0x12, 0x2e, 0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x05, 0x20, 0x01, 0x28, 0x0e, 0x32,
+	0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x51,
+	0x75, 0x6f, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x16, 0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x06, 0x20, 0x01, 0x28, 0x04,
+	0x52, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x16, 0x0a, 0x06, 0x70, 0x75, 0x62, 0x6b,
+	0x65, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x09, 0x52, 0x06, 0x70, 0x75, 0x62, 0x6b, 0x65, 0x79,
+	0x22, 0x75, 0x0a, 0x0b, 0x4d, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12,
+	0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x05,
+	0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73,
+	0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76,
+	0x31, 0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65,
+	0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x1c, 0x0a, 0x09, 0x73, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52, 0x09, 0x73, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x22, 0x4a, 0x0a, 0x0c, 0x4d, 0x69, 0x6e, 0x74, 0x52,
+	0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61,
+	0x74, 0x75, 0x72, 0x65, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69,
+	0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75,
+	0x72, 0x65, 0x73, 0x22, 0x71, 0x0a, 0x10, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65,
+	0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x2f, 0x0a, 0x07, 0x6f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73,
+	0x18, 0x03, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x15, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76,
+	0x31, 0x2e, 0x4d, 0x65, 0x6c, 0x74, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x52, 0x07, 0x6f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x73, 0x22, 0x70, 0x0a, 0x0b, 0x4d, 0x65, 0x6c, 0x74, 0x4f, 0x70,
+	0x74, 0x69, 0x6f, 0x6e, 0x73, 0x12, 0x25, 0x0a, 0x03, 0x6d, 0x70, 0x70, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x0b, 0x32, 0x13, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x70,
+	0x70, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x03, 0x6d, 0x70, 0x70, 0x12, 0x3a, 0x0a, 0x0a,
+	0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x6c, 0x65, 0x73, 0x73, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b,
+	0x32, 0x1a, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x41, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x6c, 0x65, 0x73, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x52, 0x0a, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x6c, 0x65, 0x73, 0x73, 0x22, 0x2c, 0x0a, 0x09, 0x4d, 0x70, 0x70, 0x4f,
+	0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x5f,
+	0x6d, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52, 0x0a, 0x61, 0x6d, 0x6f, 0x75,
+	0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x22, 0x33, 0x0a, 0x10, 0x41, 0x6d, 0x6f, 0x75, 0x6e, 0x74,
+	0x6c, 0x65, 0x73, 0x73, 0x4f, 0x70, 0x74, 0x69, 0x6f, 0x6e, 0x12, 0x1f, 0x0a, 0x0b, 0x61, 0x6d,
+	0x6f, 0x75, 0x6e, 0x74, 0x5f, 0x6d, 0x73, 0x61, 0x74, 0x18, 0x01, 0x20, 0x01, 0x28, 0x04, 0x52,
+	0x0a, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x4d, 0x73, 0x61, 0x74, 0x22, 0xb7, 0x02, 0x0a, 0x11,
+	0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73,
+	0x65, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x18, 0x0a, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x18, 0x02, 0x20, 0x01, 0x28, 0x09, 0x52, 0x07, 0x72, 0x65, 0x71, 0x75, 0x65, 0x73,
+	0x74, 0x12, 0x16, 0x0a, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x18, 0x03, 0x20, 0x01, 0x28,
+	0x04, 0x52, 0x06, 0x61, 0x6d, 0x6f, 0x75, 0x6e, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x75, 0x6e, 0x69,
+	0x74, 0x18, 0x04, 0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x75, 0x6e, 0x69, 0x74, 0x12, 0x1f, 0x0a,
+	0x0b, 0x66, 0x65, 0x65, 0x5f, 0x72, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x18, 0x05, 0x20, 0x01,
+	0x28, 0x04, 0x52, 0x0a, 0x66, 0x65, 0x65, 0x52, 0x65, 0x73, 0x65, 0x72, 0x76, 0x65, 0x12, 0x2e,
+	0x0a, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x06, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x18, 0x2e,
+	0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16,
+	0x0a, 0x06, 0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x18, 0x07, 0x20, 0x01, 0x28, 0x04, 0x52, 0x06,
+	0x65, 0x78, 0x70, 0x69, 0x72, 0x79, 0x12, 0x29, 0x0a, 0x10, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e,
+	0x74, 0x5f, 0x70, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67, 0x65, 0x18, 0x08, 0x20, 0x01, 0x28, 0x09,
+	0x52, 0x0f, 0x70, 0x61, 0x79, 0x6d, 0x65, 0x6e, 0x74, 0x50, 0x72, 0x65, 0x69, 0x6d, 0x61, 0x67,
+	0x65, 0x12, 0x32, 0x0a, 0x06, 0x63, 0x68, 0x61, 0x6e, 0x67, 0x65, 0x18, 0x09, 0x20, 0x03, 0x28,
+	0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x69,
+	0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x06, 0x63,
+	0x68, 0x61, 0x6e, 0x67, 0x65, 0x22, 0x80, 0x01, 0x0a, 0x0b, 0x4d, 0x65, 0x6c, 0x74, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x14, 0x0a, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x05, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x27, 0x0a, 0x06, 0x69,
+	0x6e, 0x70, 0x75, 0x74, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x0f, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x52, 0x06, 0x69, 0x6e,
+	0x70, 0x75, 0x74, 0x73, 0x12, 0x32, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18,
+	0x03, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52,
+	0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x23, 0x0a, 0x11, 0x43, 0x68, 0x65, 0x63,
+	0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x0e, 0x0a,
+	0x02, 0x79, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x09, 0x52, 0x02, 0x79, 0x73, 0x22, 0x42, 0x0a,
+	0x12, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f,
+	0x6e, 0x73, 0x65, 0x12, 0x2c, 0x0a, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x14, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x50,
+	0x72, 0x6f, 0x6f, 0x66, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x06, 0x73, 0x74, 0x61, 0x74, 0x65,
+	0x73, 0x22, 0x65, 0x0a, 0x0a, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12,
+	0x0c, 0x0a, 0x01, 0x79, 0x18, 0x01, 0x20, 0x01, 0x28, 0x09, 0x52, 0x01, 0x79, 0x12, 0x2f, 0x0a,
+	0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0e, 0x32, 0x19, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x70, 0x65,
+	0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65, 0x52, 0x05, 0x73, 0x74, 0x61, 0x74, 0x65, 0x12, 0x18,
+	0x0a, 0x07, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x18, 0x03, 0x20, 0x01, 0x28, 0x09, 0x52,
+	0x07, 0x77, 0x69, 0x74, 0x6e, 0x65, 0x73, 0x73, 0x22, 0x44, 0x0a, 0x0e, 0x52, 0x65, 0x73, 0x74,
+	0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x32, 0x0a, 0x07, 0x6f, 0x75,
+	0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65,
+	0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x22, 0x81,
+	0x01, 0x0a, 0x0f, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x32, 0x0a, 0x07, 0x6f, 0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x18, 0x01, 0x20,
+	0x03, 0x28, 0x0b, 0x32, 0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x42,
+	0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x4d, 0x65, 0x73, 0x73, 0x61, 0x67, 0x65, 0x52, 0x07, 0x6f,
+	0x75, 0x74, 0x70, 0x75, 0x74, 0x73, 0x12, 0x3a, 0x0a, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74,
+	0x75, 0x72, 0x65, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x0b, 0x32, 0x1a, 0x2e, 0x63, 0x61, 0x73,
+	0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x42, 0x6c, 0x69, 0x6e, 0x64, 0x65, 0x64, 0x53, 0x69, 0x67,
+	0x6e, 0x61, 0x74, 0x75, 0x72, 0x65, 0x52, 0x0a, 0x73, 0x69, 0x67, 0x6e, 0x61, 0x74, 0x75, 0x72,
+	0x65, 0x73, 0x22, 0x40, 0x0a, 0x10, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52,
+	0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01,
+	0x20, 0x01, 0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x18, 0x0a, 0x07, 0x66, 0x69,
+	0x6c, 0x74, 0x65, 0x72, 0x73, 0x18, 0x02, 0x20, 0x03, 0x28, 0x09, 0x52, 0x07, 0x66, 0x69, 0x6c,
+	0x74, 0x65, 0x72, 0x73, 0x22, 0x6b, 0x0a, 0x0c, 0x4e, 0x6f, 0x74, 0x69, 0x66, 0x69, 0x63, 0x61,
+	0x74, 0x69, 0x6f, 0x6e, 0x12, 0x12, 0x0a, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x18, 0x01, 0x20, 0x01,
+	0x28, 0x09, 0x52, 0x04, 0x6b, 0x69, 0x6e, 0x64, 0x12, 0x3c, 0x0a, 0x0a, 0x6d, 0x69, 0x6e, 0x74,
+	0x5f, 0x71, 0x75, 0x6f, 0x74, 0x65, 0x18, 0x02, 0x20, 0x01, 0x28, 0x0b, 0x32, 0x1b, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x48, 0x00, 0x52, 0x09, 0x6d, 0x69, 0x6e,
+	0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x42, 0x09, 0x0a, 0x07, 0x70, 0x61, 0x79, 0x6c, 0x6f, 0x61,
+	0x64, 0x2a, 0xa1, 0x01, 0x0a, 0x0e, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x49, 0x4e, 0x54, 0x5f, 0x51, 0x55, 0x4f,
+	0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x50, 0x41, 0x49, 0x44, 0x10,
+	0x00, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x49, 0x4e, 0x54, 0x5f, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x01, 0x12, 0x1b, 0x0a, 0x17,
+	0x4d, 0x49, 0x4e, 0x54, 0x5f, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x49, 0x53, 0x53, 0x55, 0x45, 0x44, 0x10, 0x02, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x49, 0x4e,
+	0x54, 0x5f, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45,
+	0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x03, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x49, 0x4e, 0x54, 0x5f,
+	0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e,
+	0x4f, 0x57, 0x4e, 0x10, 0x04, 0x2a, 0x84, 0x01, 0x0a, 0x0e, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b, 0x0a, 0x17, 0x4d, 0x45, 0x4c, 0x54,
+	0x5f, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x50,
+	0x41, 0x49, 0x44, 0x10, 0x00, 0x12, 0x1c, 0x0a, 0x18, 0x4d, 0x45, 0x4c, 0x54, 0x5f, 0x51, 0x55,
+	0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e,
+	0x47, 0x10, 0x01, 0x12, 0x19, 0x0a, 0x15, 0x4d, 0x45, 0x4c, 0x54, 0x5f, 0x51, 0x55, 0x4f, 0x54,
+	0x45, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x41, 0x49, 0x44, 0x10, 0x02, 0x12, 0x1c,
+	0x0a, 0x18, 0x4d, 0x45, 0x4c, 0x54, 0x5f, 0x51, 0x55, 0x4f, 0x54, 0x45, 0x5f, 0x53, 0x54, 0x41,
+	0x54, 0x45, 0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x03, 0x2a, 0x8b, 0x01, 0x0a,
+	0x0f, 0x50, 0x72, 0x6f, 0x6f, 0x66, 0x53, 0x70, 0x65, 0x6e, 0x64, 0x53, 0x74, 0x61, 0x74, 0x65,
+	0x12, 0x1d, 0x0a, 0x19, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x5f,
+	0x53, 0x54, 0x41, 0x54, 0x45, 0x5f, 0x55, 0x4e, 0x53, 0x50, 0x45, 0x4e, 0x54, 0x10, 0x00, 0x12,
+	0x1d, 0x0a, 0x19, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x5f, 0x53,
+	0x54, 0x41, 0x54, 0x45, 0x5f, 0x50, 0x45, 0x4e, 0x44, 0x49, 0x4e, 0x47, 0x10, 0x01, 0x12, 0x1b,
+	0x0a, 0x17, 0x50, 0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x5f, 0x53, 0x54,
+	0x41, 0x54, 0x45, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x54, 0x10, 0x02, 0x12, 0x1d, 0x0a, 0x19, 0x50,
+	0x52, 0x4f, 0x4f, 0x46, 0x5f, 0x53, 0x50, 0x45, 0x4e, 0x44, 0x5f, 0x53, 0x54, 0x41, 0x54, 0x45,
+	0x5f, 0x55, 0x4e, 0x4b, 0x4e, 0x4f, 0x57, 0x4e, 0x10, 0x03, 0x32, 0xef, 0x06, 0x0a, 0x0b, 0x4d,
+	0x69, 0x6e, 0x74, 0x53, 0x65, 0x72, 0x76, 0x69, 0x63, 0x65, 0x12, 0x2b, 0x0a, 0x04, 0x49, 0x6e,
+	0x66, 0x6f, 0x12, 0x0f, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d,
+	0x70, 0x74, 0x79, 0x1a, 0x12, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d,
+	0x69, 0x6e, 0x74, 0x49, 0x6e, 0x66, 0x6f, 0x12, 0x2f, 0x0a, 0x04, 0x4b, 0x65, 0x79, 0x73, 0x12,
+	0x0f, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74, 0x79,
+	0x1a, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x73,
+	0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3b, 0x0a, 0x08, 0x4b, 0x65, 0x79, 0x73,
+	0x42, 0x79, 0x49, 0x64, 0x12, 0x17, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e,
+	0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e,
+	0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79, 0x73, 0x52, 0x65, 0x73,
+	0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x07, 0x4b, 0x65, 0x79, 0x73, 0x65, 0x74, 0x73,
+	0x12, 0x0f, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x45, 0x6d, 0x70, 0x74,
+	0x79, 0x1a, 0x19, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4b, 0x65, 0x79,
+	0x73, 0x65, 0x74, 0x73, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x44, 0x0a, 0x09,
+	0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x2e, 0x63, 0x61, 0x73, 0x68,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65,
+	0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e,
+	0x73, 0x65, 0x12, 0x45, 0x0a, 0x0e, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x4d, 0x69, 0x6e,
+	0x74, 0x12, 0x15, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e,
+	0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x69, 0x6e, 0x74, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x44, 0x0a, 0x09, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x12, 0x1a, 0x2e,
+	0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f,
+	0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x73, 0x68,
+	0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65,
+	0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x45, 0x0a, 0x0e, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75,
+	0x6f, 0x74, 0x65, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74,
+	0x1a, 0x1b, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6c, 0x74,
+	0x51, 0x75, 0x6f, 0x74, 0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3a, 0x0a,
+	0x04, 0x4d, 0x65, 0x6c, 0x74, 0x12, 0x15, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31,
+	0x2e, 0x4d, 0x65, 0x6c, 0x74, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1b, 0x2e, 0x63,
+	0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4d, 0x65, 0x6c, 0x74, 0x51, 0x75, 0x6f, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x35, 0x0a, 0x04, 0x53, 0x77, 0x61,
+	0x70, 0x12, 0x15, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x61,
+	0x70, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75,
+	0x2e, 0x76, 0x31, 0x2e, 0x53, 0x77, 0x61, 0x70, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65,
+	0x12, 0x47, 0x0a, 0x0a, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74, 0x65, 0x12, 0x1b,
+	0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53,
+	0x74, 0x61, 0x74, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x1c, 0x2e, 0x63, 0x61,
+	0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x43, 0x68, 0x65, 0x63, 0x6b, 0x53, 0x74, 0x61, 0x74,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x3e, 0x0a, 0x07, 0x52, 0x65, 0x73,
+	0x74, 0x6f, 0x72, 0x65, 0x12, 0x18, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e,
+	0x52, 0x65, 0x73, 0x74, 0x6f, 0x72, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65, 0x73, 0x74, 0x1a, 0x19,
+	0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x52, 0x65, 0x73, 0x74, 0x6f, 0x72,
+	0x65, 0x52, 0x65, 0x73, 0x70, 0x6f, 0x6e, 0x73, 0x65, 0x12, 0x41, 0x0a, 0x09, 0x53, 0x75, 0x62,
+	0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x12, 0x1a, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76,
+	0x31, 0x2e, 0x53, 0x75, 0x62, 0x73, 0x63, 0x72, 0x69, 0x62, 0x65, 0x52, 0x65, 0x71, 0x75, 0x65,
+	0x73, 0x74, 0x1a, 0x16, 0x2e, 0x63, 0x61, 0x73, 0x68, 0x75, 0x2e, 0x76, 0x31, 0x2e, 0x4e, 0x6f,
+	0x74, 0x69, 0x66, 0x69, 0x63, 0x61, 0x74, 0x69, 0x6f, 0x6e, 0x30, 0x01, 0x42, 0x33, 0x5a, 0x31,
+	0x67, 0x69, 0x74, 0x68, 0x75, 0x62, 0x2e, 0x63, 0x6f, 0x6d, 0x2f, 0x4f, 0x72, 0x69, 0x67, 0x61,
+	0x6d, 0x69, 0x37, 0x34, 0x2f, 0x67, 0x6f, 0x6e, 0x75, 0x74, 0x73, 0x2d, 0x74, 0x6f, 0x6c, 0x6c,
+	0x67, 0x61, 0x74, 0x65, 0x2f, 0x6d, 0x69, 0x6e, 0x74, 0x2f, 0x6d, 0x69, 0x6e, 0x74, 0x72, 0x70,
+	0x63, 0x62, 0x06, 0x70, 0x72, 0x6f, 0x74, 0x6f, 0x33,
+}
+
+var (
+	file_mint_proto_rawDescOnce sync.Once
+	file_mint_proto_rawDescData = file_mint_proto_rawDesc
+)
+
+func file_mint_proto_rawDescGZIP() []byte {
+	file_mint_proto_rawDescOnce.Do(func() {
+		file_mint_proto_rawDescData = protoimpl.X.CompressGZIP(file_mint_proto_rawDescData)
+	})
+	return file_mint_proto_rawDescData
+}
+
+var file_mint_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
+var file_mint_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
+var file_mint_proto_goTypes = []interface{}{
+	(MintQuoteState)(0),        // 0: cashu.v1.MintQuoteState
+	(MeltQuoteState)(0),        // 1: cashu.v1.MeltQuoteState
+	(ProofSpendState)(0),       // 2: cashu.v1.ProofSpendState
+	(*Empty)(nil),              // 3: cashu.v1.Empty
+	(*KeysetRequest)(nil),      // 4: cashu.v1.KeysetRequest
+	(*QuoteRequest)(nil),       // 5: cashu.v1.QuoteRequest
+	(*MintInfo)(nil),           // 6: cashu.v1.MintInfo
+	(*ContactInfo)(nil),        // 7: cashu.v1.ContactInfo
+	(*Limits)(nil),             // 8: cashu.v1.Limits
+	(*Nuts)(nil),               // 9: cashu.v1.Nuts
+	(*NutSetting)(nil),         // 10: cashu.v1.NutSetting
+	(*MethodSetting)(nil),      // 11: cashu.v1.MethodSetting
+	(*MethodOptions)(nil),      // 12: cashu.v1.MethodOptions
+	(*Supported)(nil),          // 13: cashu.v1.Supported
+	(*Nut10Setting)(nil),       // 14: cashu.v1.Nut10Setting
+	(*Nut17Setting)(nil),       // 15: cashu.v1.Nut17Setting
+	(*SubscriptionMethod)(nil), // 16: cashu.v1.SubscriptionMethod
+	(*Nut19Setting)(nil),       // 17: cashu.v1.Nut19Setting
+	(*CachedEndpoint)(nil),     // 18: cashu.v1.CachedEndpoint
+	(*Keyset)(nil),             // 19: cashu.v1.Keyset
+	(*KeysResponse)(nil),       // 20: cashu.v1.KeysResponse
+	(*KeysetInfo)(nil),         // 21: cashu.v1.KeysetInfo
+	(*KeysetsResponse)(nil),    // 22: cashu.v1.KeysetsResponse
+	(*BlindedMessage)(nil),     // 23: cashu.v1.BlindedMessage
+	(*BlindedSignature)(nil),   // 24: cashu.v1.BlindedSignature
+	(*Proof)(nil),              // 25: cashu.v1.Proof
+	(*DLEQProof)(nil),          // 26: cashu.v1.DLEQProof
+	(*SwapRequest)(nil),        // 27: cashu.v1.SwapRequest
+	(*SwapResponse)(nil),       // 28: cashu.v1.SwapResponse
+	(*MintQuoteRequest)(nil),   // 29: cashu.v1.MintQuoteRequest
+	(*MintQuoteResponse)(nil),  // 30: cashu.v1.MintQuoteResponse
+	(*MintRequest)(nil),        // 31: cashu.v1.MintRequest
+	(*MintResponse)(nil),       // 32: cashu.v1.MintResponse
+	(*MeltQuoteRequest)(nil),   // 33: cashu.v1.MeltQuoteRequest
+	(*MeltOptions)(nil),        // 34: cashu.v1.MeltOptions
+	(*MppOption)(nil),          // 35: cashu.v1.MppOption
+	(*AmountlessOption)(nil),   // 36: cashu.v1.AmountlessOption
+	(*MeltQuoteResponse)(nil),  // 37: cashu.v1.MeltQuoteResponse
+	(*MeltRequest)(nil),        // 38: cashu.v1.MeltRequest
+	(*CheckStateRequest)(nil),  // 39: cashu.v1.CheckStateRequest
+	(*CheckStateResponse)(nil), // 40: cashu.v1.CheckStateResponse
+	(*ProofState)(nil),         // 41: cashu.v1.ProofState
+	(*RestoreRequest)(nil),     // 42: cashu.v1.RestoreRequest
+	(*RestoreResponse)(nil),    // 43: cashu.v1.RestoreResponse
+	(*SubscribeRequest)(nil),   // 44: cashu.v1.SubscribeRequest
+	(*Notification)(nil),       // 45: cashu.v1.Notification
+	nil,                        // 46: cashu.v1.Keyset.KeysEntry
+}
+var file_mint_proto_depIdxs = []int32{
+	7,  // 0: cashu.v1.MintInfo.contact:type_name -> cashu.v1.ContactInfo
+	9,  // 1: cashu.v1.MintInfo.nuts:type_name -> cashu.v1.Nuts
+	8,  // 2: cashu.v1.MintInfo.limits:type_name -> cashu.v1.Limits
+	10, // 3: cashu.v1.Nuts.nut04:type_name -> cashu.v1.NutSetting
+	10, // 4: cashu.v1.Nuts.nut05:type_name -> cashu.v1.NutSetting
+	13, // 5: cashu.v1.Nuts.nut07:type_name -> cashu.v1.Supported
+	13, // 6: cashu.v1.Nuts.nut08:type_name -> cashu.v1.Supported
+	13, // 7: cashu.v1.Nuts.nut09:type_name -> cashu.v1.Supported
+	14, // 8: cashu.v1.Nuts.nut10:type_name -> cashu.v1.Nut10Setting
+	13, // 9: cashu.v1.Nuts.nut11:type_name -> cashu.v1.Supported
+	13, // 10: cashu.v1.Nuts.nut12:type_name -> cashu.v1.Supported
+	13, // 11: cashu.v1.Nuts.nut14:type_name -> cashu.v1.Supported
+	10, // 12: cashu.v1.Nuts.nut15:type_name -> cashu.v1.NutSetting
+	15, // 13: cashu.v1.Nuts.nut17:type_name -> cashu.v1.Nut17Setting
+	17, // 14: cashu.v1.Nuts.nut19:type_name -> cashu.v1.Nut19Setting
+	13, // 15: cashu.v1.Nuts.nut20:type_name -> cashu.v1.Supported
+	11, // 16: cashu.v1.NutSetting.methods:type_name -> cashu.v1.MethodSetting
+	12, // 17: cashu.v1.MethodSetting.options:type_name -> cashu.v1.MethodOptions
+	16, // 18: cashu.v1.Nut17Setting.supported:type_name -> cashu.v1.SubscriptionMethod
+	18, // 19: cashu.v1.Nut19Setting.cached_endpoints:type_name -> cashu.v1.CachedEndpoint
+	46, // 20: cashu.v1.Keyset.keys:type_name -> cashu.v1.Keyset.KeysEntry
+	19, // 21: cashu.v1.KeysResponse.keysets:type_name -> cashu.v1.Keyset
+	21, // 22: cashu.v1.KeysetsResponse.keysets:type_name -> cashu.v1.KeysetInfo
+	26, // 23: cashu.v1.BlindedSignature.dleq:type_name -> cashu.v1.DLEQProof
+	26, // 24: cashu.v1.Proof.dleq:type_name -> cashu.v1.DLEQProof
+	25, // 25: cashu.v1.SwapRequest.inputs:type_name -> cashu.v1.Proof
+	23, // 26: cashu.v1.SwapRequest.outputs:type_name -> cashu.v1.BlindedMessage
+	24, // 27: cashu.v1.SwapResponse.signatures:type_name -> cashu.v1.BlindedSignature
+	0,  // 28: cashu.v1.MintQuoteResponse.state:type_name -> cashu.v1.MintQuoteState
+	23, // 29: cashu.v1.MintRequest.outputs:type_name -> cashu.v1.BlindedMessage
+	24, // 30: cashu.v1.MintResponse.signatures:type_name -> cashu.v1.BlindedSignature
+	34, // 31: cashu.v1.MeltQuoteRequest.options:type_name -> cashu.v1.MeltOptions
+	35, // 32: cashu.v1.MeltOptions.mpp:type_name -> cashu.v1.MppOption
+	36, // 33: cashu.v1.MeltOptions.amountless:type_name -> cashu.v1.AmountlessOption
+	1,  // 34: cashu.v1.MeltQuoteResponse.state:type_name -> cashu.v1.MeltQuoteState
+	24, // 35: cashu.v1.MeltQuoteResponse.change:type_name -> cashu.v1.BlindedSignature
+	25, // 36: cashu.v1.MeltRequest.inputs:type_name -> cashu.v1.Proof
+	23, // 37: cashu.v1.MeltRequest.outputs:type_name -> cashu.v1.BlindedMessage
+	41, // 38: cashu.v1.CheckStateResponse.states:type_name -> cashu.v1.ProofState
+	2,  // 39: cashu.v1.ProofState.state:type_name -> cashu.v1.ProofSpendState
+	23, // 40: cashu.v1.RestoreRequest.outputs:type_name -> cashu.v1.BlindedMessage
+	23, // 41: cashu.v1.RestoreResponse.outputs:type_name -> cashu.v1.BlindedMessage
+	24, // 42: cashu.v1.RestoreResponse.signatures:type_name -> cashu.v1.BlindedSignature
+	30, // 43: cashu.v1.Notification.mint_quote:type_name -> cashu.v1.MintQuoteResponse
+	3,  // 44: cashu.v1.MintService.Info:input_type -> cashu.v1.Empty
+	3,  // 45: cashu.v1.MintService.Keys:input_type -> cashu.v1.Empty
+	4,  // 46: cashu.v1.MintService.KeysById:input_type -> cashu.v1.KeysetRequest
+	3,  // 47: cashu.v1.MintService.Keysets:input_type -> cashu.v1.Empty
+	29, // 48: cashu.v1.MintService.MintQuote:input_type -> cashu.v1.MintQuoteRequest
+	5,  // 49: cashu.v1.MintService.MintQuoteState:input_type -> cashu.v1.QuoteRequest
+	31, // 50: cashu.v1.MintService.Mint:input_type -> cashu.v1.MintRequest
+	33, // 51: cashu.v1.MintService.MeltQuote:input_type -> cashu.v1.MeltQuoteRequest
+	5,  // 52: cashu.v1.MintService.MeltQuoteState:input_type -> cashu.v1.QuoteRequest
+	38, // 53: cashu.v1.MintService.Melt:input_type -> cashu.v1.MeltRequest
+	27, // 54: cashu.v1.MintService.Swap:input_type -> cashu.v1.SwapRequest
+	39, // 55: cashu.v1.MintService.CheckState:input_type -> cashu.v1.CheckStateRequest
+	42, // 56: cashu.v1.MintService.Restore:input_type -> cashu.v1.RestoreRequest
+	44, // 57: cashu.v1.MintService.Subscribe:input_type -> cashu.v1.SubscribeRequest
+	6,  // 58: cashu.v1.MintService.Info:output_type -> cashu.v1.MintInfo
+	20, // 59: cashu.v1.MintService.Keys:output_type -> cashu.v1.KeysResponse
+	20, // 60: cashu.v1.MintService.KeysById:output_type -> cashu.v1.KeysResponse
+	22, // 61: cashu.v1.MintService.Keysets:output_type -> cashu.v1.KeysetsResponse
+	30, // 62: cashu.v1.MintService.MintQuote:output_type -> cashu.v1.MintQuoteResponse
+	30, // 63: cashu.v1.MintService.MintQuoteState:output_type -> cashu.v1.MintQuoteResponse
+	32, // 64: cashu.v1.MintService.Mint:output_type -> cashu.v1.MintResponse
+	37, // 65: cashu.v1.MintService.MeltQuote:output_type -> cashu.v1.MeltQuoteResponse
+	37, // 66: cashu.v1.MintService.MeltQuoteState:output_type -> cashu.v1.MeltQuoteResponse
+	37, // 67: cashu.v1.MintService.Melt:output_type -> cashu.v1.MeltQuoteResponse
+	28, // 68: cashu.v1.MintService.Swap:output_type -> cashu.v1.SwapResponse
+	40, // 69: cashu.v1.MintService.CheckState:output_type -> cashu.v1.CheckStateResponse
+	43, // 70: cashu.v1.MintService.Restore:output_type -> cashu.v1.RestoreResponse
+	45, // 71: cashu.v1.MintService.Subscribe:output_type -> cashu.v1.Notification
+	58, // [58:72] is the sub-list for method output_type
+	44, // [44:58] is the sub-list for method input_type
+	44, // [44:44] is the sub-list for extension type_name
+	44, // [44:44] is the sub-list for extension extendee
+	0,  // [0:44] is the sub-list for field type_name
+}
+
+func init() { file_mint_proto_init() }
+func file_mint_proto_init() {
+	if File_mint_proto != nil {
+		return
+	}
+	if !protoimpl.UnsafeEnabled {
+		file_mint_proto_msgTypes[0].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Empty); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[1].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysetRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[2].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*QuoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[3].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[4].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ContactInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[5].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Limits); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[6].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nuts); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[7].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*NutSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[8].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MethodSetting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[9].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MethodOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[10].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Supported); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[11].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nut10Setting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[12].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nut17Setting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[13].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscriptionMethod); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[14].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Nut19Setting); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[15].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CachedEndpoint); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[16].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Keyset); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[17].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[18].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysetInfo); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[19].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*KeysetsResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[20].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlindedMessage); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[21].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*BlindedSignature); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[22].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Proof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[23].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*DLEQProof); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[24].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[25].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SwapResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[26].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintQuoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[27].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintQuoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[28].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[29].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MintResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[30].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MeltQuoteRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[31].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MeltOptions); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[32].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MppOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[33].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*AmountlessOption); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[34].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MeltQuoteResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[35].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*MeltRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[36].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckStateRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[37].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*CheckStateResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[38].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*ProofState); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[39].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[40].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*RestoreResponse); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[41].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*SubscribeRequest); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+		file_mint_proto_msgTypes[42].Exporter = func(v interface{}, i int) interface{} {
+			switch v := v.(*Notification); i {
+			case 0:
+				return &v.state
+			case 1:
+				return &v.sizeCache
+			case 2:
+				return &v.unknownFields
+			default:
+				return nil
+			}
+		}
+	}
+	file_mint_proto_msgTypes[42].OneofWrappers = []interface{}{
+		(*Notification_MintQuote)(nil),
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: file_mint_proto_rawDesc,
+			NumEnums:      3,
+			NumMessages:   44,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_mint_proto_goTypes,
+		DependencyIndexes: file_mint_proto_depIdxs,
+		EnumInfos:         file_mint_proto_enumTypes,
+		MessageInfos:      file_mint_proto_msgTypes,
+	}.Build()
+	File_mint_proto = out.File
+	file_mint_proto_rawDesc = nil
+	file_mint_proto_goTypes = nil
+	file_mint_proto_depIdxs = nil
+}
@@ -0,0 +1,644 @@
+// Code generated by protoc-gen-go-grpc. DO NOT EDIT.
+// versions:
+// - protoc-gen-go-grpc v1.4.0
+// - protoc             (unknown)
+// source: mint.proto
+
+package mintrpc
+
+import (
+	context "context"
+	grpc "google.golang.org/grpc"
+	codes "google.golang.org/grpc/codes"
+	status "google.golang.org/grpc/status"
+)
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+// Requires gRPC-Go v1.62.0 or later.
+const _ = grpc.SupportPackageIsVersion8
+
+const (
+	MintService_Info_FullMethodName           = "/cashu.v1.MintService/Info"
+	MintService_Keys_FullMethodName           = "/cashu.v1.MintService/Keys"
+	MintService_KeysById_FullMethodName       = "/cashu.v1.MintService/KeysById"
+	MintService_Keysets_FullMethodName        = "/cashu.v1.MintService/Keysets"
+	MintService_MintQuote_FullMethodName      = "/cashu.v1.MintService/MintQuote"
+	MintService_MintQuoteState_FullMethodName = "/cashu.v1.MintService/MintQuoteState"
+	MintService_Mint_FullMethodName           = "/cashu.v1.MintService/Mint"
+	MintService_MeltQuote_FullMethodName      = "/cashu.v1.MintService/MeltQuote"
+	MintService_MeltQuoteState_FullMethodName = "/cashu.v1.MintService/MeltQuoteState"
+	MintService_Melt_FullMethodName           = "/cashu.v1.MintService/Melt"
+	MintService_Swap_FullMethodName           = "/cashu.v1.MintService/Swap"
+	MintService_CheckState_FullMethodName     = "/cashu.v1.MintService/CheckState"
+	MintService_Restore_FullMethodName        = "/cashu.v1.MintService/Restore"
+	MintService_Subscribe_FullMethodName      = "/cashu.v1.MintService/Subscribe"
+)
+
+// MintServiceClient is the client API for MintService service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://pkg.go.dev/google.golang.org/grpc/?tab=doc#ClientConn.NewStream.
+//
+// MintService mirrors the REST endpoints of the mint.
+// Errors from the mint are returned with code InvalidArgument
+// and the JSON encoded cashu error as the message.
+type MintServiceClient interface {
+	Info(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MintInfo, error)
+	Keys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeysResponse, error)
+	KeysById(ctx context.Context, in *KeysetRequest, opts ...grpc.CallOption) (*KeysResponse, error)
+	Keysets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeysetsResponse, error)
+	MintQuote(ctx context.Context, in *MintQuoteRequest, opts ...grpc.CallOption) (*MintQuoteResponse, error)
+	MintQuoteState(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*MintQuoteResponse, error)
+	Mint(ctx context.Context, in *MintRequest, opts ...grpc.CallOption) (*MintResponse, error)
+	MeltQuote(ctx context.Context, in *MeltQuoteRequest, opts ...grpc.CallOption) (*MeltQuoteResponse, error)
+	MeltQuoteState(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*MeltQuoteResponse, error)
+	Melt(ctx context.Context, in *MeltRequest, opts ...grpc.CallOption) (*MeltQuoteResponse, error)
+	Swap(ctx context.Context, in *SwapRequest, opts ...grpc.CallOption) (*SwapResponse, error)
+	CheckState(ctx context.Context, in *CheckStateRequest, opts ...grpc.CallOption) (*CheckStateResponse, error)
+	Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error)
+	// Subscribe sends the current state of the quotes in the filters
+	// and then a notification every time one of them changes
+	Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (MintService_SubscribeClient, error)
+}
+
+type mintServiceClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewMintServiceClient(cc grpc.ClientConnInterface) MintServiceClient {
+	return &mintServiceClient{cc}
+}
+
+func (c *mintServiceClient) Info(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*MintInfo, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MintInfo)
+	err := c.cc.Invoke(ctx, MintService_Info_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Keys(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeysResponse)
+	err := c.cc.Invoke(ctx, MintService_Keys_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) KeysById(ctx context.Context, in *KeysetRequest, opts ...grpc.CallOption) (*KeysResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeysResponse)
+	err := c.cc.Invoke(ctx, MintService_KeysById_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Keysets(ctx context.Context, in *Empty, opts ...grpc.CallOption) (*KeysetsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeysetsResponse)
+	err := c.cc.Invoke(ctx, MintService_Keysets_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) MintQuote(ctx context.Context, in *MintQuoteRequest, opts ...grpc.CallOption) (*MintQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MintQuoteResponse)
+	err := c.cc.Invoke(ctx, MintService_MintQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) MintQuoteState(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*MintQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MintQuoteResponse)
+	err := c.cc.Invoke(ctx, MintService_MintQuoteState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Mint(ctx context.Context, in *MintRequest, opts ...grpc.CallOption) (*MintResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MintResponse)
+	err := c.cc.Invoke(ctx, MintService_Mint_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) MeltQuote(ctx context.Context, in *MeltQuoteRequest, opts ...grpc.CallOption) (*MeltQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeltQuoteResponse)
+	err := c.cc.Invoke(ctx, MintService_MeltQuote_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) MeltQuoteState(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*MeltQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeltQuoteResponse)
+	err := c.cc.Invoke(ctx, MintService_MeltQuoteState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Melt(ctx context.Context, in *MeltRequest, opts ...grpc.CallOption) (*MeltQuoteResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(MeltQuoteResponse)
+	err := c.cc.Invoke(ctx, MintService_Melt_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Swap(ctx context.Context, in *SwapRequest, opts ...grpc.CallOption) (*SwapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SwapResponse)
+	err := c.cc.Invoke(ctx, MintService_Swap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) CheckState(ctx context.Context, in *CheckStateRequest, opts ...grpc.CallOption) (*CheckStateResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CheckStateResponse)
+	err := c.cc.Invoke(ctx, MintService_CheckState_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Restore(ctx context.Context, in *RestoreRequest, opts ...grpc.CallOption) (*RestoreResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RestoreResponse)
+	err := c.cc.Invoke(ctx, MintService_Restore_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mintServiceClient) Subscribe(ctx context.Context, in *SubscribeRequest, opts ...grpc.CallOption) (MintService_SubscribeClient, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MintService_ServiceDesc.Streams[0], MintService_Subscribe_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &mintServiceSubscribeClient{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type MintService_SubscribeClient interface {
+	Recv() (*Notification, error)
+	grpc.ClientStream
+}
+
+type mintServiceSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *mintServiceSubscribeClient) Recv() (*Notification, error) {
+	m := new(Notification)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// MintServiceServer is the server API for MintService service.
+// All implementations must embed UnimplementedMintServiceServer
+// for forward compatibility
+//
+// MintService mirrors the REST endpoints of the mint.
+// Errors from the mint are returned with code InvalidArgument
+// and the JSON encoded cashu error as the message.
+type MintServiceServer interface {
+	Info(context.Context, *Empty) (*MintInfo, error)
+	Keys(context.Context, *Empty) (*KeysResponse, error)
+	KeysById(context.Context, *KeysetRequest) (*KeysResponse, error)
+	Keysets(context.Context, *Empty) (*KeysetsResponse, error)
+	MintQuote(context.Context, *MintQuoteRequest) (*MintQuoteResponse, error)
+	MintQuoteState(context.Context, *QuoteRequest) (*MintQuoteResponse, error)
+	Mint(context.Context, *MintRequest) (*MintResponse, error)
+	MeltQuote(context.Context, *MeltQuoteRequest) (*MeltQuoteResponse, error)
+	MeltQuoteState(context.Context, *QuoteRequest) (*MeltQuoteResponse, error)
+	Melt(context.Context, *MeltRequest) (*MeltQuoteResponse, error)
+	Swap(context.Context, *SwapRequest) (*SwapResponse, error)
+	CheckState(context.Context, *CheckStateRequest) (*CheckStateResponse, error)
+	Restore(context.Context, *RestoreRequest) (*RestoreResponse, error)
+	// Subscribe sends the current state of the quotes in the filters
+	// and then a notification every time one of them changes
+	Subscribe(*SubscribeRequest, MintService_SubscribeServer) error
+	mustEmbedUnimplementedMintServiceServer()
+}
+
+// UnimplementedMintServiceServer must be embedded to have forward compatible implementations.
+type UnimplementedMintServiceServer struct {
+}
+
+func (UnimplementedMintServiceServer) Info(context.Context, *Empty) (*MintInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Info not implemented")
+}
+func (UnimplementedMintServiceServer) Keys(context.Context, *Empty) (*KeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keys not implemented")
+}
+func (UnimplementedMintServiceServer) KeysById(context.Context, *KeysetRequest) (*KeysResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method KeysById not implemented")
+}
+func (UnimplementedMintServiceServer) Keysets(context.Context, *Empty) (*KeysetsResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Keysets not implemented")
+}
+func (UnimplementedMintServiceServer) MintQuote(context.Context, *MintQuoteRequest) (*MintQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MintQuote not implemented")
+}
+func (UnimplementedMintServiceServer) MintQuoteState(context.Context, *QuoteRequest) (*MintQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MintQuoteState not implemented")
+}
+func (UnimplementedMintServiceServer) Mint(context.Context, *MintRequest) (*MintResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Mint not implemented")
+}
+func (UnimplementedMintServiceServer) MeltQuote(context.Context, *MeltQuoteRequest) (*MeltQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MeltQuote not implemented")
+}
+func (UnimplementedMintServiceServer) MeltQuoteState(context.Context, *QuoteRequest) (*MeltQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method MeltQuoteState not implemented")
+}
+func (UnimplementedMintServiceServer) Melt(context.Context, *MeltRequest) (*MeltQuoteResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Melt not implemented")
+}
+func (UnimplementedMintServiceServer) Swap(context.Context, *SwapRequest) (*SwapResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Swap not implemented")
+}
+func (UnimplementedMintServiceServer) CheckState(context.Context, *CheckStateRequest) (*CheckStateResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CheckState not implemented")
+}
+func (UnimplementedMintServiceServer) Restore(context.Context, *RestoreRequest) (*RestoreResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Restore not implemented")
+}
+func (UnimplementedMintServiceServer) Subscribe(*SubscribeRequest, MintService_SubscribeServer) error {
+	return status.Errorf(codes.Unimplemented, "method Subscribe not implemented")
+}
+func (UnimplementedMintServiceServer) mustEmbedUnimplementedMintServiceServer() {}
+
+// UnsafeMintServiceServer may be embedded to opt out of forward compatibility for this service.
+// Use of this interface is not recommended, as added methods to MintServiceServer will
+// result in compilation errors.
+type UnsafeMintServiceServer interface {
+	mustEmbedUnimplementedMintServiceServer()
+}
+
+func RegisterMintServiceServer(s grpc.ServiceRegistrar, srv MintServiceServer) {
+	s.RegisterService(&MintService_ServiceDesc, srv)
+}
+
+func _MintService_Info_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Info(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Info_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Info(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Keys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Keys(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Keys_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Keys(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_KeysById_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(KeysetRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).KeysById(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_KeysById_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).KeysById(ctx, req.(*KeysetRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Keysets_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Keysets(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Keysets_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Keysets(ctx, req.(*Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_MintQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).MintQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_MintQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).MintQuote(ctx, req.(*MintQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_MintQuoteState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).MintQuoteState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_MintQuoteState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).MintQuoteState(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Mint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MintRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Mint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Mint_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Mint(ctx, req.(*MintRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_MeltQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeltQuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).MeltQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_MeltQuote_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).MeltQuote(ctx, req.(*MeltQuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_MeltQuoteState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).MeltQuoteState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_MeltQuoteState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).MeltQuoteState(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Melt_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(MeltRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Melt(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Melt_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Melt(ctx, req.(*MeltRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Swap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Swap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Swap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Swap(ctx, req.(*SwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_CheckState_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CheckStateRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).CheckState(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_CheckState_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).CheckState(ctx, req.(*CheckStateRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Restore_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RestoreRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MintServiceServer).Restore(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MintService_Restore_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MintServiceServer).Restore(ctx, req.(*RestoreRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MintService_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MintServiceServer).Subscribe(m, &mintServiceSubscribeServer{ServerStream: stream})
+}
+
+type MintService_SubscribeServer interface {
+	Send(*Notification) error
+	grpc.ServerStream
+}
+
+type mintServiceSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *mintServiceSubscribeServer) Send(m *Notification) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+// MintService_ServiceDesc is the grpc.ServiceDesc for MintService service.
+// It's only intended for direct use with grpc.RegisterService,
+// and not to be introspected or modified (even as a copy)
+var MintService_ServiceDesc = grpc.ServiceDesc{
+	ServiceName: "cashu.v1.MintService",
+	HandlerType: (*MintServiceServer)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Info",
+			Handler:    _MintService_Info_Handler,
+		},
+		{
+			MethodName: "Keys",
+			Handler:    _MintService_Keys_Handler,
+		},
+		{
+			MethodName: "KeysById",
+			Handler:    _MintService_KeysById_Handler,
+		},
+		{
+			MethodName: "Keysets",
+			Handler:    _MintService_Keysets_Handler,
+		},
+		{
+			MethodName: "MintQuote",
+			Handler:    _MintService_MintQuote_Handler,
+		},
+		{
+			MethodName: "MintQuoteState",
+			Handler:    _MintService_MintQuoteState_Handler,
+		},
+		{
+			MethodName: "Mint",
+			Handler:    _MintService_Mint_Handler,
+		},
+		{
+			MethodName: "MeltQuote",
+			Handler:    _MintService_MeltQuote_Handler,
+		},
+		{
+			MethodName: "MeltQuoteState",
+			Handler:    _MintService_MeltQuoteState_Handler,
+		},
+		{
+			MethodName: "Melt",
+			Handler:    _MintService_Melt_Handler,
+		},
+		{
+			MethodName: "Swap",
+			Handler:    _MintService_Swap_Handler,
+		},
+		{
+			MethodName: "CheckState",
+			Handler:    _MintService_CheckState_Handler,
+		},
+		{
+			MethodName: "Restore",
+			Handler:    _MintService_Restore_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "Subscribe",
+			Handler:       _MintService_Subscribe_Handler,
+			ServerStreams: true,
+		},
+	},
+	Metadata: "mint.proto",
+}
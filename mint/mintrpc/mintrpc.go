@@ -0,0 +1,69 @@
+// Package mintrpc has the gRPC service of the mint defined in mint.proto.
+//
+// The service mirrors the REST endpoints of the mint. The functions in
+// convert.go convert its messages to and from the request and response
+// types of the nuts packages used by the mint and the wallet.
+package mintrpc
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative --go-grpc_out=. --go-grpc_opt=paths=source_relative mint.proto
+
+import (
+	"encoding/json"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// names of the methods of the service
+const (
+	InfoMethod           = "Info"
+	KeysMethod           = "Keys"
+	KeysByIdMethod       = "KeysById"
+	KeysetsMethod        = "Keysets"
+	MintQuoteMethod      = "MintQuote"
+	MintQuoteStateMethod = "MintQuoteState"
+	MintMethod           = "Mint"
+	MeltQuoteMethod      = "MeltQuote"
+	MeltQuoteStateMethod = "MeltQuoteState"
+	MeltMethod           = "Melt"
+	SwapMethod           = "Swap"
+	CheckStateMethod     = "CheckState"
+	RestoreMethod        = "Restore"
+	SubscribeMethod      = "Subscribe"
+)
+
+// ToStatus returns cashu errors as a status with code InvalidArgument
+// and the json encoded error as message. Other errors are returned as Internal.
+func ToStatus(err error) error {
+	var cashuErr cashu.Error
+	switch e := err.(type) {
+	case *cashu.Error:
+		cashuErr = *e
+	case cashu.Error:
+		cashuErr = e
+	default:
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Error(codes.Internal, err.Error())
+	}
+
+	jsonErr, _ := json.Marshal(cashuErr)
+	return status.Error(codes.InvalidArgument, string(jsonErr))
+}
+
+// FromStatus returns the cashu error encoded in the status by ToStatus.
+// Other errors are returned unchanged.
+func FromStatus(err error) error {
+	st, ok := status.FromError(err)
+	if !ok || st.Code() != codes.InvalidArgument {
+		return err
+	}
+
+	var cashuErr cashu.Error
+	if jsonErr := json.Unmarshal([]byte(st.Message()), &cashuErr); jsonErr != nil || cashuErr.Code == 0 {
+		return err
+	}
+	return cashuErr
+}
@@ -28,9 +28,30 @@ func (m *Message) Payload() []byte {
 
 type Subscribers map[string]*Subscriber
 
+// ReconnectedTopic is the topic of the messages sent by a transport after it
+// reconnects. Messages published by other instances while it was
+// disconnected are lost, so subscribers should reload any state they keep in sync.
+const ReconnectedTopic = "pubsub_reconnected"
+
+// Transport distributes the messages between instances of the mint
+// so that subscribers receive the messages published in any of them
+type Transport interface {
+	// Publish sends the message to the other instances.
+	// It should not block waiting for the message to be sent
+	Publish(msg *Message) error
+	// Messages returns the messages published by the other instances
+	// and a message on ReconnectedTopic after every reconnection.
+	// The channel is closed when the transport is closed
+	Messages() <-chan *Message
+	Close() error
+}
+
 type PubSub struct {
 	topics map[string]Subscribers
 	mu     sync.RWMutex
+
+	transport Transport
+	onError   func(error)
 }
 
 func NewPubSub() *PubSub {
@@ -39,6 +60,25 @@ func NewPubSub() *PubSub {
 	}
 }
 
+// NewPubSubWithTransport creates a PubSub that also sends the published messages
+// to other instances through the transport and delivers the messages received from them.
+// onError is called if a message could not be sent.
+func NewPubSubWithTransport(transport Transport, onError func(error)) *PubSub {
+	b := &PubSub{
+		topics:    make(map[string]Subscribers),
+		transport: transport,
+		onError:   onError,
+	}
+
+	go func() {
+		for msg := range transport.Messages() {
+			b.deliver(msg.topic, msg.payload)
+		}
+	}()
+
+	return b
+}
+
 func (b *PubSub) Subscribe(topic string) *Subscriber {
 	b.mu.Lock()
 	if b.topics[topic] == nil {
@@ -58,13 +98,33 @@ func (b *PubSub) Unsubscribe(s *Subscriber, topic string) {
 }
 
 func (b *PubSub) Publish(topic string, msg []byte) {
-	b.mu.Lock()
-	topicSubscribers := b.topics[topic]
-	b.mu.Unlock()
+	b.deliver(topic, msg)
+	if b.transport != nil {
+		if err := b.transport.Publish(NewMessage(msg, topic)); err != nil && b.onError != nil {
+			b.onError(err)
+		}
+	}
+}
+
+// Close closes the transport if there is one
+func (b *PubSub) Close() error {
+	if b.transport != nil {
+		return b.transport.Close()
+	}
+	return nil
+}
+
+// deliver sends the message to the subscribers in this instance
+func (b *PubSub) deliver(topic string, msg []byte) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
 
-	for _, s := range topicSubscribers {
+	for _, s := range b.topics[topic] {
 		m := NewMessage(msg, topic)
-		if !s.active {
+		s.mu.RLock()
+		active := s.active
+		s.mu.RUnlock()
+		if !active {
 			continue
 		}
 
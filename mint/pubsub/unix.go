@@ -0,0 +1,310 @@
+package pubsub
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"net"
+	"os"
+	"sync"
+	"time"
+)
+
+const (
+	// max size of a message sent through the broker
+	maxFrameSize = 1 << 20
+	// max time to write a message to a connection
+	writeTimeout = 5 * time.Second
+	// max number of messages waiting to be sent to the broker
+	sendQueueSize = 256
+	// max time between attempts to reconnect to the broker
+	maxReconnectBackoff = 5 * time.Second
+)
+
+// frame is how messages are encoded on the socket, one per line
+type frame struct {
+	Topic   string `json:"topic"`
+	Payload []byte `json:"payload"`
+}
+
+type brokerConn struct {
+	conn net.Conn
+	mu   sync.Mutex
+}
+
+func (c *brokerConn) write(line []byte) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+	_, err := c.conn.Write(line)
+	return err
+}
+
+// Broker relays the messages published by each of the connected
+// UnixTransports to all the other ones. It listens on a unix socket
+// so it only works for mint instances running on the same host.
+type Broker struct {
+	listener net.Listener
+	mu       sync.Mutex
+	conns    map[*brokerConn]struct{}
+}
+
+// NewBroker listens on the socket path. A socket left
+// in the path by a previous broker is removed.
+func NewBroker(socketPath string) (*Broker, error) {
+	if _, err := os.Stat(socketPath); err == nil {
+		if conn, err := net.Dial("unix", socketPath); err == nil {
+			conn.Close()
+			return nil, errors.New("a broker is already running on " + socketPath)
+		}
+		if err := os.Remove(socketPath); err != nil {
+			return nil, err
+		}
+	}
+
+	listener, err := net.Listen("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(socketPath, os.ModeSocket|0600); err != nil {
+		listener.Close()
+		return nil, err
+	}
+
+	return &Broker{
+		listener: listener,
+		conns:    make(map[*brokerConn]struct{}),
+	}, nil
+}
+
+// Serve accepts connections until the broker is closed
+func (b *Broker) Serve() error {
+	for {
+		conn, err := b.listener.Accept()
+		if err != nil {
+			if errors.Is(err, net.ErrClosed) {
+				return nil
+			}
+			return err
+		}
+
+		c := &brokerConn{conn: conn}
+		b.mu.Lock()
+		b.conns[c] = struct{}{}
+		b.mu.Unlock()
+		go b.relay(c)
+	}
+}
+
+// relay sends every line read from the connection to the other connections
+func (b *Broker) relay(c *brokerConn) {
+	defer b.remove(c)
+
+	scanner := bufio.NewScanner(c.conn)
+	scanner.Buffer(make([]byte, 4096), maxFrameSize)
+	for scanner.Scan() {
+		line := append(scanner.Bytes(), '\n')
+
+		b.mu.Lock()
+		others := make([]*brokerConn, 0, len(b.conns))
+		for other := range b.conns {
+			if other != c {
+				others = append(others, other)
+			}
+		}
+		b.mu.Unlock()
+
+		for _, other := range others {
+			if err := other.write(line); err != nil {
+				b.remove(other)
+			}
+		}
+	}
+}
+
+func (b *Broker) remove(c *brokerConn) {
+	b.mu.Lock()
+	delete(b.conns, c)
+	b.mu.Unlock()
+	c.conn.Close()
+}
+
+func (b *Broker) Close() error {
+	err := b.listener.Close()
+	b.mu.Lock()
+	for c := range b.conns {
+		c.conn.Close()
+	}
+	b.mu.Unlock()
+	return err
+}
+
+// UnixTransport is a Transport that sends the messages through
+// a Broker on a unix socket. Messages are written to the socket in the background.
+// If the connection to the broker is lost, it reconnects in the background.
+// Messages published while disconnected are not sent.
+type UnixTransport struct {
+	socketPath string
+	messages   chan *Message
+	outgoing   chan []byte
+
+	mu     sync.Mutex
+	conn   net.Conn
+	closed bool
+	done   chan struct{}
+}
+
+// NewUnixTransport connects to the broker listening on the socket path
+func NewUnixTransport(socketPath string) (*UnixTransport, error) {
+	conn, err := net.Dial("unix", socketPath)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &UnixTransport{
+		socketPath: socketPath,
+		messages:   make(chan *Message, 64),
+		outgoing:   make(chan []byte, sendQueueSize),
+		conn:       conn,
+		done:       make(chan struct{}),
+	}
+	go t.run(conn)
+	go t.write()
+	return t, nil
+}
+
+func (t *UnixTransport) Publish(msg *Message) error {
+	line, err := json.Marshal(frame{Topic: msg.topic, Payload: msg.payload})
+	if err != nil {
+		return err
+	}
+	if len(line) >= maxFrameSize {
+		return errors.New("message too large for broker")
+	}
+	line = append(line, '\n')
+
+	t.mu.Lock()
+	connected := t.conn != nil
+	t.mu.Unlock()
+	if !connected {
+		return errors.New("not connected to broker")
+	}
+
+	select {
+	case t.outgoing <- line:
+		return nil
+	case <-t.done:
+		return errors.New("transport is closed")
+	default:
+		return errors.New("queue of messages to the broker is full")
+	}
+}
+
+// write sends the queued messages to the broker until the transport
+// is closed. Messages queued while disconnected are dropped
+func (t *UnixTransport) write() {
+	for {
+		select {
+		case <-t.done:
+			return
+		case line := <-t.outgoing:
+			t.mu.Lock()
+			conn := t.conn
+			t.mu.Unlock()
+			if conn == nil {
+				continue
+			}
+			conn.SetWriteDeadline(time.Now().Add(writeTimeout))
+			if _, err := conn.Write(line); err != nil {
+				conn.Close()
+			}
+		}
+	}
+}
+
+func (t *UnixTransport) Messages() <-chan *Message {
+	return t.messages
+}
+
+func (t *UnixTransport) Close() error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.closed {
+		return nil
+	}
+	t.closed = true
+	close(t.done)
+	if t.conn != nil {
+		return t.conn.Close()
+	}
+	return nil
+}
+
+// run reads the messages from the connection and reconnects
+// when it is lost until the transport is closed
+func (t *UnixTransport) run(conn net.Conn) {
+	defer close(t.messages)
+
+	for {
+		t.read(conn)
+
+		t.mu.Lock()
+		t.conn = nil
+		t.mu.Unlock()
+
+		conn = t.reconnect()
+		if conn == nil {
+			return
+		}
+		select {
+		case t.messages <- NewMessage(nil, ReconnectedTopic):
+		case <-t.done:
+			return
+		}
+	}
+}
+
+func (t *UnixTransport) read(conn net.Conn) {
+	scanner := bufio.NewScanner(conn)
+	scanner.Buffer(make([]byte, 4096), maxFrameSize)
+	for scanner.Scan() {
+		var f frame
+		if err := json.Unmarshal(scanner.Bytes(), &f); err != nil {
+			continue
+		}
+		select {
+		case t.messages <- NewMessage(f.Payload, f.Topic):
+		case <-t.done:
+			return
+		}
+	}
+}
+
+// reconnect dials the broker until it succeeds or the transport is closed.
+// It returns nil if the transport was closed
+func (t *UnixTransport) reconnect() net.Conn {
+	backoff := 100 * time.Millisecond
+	for {
+		select {
+		case <-t.done:
+			return nil
+		case <-time.After(backoff):
+		}
+
+		conn, err := net.Dial("unix", t.socketPath)
+		if err != nil {
+			backoff = min(backoff*2, maxReconnectBackoff)
+			continue
+		}
+
+		t.mu.Lock()
+		if t.closed {
+			t.mu.Unlock()
+			conn.Close()
+			return nil
+		}
+		t.conn = conn
+		t.mu.Unlock()
+		return conn
+	}
+}
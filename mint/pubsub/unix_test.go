@@ -0,0 +1,93 @@
+package pubsub
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestUnixBroker(t *testing.T) {
+	socketPath := filepath.Join(t.TempDir(), "broker.sock")
+	broker, err := NewBroker(socketPath)
+	if err != nil {
+		t.Fatalf("unexpected error starting broker: %v", err)
+	}
+	go broker.Serve()
+	defer broker.Close()
+
+	if _, err := NewBroker(socketPath); err == nil {
+		t.Fatal("expected error starting broker on socket already in use")
+	}
+
+	newInstance := func() *PubSub {
+		transport, err := NewUnixTransport(socketPath)
+		if err != nil {
+			t.Fatalf("unexpected error connecting to broker: %v", err)
+		}
+		// messages published while the broker restarts are not sent
+		instance := NewPubSubWithTransport(transport, func(err error) {})
+		t.Cleanup(func() { instance.Close() })
+		return instance
+	}
+	instance1, instance2, instance3 := newInstance(), newInstance(), newInstance()
+
+	subscribers := []*Subscriber{
+		instance1.Subscribe("topic"),
+		instance2.Subscribe("topic"),
+		instance3.Subscribe("topic"),
+	}
+	otherTopic := instance2.Subscribe("othertopic")
+
+	// wait for broker to accept all the connections
+	time.Sleep(100 * time.Millisecond)
+	instance1.Publish("topic", []byte("message"))
+
+	for i, subscriber := range subscribers {
+		select {
+		case msg := <-subscriber.GetMessages():
+			if string(msg.Payload()) != "message" || msg.Topic() != "topic" {
+				t.Fatalf("unexpected message in instance %v: %v %s", i+1, msg.Topic(), msg.Payload())
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatalf("instance %v did not receive message", i+1)
+		}
+	}
+
+	select {
+	case msg := <-otherTopic.GetMessages():
+		t.Fatalf("unexpected message for other topic: %s", msg.Payload())
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	// transports reconnect if the broker restarts
+	reconnected := instance1.Subscribe(ReconnectedTopic)
+	broker.Close()
+	broker, err = NewBroker(socketPath)
+	if err != nil {
+		t.Fatalf("unexpected error restarting broker: %v", err)
+	}
+	go broker.Serve()
+	defer broker.Close()
+
+	deadline := time.After(5 * time.Second)
+	select {
+	case <-reconnected.GetMessages():
+	case <-deadline:
+		t.Fatal("transport did not notify the reconnection")
+	}
+	for {
+		instance3.Publish("topic", []byte("after restart"))
+		// message is always delivered to the subscriber in the same instance
+		<-subscribers[2].GetMessages()
+		select {
+		case msg := <-subscribers[0].GetMessages():
+			if string(msg.Payload()) != "after restart" {
+				t.Fatalf("unexpected message: %s", msg.Payload())
+			}
+			return
+		case <-time.After(200 * time.Millisecond):
+		case <-deadline:
+			t.Fatal("transport did not reconnect to broker")
+		}
+	}
+}
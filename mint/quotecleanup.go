@@ -0,0 +1,115 @@
+package mint
+
+import (
+	"context"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/mint/lightning"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
+)
+
+const (
+	// max interval between runs of the quote cleanup
+	quoteCleanupInterval = time.Hour
+	// max number of quotes archived in a single db transaction
+	quoteArchiveBatchSize = 500
+)
+
+// runQuoteCleanup periodically removes the expired unpaid quotes
+// until the mint context is canceled. It should be called in a different goroutine.
+// If several instances share the db, only the leader does the cleanup.
+func (m *Mint) runQuoteCleanup() {
+	interval := min(m.quoteRetention, quoteCleanupInterval)
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if m.isLeader(quoteCleanupJob, interval) {
+			if err := m.CleanupExpiredQuotes(); err != nil {
+				m.logErrorf("error cleaning up expired quotes: %v", err)
+			}
+		}
+
+		select {
+		case <-m.ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// CleanupExpiredQuotes deletes the UNPAID mint and melt quotes that expired
+// longer than the retention period ago and adds them to the quote history.
+// Invoices for the deleted mint quotes are canceled if the lightning backend supports it.
+func (m *Mint) CleanupExpiredQuotes() error {
+	expiredBefore := uint64(time.Now().Add(-m.quoteRetention).Unix())
+
+	mintQuotes, err := m.db.GetExpiredMintQuotes(expiredBefore)
+	if err != nil {
+		return err
+	}
+
+	canceler, canCancel := m.lightningClient.(lightning.InvoiceCanceler)
+	mintQuoteIds := make([]string, 0, len(mintQuotes))
+	for _, quote := range mintQuotes {
+		// check with the backend before deleting in case the invoice
+		// got paid while the mint was not watching it
+		mintQuote, err := m.GetMintQuoteState(quote.Id)
+		if err != nil {
+			m.logErrorf("could not check state of expired mint quote '%v': %v", quote.Id, err)
+			continue
+		}
+		if mintQuote.State != nut04.Unpaid {
+			continue
+		}
+
+		if canCancel {
+			ctx, cancel := context.WithTimeout(m.ctx, time.Second*10)
+			if err := canceler.CancelInvoice(ctx, quote.PaymentHash); err != nil {
+				m.logDebugf("could not cancel invoice for expired mint quote '%v': %v", quote.Id, err)
+			}
+			cancel()
+		}
+		mintQuoteIds = append(mintQuoteIds, quote.Id)
+	}
+
+	if err := archiveInBatches(mintQuoteIds, m.db.ArchiveMintQuotes); err != nil {
+		return err
+	}
+
+	meltQuotes, err := m.db.GetExpiredMeltQuotes(expiredBefore)
+	if err != nil {
+		return err
+	}
+	meltQuoteIds := make([]string, len(meltQuotes))
+	for i, quote := range meltQuotes {
+		meltQuoteIds[i] = quote.Id
+	}
+
+	if err := archiveInBatches(meltQuoteIds, m.db.ArchiveMeltQuotes); err != nil {
+		return err
+	}
+
+	if len(mintQuoteIds) > 0 || len(meltQuoteIds) > 0 {
+		m.logInfof("removed %v expired mint quotes and %v expired melt quotes",
+			len(mintQuoteIds), len(meltQuoteIds))
+	}
+
+	return nil
+}
+
+func archiveInBatches(quoteIds []string, archive func([]string) error) error {
+	for start := 0; start < len(quoteIds); start += quoteArchiveBatchSize {
+		end := min(start+quoteArchiveBatchSize, len(quoteIds))
+		if err := archive(quoteIds[start:end]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// QuoteHistory returns the aggregated history of the expired quotes removed from the db
+func (m *Mint) QuoteHistory() ([]storage.QuoteHistory, error) {
+	return m.db.GetQuoteHistory()
+}
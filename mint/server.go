@@ -23,11 +23,16 @@ import (
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
 	"github.com/gorilla/mux"
 )
 
 type ServerConfig struct {
 	Port int
+	// if set, the gRPC API is served on this port alongside the REST API
+	GrpcPort int
+	// max time to wait on shutdown for in-flight melts and requests
+	ShutdownTimeout time.Duration
 	// NOTE: using this value for testing
 	MeltTimeout *time.Duration
 }
@@ -109,7 +114,9 @@ type MintServer struct {
 	httpServer       *http.Server
 	mint             *Mint
 	websocketManager *WebsocketManager
+	grpcServer       *GrpcServer
 	cache            *Cache
+	shutdownTimeout  time.Duration
 
 	// NOTE: using this value for testing
 	meltTimeout *time.Duration
@@ -123,12 +130,33 @@ func SetupMintServer(m *Mint, config ServerConfig) *MintServer {
 		websocketManager: websocketManager,
 		meltTimeout:      config.MeltTimeout,
 		cache:            NewCache(),
+		shutdownTimeout:  config.ShutdownTimeout,
+	}
+	if mintServer.shutdownTimeout <= 0 {
+		mintServer.shutdownTimeout = defaultShutdownTimeout
+	}
+	if config.GrpcPort > 0 {
+		mintServer.grpcServer = NewGrpcServer(m, config.GrpcPort, config.MeltTimeout)
 	}
 	mintServer.setupHttpServer(config.Port)
 	return mintServer
 }
 
 func (ms *MintServer) Start() error {
+	ms.startBackground()
+
+	ms.mint.logger.Info("mint server listening on: " + ms.httpServer.Addr)
+	err := ms.httpServer.ListenAndServe()
+	if err != nil && err != http.ErrServerClosed {
+		return err
+	} else if err == http.ErrServerClosed {
+		ms.mint.logger.Info("shutdown complete")
+	}
+	return nil
+}
+
+// startBackground starts the cache cleanup and the gRPC server if enabled
+func (ms *MintServer) startBackground() {
 	// background goroutine to cleanup cache every 30s
 	go func() {
 		for {
@@ -144,7 +172,7 @@ func (ms *MintServer) Start() error {
 					}
 
 					if len(activeKeysetCache.Keysets) > 0 {
-						if ms.mint.activeKeyset.Id != activeKeysetCache.Keysets[0].Id {
+						if ms.mint.getActiveKeyset().Id != activeKeysetCache.Keysets[0].Id {
 							delete(ms.cache.items, ACTIVE_KEYSET)
 						}
 					}
@@ -157,28 +185,37 @@ func (ms *MintServer) Start() error {
 		}
 	}()
 
-	ms.mint.logger.Info("mint server listening on: " + ms.httpServer.Addr)
-	err := ms.httpServer.ListenAndServe()
-	if err != nil && err != http.ErrServerClosed {
-		return err
-	} else if err == http.ErrServerClosed {
-		ms.mint.logger.Info("shutdown complete")
+	if ms.grpcServer != nil {
+		go func() {
+			if err := ms.grpcServer.Start(); err != nil {
+				ms.mint.logErrorf("error running gRPC server: %v", err)
+			}
+		}()
 	}
-	return nil
 }
 
+// Shutdown stops accepting new quotes and melts, waits for the in-flight
+// melts to resolve, closes the websocket connections, the gRPC and http servers
+// and finally shuts down the mint. Melts that did not resolve within the
+// shutdown timeout are left pending and checked on the next start.
 func (ms *MintServer) Shutdown() error {
 	ms.mint.logger.Info("starting shutdown")
-	if err := ms.mint.Shutdown(); err != nil {
-		return err
+	ctx, cancel := context.WithTimeout(context.Background(), ms.shutdownTimeout)
+	defer cancel()
+
+	if err := ms.mint.Drain(ctx); err != nil {
+		ms.mint.logErrorf("error draining mint: %v", err)
 	}
 	if err := ms.websocketManager.Shutdown(); err != nil {
 		return err
 	}
-	if err := ms.httpServer.Shutdown(context.Background()); err != nil {
-		return err
+	if ms.grpcServer != nil {
+		ms.grpcServer.Shutdown(ctx)
 	}
-	return nil
+	if err := ms.httpServer.Shutdown(ctx); err != nil {
+		ms.mint.logErrorf("error shutting down http server: %v", err)
+	}
+	return ms.mint.Shutdown()
 }
 
 func (ms *MintServer) setupHttpServer(port int) {
@@ -200,6 +237,9 @@ func (ms *MintServer) setupHttpServer(port int) {
 	r.HandleFunc("/v1/ws", ms.websocketManager.serveWS).Methods(http.MethodGet, http.MethodOptions)
 
 	r.Use(setupHeaders)
+	if ms.mint.limits.MaxRequestBytes > 0 {
+		r.Use(limitRequestBody(ms.mint.limits.MaxRequestBytes))
+	}
 
 	server := &http.Server{
 		Addr:    ":" + strconv.Itoa(port),
@@ -225,6 +265,16 @@ func setupHeaders(next http.Handler) http.Handler {
 	})
 }
 
+// limitRequestBody returns an error when reading more than maxBytes from the request body
+func limitRequestBody(maxBytes int64) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+			req.Body = http.MaxBytesReader(rw, req.Body, maxBytes)
+			next.ServeHTTP(rw, req)
+		})
+	}
+}
+
 func (ms *MintServer) logRequest(req *http.Request, statusCode int, format string, args ...any) {
 	// this is done to preserve the source position in the log msg from where this
 	// method is called. Otherwise all messages would be logged with
@@ -363,19 +413,9 @@ func (ms *MintServer) mintRequest(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	mintQuoteResponse := nut04.PostMintQuoteBolt11Response{
-		Quote:   mintQuote.Id,
-		Request: mintQuote.PaymentRequest,
-		Amount:  mintQuote.Amount,
-		Unit:    cashu.Sat.String(),
-		State:   mintQuote.State,
-		Expiry:  mintQuote.Expiry,
-	}
-	if mintQuote.Pubkey != nil {
-		mintQuoteResponse.Pubkey = hex.EncodeToString(mintQuote.Pubkey.SerializeCompressed())
-	}
+	mintQuoteRes := mintQuoteResponse(mintQuote)
 
-	jsonRes, err := json.Marshal(&mintQuoteResponse)
+	jsonRes, err := json.Marshal(&mintQuoteRes)
 	if err != nil {
 		ms.writeErr(rw, req, cashu.StandardErr)
 		return
@@ -410,17 +450,7 @@ func (ms *MintServer) mintQuoteState(rw http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	mintQuoteStateResponse := nut04.PostMintQuoteBolt11Response{
-		Quote:   mintQuote.Id,
-		Request: mintQuote.PaymentRequest,
-		Amount:  mintQuote.Amount,
-		Unit:    cashu.Sat.String(),
-		State:   mintQuote.State,
-		Expiry:  mintQuote.Expiry,
-	}
-	if mintQuote.Pubkey != nil {
-		mintQuoteStateResponse.Pubkey = hex.EncodeToString(mintQuote.Pubkey.SerializeCompressed())
-	}
+	mintQuoteStateResponse := mintQuoteResponse(mintQuote)
 
 	jsonRes, err := json.Marshal(&mintQuoteStateResponse)
 	if err != nil {
@@ -442,6 +472,11 @@ func (ms *MintServer) mintTokensRequest(rw http.ResponseWriter, req *http.Reques
 
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ms.writeErr(rw, req, cashu.RequestBodyTooLargeErr)
+			return
+		}
 		ms.writeErr(rw, req, cashu.StandardErr)
 		return
 	}
@@ -497,6 +532,11 @@ func (ms *MintServer) mintTokensRequest(rw http.ResponseWriter, req *http.Reques
 func (ms *MintServer) swapRequest(rw http.ResponseWriter, req *http.Request) {
 	body, err := io.ReadAll(req.Body)
 	if err != nil {
+		var maxBytesErr *http.MaxBytesError
+		if errors.As(err, &maxBytesErr) {
+			ms.writeErr(rw, req, cashu.RequestBodyTooLargeErr)
+			return
+		}
 		ms.writeErr(rw, req, cashu.StandardErr)
 		return
 	}
@@ -575,17 +615,9 @@ func (ms *MintServer) meltQuoteRequest(rw http.ResponseWriter, req *http.Request
 		return
 	}
 
-	meltQuoteResponse := &nut05.PostMeltQuoteBolt11Response{
-		Quote:      meltQuote.Id,
-		Request:    meltQuote.InvoiceRequest,
-		Amount:     meltQuote.Amount,
-		Unit:       cashu.Sat.String(),
-		FeeReserve: meltQuote.FeeReserve,
-		State:      meltQuote.State,
-		Expiry:     meltQuote.Expiry,
-	}
+	meltQuoteRes := meltQuoteResponse(meltQuote)
 
-	jsonRes, err := json.Marshal(&meltQuoteResponse)
+	jsonRes, err := json.Marshal(&meltQuoteRes)
 	if err != nil {
 		ms.writeErr(rw, req, cashu.StandardErr)
 		return
@@ -624,16 +656,7 @@ func (ms *MintServer) meltQuoteState(rw http.ResponseWriter, req *http.Request)
 		return
 	}
 
-	quoteState := &nut05.PostMeltQuoteBolt11Response{
-		Quote:      meltQuote.Id,
-		Request:    meltQuote.InvoiceRequest,
-		Amount:     meltQuote.Amount,
-		Unit:       cashu.Sat.String(),
-		FeeReserve: meltQuote.FeeReserve,
-		State:      meltQuote.State,
-		Expiry:     meltQuote.Expiry,
-		Preimage:   meltQuote.Preimage,
-	}
+	quoteState := meltQuoteResponse(meltQuote)
 
 	jsonRes, err := json.Marshal(&quoteState)
 	if err != nil {
@@ -686,18 +709,9 @@ func (ms *MintServer) meltTokens(rw http.ResponseWriter, req *http.Request) {
 		return
 	}
 
-	meltQuoteResponse := &nut05.PostMeltQuoteBolt11Response{
-		Quote:      meltQuote.Id,
-		Request:    meltQuote.InvoiceRequest,
-		Amount:     meltQuote.Amount,
-		Unit:       cashu.Sat.String(),
-		FeeReserve: meltQuote.FeeReserve,
-		State:      meltQuote.State,
-		Expiry:     meltQuote.Expiry,
-		Preimage:   meltQuote.Preimage,
-	}
+	meltQuoteRes := meltQuoteResponse(meltQuote)
 
-	jsonRes, err := json.Marshal(&meltQuoteResponse)
+	jsonRes, err := json.Marshal(&meltQuoteRes)
 	if err != nil {
 		ms.writeErr(rw, req, cashu.StandardErr)
 		return
@@ -709,6 +723,35 @@ func (ms *MintServer) meltTokens(rw http.ResponseWriter, req *http.Request) {
 	rw.Write(jsonRes)
 }
 
+func mintQuoteResponse(mintQuote storage.MintQuote) nut04.PostMintQuoteBolt11Response {
+	response := nut04.PostMintQuoteBolt11Response{
+		Quote:   mintQuote.Id,
+		Request: mintQuote.PaymentRequest,
+		Amount:  mintQuote.Amount,
+		Unit:    cashu.Sat.String(),
+		State:   mintQuote.State,
+		Expiry:  mintQuote.Expiry,
+	}
+	if mintQuote.Pubkey != nil {
+		response.Pubkey = hex.EncodeToString(mintQuote.Pubkey.SerializeCompressed())
+	}
+	return response
+}
+
+func meltQuoteResponse(meltQuote storage.MeltQuote) nut05.PostMeltQuoteBolt11Response {
+	return nut05.PostMeltQuoteBolt11Response{
+		Quote:      meltQuote.Id,
+		Request:    meltQuote.InvoiceRequest,
+		Amount:     meltQuote.Amount,
+		Unit:       cashu.Sat.String(),
+		FeeReserve: meltQuote.FeeReserve,
+		State:      meltQuote.State,
+		Expiry:     meltQuote.Expiry,
+		Preimage:   meltQuote.Preimage,
+		Change:     meltQuote.Change,
+	}
+}
+
 func (ms *MintServer) tokenStateCheck(rw http.ResponseWriter, req *http.Request) {
 	var stateRequest nut07.PostCheckStateRequest
 	err := decodeJsonReqBody(req, &stateRequest)
@@ -804,9 +847,13 @@ func decodeJsonReqBody(req *http.Request, dst any) error {
 	if err != nil {
 		var syntaxErr *json.SyntaxError
 		var typeErr *json.UnmarshalTypeError
+		var maxBytesErr *http.MaxBytesError
 		var cashuErr *cashu.Error
 
 		switch {
+		case errors.As(err, &maxBytesErr):
+			return cashu.RequestBodyTooLargeErr
+
 		case errors.As(err, &syntaxErr):
 			msg := fmt.Sprintf("bad json at %d", syntaxErr.Offset)
 			cashuErr = cashu.BuildCashuError(msg, cashu.StandardErrCode)
@@ -2,21 +2,33 @@ package mint
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"io"
 	"log/slog"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"os"
 	"reflect"
 	"sort"
 	"testing"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut01"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut02"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut17"
 	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/mint/lightning"
+	"github.com/Origami74/gonuts-tollgate/mint/mintrpc"
+	"github.com/Origami74/gonuts-tollgate/wallet/client"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/gorilla/mux"
 )
 
@@ -213,3 +225,252 @@ func TestGetKeysetByIdHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestBodyLimit(t *testing.T) {
+	mint := &Mint{
+		limits: MintLimits{MaxRequestBytes: 64},
+		logger: slog.New(slog.NewTextHandler(io.Discard, nil)),
+	}
+	mintServer := MintServer{
+		mint:  mint,
+		cache: NewCache(),
+	}
+	mintServer.setupHttpServer(0)
+
+	body := bytes.Repeat([]byte("a"), 128)
+	req := httptest.NewRequest(http.MethodPost, "/v1/swap", bytes.NewReader(body))
+	w := httptest.NewRecorder()
+	mintServer.httpServer.Handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusBadRequest {
+		t.Fatalf("expected status code %d but got %d", http.StatusBadRequest, w.Code)
+	}
+
+	var errRes cashu.Error
+	if err := json.Unmarshal(w.Body.Bytes(), &errRes); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if errRes != cashu.RequestBodyTooLargeErr {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.RequestBodyTooLargeErr, errRes)
+	}
+
+	// body is only read up to the limit while decoding
+	body = append([]byte(`{"outputs": "`), bytes.Repeat([]byte("a"), 128)...)
+	req = httptest.NewRequest(http.MethodPost, "/v1/restore", bytes.NewReader(body))
+	w = httptest.NewRecorder()
+	mintServer.httpServer.Handler.ServeHTTP(w, req)
+	if err := json.Unmarshal(w.Body.Bytes(), &errRes); err != nil {
+		t.Fatalf("error decoding response: %v", err)
+	}
+	if errRes != cashu.RequestBodyTooLargeErr {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.RequestBodyTooLargeErr, errRes)
+	}
+}
+
+func TestGrpcServer(t *testing.T) {
+	testMintPath := "./testmintgrpc"
+	config := Config{
+		MintPath:        testMintPath,
+		LightningClient: &lightning.FakeBackend{},
+		LogLevel:        Disable,
+		Limits:          MintLimits{MaxOutputs: 2},
+	}
+	defer os.RemoveAll(testMintPath)
+
+	mint, err := LoadMint(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading mint: %v", err)
+	}
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("unexpected error listening: %v", err)
+	}
+	grpcServer := NewGrpcServer(mint, 0, nil)
+	go grpcServer.Serve(listener)
+	defer grpcServer.Shutdown(context.Background())
+
+	grpcClient, err := client.NewGrpcClient(listener.Addr().String(), nil)
+	if err != nil {
+		t.Fatalf("unexpected error creating gRPC client: %v", err)
+	}
+	defer grpcClient.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	keys, err := grpcClient.GetActiveKeysets(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error getting keys: %v", err)
+	}
+	if len(keys.Keysets) != 1 || keys.Keysets[0].Id != mint.activeKeyset.Id {
+		t.Fatalf("expected active keyset '%v' but got %+v", mint.activeKeyset.Id, keys.Keysets)
+	}
+	if _, err := grpcClient.GetKeysetById(ctx, "00deadbeef"); !errors.Is(err, cashu.UnknownKeysetErr) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.UnknownKeysetErr, err)
+	}
+
+	keysets, err := grpcClient.GetAllKeysets(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error getting keysets: %v", err)
+	}
+	if len(keysets.Keysets) != 1 || !keysets.Keysets[0].Active {
+		t.Fatalf("expected one active keyset but got %+v", keysets.Keysets)
+	}
+
+	mintInfo, err := grpcClient.GetMintInfo(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error getting mint info: %v", err)
+	}
+	expectedInfo, _ := mint.RetrieveMintInfo()
+	if !reflect.DeepEqual(mintInfo.Nuts, expectedInfo.Nuts) {
+		t.Fatalf("expected nuts %+v but got %+v", expectedInfo.Nuts, mintInfo.Nuts)
+	}
+
+	mintQuote, err := grpcClient.PostMintQuoteBolt11(ctx, nut04.PostMintQuoteBolt11Request{Amount: 64, Unit: cashu.Sat.String()})
+	if err != nil {
+		t.Fatalf("unexpected error requesting mint quote: %v", err)
+	}
+
+	subscription, err := grpcClient.Subscribe(ctx, nut17.Bolt11MintQuote, []string{mintQuote.Quote})
+	if err != nil {
+		t.Fatalf("unexpected error subscribing: %v", err)
+	}
+	readQuoteState := func() nut04.State {
+		notification, err := subscription.Read()
+		if err != nil {
+			t.Fatalf("unexpected error reading subscription: %v", err)
+		}
+		if notification.GetMintQuote() == nil {
+			t.Fatalf("expected mint quote in notification but got %+v", notification)
+		}
+		quoteState := mintrpc.MintQuoteResponseFromProto(notification.GetMintQuote())
+		if quoteState.Quote != mintQuote.Quote {
+			t.Fatalf("expected notification for quote '%v' but got '%v'", mintQuote.Quote, quoteState.Quote)
+		}
+		return quoteState.State
+	}
+
+	// fake backend settles invoices right away so the
+	// quote could have been paid before subscribing
+	if state := readQuoteState(); state == nut04.Unpaid {
+		if _, err := grpcClient.GetMintQuoteState(ctx, mintQuote.Quote); err != nil {
+			t.Fatalf("unexpected error getting mint quote state: %v", err)
+		}
+		if state := readQuoteState(); state != nut04.Paid {
+			t.Fatalf("expected quote state '%v' but got '%v'", nut04.Paid, state)
+		}
+	} else if state != nut04.Paid {
+		t.Fatalf("expected quote state '%v' but got '%v'", nut04.Paid, state)
+	}
+
+	r, _ := secp256k1.GeneratePrivateKey()
+	B_, _, _ := crypto.BlindMessage("grpcsecret", r)
+	mintRequest := nut04.PostMintBolt11Request{
+		Quote:   mintQuote.Quote,
+		Outputs: cashu.BlindedMessages{cashu.NewBlindedMessage(mint.activeKeyset.Id, 64, B_)},
+	}
+	mintResponse, err := grpcClient.PostMintBolt11(ctx, mintRequest)
+	if err != nil {
+		t.Fatalf("unexpected error minting tokens: %v", err)
+	}
+	if len(mintResponse.Signatures) != 1 || mintResponse.Signatures[0].Amount != 64 {
+		t.Fatalf("unexpected signatures: %+v", mintResponse.Signatures)
+	}
+	if _, err := grpcClient.PostMintBolt11(ctx, mintRequest); !errors.Is(err, cashu.MintQuoteAlreadyIssued) {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MintQuoteAlreadyIssued, err)
+	}
+
+	restoreResponse, err := grpcClient.PostRestore(ctx, nut09.PostRestoreRequest{Outputs: mintRequest.Outputs})
+	if err != nil {
+		t.Fatalf("unexpected error restoring signatures: %v", err)
+	}
+	if len(restoreResponse.Signatures) != 1 || restoreResponse.Signatures[0].C_ != mintResponse.Signatures[0].C_ {
+		t.Fatalf("unexpected restored signatures: %+v", restoreResponse.Signatures)
+	}
+	tooManyOutputs := append(mintRequest.Outputs, mintRequest.Outputs[0], mintRequest.Outputs[0])
+	_, err = grpcClient.PostRestore(ctx, nut09.PostRestoreRequest{Outputs: tooManyOutputs})
+	if err == nil || err.Error() != cashu.MaxOutputsExceededErr.Error() {
+		t.Fatalf("expected error '%v' but got '%v'", cashu.MaxOutputsExceededErr, err)
+	}
+}
+
+func TestHost(t *testing.T) {
+	tenantPathA, tenantPathB := "./testmintTenantA", "./testmintTenantB"
+	defer os.RemoveAll(tenantPathA)
+	defer os.RemoveAll(tenantPathB)
+
+	tenants := []TenantConfig{
+		{
+			Name:      "a",
+			Hostnames: []string{"a.tollgate.test"},
+			Config:    Config{MintPath: tenantPathA, LightningClient: &lightning.FakeBackend{}, LogLevel: Disable},
+		},
+		{
+			Name:       "b",
+			PathPrefix: "/b/",
+			Config:     Config{MintPath: tenantPathB, LightningClient: &lightning.FakeBackend{}, LogLevel: Disable},
+		},
+	}
+
+	duplicatePath := append([]TenantConfig{}, tenants...)
+	duplicatePath[1].Config.MintPath = tenantPathA + "/"
+	if _, err := LoadHost(HostConfig{}, duplicatePath); err == nil {
+		t.Fatal("expected error loading tenants with the same mint path")
+	}
+
+	host, err := LoadHost(HostConfig{}, tenants)
+	if err != nil {
+		t.Fatalf("unexpected error loading host: %v", err)
+	}
+	defer host.Shutdown()
+
+	mints := host.Mints()
+	if len(mints) != 2 {
+		t.Fatalf("expected 2 mints but got %v", len(mints))
+	}
+	if mints["a"].activeKeyset.Id == mints["b"].activeKeyset.Id {
+		t.Fatal("expected tenants to have different keysets")
+	}
+
+	getActiveKeyset := func(req *http.Request) (string, int) {
+		w := httptest.NewRecorder()
+		host.ServeHTTP(w, req)
+		if w.Code != http.StatusOK {
+			return "", w.Code
+		}
+		var keysResponse nut01.GetKeysResponse
+		if err := json.NewDecoder(w.Body).Decode(&keysResponse); err != nil {
+			t.Fatalf("error decoding keys response: %v", err)
+		}
+		return keysResponse.Keysets[0].Id, w.Code
+	}
+
+	tests := []struct {
+		name           string
+		host           string
+		path           string
+		expectedStatus int
+		expectedKeyset string
+	}{
+		{"hostname", "a.tollgate.test", "/v1/keys", http.StatusOK, mints["a"].activeKeyset.Id},
+		{"hostname with port", "A.tollgate.test:3338", "/v1/keys", http.StatusOK, mints["a"].activeKeyset.Id},
+		{"path prefix", "localhost", "/b/v1/keys", http.StatusOK, mints["b"].activeKeyset.Id},
+		{"prefix without separator", "localhost", "/bb/v1/keys", http.StatusNotFound, ""},
+		{"unknown tenant", "localhost", "/c/v1/keys", http.StatusNotFound, ""},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			req.Host = test.host
+			keysetId, status := getActiveKeyset(req)
+			if status != test.expectedStatus {
+				t.Fatalf("expected status %v but got %v", test.expectedStatus, status)
+			}
+			if keysetId != test.expectedKeyset {
+				t.Fatalf("expected keyset '%v' but got '%v'", test.expectedKeyset, keysetId)
+			}
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package mint
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/mint/storage"
+)
+
+const (
+	// default time to wait for in-flight melts when shutting down
+	defaultShutdownTimeout = 30 * time.Second
+	// time given to in-flight melts to return after their payments are canceled
+	meltCancelGracePeriod = 5 * time.Second
+	// max time to check the payment of a pending melt quote on startup
+	pendingMeltCheckTimeout = 30 * time.Second
+)
+
+// drainState keeps track of the melts in progress so that
+// the shutdown can wait for their payments to resolve
+type drainState struct {
+	mu       sync.Mutex
+	draining bool
+	// number of operations in progress by quote id.
+	// There can be more than one for the same quote
+	inflight map[string]int
+	wg       sync.WaitGroup
+}
+
+func newDrainState() *drainState {
+	return &drainState{inflight: make(map[string]int)}
+}
+
+// enter registers an operation for the quote.
+// It returns false if the mint is draining and no new operations are accepted
+func (d *drainState) enter(quoteId string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	if d.draining {
+		return false
+	}
+	d.inflight[quoteId]++
+	d.wg.Add(1)
+	return true
+}
+
+func (d *drainState) exit(quoteId string) {
+	d.mu.Lock()
+	d.inflight[quoteId]--
+	if d.inflight[quoteId] <= 0 {
+		delete(d.inflight, quoteId)
+	}
+	d.mu.Unlock()
+	d.wg.Done()
+}
+
+func (d *drainState) stopAccepting() {
+	d.mu.Lock()
+	d.draining = true
+	d.mu.Unlock()
+}
+
+func (d *drainState) isDraining() bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return d.draining
+}
+
+func (d *drainState) inflightQuotes() []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	quoteIds := make([]string, 0, len(d.inflight))
+	for quoteId := range d.inflight {
+		quoteIds = append(quoteIds, quoteId)
+	}
+	return quoteIds
+}
+
+// wait blocks until all the operations exited or the ctx is done
+func (d *drainState) wait(ctx context.Context) error {
+	done := make(chan struct{})
+	go func() {
+		d.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Drain stops accepting new quotes and melts and waits until the payments
+// of the melts in progress resolve or the ctx is done.
+// Melts that did not resolve stay pending and are checked on the next start.
+func (m *Mint) Drain(ctx context.Context) error {
+	m.drain.stopAccepting()
+
+	m.logInfof("draining mint. Waiting for %v in-flight melts", len(m.drain.inflightQuotes()))
+	if err := m.drain.wait(ctx); err != nil {
+		quoteIds := m.drain.inflightQuotes()
+		m.logErrorf("melt quotes %v did not resolve before shutdown. They will be checked on next start", quoteIds)
+		return fmt.Errorf("%v melts still in flight: %v", len(quoteIds), err)
+	}
+	return nil
+}
+
+// checkDraining returns an error if the mint is not accepting new operations
+func (m *Mint) checkDraining() error {
+	if m.drain.isDraining() {
+		return cashu.MintShuttingDownErr
+	}
+	return nil
+}
+
+// checkPendingMeltQuotes checks with the lightning backend the payments
+// of the melt quotes that were left pending by a previous run of the mint
+func (m *Mint) checkPendingMeltQuotes(pendingQuotes []storage.MeltQuote) {
+	for _, quote := range pendingQuotes {
+		ctx, cancel := context.WithTimeout(m.ctx, pendingMeltCheckTimeout)
+		meltQuote, err := m.GetMeltQuoteState(ctx, quote.Id)
+		cancel()
+		if err != nil {
+			m.logErrorf("could not check state of pending melt quote '%v': %v", quote.Id, err)
+			continue
+		}
+		if meltQuote.State != nut05.Pending {
+			m.logInfof("pending melt quote '%v' from previous run resolved to %v", quote.Id, meltQuote.State)
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package mint
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/mint/lightning"
+	"github.com/Origami74/gonuts-tollgate/mint/pubsub"
+)
+
+// SolvencyStatus is the result of comparing the outstanding ecash
+// with the funds in the lightning backend
+type SolvencyStatus struct {
+	// ecash in circulation (issued - redeemed)
+	Liabilities uint64            `json:"liabilities"`
+	Reserves    lightning.Balance `json:"reserves"`
+	// reserves / liabilities. 0 if there are no liabilities
+	Ratio float64 `json:"ratio"`
+	// true if the reserves cover all the outstanding ecash
+	Solvent bool `json:"solvent"`
+	// true if minting was disabled because the ratio
+	// dropped below the MinReserveRatio in the limits
+	MintingDisabled bool  `json:"minting_disabled"`
+	CheckedAt       int64 `json:"checked_at"`
+}
+
+type solvencyMonitor struct {
+	mu     sync.RWMutex
+	status *SolvencyStatus
+}
+
+func (s *solvencyMonitor) set(status SolvencyStatus) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.status = &status
+}
+
+func (s *solvencyMonitor) last() (SolvencyStatus, bool) {
+	if s == nil {
+		return SolvencyStatus{}, false
+	}
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	if s.status == nil {
+		return SolvencyStatus{}, false
+	}
+	return *s.status, true
+}
+
+func (s *solvencyMonitor) mintingDisabled() bool {
+	status, ok := s.last()
+	return ok && status.MintingDisabled
+}
+
+// runSolvencyCheck periodically checks the solvency of the mint
+// until the mint context is canceled. It should be called in a different goroutine.
+// If several instances share the db, only the leader does the check
+// and the others take the status it publishes.
+func (m *Mint) runSolvencyCheck(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	subscriber := m.SubscribeSolvency()
+	defer func() {
+		m.UnsubscribeSolvency(subscriber)
+		go func() {
+			for range subscriber.GetMessages() {
+			}
+		}()
+		subscriber.Close()
+	}()
+
+	for {
+		if m.isLeader(solvencyCheckJob, interval) {
+			if _, err := m.CheckSolvency(); err != nil {
+				m.logErrorf("error checking solvency: %v", err)
+			}
+		}
+
+	wait:
+		for {
+			select {
+			case <-m.ctx.Done():
+				return
+			case <-ticker.C:
+				break wait
+			case msg := <-subscriber.GetMessages():
+				var status SolvencyStatus
+				if err := json.Unmarshal(msg.Payload(), &status); err != nil {
+					m.logErrorf("invalid solvency status published: %v", err)
+					continue
+				}
+				m.solvency.set(status)
+			}
+		}
+	}
+}
+
+// CheckSolvency compares the ecash in circulation with the balance
+// of the lightning backend. If the reserves are below the MinReserveRatio
+// of the limits, minting is disabled until a later check finds enough reserves.
+// The status is published to the SOLVENCY_TOPIC.
+func (m *Mint) CheckSolvency() (SolvencyStatus, error) {
+	liabilities, err := m.TotalBalance()
+	if err != nil {
+		return SolvencyStatus{}, err
+	}
+
+	ctx, cancel := context.WithTimeout(m.ctx, time.Minute)
+	defer cancel()
+	reserves, err := m.lightningClient.Balance(ctx)
+	if err != nil {
+		return SolvencyStatus{}, err
+	}
+
+	status := SolvencyStatus{
+		Liabilities: liabilities,
+		Reserves:    reserves,
+		Solvent:     reserves.Total() >= liabilities,
+		CheckedAt:   time.Now().Unix(),
+	}
+	if liabilities > 0 {
+		status.Ratio = float64(reserves.Total()) / float64(liabilities)
+	}
+	if m.limits.MinReserveRatio > 0 && liabilities > 0 {
+		status.MintingDisabled = status.Ratio < m.limits.MinReserveRatio
+	}
+
+	previous, _ := m.solvency.last()
+	m.solvency.set(status)
+
+	if !status.Solvent {
+		m.logWarnf("lightning backend balance of %v sats does not cover the %v sats of ecash in circulation",
+			reserves.Total(), liabilities)
+	}
+	if status.MintingDisabled && !previous.MintingDisabled {
+		m.logWarnf("disabling minting. Reserve ratio %.4f is below the minimum of %v",
+			status.Ratio, m.limits.MinReserveRatio)
+	} else if !status.MintingDisabled && previous.MintingDisabled {
+		m.logInfof("enabling minting. Reserve ratio %.4f is above the minimum of %v",
+			status.Ratio, m.limits.MinReserveRatio)
+	}
+
+	jsonStatus, _ := json.Marshal(status)
+	m.publisher.Publish(SOLVENCY_TOPIC, jsonStatus)
+
+	return status, nil
+}
+
+// SolvencyStatus returns the result of the last solvency check.
+// It returns false if no check has been done.
+func (m *Mint) SolvencyStatus() (SolvencyStatus, bool) {
+	return m.solvency.last()
+}
+
+// SubscribeSolvency returns a subscriber that receives the
+// json encoded SolvencyStatus after every check
+func (m *Mint) SubscribeSolvency() *pubsub.Subscriber {
+	return m.publisher.Subscribe(SOLVENCY_TOPIC)
+}
+
+func (m *Mint) UnsubscribeSolvency(subscriber *pubsub.Subscriber) {
+	m.publisher.Unsubscribe(subscriber, SOLVENCY_TOPIC)
+}
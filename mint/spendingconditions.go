@@ -0,0 +1,77 @@
+package mint
+
+import (
+	"slices"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut10"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut11"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut14"
+)
+
+// SpendingConditionVerifier verifies the spending conditions of
+// proofs locked to a NUT-10 secret kind.
+// Proofs in a request are verified concurrently so
+// implementations must be safe for concurrent use.
+type SpendingConditionVerifier interface {
+	// VerifyProof checks that the witness in the proof satisfies
+	// the spending conditions in its secret
+	VerifyProof(proof cashu.Proof, secret nut10.WellKnownSecret) error
+}
+
+// OutputsVerifier can optionally be implemented by a SpendingConditionVerifier
+// if the spending conditions of its kind also put requirements on the
+// outputs of a transaction (i.e SIG_ALL in P2PK).
+type OutputsVerifier interface {
+	// VerifyOutputs is called with all the inputs and outputs of a swap
+	// or melt that has inputs locked to the kind of the verifier.
+	// quoteId is the id of the melt quote and empty for swaps
+	VerifyOutputs(inputs cashu.Proofs, outputs cashu.BlindedMessages, quoteId string) error
+}
+
+// builtinSpendingConditions returns the verifiers for the
+// NUT-10 kinds supported by default by the mint
+func builtinSpendingConditions() map[nut10.SecretKind]SpendingConditionVerifier {
+	return map[nut10.SecretKind]SpendingConditionVerifier{
+		nut10.P2PK: p2pkVerifier{},
+		nut10.HTLC: htlcVerifier{},
+	}
+}
+
+// p2pkVerifier verifies NUT-11 P2PK locked proofs
+type p2pkVerifier struct{}
+
+func (p2pkVerifier) VerifyProof(proof cashu.Proof, secret nut10.WellKnownSecret) error {
+	return nut11.VerifyP2PKLockedProof(proof, secret)
+}
+
+func (p2pkVerifier) VerifyOutputs(inputs cashu.Proofs, outputs cashu.BlindedMessages, quoteId string) error {
+	if !nut11.ProofsSigAll(inputs) {
+		return nil
+	}
+	return verifySigAll(inputs, outputs, quoteId)
+}
+
+// htlcVerifier verifies NUT-14 HTLC locked proofs
+type htlcVerifier struct{}
+
+func (htlcVerifier) VerifyProof(proof cashu.Proof, secret nut10.WellKnownSecret) error {
+	return nut14.VerifyHTLCProof(proof, secret)
+}
+
+func (htlcVerifier) VerifyOutputs(inputs cashu.Proofs, outputs cashu.BlindedMessages, quoteId string) error {
+	if !nut11.ProofsSigAll(inputs) {
+		return nil
+	}
+	return verifySigAll(inputs, outputs, quoteId)
+}
+
+// supportedKinds returns the NUT-10 kinds for which the mint has a verifier
+func (m *Mint) supportedKinds() []string {
+	kinds := make([]string, 0, len(m.spendingConditions))
+	for kind := range m.spendingConditions {
+		kinds = append(kinds, kind.String())
+	}
+	slices.Sort(kinds)
+	return kinds
+}
@@ -0,0 +1 @@
+ALTER TABLE melt_quotes DROP COLUMN fee_paid;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE melt_quotes ADD COLUMN fee_paid INTEGER;
\ No newline at end of file
@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_melt_change_melt_quote_id;
+DROP TABLE IF EXISTS melt_change;
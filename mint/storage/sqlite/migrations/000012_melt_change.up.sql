@@ -0,0 +1,8 @@
+CREATE TABLE IF NOT EXISTS melt_change (
+	b_ TEXT NOT NULL PRIMARY KEY,
+	keyset_id TEXT NOT NULL,
+	melt_quote_id TEXT NOT NULL,
+	idx INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_melt_change_melt_quote_id ON melt_change(melt_quote_id);
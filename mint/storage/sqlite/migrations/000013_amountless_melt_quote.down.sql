@@ -0,0 +1 @@
+ALTER TABLE melt_quotes DROP COLUMN is_amountless;
\ No newline at end of file
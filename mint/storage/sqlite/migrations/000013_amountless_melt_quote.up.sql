@@ -0,0 +1 @@
+ALTER TABLE melt_quotes ADD COLUMN is_amountless BOOLEAN;
\ No newline at end of file
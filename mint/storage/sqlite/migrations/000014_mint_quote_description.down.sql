@@ -0,0 +1 @@
+ALTER TABLE mint_quotes DROP COLUMN description;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE mint_quotes ADD COLUMN description TEXT;
\ No newline at end of file
@@ -0,0 +1,3 @@
+DROP INDEX IF EXISTS idx_melt_quotes_state_expiry;
+DROP INDEX IF EXISTS idx_mint_quotes_state_expiry;
+DROP TABLE IF EXISTS quote_history;
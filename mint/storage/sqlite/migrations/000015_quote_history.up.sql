@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS quote_history (
+	day TEXT NOT NULL,
+	kind TEXT NOT NULL,
+	count INTEGER NOT NULL,
+	amount INTEGER NOT NULL,
+	PRIMARY KEY (day, kind)
+);
+
+CREATE INDEX IF NOT EXISTS idx_mint_quotes_state_expiry ON mint_quotes(state, expiry);
+CREATE INDEX IF NOT EXISTS idx_melt_quotes_state_expiry ON melt_quotes(state, expiry);
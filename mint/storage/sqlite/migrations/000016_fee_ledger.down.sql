@@ -0,0 +1,2 @@
+DROP INDEX IF EXISTS idx_fee_ledger_created_at;
+DROP TABLE IF EXISTS fee_ledger;
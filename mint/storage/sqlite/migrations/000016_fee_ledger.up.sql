@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS fee_ledger (
+	id INTEGER PRIMARY KEY AUTOINCREMENT,
+	operation TEXT NOT NULL,
+	quote_id TEXT,
+	input_fee INTEGER NOT NULL,
+	fee_reserve INTEGER NOT NULL,
+	lightning_fee INTEGER NOT NULL,
+	reserve_kept INTEGER NOT NULL,
+	created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_fee_ledger_created_at ON fee_ledger(created_at);
@@ -0,0 +1,3 @@
+DROP TABLE IF EXISTS leases;
+DROP TRIGGER IF EXISTS pending_proofs_not_spent;
+DROP TRIGGER IF EXISTS proofs_not_pending;
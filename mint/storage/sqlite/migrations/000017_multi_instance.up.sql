@@ -0,0 +1,21 @@
+-- a proof cannot be spent while it is pending in a melt and vice versa,
+-- even if the requests are handled by different instances of the mint
+CREATE TRIGGER IF NOT EXISTS proofs_not_pending BEFORE INSERT ON proofs
+WHEN EXISTS (SELECT 1 FROM pending_proofs WHERE y = NEW.y)
+BEGIN
+	SELECT RAISE(ABORT, 'proof is pending');
+END;
+
+CREATE TRIGGER IF NOT EXISTS pending_proofs_not_spent BEFORE INSERT ON pending_proofs
+WHEN EXISTS (SELECT 1 FROM proofs WHERE y = NEW.y)
+BEGIN
+	SELECT RAISE(ABORT, 'proof already spent');
+END;
+
+-- leases to elect the instance that runs a background job.
+-- expires_at is a unix timestamp in milliseconds
+CREATE TABLE IF NOT EXISTS leases (
+	name TEXT NOT NULL PRIMARY KEY,
+	holder TEXT NOT NULL,
+	expires_at INTEGER NOT NULL
+);
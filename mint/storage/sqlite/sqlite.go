@@ -10,6 +10,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
@@ -20,7 +21,7 @@ import (
 	"github.com/golang-migrate/migrate/v4"
 	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
 	_ "github.com/golang-migrate/migrate/v4/source/file"
-	_ "github.com/mattn/go-sqlite3"
+	"github.com/mattn/go-sqlite3"
 )
 
 //go:embed migrations
@@ -72,7 +73,9 @@ func migrationsDir() (string, error) {
 
 func InitSQLite(path string) (*SQLiteDB, error) {
 	dbpath := filepath.Join(path, "mint.sqlite.db")
-	db, err := sql.Open("sqlite3", dbpath)
+	// several instances of the mint can share the db so wait for the
+	// write lock instead of failing if another one is holding it
+	db, err := sql.Open("sqlite3", dbpath+"?_busy_timeout=5000&_txlock=immediate")
 	if err != nil {
 		return nil, err
 	}
@@ -203,7 +206,7 @@ func (sqlite *SQLiteDB) SaveProofs(proofs cashu.Proofs) error {
 
 		if _, err := stmt.Exec(Yhex, proof.Amount, proof.Id, proof.Secret, proof.C, proof.Witness); err != nil {
 			tx.Rollback()
-			return err
+			return proofConstraintErr(err, storage.ErrProofSpent)
 		}
 	}
 
@@ -214,6 +217,27 @@ func (sqlite *SQLiteDB) SaveProofs(proofs cashu.Proofs) error {
 	return nil
 }
 
+// proofConstraintErr returns the storage error for a constraint that failed
+// when inserting a proof. conflictErr is returned if the proof was already in the table
+func proofConstraintErr(err, conflictErr error) error {
+	var sqliteErr sqlite3.Error
+	if !errors.As(err, &sqliteErr) || sqliteErr.Code != sqlite3.ErrConstraint {
+		return err
+	}
+
+	switch sqliteErr.ExtendedCode {
+	case sqlite3.ErrConstraintPrimaryKey, sqlite3.ErrConstraintUnique:
+		return conflictErr
+	case sqlite3.ErrConstraintTrigger:
+		// message is the one raised by the trigger
+		if strings.Contains(sqliteErr.Error(), storage.ErrProofPending.Error()) {
+			return storage.ErrProofPending
+		}
+		return storage.ErrProofSpent
+	}
+	return err
+}
+
 func (sqlite *SQLiteDB) GetProofsUsed(Ys []string) ([]storage.DBProof, error) {
 	proofs := []storage.DBProof{}
 	query := `SELECT * FROM proofs WHERE y in (?` + strings.Repeat(",?", len(Ys)-1) + `)`
@@ -275,7 +299,7 @@ func (sqlite *SQLiteDB) AddPendingProofs(proofs cashu.Proofs, quoteId string) er
 
 		if _, err := stmt.Exec(Yhex, proof.Amount, proof.Id, proof.Secret, proof.C, proof.Witness, quoteId); err != nil {
 			tx.Rollback()
-			return err
+			return proofConstraintErr(err, storage.ErrProofPending)
 		}
 	}
 
@@ -390,6 +414,64 @@ func (sqlite *SQLiteDB) RemovePendingProofs(Ys []string) error {
 	return nil
 }
 
+func (sqlite *SQLiteDB) SettlePendingProofs(Ys []string) error {
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	selectStmt, err := tx.Prepare("SELECT amount, keyset_id, secret, c, witness FROM pending_proofs WHERE y = ?")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer selectStmt.Close()
+
+	deleteStmt, err := tx.Prepare("DELETE FROM pending_proofs WHERE y = ?")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer deleteStmt.Close()
+
+	insertStmt, err := tx.Prepare("INSERT INTO proofs (y, amount, keyset_id, secret, c, witness) VALUES (?, ?, ?, ?, ?, ?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer insertStmt.Close()
+
+	for _, y := range Ys {
+		var proof storage.DBProof
+		var witness sql.NullString
+		err := selectStmt.QueryRow(y).Scan(&proof.Amount, &proof.Id, &proof.Secret, &proof.C, &witness)
+		if err != nil {
+			tx.Rollback()
+			if errors.Is(err, sql.ErrNoRows) {
+				return fmt.Errorf("proof '%v' is not pending", y)
+			}
+			return err
+		}
+
+		// the pending proof has to be removed first, otherwise
+		// the insert is rejected because the proof is pending
+		if _, err := deleteStmt.Exec(y); err != nil {
+			tx.Rollback()
+			return err
+		}
+		if _, err := insertStmt.Exec(y, proof.Amount, proof.Id, proof.Secret, proof.C, witness); err != nil {
+			tx.Rollback()
+			return proofConstraintErr(err, storage.ErrProofSpent)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
 func (sqlite *SQLiteDB) SaveMintQuote(mintQuote storage.MintQuote) error {
 	var pubkey string
 	if mintQuote.Pubkey != nil {
@@ -397,8 +479,8 @@ func (sqlite *SQLiteDB) SaveMintQuote(mintQuote storage.MintQuote) error {
 	}
 
 	_, err := sqlite.db.Exec(
-		`INSERT INTO mint_quotes (id, payment_request, payment_hash, amount, state, expiry, pubkey)
-		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		`INSERT INTO mint_quotes (id, payment_request, payment_hash, amount, state, expiry, pubkey, description)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
 		mintQuote.Id,
 		mintQuote.PaymentRequest,
 		mintQuote.PaymentHash,
@@ -406,6 +488,7 @@ func (sqlite *SQLiteDB) SaveMintQuote(mintQuote storage.MintQuote) error {
 		mintQuote.State.String(),
 		mintQuote.Expiry,
 		pubkey,
+		mintQuote.Description,
 	)
 
 	return err
@@ -417,6 +500,7 @@ func (sqlite *SQLiteDB) GetMintQuote(quoteId string) (storage.MintQuote, error)
 	var mintQuote storage.MintQuote
 	var state string
 	var pubkey sql.NullString
+	var description sql.NullString
 
 	err := row.Scan(
 		&mintQuote.Id,
@@ -426,11 +510,15 @@ func (sqlite *SQLiteDB) GetMintQuote(quoteId string) (storage.MintQuote, error)
 		&state,
 		&mintQuote.Expiry,
 		&pubkey,
+		&description,
 	)
 	if err != nil {
 		return storage.MintQuote{}, err
 	}
 	mintQuote.State = nut04.StringToState(state)
+	if description.Valid {
+		mintQuote.Description = description.String
+	}
 
 	if pubkey.Valid && len(pubkey.String) > 0 {
 		// these should not error because validation is done before saving with public key
@@ -456,6 +544,7 @@ func (sqlite *SQLiteDB) GetMintQuoteByPaymentHash(paymentHash string) (storage.M
 	var mintQuote storage.MintQuote
 	var state string
 	var pubkey sql.NullString
+	var description sql.NullString
 
 	err := row.Scan(
 		&mintQuote.Id,
@@ -465,11 +554,15 @@ func (sqlite *SQLiteDB) GetMintQuoteByPaymentHash(paymentHash string) (storage.M
 		&state,
 		&mintQuote.Expiry,
 		&pubkey,
+		&description,
 	)
 	if err != nil {
 		return storage.MintQuote{}, err
 	}
 	mintQuote.State = nut04.StringToState(state)
+	if description.Valid {
+		mintQuote.Description = description.String
+	}
 
 	if pubkey.Valid && len(pubkey.String) > 0 {
 		// these should not error because validation is done before saving with public key
@@ -506,11 +599,27 @@ func (sqlite *SQLiteDB) UpdateMintQuoteState(quoteId string, state nut04.State)
 	return nil
 }
 
+func (sqlite *SQLiteDB) CompareAndSetMintQuoteState(quoteId string, expected, state nut04.State) (bool, error) {
+	result, err := sqlite.db.Exec(
+		"UPDATE mint_quotes SET state = ? WHERE id = ? AND state = ?",
+		state.String(), quoteId, expected.String(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
 func (sqlite *SQLiteDB) SaveMeltQuote(meltQuote storage.MeltQuote) error {
 	_, err := sqlite.db.Exec(`
 		INSERT INTO melt_quotes 
-		(id, request, payment_hash, amount, fee_reserve, state, expiry, preimage, is_mpp, amount_msat) 
-		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		(id, request, payment_hash, amount, fee_reserve, state, expiry, preimage, is_mpp, amount_msat, is_amountless) 
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
 		meltQuote.Id,
 		meltQuote.InvoiceRequest,
 		meltQuote.PaymentHash,
@@ -521,6 +630,7 @@ func (sqlite *SQLiteDB) SaveMeltQuote(meltQuote storage.MeltQuote) error {
 		meltQuote.Preimage,
 		meltQuote.IsMpp,
 		meltQuote.AmountMsat,
+		meltQuote.IsAmountless,
 	)
 
 	return err
@@ -533,6 +643,8 @@ func (sqlite *SQLiteDB) GetMeltQuote(quoteId string) (storage.MeltQuote, error)
 	var state string
 	var isMpp sql.NullBool
 	var amountMsat sql.NullInt64
+	var feePaid sql.NullInt64
+	var isAmountless sql.NullBool
 
 	err := row.Scan(
 		&meltQuote.Id,
@@ -545,6 +657,8 @@ func (sqlite *SQLiteDB) GetMeltQuote(quoteId string) (storage.MeltQuote, error)
 		&meltQuote.Preimage,
 		&isMpp,
 		&amountMsat,
+		&feePaid,
+		&isAmountless,
 	)
 	if err != nil {
 		return storage.MeltQuote{}, err
@@ -556,6 +670,12 @@ func (sqlite *SQLiteDB) GetMeltQuote(quoteId string) (storage.MeltQuote, error)
 	if amountMsat.Valid {
 		meltQuote.AmountMsat = uint64(amountMsat.Int64)
 	}
+	if feePaid.Valid {
+		meltQuote.FeePaid = uint64(feePaid.Int64)
+	}
+	if isAmountless.Valid {
+		meltQuote.IsAmountless = isAmountless.Bool
+	}
 
 	return meltQuote, nil
 }
@@ -567,6 +687,8 @@ func (sqlite *SQLiteDB) GetMeltQuoteByPaymentRequest(invoice string) (*storage.M
 	var state string
 	var isMpp sql.NullBool
 	var amountMsat sql.NullInt64
+	var feePaid sql.NullInt64
+	var isAmountless sql.NullBool
 
 	err := row.Scan(
 		&meltQuote.Id,
@@ -579,6 +701,8 @@ func (sqlite *SQLiteDB) GetMeltQuoteByPaymentRequest(invoice string) (*storage.M
 		&meltQuote.Preimage,
 		&isMpp,
 		&amountMsat,
+		&feePaid,
+		&isAmountless,
 	)
 	if err != nil {
 		return nil, err
@@ -590,6 +714,12 @@ func (sqlite *SQLiteDB) GetMeltQuoteByPaymentRequest(invoice string) (*storage.M
 	if amountMsat.Valid {
 		meltQuote.AmountMsat = uint64(amountMsat.Int64)
 	}
+	if feePaid.Valid {
+		meltQuote.FeePaid = uint64(feePaid.Int64)
+	}
+	if isAmountless.Valid {
+		meltQuote.IsAmountless = isAmountless.Bool
+	}
 
 	return &meltQuote, nil
 }
@@ -614,6 +744,444 @@ func (sqlite *SQLiteDB) UpdateMeltQuote(quoteId, preimage string, state nut05.St
 	return nil
 }
 
+func (sqlite *SQLiteDB) CompareAndSetMeltQuote(quoteId, preimage string, expected, state nut05.State) (bool, error) {
+	result, err := sqlite.db.Exec(
+		"UPDATE melt_quotes SET state = ?, preimage = ? WHERE id = ? AND state = ?",
+		state.String(), preimage, quoteId, expected.String(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+func (sqlite *SQLiteDB) UpdateMeltQuoteFeePaid(quoteId string, feePaid uint64) error {
+	result, err := sqlite.db.Exec("UPDATE melt_quotes SET fee_paid = ? WHERE id = ?", feePaid, quoteId)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.New("melt quote was not updated")
+	}
+	return nil
+}
+
+func (sqlite *SQLiteDB) SaveMeltChange(quoteId string, blindedMessages cashu.BlindedMessages) error {
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("INSERT INTO melt_change (b_, keyset_id, melt_quote_id, idx) VALUES (?, ?, ?, ?)")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for i, bm := range blindedMessages {
+		if _, err := stmt.Exec(bm.B_, bm.Id, quoteId, i); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sqlite *SQLiteDB) GetMeltChangeOutputs(B_s []string) (cashu.BlindedMessages, error) {
+	blindedMessages := cashu.BlindedMessages{}
+	if len(B_s) == 0 {
+		return blindedMessages, nil
+	}
+	query := `SELECT b_, keyset_id FROM melt_change WHERE b_ in (?` + strings.Repeat(",?", len(B_s)-1) + `)`
+
+	args := make([]any, len(B_s))
+	for i, B_ := range B_s {
+		args[i] = B_
+	}
+
+	rows, err := sqlite.db.Query(query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bm cashu.BlindedMessage
+		if err := rows.Scan(&bm.B_, &bm.Id); err != nil {
+			return nil, err
+		}
+		blindedMessages = append(blindedMessages, bm)
+	}
+
+	return blindedMessages, nil
+}
+
+func (sqlite *SQLiteDB) GetMeltChange(quoteId string) (cashu.BlindedMessages, error) {
+	blindedMessages := cashu.BlindedMessages{}
+
+	rows, err := sqlite.db.Query(
+		"SELECT b_, keyset_id FROM melt_change WHERE melt_quote_id = ? ORDER BY idx",
+		quoteId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var bm cashu.BlindedMessage
+		if err := rows.Scan(&bm.B_, &bm.Id); err != nil {
+			return nil, err
+		}
+		blindedMessages = append(blindedMessages, bm)
+	}
+
+	return blindedMessages, nil
+}
+
+func (sqlite *SQLiteDB) GetMeltChangeSignatures(quoteId string) (cashu.BlindedSignatures, error) {
+	signatures := cashu.BlindedSignatures{}
+
+	rows, err := sqlite.db.Query(`
+		SELECT bs.amount, bs.c_, bs.keyset_id, bs.e, bs.s 
+		FROM melt_change mc INNER JOIN blind_signatures bs ON mc.b_ = bs.b_
+		WHERE mc.melt_quote_id = ? ORDER BY mc.idx`,
+		quoteId,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var signature cashu.BlindedSignature
+		var e sql.NullString
+		var s sql.NullString
+
+		err := rows.Scan(
+			&signature.Amount,
+			&signature.C_,
+			&signature.Id,
+			&e,
+			&s,
+		)
+		if err != nil {
+			return nil, err
+		}
+
+		if e.Valid && s.Valid {
+			signature.DLEQ = &cashu.DLEQProof{
+				E: e.String,
+				S: s.String,
+			}
+		}
+
+		signatures = append(signatures, signature)
+	}
+
+	return signatures, nil
+}
+
+func (sqlite *SQLiteDB) DeleteMeltChange(quoteId string) error {
+	_, err := sqlite.db.Exec("DELETE FROM melt_change WHERE melt_quote_id = ?", quoteId)
+	return err
+}
+
+func (sqlite *SQLiteDB) ReplaceMeltChange(
+	quoteId string,
+	outputs cashu.BlindedMessages,
+	B_s []string,
+	signatures cashu.BlindedSignatures,
+) error {
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	if _, err := tx.Exec("DELETE FROM melt_change WHERE melt_quote_id = ?", quoteId); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	for i, sig := range signatures {
+		_, err := tx.Exec(
+			"INSERT INTO blind_signatures (b_, c_, keyset_id, amount, e, s) VALUES (?, ?, ?, ?, ?, ?)",
+			B_s[i], sig.C_, sig.Id, sig.Amount, sig.DLEQ.E, sig.DLEQ.S,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	for i, bm := range outputs {
+		_, err := tx.Exec(
+			"INSERT INTO melt_change (b_, keyset_id, melt_quote_id, idx) VALUES (?, ?, ?, ?)",
+			bm.B_, bm.Id, quoteId, i,
+		)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (sqlite *SQLiteDB) GetExpiredMintQuotes(expiredBefore uint64) ([]storage.MintQuote, error) {
+	mintQuotes := []storage.MintQuote{}
+
+	rows, err := sqlite.db.Query(
+		"SELECT id, payment_request, payment_hash, amount, expiry FROM mint_quotes WHERE state = ? AND expiry < ?",
+		nut04.Unpaid.String(), expiredBefore,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		mintQuote := storage.MintQuote{State: nut04.Unpaid}
+		err := rows.Scan(
+			&mintQuote.Id,
+			&mintQuote.PaymentRequest,
+			&mintQuote.PaymentHash,
+			&mintQuote.Amount,
+			&mintQuote.Expiry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		mintQuotes = append(mintQuotes, mintQuote)
+	}
+
+	return mintQuotes, nil
+}
+
+func (sqlite *SQLiteDB) GetExpiredMeltQuotes(expiredBefore uint64) ([]storage.MeltQuote, error) {
+	meltQuotes := []storage.MeltQuote{}
+
+	rows, err := sqlite.db.Query(
+		"SELECT id, request, payment_hash, amount, fee_reserve, expiry FROM melt_quotes WHERE state = ? AND expiry < ?",
+		nut05.Unpaid.String(), expiredBefore,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		meltQuote := storage.MeltQuote{State: nut05.Unpaid}
+		err := rows.Scan(
+			&meltQuote.Id,
+			&meltQuote.InvoiceRequest,
+			&meltQuote.PaymentHash,
+			&meltQuote.Amount,
+			&meltQuote.FeeReserve,
+			&meltQuote.Expiry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		meltQuotes = append(meltQuotes, meltQuote)
+	}
+
+	return meltQuotes, nil
+}
+
+func (sqlite *SQLiteDB) GetPendingMeltQuotes() ([]storage.MeltQuote, error) {
+	meltQuotes := []storage.MeltQuote{}
+
+	rows, err := sqlite.db.Query(
+		"SELECT id, request, payment_hash, amount, fee_reserve, expiry FROM melt_quotes WHERE state = ?",
+		nut05.Pending.String(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		meltQuote := storage.MeltQuote{State: nut05.Pending}
+		err := rows.Scan(
+			&meltQuote.Id,
+			&meltQuote.InvoiceRequest,
+			&meltQuote.PaymentHash,
+			&meltQuote.Amount,
+			&meltQuote.FeeReserve,
+			&meltQuote.Expiry,
+		)
+		if err != nil {
+			return nil, err
+		}
+		meltQuotes = append(meltQuotes, meltQuote)
+	}
+
+	return meltQuotes, nil
+}
+
+func (sqlite *SQLiteDB) ArchiveMintQuotes(quoteIds []string) error {
+	return sqlite.archiveQuotes("mint_quotes", storage.MintQuoteKind, nut04.Unpaid.String(), quoteIds)
+}
+
+func (sqlite *SQLiteDB) ArchiveMeltQuotes(quoteIds []string) error {
+	return sqlite.archiveQuotes("melt_quotes", storage.MeltQuoteKind, nut05.Unpaid.String(), quoteIds)
+}
+
+// archiveQuotes adds the quotes that are still unpaid to the aggregated
+// quote history and deletes them from the table in a single transaction
+func (sqlite *SQLiteDB) archiveQuotes(table, kind, unpaidState string, quoteIds []string) error {
+	if len(quoteIds) == 0 {
+		return nil
+	}
+
+	inClause := `(?` + strings.Repeat(",?", len(quoteIds)-1) + `)`
+	args := make([]any, len(quoteIds)+1)
+	args[0] = unpaidState
+	for i, id := range quoteIds {
+		args[i+1] = id
+	}
+
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	historyQuery := `
+		INSERT INTO quote_history (day, kind, count, amount)
+		SELECT date(expiry, 'unixepoch'), '` + kind + `', COUNT(*), SUM(amount)
+		FROM ` + table + ` WHERE state = ? AND id IN ` + inClause + `
+		GROUP BY date(expiry, 'unixepoch')
+		ON CONFLICT (day, kind) DO UPDATE SET
+		count = count + excluded.count, amount = amount + excluded.amount`
+	if _, err := tx.Exec(historyQuery, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	deleteQuery := `DELETE FROM ` + table + ` WHERE state = ? AND id IN ` + inClause
+	if _, err := tx.Exec(deleteQuery, args...); err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (sqlite *SQLiteDB) GetQuoteHistory() ([]storage.QuoteHistory, error) {
+	history := []storage.QuoteHistory{}
+
+	rows, err := sqlite.db.Query("SELECT day, kind, count, amount FROM quote_history ORDER BY day, kind")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var quoteHistory storage.QuoteHistory
+		if err := rows.Scan(&quoteHistory.Day, &quoteHistory.Kind, &quoteHistory.Count, &quoteHistory.Amount); err != nil {
+			return nil, err
+		}
+		history = append(history, quoteHistory)
+	}
+
+	return history, nil
+}
+
+func (sqlite *SQLiteDB) SaveFeeEntry(entry storage.FeeEntry) error {
+	_, err := sqlite.db.Exec(`
+		INSERT INTO fee_ledger (operation, quote_id, input_fee, fee_reserve, lightning_fee, reserve_kept, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		entry.Operation,
+		entry.QuoteId,
+		entry.InputFee,
+		entry.FeeReserve,
+		entry.LightningFee,
+		entry.ReserveKept,
+		entry.CreatedAt,
+	)
+	return err
+}
+
+func (sqlite *SQLiteDB) GetFeeEntries(from, to int64) ([]storage.FeeEntry, error) {
+	entries := []storage.FeeEntry{}
+
+	rows, err := sqlite.db.Query(`
+		SELECT operation, quote_id, input_fee, fee_reserve, lightning_fee, reserve_kept, created_at
+		FROM fee_ledger WHERE created_at >= ? AND created_at <= ? ORDER BY created_at, id`,
+		from, to,
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry storage.FeeEntry
+		var quoteId sql.NullString
+		err := rows.Scan(
+			&entry.Operation,
+			&quoteId,
+			&entry.InputFee,
+			&entry.FeeReserve,
+			&entry.LightningFee,
+			&entry.ReserveKept,
+			&entry.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		entry.QuoteId = quoteId.String
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+func (sqlite *SQLiteDB) AcquireLease(name, holder string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+	result, err := sqlite.db.Exec(`
+		INSERT INTO leases (name, holder, expires_at) VALUES (?, ?, ?)
+		ON CONFLICT(name) DO UPDATE SET holder = excluded.holder, expires_at = excluded.expires_at
+		WHERE leases.holder = excluded.holder OR leases.expires_at < ?`,
+		name, holder, now.Add(ttl).UnixMilli(), now.UnixMilli(),
+	)
+	if err != nil {
+		return false, err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return count == 1, nil
+}
+
+func (sqlite *SQLiteDB) ReleaseLease(name, holder string) error {
+	_, err := sqlite.db.Exec("DELETE FROM leases WHERE name = ? AND holder = ?", name, holder)
+	return err
+}
+
 func (sqlite *SQLiteDB) SaveBlindSignatures(B_s []string, blindSignatures cashu.BlindedSignatures) error {
 	tx, err := sqlite.db.Begin()
 	if err != nil {
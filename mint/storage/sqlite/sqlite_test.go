@@ -3,6 +3,7 @@ package sqlite
 import (
 	"bytes"
 	"encoding/hex"
+	"errors"
 	"log"
 	"math/rand/v2"
 	"os"
@@ -11,6 +12,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
@@ -333,6 +335,19 @@ func TestMeltQuote(t *testing.T) {
 	if !reflect.DeepEqual(expectedQuote, quote) {
 		t.Fatal("quote from db does not match generated one")
 	}
+
+	if err := db.UpdateMeltQuoteFeePaid(quote.Id, 3); err != nil {
+		t.Fatalf("error updating melt quote fee paid: %v", err)
+	}
+
+	expectedQuote.FeePaid = 3
+	quote, err = db.GetMeltQuote(expectedQuote.Id)
+	if err != nil {
+		t.Fatalf("error getting melt quote by id: %v", err)
+	}
+	if !reflect.DeepEqual(expectedQuote, quote) {
+		t.Fatal("quote from db does not match generated one")
+	}
 }
 
 func TestBlindSignatures(t *testing.T) {
@@ -366,6 +381,84 @@ func TestBlindSignatures(t *testing.T) {
 
 }
 
+func TestMeltChange(t *testing.T) {
+	B_s := generateRandomB_s(5)
+	blankOutputs := make(cashu.BlindedMessages, len(B_s))
+	for i, B_ := range B_s {
+		blankOutputs[i] = cashu.BlindedMessage{B_: B_, Id: "keysetId"}
+	}
+
+	if err := db.SaveMeltChange("meltQuoteId", blankOutputs); err != nil {
+		t.Fatalf("unexpected error saving melt change: %v", err)
+	}
+	changeOutputs, err := db.GetMeltChange("meltQuoteId")
+	if err != nil {
+		t.Fatalf("unexpected error getting melt change: %v", err)
+	}
+	if !reflect.DeepEqual(changeOutputs, blankOutputs) {
+		t.Fatal("melt change from db does not match saved one")
+	}
+
+	changeOutputs, err = db.GetMeltChangeOutputs(append(generateRandomB_s(3), B_s[1], B_s[3]))
+	if err != nil {
+		t.Fatalf("unexpected error getting melt change outputs: %v", err)
+	}
+	if len(changeOutputs) != 2 {
+		t.Fatalf("expected '%v' melt change outputs but got '%v'", 2, len(changeOutputs))
+	}
+
+	// outputs already stored for a melt cannot be saved for another one
+	if err := db.SaveMeltChange("otherMeltQuoteId", blankOutputs[:1]); err == nil {
+		t.Fatal("expected error saving outputs already stored as melt change")
+	}
+	changeOutputs, err = db.GetMeltChange("otherMeltQuoteId")
+	if err != nil || len(changeOutputs) != 0 {
+		t.Fatalf("expected no melt change for other quote but got '%v' (%v)", changeOutputs, err)
+	}
+
+	// stored outputs are kept if the change cannot be saved
+	signedB_s := B_s[:2]
+	signatures := generateBlindSignatures(2)
+	if err := db.SaveBlindSignatures(signedB_s[1:], signatures[1:]); err != nil {
+		t.Fatalf("unexpected error saving blind signatures: %v", err)
+	}
+	if err := db.ReplaceMeltChange("meltQuoteId", blankOutputs[:2], signedB_s, signatures); err == nil {
+		t.Fatal("expected error replacing melt change with outputs already signed")
+	}
+	changeOutputs, err = db.GetMeltChange("meltQuoteId")
+	if err != nil || len(changeOutputs) != len(blankOutputs) {
+		t.Fatalf("expected '%v' melt change outputs to be kept but got '%v' (%v)",
+			len(blankOutputs), len(changeOutputs), err)
+	}
+
+	signedB_s = B_s[2:4]
+	if err := db.ReplaceMeltChange("meltQuoteId", blankOutputs[2:4], signedB_s, signatures); err != nil {
+		t.Fatalf("unexpected error replacing melt change: %v", err)
+	}
+	changeOutputs, err = db.GetMeltChange("meltQuoteId")
+	if err != nil {
+		t.Fatalf("unexpected error getting melt change: %v", err)
+	}
+	if !reflect.DeepEqual(changeOutputs, blankOutputs[2:4]) {
+		t.Fatal("melt change from db does not match the signed outputs")
+	}
+	change, err := db.GetMeltChangeSignatures("meltQuoteId")
+	if err != nil {
+		t.Fatalf("unexpected error getting melt change signatures: %v", err)
+	}
+	if !reflect.DeepEqual(change, signatures) {
+		t.Fatal("melt change signatures from db do not match saved ones")
+	}
+
+	if err := db.DeleteMeltChange("meltQuoteId"); err != nil {
+		t.Fatalf("unexpected error deleting melt change: %v", err)
+	}
+	changeOutputs, err = db.GetMeltChangeOutputs(B_s)
+	if err != nil || len(changeOutputs) != 0 {
+		t.Fatalf("expected no melt change outputs after delete but got '%v' (%v)", changeOutputs, err)
+	}
+}
+
 func TestBalanceViews(t *testing.T) {
 	dbpath := "./balanceviewsdb"
 	if err := os.MkdirAll(dbpath, 0750); err != nil {
@@ -448,6 +541,138 @@ func TestBalanceViews(t *testing.T) {
 	}
 }
 
+func TestArchiveQuotes(t *testing.T) {
+	dbpath := "./archivequotesdb"
+	if err := os.MkdirAll(dbpath, 0750); err != nil {
+		t.Fatalf("could not create directory test db: %v", err)
+	}
+
+	db, err := InitSQLite(dbpath)
+	if err != nil {
+		t.Fatalf("unexpected error creating sqlite db: %v", err)
+	}
+	defer os.RemoveAll(dbpath)
+
+	// 2024-01-01 and 2024-01-02
+	day1 := uint64(1704067200)
+	day2 := uint64(1704153600)
+	now := uint64(time.Now().Unix())
+
+	mintQuotes := generateRandomMintQuotes(20, false)
+	for i := range mintQuotes {
+		switch {
+		case i < 10:
+			mintQuotes[i].Expiry = day1 + 100
+		case i < 15:
+			mintQuotes[i].Expiry = day2 + 100
+		case i < 17:
+			// expired but paid
+			mintQuotes[i].Expiry = day1 + 100
+			mintQuotes[i].State = nut04.Paid
+		default:
+			mintQuotes[i].Expiry = now + 3600
+		}
+		if err := db.SaveMintQuote(mintQuotes[i]); err != nil {
+			t.Fatalf("error saving mint quote: %v", err)
+		}
+	}
+
+	meltQuotes := generateRandomMeltQuotes(10)
+	for i := range meltQuotes {
+		switch {
+		case i < 6:
+			meltQuotes[i].Expiry = day1 + 100
+		case i < 8:
+			meltQuotes[i].Expiry = day1 + 100
+			meltQuotes[i].State = nut05.Pending
+		default:
+			meltQuotes[i].Expiry = now + 3600
+		}
+		if err := db.SaveMeltQuote(meltQuotes[i]); err != nil {
+			t.Fatalf("error saving melt quote: %v", err)
+		}
+	}
+
+	expiredMintQuotes, err := db.GetExpiredMintQuotes(now)
+	if err != nil {
+		t.Fatalf("error getting expired mint quotes: %v", err)
+	}
+	if len(expiredMintQuotes) != 15 {
+		t.Fatalf("expected '%v' expired mint quotes but got '%v'", 15, len(expiredMintQuotes))
+	}
+
+	expiredMeltQuotes, err := db.GetExpiredMeltQuotes(now)
+	if err != nil {
+		t.Fatalf("error getting expired melt quotes: %v", err)
+	}
+	if len(expiredMeltQuotes) != 6 {
+		t.Fatalf("expected '%v' expired melt quotes but got '%v'", 6, len(expiredMeltQuotes))
+	}
+
+	mintQuoteIds := make([]string, len(expiredMintQuotes))
+	for i, quote := range expiredMintQuotes {
+		mintQuoteIds[i] = quote.Id
+	}
+	// paid quote should not get archived even if passed
+	mintQuoteIds = append(mintQuoteIds, mintQuotes[15].Id)
+	if err := db.ArchiveMintQuotes(mintQuoteIds); err != nil {
+		t.Fatalf("error archiving mint quotes: %v", err)
+	}
+
+	meltQuoteIds := make([]string, len(expiredMeltQuotes))
+	for i, quote := range expiredMeltQuotes {
+		meltQuoteIds[i] = quote.Id
+	}
+	if err := db.ArchiveMeltQuotes(meltQuoteIds); err != nil {
+		t.Fatalf("error archiving melt quotes: %v", err)
+	}
+
+	if _, err := db.GetMintQuote(mintQuotes[0].Id); err == nil {
+		t.Fatal("expected error getting archived mint quote")
+	}
+	if _, err := db.GetMintQuote(mintQuotes[15].Id); err != nil {
+		t.Fatalf("unexpected error getting paid mint quote: %v", err)
+	}
+	if _, err := db.GetMeltQuote(meltQuotes[6].Id); err != nil {
+		t.Fatalf("unexpected error getting pending melt quote: %v", err)
+	}
+
+	expiredMintQuotes, err = db.GetExpiredMintQuotes(now)
+	if err != nil {
+		t.Fatalf("error getting expired mint quotes: %v", err)
+	}
+	if len(expiredMintQuotes) != 0 {
+		t.Fatalf("expected no expired mint quotes but got '%v'", len(expiredMintQuotes))
+	}
+
+	// archive again to check history gets aggregated
+	moreMintQuotes := generateRandomMintQuotes(3, false)
+	moreMintQuoteIds := make([]string, len(moreMintQuotes))
+	for i := range moreMintQuotes {
+		moreMintQuotes[i].Expiry = day1 + 200
+		if err := db.SaveMintQuote(moreMintQuotes[i]); err != nil {
+			t.Fatalf("error saving mint quote: %v", err)
+		}
+		moreMintQuoteIds[i] = moreMintQuotes[i].Id
+	}
+	if err := db.ArchiveMintQuotes(moreMintQuoteIds); err != nil {
+		t.Fatalf("error archiving mint quotes: %v", err)
+	}
+
+	history, err := db.GetQuoteHistory()
+	if err != nil {
+		t.Fatalf("error getting quote history: %v", err)
+	}
+	expectedHistory := []storage.QuoteHistory{
+		{Day: "2024-01-01", Kind: storage.MeltQuoteKind, Count: 6, Amount: 6 * 21},
+		{Day: "2024-01-01", Kind: storage.MintQuoteKind, Count: 13, Amount: 13 * 21},
+		{Day: "2024-01-02", Kind: storage.MintQuoteKind, Count: 5, Amount: 5 * 21},
+	}
+	if !reflect.DeepEqual(expectedHistory, history) {
+		t.Fatalf("expected quote history '%+v' but got '%+v'", expectedHistory, history)
+	}
+}
+
 func generateRandomString(length int) string {
 	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 	b := make([]byte, length)
@@ -500,6 +725,7 @@ func generateRandomMintQuotes(num int, pubkey bool) []storage.MintQuote {
 			PaymentRequest: generateRandomString(100),
 			PaymentHash:    generateRandomString(50),
 			State:          nut04.Unpaid,
+			Description:    generateRandomString(20),
 		}
 		if pubkey {
 			key, err := secp256k1.GeneratePrivateKey()
@@ -554,3 +780,232 @@ func generateBlindSignatures(num int) cashu.BlindedSignatures {
 	}
 	return blindSigs
 }
+
+func TestFeeLedger(t *testing.T) {
+	entries := []storage.FeeEntry{
+		{Operation: storage.SwapOperation, InputFee: 2, CreatedAt: 1000},
+		{Operation: storage.MeltOperation, QuoteId: "quote1", InputFee: 1, FeeReserve: 10, LightningFee: 4, ReserveKept: 6, CreatedAt: 2000},
+		{Operation: storage.InternalMeltOperation, QuoteId: "quote2", InputFee: 1, CreatedAt: 3000},
+	}
+	for _, entry := range entries {
+		if err := db.SaveFeeEntry(entry); err != nil {
+			t.Fatalf("error saving fee entry: %v", err)
+		}
+	}
+
+	allEntries, err := db.GetFeeEntries(0, 3000)
+	if err != nil {
+		t.Fatalf("error getting fee entries: %v", err)
+	}
+	if !reflect.DeepEqual(entries, allEntries) {
+		t.Fatalf("expected fee entries '%+v' but got '%+v'", entries, allEntries)
+	}
+
+	periodEntries, err := db.GetFeeEntries(1500, 2500)
+	if err != nil {
+		t.Fatalf("error getting fee entries: %v", err)
+	}
+	if len(periodEntries) != 1 || !reflect.DeepEqual(entries[1], periodEntries[0]) {
+		t.Fatalf("expected fee entry '%+v' but got '%+v'", entries[1], periodEntries)
+	}
+}
+
+func TestProofConstraints(t *testing.T) {
+	proofs := generateRandomProofs(10)
+	if err := db.SaveProofs(proofs[:5]); err != nil {
+		t.Fatalf("error saving proofs: %v", err)
+	}
+	if err := db.AddPendingProofs(proofs[5:], "quote"); err != nil {
+		t.Fatalf("error adding pending proofs: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		save     func(cashu.Proofs) error
+		proofs   cashu.Proofs
+		expected error
+	}{
+		{"spend spent proof", db.SaveProofs, proofs[:1], storage.ErrProofSpent},
+		{"spend pending proof", db.SaveProofs, proofs[5:6], storage.ErrProofPending},
+		{
+			"set spent proof as pending",
+			func(p cashu.Proofs) error { return db.AddPendingProofs(p, "quote2") },
+			proofs[:1],
+			storage.ErrProofSpent,
+		},
+		{
+			"set pending proof as pending",
+			func(p cashu.Proofs) error { return db.AddPendingProofs(p, "quote2") },
+			proofs[5:6],
+			storage.ErrProofPending,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			// new proof in the same request should not be saved
+			newProof := generateRandomProofs(1)
+			err := test.save(append(newProof, test.proofs...))
+			if !errors.Is(err, test.expected) {
+				t.Fatalf("expected error '%v' but got '%v'", test.expected, err)
+			}
+
+			Y, _ := crypto.HashToCurve([]byte(newProof[0].Secret))
+			Ys := []string{hex.EncodeToString(Y.SerializeCompressed())}
+			used, _ := db.GetProofsUsed(Ys)
+			pending, _ := db.GetPendingProofs(Ys)
+			if len(used) > 0 || len(pending) > 0 {
+				t.Fatal("expected no proofs saved from a request that failed")
+			}
+		})
+	}
+}
+
+func TestSettlePendingProofs(t *testing.T) {
+	proofs := generateRandomProofs(10)
+	if err := db.AddPendingProofs(proofs, "settlequote"); err != nil {
+		t.Fatalf("error adding pending proofs: %v", err)
+	}
+	Ys := make([]string, len(proofs))
+	for i, proof := range proofs {
+		Y, _ := crypto.HashToCurve([]byte(proof.Secret))
+		Ys[i] = hex.EncodeToString(Y.SerializeCompressed())
+	}
+
+	// nothing is settled if one of the proofs is not pending
+	notPending := generateRandomProofs(1)
+	Y, _ := crypto.HashToCurve([]byte(notPending[0].Secret))
+	if err := db.SettlePendingProofs(append(Ys[:1:1], hex.EncodeToString(Y.SerializeCompressed()))); err == nil {
+		t.Fatal("expected error settling proof that is not pending")
+	}
+	pending, _ := db.GetPendingProofs(Ys)
+	if len(pending) != len(proofs) {
+		t.Fatalf("expected %v pending proofs but got %v", len(proofs), len(pending))
+	}
+
+	if err := db.SettlePendingProofs(Ys); err != nil {
+		t.Fatalf("unexpected error settling pending proofs: %v", err)
+	}
+	pending, _ = db.GetPendingProofs(Ys)
+	if len(pending) != 0 {
+		t.Fatalf("expected no pending proofs but got %v", len(pending))
+	}
+	used, _ := db.GetProofsUsed(Ys)
+	if len(used) != len(proofs) {
+		t.Fatalf("expected %v spent proofs but got %v", len(proofs), len(used))
+	}
+	for _, proof := range used {
+		if proof.Id != proofs[0].Id || proof.Secret == "" || proof.C == "" {
+			t.Fatalf("unexpected spent proof: %+v", proof)
+		}
+	}
+}
+
+func TestCompareAndSetQuoteState(t *testing.T) {
+	mintQuote := generateRandomMintQuotes(1, false)[0]
+	if err := db.SaveMintQuote(mintQuote); err != nil {
+		t.Fatalf("error saving mint quote: %v", err)
+	}
+
+	set, err := db.CompareAndSetMintQuoteState(mintQuote.Id, nut04.Paid, nut04.Pending)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if set {
+		t.Fatal("expected state not to be set for quote in another state")
+	}
+	set, err = db.CompareAndSetMintQuoteState(mintQuote.Id, nut04.Unpaid, nut04.Paid)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !set {
+		t.Fatal("expected state to be set")
+	}
+	dbMintQuote, _ := db.GetMintQuote(mintQuote.Id)
+	if dbMintQuote.State != nut04.Paid {
+		t.Fatalf("expected state '%v' but got '%v'", nut04.Paid, dbMintQuote.State)
+	}
+
+	meltQuote := generateRandomMeltQuotes(1)[0]
+	if err := db.SaveMeltQuote(meltQuote); err != nil {
+		t.Fatalf("error saving melt quote: %v", err)
+	}
+
+	// only one of several concurrent transitions should succeed
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	transitions := 0
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			set, err := db.CompareAndSetMeltQuote(meltQuote.Id, "", nut05.Unpaid, nut05.Pending)
+			if err != nil {
+				t.Errorf("unexpected error: %v", err)
+				return
+			}
+			if set {
+				mu.Lock()
+				transitions++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+	if transitions != 1 {
+		t.Fatalf("expected 1 transition but got %v", transitions)
+	}
+
+	set, err = db.CompareAndSetMeltQuote(meltQuote.Id, "preimage", nut05.Pending, nut05.Paid)
+	if err != nil || !set {
+		t.Fatalf("expected quote to be set as paid. Error: %v", err)
+	}
+	dbMeltQuote, _ := db.GetMeltQuote(meltQuote.Id)
+	if dbMeltQuote.State != nut05.Paid || dbMeltQuote.Preimage != "preimage" {
+		t.Fatalf("unexpected melt quote in db: %+v", dbMeltQuote)
+	}
+}
+
+func TestLeases(t *testing.T) {
+	ttl := 200 * time.Millisecond
+
+	acquired, err := db.AcquireLease("job", "instance1", ttl)
+	if err != nil || !acquired {
+		t.Fatalf("expected lease to be acquired. Error: %v", err)
+	}
+	acquired, err = db.AcquireLease("job", "instance2", ttl)
+	if err != nil || acquired {
+		t.Fatalf("expected lease to be held by other instance. Error: %v", err)
+	}
+	// holder can renew it
+	acquired, err = db.AcquireLease("job", "instance1", ttl)
+	if err != nil || !acquired {
+		t.Fatalf("expected lease to be renewed. Error: %v", err)
+	}
+	// other leases are independent
+	acquired, err = db.AcquireLease("otherjob", "instance2", ttl)
+	if err != nil || !acquired {
+		t.Fatalf("expected lease to be acquired. Error: %v", err)
+	}
+
+	time.Sleep(ttl + 50*time.Millisecond)
+	acquired, err = db.AcquireLease("job", "instance2", ttl)
+	if err != nil || !acquired {
+		t.Fatalf("expected expired lease to be acquired. Error: %v", err)
+	}
+
+	if err := db.ReleaseLease("job", "instance1"); err != nil {
+		t.Fatalf("unexpected error releasing lease: %v", err)
+	}
+	acquired, _ = db.AcquireLease("job", "instance1", ttl)
+	if acquired {
+		t.Fatal("expected release from previous holder to not remove lease")
+	}
+	if err := db.ReleaseLease("job", "instance2"); err != nil {
+		t.Fatalf("unexpected error releasing lease: %v", err)
+	}
+	acquired, _ = db.AcquireLease("job", "instance1", ttl)
+	if !acquired {
+		t.Fatal("expected released lease to be acquired")
+	}
+}
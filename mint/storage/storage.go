@@ -1,6 +1,9 @@
 package storage
 
 import (
+	"errors"
+	"time"
+
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
@@ -15,23 +18,70 @@ type MintDB interface {
 	GetKeysets() ([]DBKeyset, error)
 	UpdateKeysetActive(keysetId string, active bool) error
 
+	// returns ErrProofSpent or ErrProofPending if any of the proofs
+	// is already spent or pending
 	SaveProofs(cashu.Proofs) error
 	GetProofsUsed(Ys []string) ([]DBProof, error)
+	// returns ErrProofSpent or ErrProofPending if any of the proofs
+	// is already spent or pending
 	AddPendingProofs(proofs cashu.Proofs, quoteId string) error
 	GetPendingProofs(Ys []string) ([]DBProof, error)
 	GetPendingProofsByQuote(quoteId string) ([]DBProof, error)
 	RemovePendingProofs(Ys []string) error
+	// moves the pending proofs with the Ys to the spent proofs in a single
+	// transaction so that they cannot be spent again in between
+	SettlePendingProofs(Ys []string) error
 
 	SaveMintQuote(MintQuote) error
 	GetMintQuote(string) (MintQuote, error)
 	GetMintQuoteByPaymentHash(string) (MintQuote, error)
 	UpdateMintQuoteState(quoteId string, state nut04.State) error
+	// updates the state only if the current state is the expected one.
+	// Returns false if the quote was in another state
+	CompareAndSetMintQuoteState(quoteId string, expected, state nut04.State) (bool, error)
 
 	SaveMeltQuote(MeltQuote) error
 	GetMeltQuote(string) (MeltQuote, error)
 	// used to check if a melt quote already exists for the passed invoice
 	GetMeltQuoteByPaymentRequest(string) (*MeltQuote, error)
 	UpdateMeltQuote(quoteId string, preimage string, state nut05.State) error
+	// updates the quote only if the current state is the expected one.
+	// Returns false if the quote was in another state
+	CompareAndSetMeltQuote(quoteId string, preimage string, expected, state nut05.State) (bool, error)
+	// records the lightning fee actually paid for the melt quote
+	UpdateMeltQuoteFeePaid(quoteId string, feePaid uint64) error
+
+	// blank outputs (NUT-08) provided in a melt request to return overpaid fees
+	SaveMeltChange(quoteId string, blindedMessages cashu.BlindedMessages) error
+	GetMeltChange(quoteId string) (cashu.BlindedMessages, error)
+	// returns the blank outputs stored for any melt quote that have one of the B_ values
+	GetMeltChangeOutputs(B_s []string) (cashu.BlindedMessages, error)
+	// returns the blind signatures for the change of a melt quote in the order of the outputs
+	GetMeltChangeSignatures(quoteId string) (cashu.BlindedSignatures, error)
+	DeleteMeltChange(quoteId string) error
+	// replaces the blank outputs stored for the melt quote with the outputs signed
+	// as change and saves their signatures in a single transaction
+	ReplaceMeltChange(quoteId string, outputs cashu.BlindedMessages, B_s []string, signatures cashu.BlindedSignatures) error
+
+	// return the UNPAID quotes that expired before the unix timestamp
+	GetExpiredMintQuotes(expiredBefore uint64) ([]MintQuote, error)
+	GetExpiredMeltQuotes(expiredBefore uint64) ([]MeltQuote, error)
+	// return the melt quotes with a payment in PENDING state
+	GetPendingMeltQuotes() ([]MeltQuote, error)
+	// delete the quotes if they are still UNPAID and add them to the quote history
+	ArchiveMintQuotes(quoteIds []string) error
+	ArchiveMeltQuotes(quoteIds []string) error
+	GetQuoteHistory() ([]QuoteHistory, error)
+
+	// fees collected and paid by the mint in each operation
+	SaveFeeEntry(FeeEntry) error
+	// returns the entries created between the unix timestamps (inclusive)
+	GetFeeEntries(from, to int64) ([]FeeEntry, error)
+
+	// acquires the lease with the name for the holder, or renews it if the holder
+	// already has it. Returns false if the lease is held by another holder and has not expired
+	AcquireLease(name, holder string, ttl time.Duration) (bool, error)
+	ReleaseLease(name, holder string) error
 
 	SaveBlindSignatures(B_s []string, blindSignatures cashu.BlindedSignatures) error
 	GetBlindSignature(B_ string) (cashu.BlindedSignature, error)
@@ -44,6 +94,44 @@ type MintDB interface {
 	Close() error
 }
 
+var (
+	ErrProofSpent   = errors.New("proof already spent")
+	ErrProofPending = errors.New("proof is pending")
+)
+
+const (
+	MintQuoteKind = "mint"
+	MeltQuoteKind = "melt"
+)
+
+// QuoteHistory aggregates, per day of expiry and kind of quote,
+// the expired quotes that were deleted from the db
+type QuoteHistory struct {
+	Day    string `json:"day"`
+	Kind   string `json:"kind"`
+	Count  uint64 `json:"count"`
+	Amount uint64 `json:"amount"`
+}
+
+const (
+	SwapOperation         = "swap"
+	MeltOperation         = "melt"
+	InternalMeltOperation = "internal_melt"
+)
+
+// FeeEntry records the fees of a single operation.
+// ReserveKept is what the mint kept from the inputs of a melt
+// after paying the lightning fee and returning the change
+type FeeEntry struct {
+	Operation    string `json:"operation"`
+	QuoteId      string `json:"quote_id,omitempty"`
+	InputFee     uint64 `json:"input_fee"`
+	FeeReserve   uint64 `json:"fee_reserve"`
+	LightningFee uint64 `json:"lightning_fee"`
+	ReserveKept  uint64 `json:"reserve_kept"`
+	CreatedAt    int64  `json:"created_at"`
+}
+
 type DBKeyset struct {
 	Id                string
 	Unit              string
@@ -72,6 +160,7 @@ type MintQuote struct {
 	State          nut04.State
 	Expiry         uint64
 	Pubkey         *secp256k1.PublicKey
+	Description    string
 }
 
 type MeltQuote struct {
@@ -84,6 +173,11 @@ type MeltQuote struct {
 	Expiry         uint64
 	Preimage       string
 	IsMpp          bool
-	// used when the melt quote is MPP
-	AmountMsat uint64
+	// used when the melt quote is MPP or amountless
+	AmountMsat   uint64
+	IsAmountless bool
+	// lightning fee actually paid. Only set once the quote is paid
+	FeePaid uint64
+	// blind signatures for overpaid fees (NUT-08). Not stored with the quote
+	Change cashu.BlindedSignatures
 }
@@ -20,6 +20,8 @@ const (
 	BOLT11_MINT_QUOTE_TOPIC = "bolt11_mint_quote_topic"
 	BOLT11_MELT_QUOTE_TOPIC = "bolt11_melt_quote_topic"
 	PROOF_STATE_TOPIC       = "proof_state_topic"
+	SOLVENCY_TOPIC          = "solvency_topic"
+	KEYSET_TOPIC            = "keyset_topic"
 )
 
 var upgrader = websocket.Upgrader{
@@ -376,6 +378,8 @@ func (c *Client) close() error {
 	for _, subClient := range c.subscriptions {
 		subClient.Close()
 	}
+	closeMsg := websocket.FormatCloseMessage(websocket.CloseGoingAway, "mint shutting down")
+	c.conn.WriteControl(websocket.CloseMessage, closeMsg, time.Now().Add(time.Second))
 	c.conn.Close()
 	close(c.send)
 	return nil
@@ -0,0 +1,59 @@
+package mint
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// runParallel calls fn for every index in [0, n) using at most the given number
+// of workers. If fn fails for more than one index, the error for the lowest
+// index is returned so the result does not depend on the scheduling of the workers.
+func runParallel(n, workers int, fn func(i int) error) error {
+	if workers > n {
+		workers = n
+	}
+	if workers <= 1 {
+		for i := 0; i < n; i++ {
+			if err := fn(i); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	var next atomic.Int64
+	var failedIndex atomic.Int64
+	failedIndex.Store(int64(n))
+	errs := make([]error, n)
+
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for w := 0; w < workers; w++ {
+		go func() {
+			defer wg.Done()
+			for {
+				// indexes are handed out in increasing order so once past
+				// a failed index, the remaining ones do not need to be checked
+				i := next.Add(1) - 1
+				if i >= int64(n) || i > failedIndex.Load() {
+					return
+				}
+				if err := fn(int(i)); err != nil {
+					errs[i] = err
+					for {
+						current := failedIndex.Load()
+						if i >= current || failedIndex.CompareAndSwap(current, i) {
+							break
+						}
+					}
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	if i := failedIndex.Load(); i < int64(n) {
+		return errs[i]
+	}
+	return nil
+}
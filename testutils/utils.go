@@ -457,6 +457,10 @@ func (clnContainer *CLNBackend) CreateInvoice(amount uint64) (*Invoice, error) {
 		"label":       time.Now().Unix() + int64(r.Int()),
 		"description": "test",
 	}
+	// create invoice with no amount
+	if amount == 0 {
+		body["amount_msat"] = "any"
+	}
 
 	resp, err := clnContainer.Post(clnContainer.url+"/invoice", body)
 	if err != nil {
@@ -1005,39 +1009,6 @@ func AddP2PKWitnessToInputs(inputs cashu.Proofs, signingKeys []*btcec.PrivateKey
 	return inputs, nil
 }
 
-func AddP2PKWitnessToOutputs(
-	outputs cashu.BlindedMessages,
-	signingKeys []*btcec.PrivateKey,
-) (cashu.BlindedMessages, error) {
-	for i, output := range outputs {
-		msgToSign, err := hex.DecodeString(output.B_)
-		if err != nil {
-			return nil, err
-		}
-		hash := sha256.Sum256(msgToSign)
-		signatures := make([]string, len(signingKeys))
-
-		for j, key := range signingKeys {
-			signature, err := schnorr.Sign(key, hash[:])
-			if err != nil {
-				return nil, err
-			}
-			sig := hex.EncodeToString(signature.Serialize())
-			signatures[j] = sig
-		}
-
-		p2pkWitness := nut11.P2PKWitness{Signatures: signatures}
-		witness, err := json.Marshal(p2pkWitness)
-		if err != nil {
-			return nil, err
-		}
-		output.Witness = string(witness)
-		outputs[i] = output
-	}
-
-	return outputs, nil
-}
-
 // it will add signatures if signingKey is not nil
 func AddHTLCWitnessToInputs(inputs cashu.Proofs, preimage string, signingKey *btcec.PrivateKey) (cashu.Proofs, error) {
 	for i, proof := range inputs {
@@ -1065,37 +1036,6 @@ func AddHTLCWitnessToInputs(inputs cashu.Proofs, preimage string, signingKey *bt
 	return inputs, nil
 }
 
-// it will add signatures if signingKey is not nil
-func AddHTLCWitnessToOutputs(outputs cashu.BlindedMessages, preimage string, signingKey *btcec.PrivateKey) (cashu.BlindedMessages, error) {
-	for i, output := range outputs {
-		htlcWitness := nut14.HTLCWitness{Preimage: preimage}
-
-		if signingKey != nil {
-			msgToSign, err := hex.DecodeString(output.B_)
-			if err != nil {
-				return nil, err
-			}
-			hash := sha256.Sum256(msgToSign)
-			signature, err := schnorr.Sign(signingKey, hash[:])
-			if err != nil {
-				return nil, err
-			}
-			sig := hex.EncodeToString(signature.Serialize())
-			htlcWitness.Signatures = []string{sig}
-		}
-
-		witness, err := json.Marshal(htlcWitness)
-		if err != nil {
-			return nil, err
-		}
-
-		output.Witness = string(witness)
-		outputs[i] = output
-	}
-
-	return outputs, nil
-}
-
 func Fees(proofs cashu.Proofs, mint string) (uint, error) {
 	keysetResponse, err := client.GetAllKeysets(mint)
 	if err != nil {
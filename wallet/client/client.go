@@ -2,10 +2,13 @@ package client
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
+	"time"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut01"
@@ -18,346 +21,420 @@ import (
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
 )
 
-func GetMintInfo(mintURL string) (*nut06.MintInfo, error) {
-	resp, err := get(mintURL + "/v1/info")
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+const (
+	DefaultTimeout     = 30 * time.Second
+	DefaultMeltTimeout = 5 * time.Minute
+	DefaultUserAgent   = "gonuts-tollgate"
+)
 
-	var mintInfo nut06.MintInfo
-	if err := json.Unmarshal(body, &mintInfo); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
-	}
+// Config of the HTTP client used to make requests to the mints
+type Config struct {
+	// Timeout of each request. Defaults to DefaultTimeout.
+	Timeout time.Duration
+	// Timeout of melt requests, which can take until the payment completes.
+	// Defaults to DefaultMeltTimeout.
+	MeltTimeout time.Duration
+	// ProxyURL routes the requests through a proxy. Schemes http, https,
+	// socks5 and socks5h are supported (e.g socks5h://127.0.0.1:9050 for Tor).
+	ProxyURL string
+	// UserAgent sent in the requests. Defaults to DefaultUserAgent.
+	UserAgent string
+	// Retry of the requests that do not change the state of the mint.
+	Retry RetryPolicy
+	// HTTPClient used to make the requests. If set, ProxyURL is ignored.
+	HTTPClient *http.Client
+}
 
-	return &mintInfo, nil
+// RetryPolicy retries a request that failed with a network error (except
+// ErrTLS) or a 5xx or 429 response. Requests that change the state of the mint
+// (i.e mint, swap, melt) are not retried.
+type RetryPolicy struct {
+	// MaxRetries is the number of times a request is retried. 0 disables retries.
+	MaxRetries int
+	// Backoff is the wait before the first retry. It is doubled after each retry.
+	Backoff time.Duration
 }
 
-func GetActiveKeysets(mintURL string) (*nut01.GetKeysResponse, error) {
-	resp, err := get(mintURL + "/v1/keys")
-	if err != nil {
-		return nil, err
+// DefaultConfig returns the config of the client used when none is provided
+func DefaultConfig() Config {
+	return Config{
+		Timeout:     DefaultTimeout,
+		MeltTimeout: DefaultMeltTimeout,
+		UserAgent:   DefaultUserAgent,
+		Retry:       RetryPolicy{MaxRetries: 2, Backoff: 500 * time.Millisecond},
 	}
-	defer resp.Body.Close()
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+// Client makes requests to the REST API of a mint.
+// Errors returned by the mint are cashu.Error.
+type Client struct {
+	mintURL    string
+	httpClient *http.Client
+	config     Config
+}
 
-	var keysetRes nut01.GetKeysResponse
-	if err := json.Unmarshal(body, &keysetRes); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+// NewClient creates a client for the mint at mintURL.
+// Zero values in the config are set to the defaults.
+func NewClient(mintURL string, config Config) (*Client, error) {
+	defaultConfig := DefaultConfig()
+	if config.Timeout == 0 {
+		config.Timeout = defaultConfig.Timeout
+	}
+	if config.MeltTimeout == 0 {
+		config.MeltTimeout = defaultConfig.MeltTimeout
+	}
+	if len(config.UserAgent) == 0 {
+		config.UserAgent = defaultConfig.UserAgent
+	}
+
+	httpClient := config.HTTPClient
+	if httpClient == nil {
+		transport := http.DefaultTransport.(*http.Transport).Clone()
+		if len(config.ProxyURL) > 0 {
+			proxyURL, err := url.Parse(config.ProxyURL)
+			if err != nil {
+				return nil, fmt.Errorf("invalid proxy url: %v", err)
+			}
+			switch proxyURL.Scheme {
+			case "http", "https", "socks5", "socks5h":
+			default:
+				return nil, fmt.Errorf("unsupported proxy scheme '%v'", proxyURL.Scheme)
+			}
+			transport.Proxy = http.ProxyURL(proxyURL)
+		}
+		httpClient = &http.Client{Transport: transport}
 	}
 
-	return &keysetRes, nil
+	return &Client{
+		mintURL:    mintURL,
+		httpClient: httpClient,
+		config:     config,
+	}, nil
 }
 
-func GetAllKeysets(mintURL string) (*nut02.GetKeysetsResponse, error) {
-	resp, err := get(mintURL + "/v1/keysets")
-	if err != nil {
-		return nil, err
+// WithMintURL returns a client for another mint that
+// shares the connections and config of this one
+func (c *Client) WithMintURL(mintURL string) *Client {
+	return &Client{
+		mintURL:    mintURL,
+		httpClient: c.httpClient,
+		config:     c.config,
 	}
-	defer resp.Body.Close()
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) MintURL() string {
+	return c.mintURL
+}
 
-	var keysetsRes nut02.GetKeysetsResponse
-	if err := json.Unmarshal(body, &keysetsRes); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+// Proxy returns the proxy used for the requests to the mint so that other
+// connections to it (i.e websockets) can go through the same one
+func (c *Client) Proxy() func(*http.Request) (*url.URL, error) {
+	if transport, ok := c.httpClient.Transport.(*http.Transport); ok {
+		return transport.Proxy
 	}
-
-	return &keysetsRes, nil
+	return nil
 }
 
-func GetKeysetById(mintURL, id string) (*nut01.GetKeysResponse, error) {
-	resp, err := get(mintURL + "/v1/keys/" + id)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+// Timeout returns the timeout of each request to the mint
+func (c *Client) Timeout() time.Duration {
+	return c.config.Timeout
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+func (c *Client) GetMintInfo(ctx context.Context) (*nut06.MintInfo, error) {
+	var mintInfo nut06.MintInfo
+	if err := c.get(ctx, "/v1/info", &mintInfo); err != nil {
 		return nil, err
 	}
+	return &mintInfo, nil
+}
 
+func (c *Client) GetActiveKeysets(ctx context.Context) (*nut01.GetKeysResponse, error) {
 	var keysetRes nut01.GetKeysResponse
-	if err := json.Unmarshal(body, &keysetRes); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+	if err := c.get(ctx, "/v1/keys", &keysetRes); err != nil {
+		return nil, err
 	}
-
 	return &keysetRes, nil
 }
 
-func PostMintQuoteBolt11(mintURL string, mintQuoteRequest nut04.PostMintQuoteBolt11Request) (
-	*nut04.PostMintQuoteBolt11Response, error) {
-	requestBody, err := json.Marshal(mintQuoteRequest)
-	if err != nil {
-		return nil, fmt.Errorf("json.Marshal: %v", err)
-	}
-
-	resp, err := httpPost(mintURL+"/v1/mint/quote/bolt11", "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
+func (c *Client) GetAllKeysets(ctx context.Context) (*nut02.GetKeysetsResponse, error) {
+	var keysetsRes nut02.GetKeysetsResponse
+	if err := c.get(ctx, "/v1/keysets", &keysetsRes); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return &keysetsRes, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+func (c *Client) GetKeysetById(ctx context.Context, id string) (*nut01.GetKeysResponse, error) {
+	var keysetRes nut01.GetKeysResponse
+	if err := c.get(ctx, "/v1/keys/"+id, &keysetRes); err != nil {
 		return nil, err
 	}
+	return &keysetRes, nil
+}
 
+func (c *Client) PostMintQuoteBolt11(
+	ctx context.Context,
+	mintQuoteRequest nut04.PostMintQuoteBolt11Request,
+) (*nut04.PostMintQuoteBolt11Response, error) {
 	var reqMintResponse nut04.PostMintQuoteBolt11Response
-	if err := json.Unmarshal(body, &reqMintResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+	if err := c.post(ctx, "/v1/mint/quote/bolt11", mintQuoteRequest, &reqMintResponse, false); err != nil {
+		return nil, err
 	}
-
 	return &reqMintResponse, nil
 }
 
-func GetMintQuoteState(mintURL, quoteId string) (*nut04.PostMintQuoteBolt11Response, error) {
-	resp, err := get(mintURL + "/v1/mint/quote/bolt11/" + quoteId)
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) GetMintQuoteState(ctx context.Context, quoteId string) (*nut04.PostMintQuoteBolt11Response, error) {
 	var mintQuoteResponse nut04.PostMintQuoteBolt11Response
-	if err := json.Unmarshal(body, &mintQuoteResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+	if err := c.get(ctx, "/v1/mint/quote/bolt11/"+quoteId, &mintQuoteResponse); err != nil {
+		return nil, err
 	}
-
 	return &mintQuoteResponse, nil
 }
 
-func PostMintBolt11(mintURL string, mintRequest nut04.PostMintBolt11Request) (
-	*nut04.PostMintBolt11Response, error) {
-	requestBody, err := json.Marshal(mintRequest)
-	if err != nil {
-		return nil, fmt.Errorf("json.Marshal: %v", err)
-	}
-
-	resp, err := httpPost(mintURL+"/v1/mint/bolt11", "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
+func (c *Client) PostMintBolt11(
+	ctx context.Context,
+	mintRequest nut04.PostMintBolt11Request,
+) (*nut04.PostMintBolt11Response, error) {
 	var reqMintResponse nut04.PostMintBolt11Response
-	if err := json.Unmarshal(body, &reqMintResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+	if err := c.post(ctx, "/v1/mint/bolt11", mintRequest, &reqMintResponse, false); err != nil {
+		return nil, err
 	}
-
 	return &reqMintResponse, nil
 }
 
-func PostSwap(mintURL string, swapRequest nut03.PostSwapRequest) (*nut03.PostSwapResponse, error) {
-	requestBody, err := json.Marshal(swapRequest)
-	if err != nil {
-		return nil, fmt.Errorf("json.Marshal: %v", err)
-	}
-
-	resp, err := httpPost(mintURL+"/v1/swap", "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
+func (c *Client) PostSwap(ctx context.Context, swapRequest nut03.PostSwapRequest) (*nut03.PostSwapResponse, error) {
+	var swapResponse nut03.PostSwapResponse
+	if err := c.post(ctx, "/v1/swap", swapRequest, &swapResponse, false); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return &swapResponse, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+func (c *Client) PostMeltQuoteBolt11(
+	ctx context.Context,
+	meltQuoteRequest nut05.PostMeltQuoteBolt11Request,
+) (*nut05.PostMeltQuoteBolt11Response, error) {
+	var meltQuoteResponse nut05.PostMeltQuoteBolt11Response
+	if err := c.post(ctx, "/v1/melt/quote/bolt11", meltQuoteRequest, &meltQuoteResponse, false); err != nil {
 		return nil, err
 	}
+	return &meltQuoteResponse, nil
+}
 
-	var swapResponse nut03.PostSwapResponse
-	if err := json.Unmarshal(body, &swapResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+func (c *Client) GetMeltQuoteState(ctx context.Context, quoteId string) (*nut05.PostMeltQuoteBolt11Response, error) {
+	var meltQuoteResponse nut05.PostMeltQuoteBolt11Response
+	if err := c.get(ctx, "/v1/melt/quote/bolt11/"+quoteId, &meltQuoteResponse); err != nil {
+		return nil, err
 	}
-
-	return &swapResponse, nil
+	return &meltQuoteResponse, nil
 }
 
-func PostMeltQuoteBolt11(mintURL string, meltQuoteRequest nut05.PostMeltQuoteBolt11Request) (
-	*nut05.PostMeltQuoteBolt11Response, error) {
-
-	requestBody, err := json.Marshal(meltQuoteRequest)
+// PostMeltBolt11 uses MeltTimeout instead of Timeout
+// since the mint can respond once the payment completes
+func (c *Client) PostMeltBolt11(
+	ctx context.Context,
+	meltRequest nut05.PostMeltBolt11Request,
+) (*nut05.PostMeltQuoteBolt11Response, error) {
+	requestBody, err := json.Marshal(meltRequest)
 	if err != nil {
 		return nil, fmt.Errorf("json.Marshal: %v", err)
 	}
 
-	resp, err := httpPost(mintURL+"/v1/melt/quote/bolt11", "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
+	var meltResponse nut05.PostMeltQuoteBolt11Response
+	timeout := max(c.config.MeltTimeout, c.config.Timeout)
+	if err := c.do(ctx, http.MethodPost, "/v1/melt/bolt11", requestBody, &meltResponse, false, timeout); err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	return &meltResponse, nil
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
+func (c *Client) PostCheckProofState(
+	ctx context.Context,
+	stateRequest nut07.PostCheckStateRequest,
+) (*nut07.PostCheckStateResponse, error) {
+	var stateResponse nut07.PostCheckStateResponse
+	if err := c.post(ctx, "/v1/checkstate", stateRequest, &stateResponse, true); err != nil {
 		return nil, err
 	}
+	return &stateResponse, nil
+}
 
-	var meltQuoteResponse nut05.PostMeltQuoteBolt11Response
-	if err := json.Unmarshal(body, &meltQuoteResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+func (c *Client) PostRestore(ctx context.Context, restoreRequest nut09.PostRestoreRequest) (*nut09.PostRestoreResponse, error) {
+	var restoreResponse nut09.PostRestoreResponse
+	if err := c.post(ctx, "/v1/restore", restoreRequest, &restoreResponse, true); err != nil {
+		return nil, err
 	}
+	return &restoreResponse, nil
+}
 
-	return &meltQuoteResponse, nil
+func (c *Client) get(ctx context.Context, path string, response any) error {
+	return c.do(ctx, http.MethodGet, path, nil, response, true, c.config.Timeout)
 }
 
-func GetMeltQuoteState(mintURL, quoteId string) (*nut05.PostMeltQuoteBolt11Response, error) {
-	resp, err := get(mintURL + "/v1/melt/quote/bolt11/" + quoteId)
+// post sends the request as json. If retryable is false, the request
+// changes the state of the mint and it is not sent more than once.
+func (c *Client) post(ctx context.Context, path string, request, response any, retryable bool) error {
+	requestBody, err := json.Marshal(request)
 	if err != nil {
-		return nil, err
+		return fmt.Errorf("json.Marshal: %v", err)
 	}
-	defer resp.Body.Close()
+	return c.do(ctx, http.MethodPost, path, requestBody, response, retryable, c.config.Timeout)
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+func (c *Client) do(
+	ctx context.Context,
+	method, path string,
+	requestBody []byte,
+	response any,
+	retryable bool,
+	timeout time.Duration,
+) error {
+	maxRetries := 0
+	if retryable {
+		maxRetries = c.config.Retry.MaxRetries
+	}
+	backoff := c.config.Retry.Backoff
+
+	for attempt := 0; ; attempt++ {
+		body, err := c.send(ctx, method, path, requestBody, timeout)
+		if err == nil {
+			if err := json.Unmarshal(body, response); err != nil {
+				return fmt.Errorf("error reading response from mint: %v", err)
+			}
+			return nil
+		}
+		if attempt >= maxRetries || !isTransientError(err) {
+			return err
+		}
 
-	var meltQuoteResponse nut05.PostMeltQuoteBolt11Response
-	if err := json.Unmarshal(body, &meltQuoteResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff *= 2
 	}
-
-	return &meltQuoteResponse, nil
 }
 
-func PostMeltBolt11(mintURL string, meltRequest nut05.PostMeltBolt11Request) (
-	*nut05.PostMeltQuoteBolt11Response, error) {
+// send makes a single request and returns the body of the response
+func (c *Client) send(
+	ctx context.Context,
+	method, path string,
+	requestBody []byte,
+	timeout time.Duration,
+) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
 
-	requestBody, err := json.Marshal(meltRequest)
-	if err != nil {
-		return nil, fmt.Errorf("json.Marshal: %v", err)
+	var body io.Reader
+	if requestBody != nil {
+		body = bytes.NewReader(requestBody)
 	}
-
-	resp, err := httpPost(mintURL+"/v1/melt/bolt11", "application/json", bytes.NewBuffer(requestBody))
+	req, err := http.NewRequestWithContext(ctx, method, c.mintURL+path, body)
 	if err != nil {
 		return nil, err
 	}
-	defer resp.Body.Close()
+	req.Header.Set("User-Agent", c.config.UserAgent)
+	if requestBody != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
 
-	body, err := io.ReadAll(resp.Body)
+	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, err
+		return nil, transportError(err)
 	}
+	defer resp.Body.Close()
 
-	var meltResponse nut05.PostMeltQuoteBolt11Response
-	if err := json.Unmarshal(body, &meltResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+	if err := parse(resp); err != nil {
+		return nil, err
 	}
-
-	return &meltResponse, nil
+	return readBody(resp.Body)
 }
 
-func PostCheckProofState(mintURL string, stateRequest nut07.PostCheckStateRequest) (
-	*nut07.PostCheckStateResponse, error) {
-
-	requestBody, err := json.Marshal(stateRequest)
-	if err != nil {
-		return nil, fmt.Errorf("json.Marshal: %v", err)
+// parse returns the error in the response if the status is not 200.
+// Mints can return a cashu.Error with statuses other than 400.
+func parse(response *http.Response) error {
+	if response.StatusCode == http.StatusOK {
+		return nil
 	}
 
-	resp, err := httpPost(mintURL+"/v1/checkstate", "application/json", bytes.NewBuffer(requestBody))
+	body, err := readBody(response.Body)
 	if err != nil {
-		return nil, err
+		return err
 	}
-	defer resp.Body.Close()
-
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
-	var stateResponse nut07.PostCheckStateResponse
-	if err := json.Unmarshal(body, &stateResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
+	var errResponse cashu.Error
+	if err := json.Unmarshal(body, &errResponse); err == nil && errResponse.Code != 0 {
+		return errResponse
 	}
+	return &HTTPError{StatusCode: response.StatusCode, Body: body}
+}
 
-	return &stateResponse, nil
+// NewDefaultClient returns a client for the mint with the default config.
+// The clients created with it share http.DefaultClient.
+func NewDefaultClient(mintURL string) *Client {
+	config := DefaultConfig()
+	config.HTTPClient = http.DefaultClient
+	c, _ := NewClient(mintURL, config)
+	return c
 }
 
-func PostRestore(mintURL string, restoreRequest nut09.PostRestoreRequest) (
-	*nut09.PostRestoreResponse, error) {
+// The functions below make a request with the default client.
+// Use a Client to set a context, timeouts or a proxy.
 
-	requestBody, err := json.Marshal(restoreRequest)
-	if err != nil {
-		return nil, fmt.Errorf("json.Marshal: %v", err)
-	}
+func GetMintInfo(mintURL string) (*nut06.MintInfo, error) {
+	return NewDefaultClient(mintURL).GetMintInfo(context.Background())
+}
 
-	resp, err := httpPost(mintURL+"/v1/restore", "application/json", bytes.NewBuffer(requestBody))
-	if err != nil {
-		return nil, err
-	}
-	defer resp.Body.Close()
+func GetActiveKeysets(mintURL string) (*nut01.GetKeysResponse, error) {
+	return NewDefaultClient(mintURL).GetActiveKeysets(context.Background())
+}
 
-	body, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
+func GetAllKeysets(mintURL string) (*nut02.GetKeysetsResponse, error) {
+	return NewDefaultClient(mintURL).GetAllKeysets(context.Background())
+}
 
-	var restoreResponse nut09.PostRestoreResponse
-	if err := json.Unmarshal(body, &restoreResponse); err != nil {
-		return nil, fmt.Errorf("error reading response from mint: %v", err)
-	}
+func GetKeysetById(mintURL, id string) (*nut01.GetKeysResponse, error) {
+	return NewDefaultClient(mintURL).GetKeysetById(context.Background(), id)
+}
 
-	return &restoreResponse, nil
+func PostMintQuoteBolt11(mintURL string, mintQuoteRequest nut04.PostMintQuoteBolt11Request) (
+	*nut04.PostMintQuoteBolt11Response, error) {
+	return NewDefaultClient(mintURL).PostMintQuoteBolt11(context.Background(), mintQuoteRequest)
 }
 
-func get(url string) (*http.Response, error) {
-	resp, err := http.Get(url)
-	if err != nil {
-		return nil, err
-	}
+func GetMintQuoteState(mintURL, quoteId string) (*nut04.PostMintQuoteBolt11Response, error) {
+	return NewDefaultClient(mintURL).GetMintQuoteState(context.Background(), quoteId)
+}
 
-	return parse(resp)
+func PostMintBolt11(mintURL string, mintRequest nut04.PostMintBolt11Request) (
+	*nut04.PostMintBolt11Response, error) {
+	return NewDefaultClient(mintURL).PostMintBolt11(context.Background(), mintRequest)
 }
 
-func httpPost(url, contentType string, body io.Reader) (*http.Response, error) {
-	resp, err := http.Post(url, contentType, body)
-	if err != nil {
-		return nil, err
-	}
+func PostSwap(mintURL string, swapRequest nut03.PostSwapRequest) (*nut03.PostSwapResponse, error) {
+	return NewDefaultClient(mintURL).PostSwap(context.Background(), swapRequest)
+}
 
-	return parse(resp)
+func PostMeltQuoteBolt11(mintURL string, meltQuoteRequest nut05.PostMeltQuoteBolt11Request) (
+	*nut05.PostMeltQuoteBolt11Response, error) {
+	return NewDefaultClient(mintURL).PostMeltQuoteBolt11(context.Background(), meltQuoteRequest)
 }
 
-func parse(response *http.Response) (*http.Response, error) {
-	if response.StatusCode == 400 {
-		var errResponse cashu.Error
-		err := json.NewDecoder(response.Body).Decode(&errResponse)
-		if err != nil {
-			return nil, fmt.Errorf("could not decode error response from mint: %v", err)
-		}
-		return nil, errResponse
-	}
+func GetMeltQuoteState(mintURL, quoteId string) (*nut05.PostMeltQuoteBolt11Response, error) {
+	return NewDefaultClient(mintURL).GetMeltQuoteState(context.Background(), quoteId)
+}
 
-	if response.StatusCode != 200 {
-		body, err := io.ReadAll(response.Body)
-		if err != nil {
-			return nil, err
-		}
-		return nil, fmt.Errorf("%s", body)
-	}
+func PostMeltBolt11(mintURL string, meltRequest nut05.PostMeltBolt11Request) (
+	*nut05.PostMeltQuoteBolt11Response, error) {
+	return NewDefaultClient(mintURL).PostMeltBolt11(context.Background(), meltRequest)
+}
 
-	return response, nil
+func PostCheckProofState(mintURL string, stateRequest nut07.PostCheckStateRequest) (
+	*nut07.PostCheckStateResponse, error) {
+	return NewDefaultClient(mintURL).PostCheckProofState(context.Background(), stateRequest)
+}
+
+func PostRestore(mintURL string, restoreRequest nut09.PostRestoreRequest) (
+	*nut09.PostRestoreResponse, error) {
+	return NewDefaultClient(mintURL).PostRestore(context.Background(), restoreRequest)
 }
@@ -0,0 +1,181 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut03"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut06"
+)
+
+func TestClientRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v1/info" {
+			t.Errorf("unexpected path '%v'", r.URL.Path)
+		}
+		if r.UserAgent() != "test-agent" {
+			t.Errorf("expected user agent '%v' but got '%v'", "test-agent", r.UserAgent())
+		}
+		json.NewEncoder(w).Encode(nut06.MintInfo{Name: "test mint"})
+	}))
+	defer server.Close()
+
+	client, err := NewClient(server.URL, Config{UserAgent: "test-agent"})
+	if err != nil {
+		t.Fatalf("unexpected error creating client: %v", err)
+	}
+	mintInfo, err := client.GetMintInfo(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error getting mint info: %v", err)
+	}
+	if mintInfo.Name != "test mint" {
+		t.Fatalf("expected mint name '%v' but got '%v'", "test mint", mintInfo.Name)
+	}
+}
+
+func TestClientCashuError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		json.NewEncoder(w).Encode(cashu.Error{Detail: "proof already used", Code: cashu.ProofAlreadyUsedErrCode})
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, DefaultConfig())
+	_, err := client.PostSwap(context.Background(), nut03.PostSwapRequest{})
+	var cashuErr cashu.Error
+	if !errors.As(err, &cashuErr) || !errors.Is(err, cashu.ProofAlreadyUsedErrCode) {
+		t.Fatalf("expected cashu error with code '%d' but got '%v'", cashu.ProofAlreadyUsedErrCode, err)
+	}
+
+	// cashu errors are also decoded from other statuses
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+		json.NewEncoder(w).Encode(cashu.Error{Detail: "unknown keyset", Code: cashu.UnknownKeysetErrCode})
+	})
+	if _, err := client.GetKeysetById(context.Background(), "id"); !errors.Is(err, cashu.UnknownKeysetErrCode) {
+		t.Fatalf("expected cashu error with code '%d' but got '%v'", cashu.UnknownKeysetErrCode, err)
+	}
+
+	server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("bad gateway"))
+	})
+	var httpErr *HTTPError
+	_, err = client.PostSwap(context.Background(), nut03.PostSwapRequest{})
+	if !errors.As(err, &httpErr) || httpErr.StatusCode != http.StatusBadGateway {
+		t.Fatalf("expected http error with status '%v' but got '%v'", http.StatusBadGateway, err)
+	}
+	if errors.Is(err, ErrNetwork) {
+		t.Fatal("expected response from mint to not be a network error")
+	}
+}
+
+func TestClientTransportErrors(t *testing.T) {
+	closedServer := httptest.NewServer(http.NotFoundHandler())
+	closedServer.Close()
+	tlsServer := httptest.NewTLSServer(http.NotFoundHandler())
+	defer tlsServer.Close()
+
+	tests := []struct {
+		mintURL  string
+		expected error
+	}{
+		{mintURL: closedServer.URL, expected: ErrConnectionRefused},
+		{mintURL: tlsServer.URL, expected: ErrTLS},
+		{mintURL: "http://mint.invalid", expected: ErrDNS},
+	}
+
+	for _, test := range tests {
+		client, _ := NewClient(test.mintURL, Config{})
+		_, err := client.GetMintInfo(context.Background())
+		if !errors.Is(err, test.expected) {
+			t.Errorf("expected error '%v' for '%v' but got '%v'", test.expected, test.mintURL, err)
+		}
+		if !errors.Is(err, ErrNetwork) {
+			t.Errorf("expected '%v' to match '%v'", err, ErrNetwork)
+		}
+		var transportErr *TransportError
+		if !errors.As(err, &transportErr) || transportErr.Kind != test.expected {
+			t.Errorf("expected transport error of kind '%v' but got '%v'", test.expected, err)
+		}
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(time.Second * 5):
+		}
+	}))
+	defer server.Close()
+
+	client, _ := NewClient(server.URL, Config{Timeout: time.Millisecond * 100})
+	start := time.Now()
+	_, err := client.GetMintInfo(context.Background())
+	if !errors.Is(err, ErrTimeout) || !errors.Is(err, ErrNetwork) || !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrTimeout, err)
+	}
+	if time.Since(start) > time.Second {
+		t.Fatal("expected request to time out")
+	}
+
+	// canceling the context stops the request
+	ctx, cancel := context.WithCancel(context.Background())
+	client, _ = NewClient(server.URL, DefaultConfig())
+	time.AfterFunc(time.Millisecond*100, cancel)
+	_, err = client.GetMintInfo(ctx)
+	if !errors.Is(err, context.Canceled) || errors.Is(err, ErrNetwork) {
+		t.Fatalf("expected error '%v' but got '%v'", context.Canceled, err)
+	}
+}
+
+func TestClientRetry(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		json.NewEncoder(w).Encode(nut06.MintInfo{Name: "test mint"})
+	}))
+	defer server.Close()
+
+	config := Config{Retry: RetryPolicy{MaxRetries: 2, Backoff: time.Millisecond}}
+	client, _ := NewClient(server.URL, config)
+	if _, err := client.GetMintInfo(context.Background()); err != nil {
+		t.Fatalf("unexpected error after retries: %v", err)
+	}
+	if requests.Load() != 3 {
+		t.Fatalf("expected '%v' requests but got '%v'", 3, requests.Load())
+	}
+
+	// requests that change the state of the mint are not retried
+	requests.Store(0)
+	if _, err := client.PostSwap(context.Background(), nut03.PostSwapRequest{}); err == nil {
+		t.Fatal("expected error from swap")
+	}
+	if requests.Load() != 1 {
+		t.Fatalf("expected '%v' request but got '%v'", 1, requests.Load())
+	}
+}
+
+func TestClientProxy(t *testing.T) {
+	client, err := NewClient("http://localhost:3338", Config{ProxyURL: "socks5h://127.0.0.1:9050"})
+	if err != nil {
+		t.Fatalf("unexpected error with socks proxy: %v", err)
+	}
+	if client.Proxy() == nil {
+		t.Fatal("expected client to expose the configured proxy")
+	}
+	if _, err := NewClient("http://localhost:3338", Config{ProxyURL: "ftp://127.0.0.1:21"}); err == nil {
+		t.Fatal("expected error with unsupported proxy scheme")
+	}
+}
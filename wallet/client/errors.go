@@ -0,0 +1,119 @@
+package client
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"syscall"
+)
+
+// ErrNetwork matches every error from a request that did not get a response
+// from the mint. The errors below match the cause of the failure.
+// Errors returned by the mint are cashu.Error and match their
+// cashu.CashuErrCode with errors.Is.
+var (
+	ErrNetwork           = errors.New("could not connect to mint")
+	ErrDNS               = errors.New("could not resolve mint host")
+	ErrTimeout           = errors.New("request to mint timed out")
+	ErrConnectionRefused = errors.New("connection to mint refused")
+	ErrTLS               = errors.New("tls error connecting to mint")
+	ErrConnectionFailed  = errors.New("connection to mint failed")
+)
+
+// TransportError is returned when a request fails before a response
+// from the mint is read. Kind is one of ErrDNS, ErrTimeout,
+// ErrConnectionRefused, ErrTLS or ErrConnectionFailed.
+type TransportError struct {
+	Kind error
+	Err  error
+}
+
+func (e *TransportError) Error() string {
+	return fmt.Sprintf("%v: %v", e.Kind, e.Err)
+}
+
+func (e *TransportError) Unwrap() []error {
+	return []error{e.Kind, e.Err}
+}
+
+func (e *TransportError) Is(target error) bool {
+	return target == ErrNetwork
+}
+
+// HTTPError is returned when the mint responds with
+// a status other than 200 that is not a cashu.Error
+type HTTPError struct {
+	StatusCode int
+	Body       []byte
+}
+
+func (e *HTTPError) Error() string {
+	if len(e.Body) == 0 {
+		return fmt.Sprintf("mint responded with status %v", e.StatusCode)
+	}
+	return string(e.Body)
+}
+
+// transportError classifies the error from a request that did not get a
+// response. Requests canceled by the caller are returned as they are.
+func transportError(err error) error {
+	if errors.Is(err, context.Canceled) {
+		return err
+	}
+	return &TransportError{Kind: transportErrorKind(err), Err: err}
+}
+
+func transportErrorKind(err error) error {
+	var dnsErr *net.DNSError
+	if errors.As(err, &dnsErr) {
+		return ErrDNS
+	}
+
+	var netErr net.Error
+	if errors.Is(err, context.DeadlineExceeded) || (errors.As(err, &netErr) && netErr.Timeout()) {
+		return ErrTimeout
+	}
+
+	if errors.Is(err, syscall.ECONNREFUSED) {
+		return ErrConnectionRefused
+	}
+
+	var (
+		verificationErr *tls.CertificateVerificationError
+		recordHeaderErr tls.RecordHeaderError
+		alertErr        tls.AlertError
+		unknownAuthErr  x509.UnknownAuthorityError
+		hostnameErr     x509.HostnameError
+		invalidCertErr  x509.CertificateInvalidError
+	)
+	if errors.As(err, &verificationErr) || errors.As(err, &recordHeaderErr) ||
+		errors.As(err, &alertErr) || errors.As(err, &unknownAuthErr) ||
+		errors.As(err, &hostnameErr) || errors.As(err, &invalidCertErr) {
+		return ErrTLS
+	}
+
+	return ErrConnectionFailed
+}
+
+// isTransientError returns true if the request can be retried
+func isTransientError(err error) bool {
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.StatusCode >= 500 || httpErr.StatusCode == 429
+	}
+	return errors.Is(err, ErrNetwork) && !errors.Is(err, ErrTLS)
+}
+
+// readBody reads the body of a response. If the connection
+// fails while reading, it is returned as a transport error.
+func readBody(body io.Reader) ([]byte, error) {
+	data, err := io.ReadAll(body)
+	if err != nil {
+		return nil, transportError(err)
+	}
+	return data, nil
+}
@@ -0,0 +1,207 @@
+package client
+
+import (
+	"context"
+
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut01"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut02"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut03"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut06"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut17"
+	"github.com/Origami74/gonuts-tollgate/mint/mintrpc"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GrpcClient makes requests to the gRPC API of a mint.
+// Errors returned by the mint are cashu.Error like with the REST API.
+type GrpcClient struct {
+	conn   *grpc.ClientConn
+	client mintrpc.MintServiceClient
+}
+
+// NewGrpcClient creates a client for the mint at target (host:port).
+// If creds is nil, the connection is not encrypted.
+func NewGrpcClient(target string, creds credentials.TransportCredentials) (*GrpcClient, error) {
+	if creds == nil {
+		creds = insecure.NewCredentials()
+	}
+	conn, err := grpc.NewClient(target, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, err
+	}
+	return &GrpcClient{conn: conn, client: mintrpc.NewMintServiceClient(conn)}, nil
+}
+
+func (c *GrpcClient) Close() error {
+	return c.conn.Close()
+}
+
+func (c *GrpcClient) GetMintInfo(ctx context.Context) (*nut06.MintInfo, error) {
+	response, err := c.client.Info(ctx, &mintrpc.Empty{})
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	mintInfo := mintrpc.MintInfoFromProto(response)
+	return &mintInfo, nil
+}
+
+func (c *GrpcClient) GetActiveKeysets(ctx context.Context) (*nut01.GetKeysResponse, error) {
+	response, err := c.client.Keys(ctx, &mintrpc.Empty{})
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	return keysResponse(response)
+}
+
+func (c *GrpcClient) GetAllKeysets(ctx context.Context) (*nut02.GetKeysetsResponse, error) {
+	response, err := c.client.Keysets(ctx, &mintrpc.Empty{})
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	keysetsResponse := mintrpc.KeysetsResponseFromProto(response)
+	return &keysetsResponse, nil
+}
+
+func (c *GrpcClient) GetKeysetById(ctx context.Context, id string) (*nut01.GetKeysResponse, error) {
+	response, err := c.client.KeysById(ctx, &mintrpc.KeysetRequest{Id: id})
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	return keysResponse(response)
+}
+
+func keysResponse(response *mintrpc.KeysResponse) (*nut01.GetKeysResponse, error) {
+	keysResponse, err := mintrpc.KeysResponseFromProto(response)
+	if err != nil {
+		return nil, err
+	}
+	return &keysResponse, nil
+}
+
+func (c *GrpcClient) PostMintQuoteBolt11(
+	ctx context.Context,
+	mintQuoteRequest nut04.PostMintQuoteBolt11Request,
+) (*nut04.PostMintQuoteBolt11Response, error) {
+	response, err := c.client.MintQuote(ctx, mintrpc.MintQuoteRequestToProto(mintQuoteRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	mintQuoteResponse := mintrpc.MintQuoteResponseFromProto(response)
+	return &mintQuoteResponse, nil
+}
+
+func (c *GrpcClient) GetMintQuoteState(ctx context.Context, quoteId string) (*nut04.PostMintQuoteBolt11Response, error) {
+	response, err := c.client.MintQuoteState(ctx, &mintrpc.QuoteRequest{Quote: quoteId})
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	mintQuoteResponse := mintrpc.MintQuoteResponseFromProto(response)
+	return &mintQuoteResponse, nil
+}
+
+func (c *GrpcClient) PostMintBolt11(
+	ctx context.Context,
+	mintRequest nut04.PostMintBolt11Request,
+) (*nut04.PostMintBolt11Response, error) {
+	response, err := c.client.Mint(ctx, mintrpc.MintRequestToProto(mintRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	mintResponse := mintrpc.MintResponseFromProto(response)
+	return &mintResponse, nil
+}
+
+func (c *GrpcClient) PostSwap(ctx context.Context, swapRequest nut03.PostSwapRequest) (*nut03.PostSwapResponse, error) {
+	response, err := c.client.Swap(ctx, mintrpc.SwapRequestToProto(swapRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	swapResponse := mintrpc.SwapResponseFromProto(response)
+	return &swapResponse, nil
+}
+
+func (c *GrpcClient) PostMeltQuoteBolt11(
+	ctx context.Context,
+	meltQuoteRequest nut05.PostMeltQuoteBolt11Request,
+) (*nut05.PostMeltQuoteBolt11Response, error) {
+	response, err := c.client.MeltQuote(ctx, mintrpc.MeltQuoteRequestToProto(meltQuoteRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	meltQuoteResponse := mintrpc.MeltQuoteResponseFromProto(response)
+	return &meltQuoteResponse, nil
+}
+
+func (c *GrpcClient) GetMeltQuoteState(ctx context.Context, quoteId string) (*nut05.PostMeltQuoteBolt11Response, error) {
+	response, err := c.client.MeltQuoteState(ctx, &mintrpc.QuoteRequest{Quote: quoteId})
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	meltQuoteResponse := mintrpc.MeltQuoteResponseFromProto(response)
+	return &meltQuoteResponse, nil
+}
+
+func (c *GrpcClient) PostMeltBolt11(
+	ctx context.Context,
+	meltRequest nut05.PostMeltBolt11Request,
+) (*nut05.PostMeltQuoteBolt11Response, error) {
+	response, err := c.client.Melt(ctx, mintrpc.MeltRequestToProto(meltRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	meltQuoteResponse := mintrpc.MeltQuoteResponseFromProto(response)
+	return &meltQuoteResponse, nil
+}
+
+func (c *GrpcClient) PostCheckProofState(
+	ctx context.Context,
+	stateRequest nut07.PostCheckStateRequest,
+) (*nut07.PostCheckStateResponse, error) {
+	response, err := c.client.CheckState(ctx, mintrpc.CheckStateRequestToProto(stateRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	stateResponse := mintrpc.CheckStateResponseFromProto(response)
+	return &stateResponse, nil
+}
+
+func (c *GrpcClient) PostRestore(ctx context.Context, restoreRequest nut09.PostRestoreRequest) (*nut09.PostRestoreResponse, error) {
+	response, err := c.client.Restore(ctx, mintrpc.RestoreRequestToProto(restoreRequest))
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	restoreResponse := mintrpc.RestoreResponseFromProto(response)
+	return &restoreResponse, nil
+}
+
+// GrpcSubscription receives the notifications of a subscription
+type GrpcSubscription struct {
+	stream mintrpc.MintService_SubscribeClient
+}
+
+// Subscribe opens a subscription of the kind for the filters (i.e quote ids).
+// The first notifications have the current state. The subscription ends when ctx is canceled.
+func (c *GrpcClient) Subscribe(ctx context.Context, kind nut17.SubscriptionKind, filters []string) (*GrpcSubscription, error) {
+	request := &mintrpc.SubscribeRequest{Kind: kind.String(), Filters: filters}
+	stream, err := c.client.Subscribe(ctx, request)
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	return &GrpcSubscription{stream: stream}, nil
+}
+
+// Read blocks until the next notification is received.
+// For mint quote subscriptions, the state of the quote is in its MintQuote payload.
+func (s *GrpcSubscription) Read() (*mintrpc.Notification, error) {
+	notification, err := s.stream.Recv()
+	if err != nil {
+		return nil, mintrpc.FromStatus(err)
+	}
+	return notification, nil
+}
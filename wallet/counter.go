@@ -0,0 +1,74 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+)
+
+// number of counters checked with the mint in each request when syncing a keyset counter
+const counterSyncBatchSize = 100
+
+var errCounterNotBehind = errors.New("keyset counter is not behind the mint")
+
+// retryOnCounterDesync runs the operation and, if the mint had already signed
+// the outputs derived from the keyset counter, syncs the counter
+// with the mint and runs the operation once more
+func (w *Wallet) retryOnCounterDesync(mintURL, keysetId string, operation func() error) error {
+	err := operation()
+	if !errors.Is(err, cashu.BlindedMessageAlreadySignedErrCode) {
+		return err
+	}
+
+	if _, syncErr := w.syncKeysetCounter(mintURL, keysetId); syncErr != nil {
+		return fmt.Errorf("%w. Could not sync keyset counter: %w", err, syncErr)
+	}
+	return operation()
+}
+
+// syncKeysetCounter scans forward from the counter of the keyset with NUT-09
+// restore until it finds the first counter whose output the mint has not
+// signed. Unspent proofs found along the way are saved and the counter is
+// moved past them. It returns the amount of the proofs recovered.
+func (w *Wallet) syncKeysetCounter(mintURL, keysetId string) (uint64, error) {
+	keyset := w.db.GetKeyset(keysetId)
+	if keyset == nil {
+		return 0, fmt.Errorf("keyset '%v' not found", keysetId)
+	}
+
+	startCounter := w.counterForKeyset(keysetId)
+	counter := startCounter
+	var recoveredProofs cashu.Proofs
+	for {
+		batchCounter := counter
+		outputs, secrets, rs, err := w.createBlindedMessages(make([]uint64, counterSyncBatchSize), keysetId, &batchCounter)
+		if err != nil {
+			return 0, err
+		}
+
+		proofs, lastSigned, err := w.restoreOutputs(mintURL, outputs, secrets, rs, keyset)
+		if err != nil {
+			return 0, err
+		}
+		recoveredProofs = append(recoveredProofs, proofs...)
+		if lastSigned == 0 {
+			break
+		}
+		counter += uint32(lastSigned)
+		if lastSigned < counterSyncBatchSize {
+			break
+		}
+	}
+
+	if counter == startCounter {
+		return 0, errCounterNotBehind
+	}
+	if err := w.db.IncrementKeysetCounter(keysetId, counter-startCounter); err != nil {
+		return 0, fmt.Errorf("error incrementing keyset counter: %w", err)
+	}
+	if err := w.db.SaveProofs(recoveredProofs); err != nil {
+		return 0, fmt.Errorf("error storing proofs: %w", err)
+	}
+	return recoveredProofs.Amount(), nil
+}
@@ -0,0 +1,250 @@
+package wallet
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+	"github.com/btcsuite/btcd/btcutil/hdkeychain"
+	"github.com/btcsuite/btcd/chaincfg"
+	"github.com/tyler-smith/go-bip39"
+)
+
+// dir in the wallet path where the encrypted copy
+// of the storage is written while it is being encrypted
+const encryptingDir = ".encrypting"
+
+var ErrWalletNotEncrypted = errors.New("wallet is not encrypted")
+
+// IsEncrypted returns true if the storage of the wallet in the path is encrypted
+func IsEncrypted(walletPath string) bool {
+	return storage.KeyFileExists(walletPath)
+}
+
+// newEncryptedDB writes a new key file encrypted with the
+// passphrase and returns the db wrapped with that key
+func newEncryptedDB(path string, db storage.WalletDB, passphrase string) (*storage.EncryptedDB, error) {
+	dataKey := storage.NewDataKey()
+	if err := storage.SaveKeyFile(path, passphrase, dataKey); err != nil {
+		return nil, fmt.Errorf("error encrypting wallet: %w", err)
+	}
+	encryptedDB := storage.NewEncryptedDB(db)
+	encryptedDB.Unlock(dataKey)
+	return encryptedDB, nil
+}
+
+// loadKeys derives the keys of the wallet from the seed. If there is no seed,
+// a new one is created from a new mnemonic and the BIP39 passphrase and saved.
+func (w *Wallet) loadKeys(mnemonicPassphrase string) error {
+	seed := w.db.GetSeed()
+	if len(seed) == 0 {
+		// create and save new seed if none existed previously
+		entropy, err := bip39.NewEntropy(128)
+		if err != nil {
+			return fmt.Errorf("error generating seed: %w", err)
+		}
+
+		mnemonic, err := bip39.NewMnemonic(entropy)
+		if err != nil {
+			return fmt.Errorf("error generating seed: %w", err)
+		}
+
+		seed, err = saveSeed(w.db, mnemonic, mnemonicPassphrase)
+		if err != nil {
+			return err
+		}
+	}
+
+	// TODO: what's the point of chain params here?
+	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	if err != nil {
+		return err
+	}
+
+	privateKey, err := DeriveP2PK(masterKey)
+	if err != nil {
+		return err
+	}
+
+	w.masterKey = masterKey
+	w.privateKey = privateKey
+	return nil
+}
+
+// saveSeed derives the seed from the mnemonic and the BIP39 passphrase
+// and saves it along with whether a passphrase was used
+func saveSeed(db storage.WalletDB, mnemonic, passphrase string) ([]byte, error) {
+	seed := bip39.NewSeed(mnemonic, passphrase)
+	db.SaveMnemonicSeed(mnemonic, seed)
+
+	scheme := storage.SeedSchemeBIP39
+	if len(passphrase) > 0 {
+		scheme = storage.SeedSchemeBIP39Passphrase
+	}
+	if err := db.SaveSeedScheme(scheme); err != nil {
+		return nil, fmt.Errorf("error saving seed: %w", err)
+	}
+	return seed, nil
+}
+
+// IsLocked returns true if the storage of the wallet is encrypted and
+// it has not been unlocked. Operations that need the proofs or the keys
+// of the wallet fail with ErrWalletLocked while it is locked.
+func (w *Wallet) IsLocked() bool {
+	return w.encryptedDB != nil && w.encryptedDB.Locked()
+}
+
+// Unlock decrypts the storage of the wallet with the passphrase
+func (w *Wallet) Unlock(passphrase string) error {
+	if w.encryptedDB == nil {
+		return ErrWalletNotEncrypted
+	}
+
+	dataKey, err := storage.ReadDataKey(w.path, passphrase)
+	if err != nil {
+		return err
+	}
+
+	w.mu.Lock()
+	w.encryptedDB.Unlock(dataKey)
+	// the seed was created when the storage was encrypted
+	if err := w.loadKeys(""); err != nil {
+		w.encryptedDB.Lock()
+		w.mu.Unlock()
+		return err
+	}
+	w.mu.Unlock()
+
+	// mints are not set up yet if it is unlocked while loading the wallet
+	if w.mints != nil {
+		w.recoverPendingOperations()
+	}
+	return nil
+}
+
+// Lock removes the keys of the wallet from memory
+// until it is unlocked again with the passphrase
+func (w *Wallet) Lock() error {
+	if w.encryptedDB == nil {
+		return ErrWalletNotEncrypted
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.encryptedDB.Lock()
+	w.masterKey = nil
+	w.privateKey = nil
+	return nil
+}
+
+// ChangePassphrase changes the passphrase that encrypts the storage of the wallet.
+// If the storage is not encrypted, oldPassphrase has to be empty and the
+// storage is encrypted with the new passphrase.
+func (w *Wallet) ChangePassphrase(oldPassphrase, newPassphrase string) error {
+	if len(newPassphrase) == 0 {
+		return errors.New("new passphrase cannot be empty")
+	}
+	if len(w.path) == 0 {
+		return errors.New("cannot encrypt storage provided with LoadWalletWithDB")
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	if w.encryptedDB == nil {
+		if len(oldPassphrase) > 0 {
+			return ErrWalletNotEncrypted
+		}
+		return w.encryptStorage(newPassphrase)
+	}
+
+	// only the key that encrypts the data is encrypted with the passphrase
+	dataKey, err := storage.ReadDataKey(w.path, oldPassphrase)
+	if err != nil {
+		return err
+	}
+	return storage.SaveKeyFile(w.path, newPassphrase, dataKey)
+}
+
+// encryptStorage writes an encrypted copy of the storage in a temporary dir.
+// Once the copy is complete, the key file is written and the copy replaces
+// the unencrypted db. If this is interrupted, recoverEncryption
+// finishes or discards it the next time the wallet is loaded.
+func (w *Wallet) encryptStorage(passphrase string) error {
+	tempPath := filepath.Join(w.path, encryptingDir)
+	if err := os.RemoveAll(tempPath); err != nil {
+		return err
+	}
+	if err := os.MkdirAll(tempPath, 0700); err != nil {
+		return err
+	}
+
+	tempDB, err := InitStorage(tempPath, w.storageBackend)
+	if err != nil {
+		return err
+	}
+	dataKey := storage.NewDataKey()
+	encryptedDB := storage.NewEncryptedDB(tempDB)
+	encryptedDB.Unlock(dataKey)
+	if err := storage.CopyWallet(encryptedDB, w.db); err != nil {
+		encryptedDB.Close()
+		os.RemoveAll(tempPath)
+		return fmt.Errorf("error copying wallet: %w", err)
+	}
+	if err := encryptedDB.Close(); err != nil {
+		os.RemoveAll(tempPath)
+		return err
+	}
+
+	if err := w.db.Close(); err != nil {
+		return err
+	}
+	if err := storage.SaveKeyFile(w.path, passphrase, dataKey); err != nil {
+		os.RemoveAll(tempPath)
+		if db, openErr := InitStorage(w.path, w.storageBackend); openErr == nil {
+			w.db = db
+		}
+		return err
+	}
+	if err := recoverEncryption(w.path); err != nil {
+		return err
+	}
+
+	db, err := InitStorage(w.path, w.storageBackend)
+	if err != nil {
+		return err
+	}
+	w.encryptedDB = storage.NewEncryptedDB(db)
+	w.encryptedDB.Unlock(dataKey)
+	w.db = w.encryptedDB
+	return nil
+}
+
+// recoverEncryption finishes an encryption of the storage that was interrupted
+// after the key file was written or discards the copy if it was not
+func recoverEncryption(path string) error {
+	tempPath := filepath.Join(path, encryptingDir)
+	if _, err := os.Stat(tempPath); err != nil {
+		return nil
+	}
+	if !storage.KeyFileExists(path) {
+		return os.RemoveAll(tempPath)
+	}
+
+	entries, err := os.ReadDir(tempPath)
+	if err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if err := os.Rename(filepath.Join(tempPath, entry.Name()), filepath.Join(path, entry.Name())); err != nil {
+			return err
+		}
+	}
+	// unencrypted copy of the wallet left by the migration from bolt to sqlite
+	if err := os.Remove(filepath.Join(path, "wallet.db.migrated")); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	return os.RemoveAll(tempPath)
+}
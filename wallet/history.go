@@ -0,0 +1,98 @@
+package wallet
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+)
+
+// History returns the operations done by the wallet
+// that match the filter, newest first
+func (w *Wallet) History(filter storage.HistoryFilter) []storage.HistoryEntry {
+	return w.db.GetHistory(filter)
+}
+
+// addHistoryEntry saves a record of an operation that completed. The funds
+// have already moved, so the operation does not fail if the entry cannot be saved.
+func (w *Wallet) addHistoryEntry(entry storage.HistoryEntry) {
+	now := time.Now()
+	entry.Id = newRecordId(now)
+	entry.Unit = w.unit.String()
+	entry.CreatedAt = now.Unix()
+
+	if err := w.db.SaveHistoryEntry(entry); err != nil {
+		fmt.Printf("Warning: could not save history entry: %v\n", err)
+	}
+}
+
+// addSendEntry records proofs sent from the mint. Fees are the part of
+// the decrease in the balance of the mint that was not sent.
+func (w *Wallet) addSendEntry(mintURL string, proofs cashu.Proofs, balanceBefore uint64) {
+	var tokenString string
+	token, err := cashu.NewTokenV4(proofs, mintURL, w.unit, false)
+	if err == nil {
+		tokenString, _ = token.Serialize()
+	}
+
+	amount := proofs.Amount()
+	w.addHistoryEntry(storage.HistoryEntry{
+		Type:   storage.SendHistory,
+		Mint:   mintURL,
+		Amount: amount,
+		Fee:    feesFromBalance(balanceBefore, w.mintBalance(mintURL), amount),
+		Token:  tokenString,
+	})
+}
+
+// addReceiveEntry records a received token. If it was swapped
+// to another mint, toMint is the mint that holds the funds.
+func (w *Wallet) addReceiveEntry(token cashu.Token, toMint string, amountReceived uint64) {
+	tokenString, _ := token.Serialize()
+	var fee uint64
+	if token.Amount() > amountReceived {
+		fee = token.Amount() - amountReceived
+	}
+
+	w.addHistoryEntry(storage.HistoryEntry{
+		Type:   storage.ReceiveHistory,
+		Mint:   token.Mint(),
+		ToMint: toMint,
+		Amount: amountReceived,
+		Fee:    fee,
+		Token:  tokenString,
+	})
+}
+
+func (w *Wallet) addMeltEntry(quote *storage.MeltQuote, fee uint64) {
+	w.addHistoryEntry(storage.HistoryEntry{
+		Type:           storage.MeltHistory,
+		Mint:           quote.Mint,
+		Amount:         quote.Amount,
+		Fee:            fee,
+		QuoteId:        quote.QuoteId,
+		PaymentRequest: quote.PaymentRequest,
+	})
+}
+
+func (w *Wallet) mintBalance(mintURL string) uint64 {
+	return w.getProofsFromMint(mintURL).Amount()
+}
+
+func feesFromBalance(balanceBefore, balanceAfter, amount uint64) uint64 {
+	if balanceBefore < balanceAfter+amount {
+		return 0
+	}
+	return balanceBefore - balanceAfter - amount
+}
+
+// newRecordId returns a random id that sorts in the order the records
+// were created, which orders records created in the same second
+func newRecordId(now time.Time) string {
+	random := make([]byte, 8)
+	rand.Read(random)
+	return fmt.Sprintf("%016x", now.UnixNano()) + hex.EncodeToString(random)
+}
@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"context"
 	"encoding/hex"
 	"errors"
 	"fmt"
@@ -12,16 +13,29 @@ import (
 
 // GetMintActiveKeyset gets the active keyset with the specified unit
 func GetMintActiveKeyset(mintURL string, unit cashu.Unit) (*crypto.WalletKeyset, error) {
-	keysets, err := client.GetAllKeysets(mintURL)
+	return getMintActiveKeyset(context.Background(), client.NewDefaultClient(mintURL), unit)
+}
+
+func GetMintInactiveKeysets(mintURL string, unit cashu.Unit) (map[string]crypto.WalletKeyset, error) {
+	return getMintInactiveKeysets(context.Background(), client.NewDefaultClient(mintURL), unit)
+}
+
+func GetKeysetKeys(mintURL, id string) (crypto.PublicKeys, error) {
+	return getKeysetKeys(context.Background(), client.NewDefaultClient(mintURL), id)
+}
+
+func getMintActiveKeyset(ctx context.Context, mintClient *client.Client, unit cashu.Unit) (*crypto.WalletKeyset, error) {
+	mintURL := mintClient.MintURL()
+	keysets, err := mintClient.GetAllKeysets(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting active keysets from mint: %v", err)
+		return nil, fmt.Errorf("error getting active keysets from mint: %w", err)
 	}
 
 	for _, keyset := range keysets.Keysets {
 		if keyset.Active && keyset.Unit == unit.String() {
 			_, err := hex.DecodeString(keyset.Id)
 			if err == nil {
-				keys, err := GetKeysetKeys(mintURL, keyset.Id)
+				keys, err := getKeysetKeys(ctx, mintClient, keyset.Id)
 				if err != nil {
 					return nil, err
 				}
@@ -40,10 +54,15 @@ func GetMintActiveKeyset(mintURL string, unit cashu.Unit) (*crypto.WalletKeyset,
 	return nil, errors.New("could not find an active keyset for the unit")
 }
 
-func GetMintInactiveKeysets(mintURL string, unit cashu.Unit) (map[string]crypto.WalletKeyset, error) {
-	keysetsResponse, err := client.GetAllKeysets(mintURL)
+func getMintInactiveKeysets(
+	ctx context.Context,
+	mintClient *client.Client,
+	unit cashu.Unit,
+) (map[string]crypto.WalletKeyset, error) {
+	mintURL := mintClient.MintURL()
+	keysetsResponse, err := mintClient.GetAllKeysets(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting keysets from mint: %v", err)
+		return nil, fmt.Errorf("error getting keysets from mint: %w", err)
 	}
 
 	inactiveKeysets := make(map[string]crypto.WalletKeyset)
@@ -63,10 +82,10 @@ func GetMintInactiveKeysets(mintURL string, unit cashu.Unit) (map[string]crypto.
 	return inactiveKeysets, nil
 }
 
-func GetKeysetKeys(mintURL, id string) (crypto.PublicKeys, error) {
-	keysetsResponse, err := client.GetKeysetById(mintURL, id)
+func getKeysetKeys(ctx context.Context, mintClient *client.Client, id string) (crypto.PublicKeys, error) {
+	keysetsResponse, err := mintClient.GetKeysetById(ctx, id)
 	if err != nil {
-		return nil, fmt.Errorf("error getting keyset from mint: %v", err)
+		return nil, fmt.Errorf("error getting keyset from mint: %w", err)
 	}
 
 	derivedId := crypto.DeriveKeysetId(keysetsResponse.Keysets[0].Keys)
@@ -84,7 +103,7 @@ func (w *Wallet) getActiveKeyset(mintURL string) (*crypto.WalletKeyset, error) {
 	mint, ok := w.mints[mintURL]
 	// if mint is not known, get active sat keyset from calling mint
 	if !ok {
-		activeKeyset, err := GetMintActiveKeyset(mintURL, w.unit)
+		activeKeyset, err := getMintActiveKeyset(w.ctx, w.mintClient(mintURL), w.unit)
 		if err != nil {
 			return nil, err
 		}
@@ -93,7 +112,7 @@ func (w *Wallet) getActiveKeyset(mintURL string) (*crypto.WalletKeyset, error) {
 
 	activeKeyset := mint.activeKeyset
 
-	allKeysets, err := client.GetAllKeysets(mintURL)
+	allKeysets, err := w.mintClient(mintURL).GetAllKeysets(w.ctx)
 	if err != nil {
 		if isNetworkError(err) {
 			// Use cached keyset when offline
@@ -135,7 +154,7 @@ func (w *Wallet) getActiveKeyset(mintURL string) (*crypto.WalletKeyset, error) {
 					mint.activeKeyset = activeKeyset
 					delete(mint.inactiveKeysets, storedKeyset.Id)
 				} else {
-					keys, err := GetKeysetKeys(mintURL, keyset.Id)
+					keys, err := getKeysetKeys(w.ctx, w.mintClient(mintURL), keyset.Id)
 					if err != nil {
 						return nil, err
 					}
@@ -1,52 +1,19 @@
 package wallet
 
 import (
+	"errors"
 	"fmt"
 	"net"
 	"net/url"
-	"strings"
 	"time"
+
+	"github.com/Origami74/gonuts-tollgate/wallet/client"
 )
 
-// isNetworkError checks if an error is related to network connectivity
+// isNetworkError checks if the request to the mint failed because the
+// mint could not be reached, in which case the wallet can continue offline
 func isNetworkError(err error) bool {
-	if err == nil {
-		return false
-	}
-
-	errStr := err.Error()
-	networkErrors := []string{
-		"no such host",
-		"connection refused",
-		"connection timeout",
-		"network is unreachable",
-		"temporary failure in name resolution",
-		"i/o timeout",
-		"no route to host",
-		"connection reset by peer",
-		"server misbehaving",
-		"dial tcp",
-		"lookup",
-	}
-
-	for _, netErr := range networkErrors {
-		if strings.Contains(strings.ToLower(errStr), netErr) {
-			return true
-		}
-	}
-
-	// Check for specific network error types
-	if _, ok := err.(net.Error); ok {
-		return true
-	}
-	if _, ok := err.(*net.OpError); ok {
-		return true
-	}
-	if _, ok := err.(*url.Error); ok {
-		return true
-	}
-
-	return false
+	return errors.Is(err, client.ErrNetwork)
 }
 
 // checkConnectivity performs a basic connectivity check to a mint
@@ -94,7 +61,7 @@ func wrapNetworkError(err error, operation string) error {
 	}
 
 	// Create informative error message for users
-	return fmt.Errorf("network connection issue during %s: %v\n"+
+	return fmt.Errorf("network connection issue during %s: %w\n"+
 		"This may indicate:\n"+
 		"- Internet connection is down\n"+
 		"- DNS resolution problems\n"+
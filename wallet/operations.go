@@ -0,0 +1,315 @@
+package wallet
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut10"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var errOperationPending = errors.New("operation is still pending in the mint")
+
+// savePendingOperation saves the operation before its request is sent to the mint
+// so that the proofs can be recovered if the wallet stops before they are saved.
+// counter is the counter of the keyset after the deterministic outputs were derived.
+func (w *Wallet) savePendingOperation(
+	operationType storage.OperationType,
+	mintURL string,
+	quoteId string,
+	inputs cashu.Proofs,
+	outputs cashu.BlindedMessages,
+	secrets []string,
+	rs []*secp256k1.PrivateKey,
+	keysetId string,
+	counter uint32,
+) (string, error) {
+	hexRs := make([]string, len(rs))
+	for i, r := range rs {
+		hexRs[i] = hex.EncodeToString(r.Serialize())
+	}
+
+	now := time.Now()
+	operation := storage.PendingOperation{
+		Id:        newRecordId(now),
+		Type:      operationType,
+		Mint:      mintURL,
+		QuoteId:   quoteId,
+		Inputs:    inputs,
+		Outputs:   outputs,
+		Secrets:   secrets,
+		Rs:        hexRs,
+		KeysetId:  keysetId,
+		Counter:   counter,
+		CreatedAt: now.Unix(),
+	}
+	if err := w.db.SavePendingOperation(operation); err != nil {
+		return "", fmt.Errorf("error saving pending operation: %w", err)
+	}
+	return operation.Id, nil
+}
+
+// deletePendingOperation deletes the operation once its proofs are saved.
+// If it cannot be deleted, it is resolved again the next time the wallet is loaded.
+func (w *Wallet) deletePendingOperation(id string) {
+	if err := w.db.DeletePendingOperation(id); err != nil {
+		fmt.Printf("Warning: could not delete pending operation '%v': %v\n", id, err)
+	}
+}
+
+// requestRejected returns true if the mint responded to the request of an
+// operation with an error, so none of its outputs were signed
+func requestRejected(err error) bool {
+	var cashuErr cashu.Error
+	return errors.As(err, &cashuErr)
+}
+
+// recoverPendingOperations resolves the swaps, mints and melts that were
+// interrupted before their proofs were saved. Operations with mints that
+// cannot be reached or that are still pending are kept for the next load.
+func (w *Wallet) recoverPendingOperations() {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	for _, operation := range w.db.GetPendingOperations() {
+		amount, err := w.recoverPendingOperation(operation)
+		if err != nil {
+			if isNetworkError(err) || errors.Is(err, errOperationPending) {
+				continue
+			}
+			fmt.Printf("Warning: could not recover %v operation '%v' from %v: %v\n",
+				operation.Type, operation.Id, operation.Mint, err)
+			continue
+		}
+		if amount > 0 {
+			fmt.Printf("Recovered %v sats from interrupted %v with %v\n", amount, operation.Type, operation.Mint)
+		}
+		w.deletePendingOperation(operation.Id)
+	}
+}
+
+// recoverPendingOperation checks the state of the inputs of the operation and
+// restores the signatures for its outputs from the mint. It saves the proofs
+// that were signed and are unspent, and returns their amount.
+func (w *Wallet) recoverPendingOperation(operation storage.PendingOperation) (uint64, error) {
+	keyset := w.db.GetKeyset(operation.KeysetId)
+	if keyset == nil {
+		return 0, fmt.Errorf("keyset '%v' not found", operation.KeysetId)
+	}
+	mintClient := w.mintClient(operation.Mint)
+
+	inputYs := make([]string, len(operation.Inputs))
+	for i, proof := range operation.Inputs {
+		Y, err := crypto.HashToCurve([]byte(proof.Secret))
+		if err != nil {
+			return 0, err
+		}
+		inputYs[i] = hex.EncodeToString(Y.SerializeCompressed())
+	}
+
+	var spentInputs cashu.Proofs
+	var spentYs []string
+	if len(inputYs) > 0 {
+		proofStateResponse, err := mintClient.PostCheckProofState(w.ctx, nut07.PostCheckStateRequest{Ys: inputYs})
+		if err != nil {
+			return 0, err
+		}
+		for _, state := range proofStateResponse.States {
+			i := slices.Index(inputYs, state.Y)
+			if i < 0 {
+				continue
+			}
+			switch state.State {
+			case nut07.Pending:
+				return 0, errOperationPending
+			case nut07.Spent:
+				spentInputs = append(spentInputs, operation.Inputs[i])
+				spentYs = append(spentYs, state.Y)
+			}
+		}
+	}
+
+	switch operation.Type {
+	case storage.MintOperation:
+		// update the state of the quote with the one in the mint
+		if _, err := w.MintQuoteState(operation.QuoteId); err != nil && !errors.Is(err, ErrQuoteNotFound) {
+			return 0, err
+		}
+	case storage.MeltOperation:
+		// returns the inputs to the wallet if the payment failed
+		// and removes them from pending if it succeeded
+		if w.db.GetMeltQuoteById(operation.QuoteId) != nil {
+			meltState, err := w.CheckMeltQuoteState(operation.QuoteId)
+			if err != nil {
+				return 0, err
+			}
+			if meltState.State == nut05.Pending {
+				return 0, errOperationPending
+			}
+		}
+	case storage.SwapOperation:
+		// inputs spent by the swap are removed from the wallet
+		for _, proof := range spentInputs {
+			w.db.DeleteProof(proof.Secret)
+		}
+		if len(spentYs) > 0 {
+			if err := w.db.DeletePendingProofs(spentYs); err != nil {
+				return 0, fmt.Errorf("error removing pending proofs: %w", err)
+			}
+			if err := w.settleSentTokens(spentYs, storage.SentTokenReclaimed); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	proofs, err := w.restoreOperationOutputs(operation, keyset)
+	if err != nil {
+		return 0, err
+	}
+	if len(proofs) == 0 {
+		return 0, nil
+	}
+
+	// the outputs were signed so their counters cannot be used again
+	if counter := w.counterForKeyset(keyset.Id); counter < operation.Counter {
+		if err := w.db.IncrementKeysetCounter(keyset.Id, operation.Counter-counter); err != nil {
+			return 0, fmt.Errorf("error incrementing keyset counter: %w", err)
+		}
+	}
+
+	if operation.Type == storage.MintOperation {
+		quote := w.db.GetMintQuoteById(operation.QuoteId)
+		if quote != nil && quote.State != nut04.Issued {
+			quote.State = nut04.Issued
+			quote.SettledAt = time.Now().Unix()
+			if err := w.db.SaveMintQuote(*quote); err != nil {
+				return 0, err
+			}
+		}
+	}
+
+	if err := w.db.SaveProofs(proofs); err != nil {
+		return 0, fmt.Errorf("error storing proofs: %w", err)
+	}
+	return proofs.Amount(), nil
+}
+
+// restoreOperationOutputs restores the signatures of the outputs of the
+// operation with NUT-09 and returns the proofs that are unspent
+// and not already in the wallet
+func (w *Wallet) restoreOperationOutputs(
+	operation storage.PendingOperation,
+	keyset *crypto.WalletKeyset,
+) (cashu.Proofs, error) {
+	if len(operation.Outputs) == 0 {
+		return nil, nil
+	}
+
+	rs := make([]*secp256k1.PrivateKey, len(operation.Rs))
+	for i, r := range operation.Rs {
+		rBytes, err := hex.DecodeString(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid blinding factor: %w", err)
+		}
+		rs[i] = secp256k1.PrivKeyFromBytes(rBytes)
+	}
+
+	proofs, _, err := w.restoreOutputs(operation.Mint, operation.Outputs, operation.Secrets, rs, keyset)
+	return proofs, err
+}
+
+// restoreOutputs restores the signatures of the outputs with NUT-09 and returns
+// the proofs that are unspent and not already in the wallet. It also returns
+// the number of outputs up to the last one that was signed.
+func (w *Wallet) restoreOutputs(
+	mintURL string,
+	outputs cashu.BlindedMessages,
+	secrets []string,
+	rs []*secp256k1.PrivateKey,
+	keyset *crypto.WalletKeyset,
+) (cashu.Proofs, int, error) {
+	mintClient := w.mintClient(mintURL)
+
+	restoreRequest := nut09.PostRestoreRequest{Outputs: outputs}
+	restoreResponse, err := mintClient.PostRestore(w.ctx, restoreRequest)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(restoreResponse.Signatures) != len(restoreResponse.Outputs) {
+		return nil, 0, errors.New("number of outputs and signatures in restore response do not match")
+	}
+
+	walletSecrets := make(map[string]bool)
+	for _, proof := range w.db.GetProofs() {
+		walletSecrets[proof.Secret] = true
+	}
+
+	var (
+		signedOutputs cashu.BlindedMessages
+		signedSecrets []string
+		signedRs      []*secp256k1.PrivateKey
+		signatures    cashu.BlindedSignatures
+		lastSigned    int
+	)
+	for i, output := range restoreResponse.Outputs {
+		j := slices.IndexFunc(outputs, func(o cashu.BlindedMessage) bool { return o.B_ == output.B_ })
+		if j < 0 {
+			continue
+		}
+		lastSigned = max(lastSigned, j+1)
+
+		// proofs locked to a spending condition were meant for someone else
+		if _, err := nut10.DeserializeSecret(secrets[j]); err == nil {
+			continue
+		}
+		if walletSecrets[secrets[j]] {
+			continue
+		}
+		signedOutputs = append(signedOutputs, outputs[j])
+		signedSecrets = append(signedSecrets, secrets[j])
+		signedRs = append(signedRs, rs[j])
+		signatures = append(signatures, restoreResponse.Signatures[i])
+	}
+	if len(signedOutputs) == 0 {
+		return nil, lastSigned, nil
+	}
+
+	proofs, err := constructProofs(signatures, signedOutputs, signedSecrets, signedRs, keyset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("error constructing proofs: %w", err)
+	}
+
+	Ys := make([]string, len(proofs))
+	for i, proof := range proofs {
+		Y, err := crypto.HashToCurve([]byte(proof.Secret))
+		if err != nil {
+			return nil, 0, err
+		}
+		Ys[i] = hex.EncodeToString(Y.SerializeCompressed())
+	}
+	proofStateResponse, err := mintClient.PostCheckProofState(w.ctx, nut07.PostCheckStateRequest{Ys: Ys})
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var unspentProofs cashu.Proofs
+	for _, state := range proofStateResponse.States {
+		if state.State != nut07.Unspent {
+			continue
+		}
+		if i := slices.Index(Ys, state.Y); i >= 0 {
+			unspentProofs = append(unspentProofs, proofs[i])
+		}
+	}
+	return unspentProofs, lastSigned, nil
+}
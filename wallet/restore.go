@@ -5,25 +5,22 @@ import (
 	"errors"
 	"fmt"
 	"os"
-	"path/filepath"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut09"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut13"
 	"github.com/Origami74/gonuts-tollgate/crypto"
-	"github.com/Origami74/gonuts-tollgate/wallet/client"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
 	"github.com/tyler-smith/go-bip39"
 )
 
-func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, error) {
+func Restore(config Config, mnemonic string, mintsToRestore []string) (uint64, error) {
+	walletPath := config.WalletPath
 	// check if wallet db already exists, if there is one, throw error.
-	dbpath := filepath.Join(walletPath, "wallet.db")
-	_, err := os.Stat(dbpath)
-	if err == nil {
+	if Exists(walletPath) {
 		return 0, errors.New("wallet already exists")
 	}
 
@@ -36,27 +33,42 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 		return 0, errors.New("invalid mnemonic")
 	}
 
+	restoreClient, ctx, cancel, err := newWalletClient(config)
+	if err != nil {
+		return 0, err
+	}
+	defer cancel()
+
 	// create wallet db
-	db, err := InitStorage(walletPath)
+	db, err := InitStorage(walletPath, config.StorageBackend)
 	if err != nil {
-		return 0, fmt.Errorf("error restoring wallet: %v", err)
+		return 0, fmt.Errorf("error restoring wallet: %w", err)
+	}
+	if len(config.EncryptionPassphrase) > 0 {
+		db, err = newEncryptedDB(walletPath, db, config.EncryptionPassphrase)
+		if err != nil {
+			return 0, fmt.Errorf("error restoring wallet: %w", err)
+		}
 	}
 
-	seed := bip39.NewSeed(mnemonic, "")
+	seed, err := saveSeed(db, mnemonic, config.MnemonicPassphrase)
+	if err != nil {
+		return 0, err
+	}
 	// get master key from seed
 	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
 	if err != nil {
 		return 0, err
 	}
-	db.SaveMnemonicSeed(mnemonic, seed)
 
 	proofsRestored := cashu.Proofs{}
 
 	// for each mint get the keysets and do restore process for each keyset
 	for _, mint := range mintsToRestore {
-		mintInfo, err := client.GetMintInfo(mint)
+		mintClient := restoreClient.WithMintURL(mint)
+		mintInfo, err := mintClient.GetMintInfo(ctx)
 		if err != nil {
-			return 0, fmt.Errorf("error getting info from mint: %v", err)
+			return 0, fmt.Errorf("error getting info from mint: %w", err)
 		}
 
 		if !mintInfo.Nuts.Nut07.Supported || !mintInfo.Nuts.Nut09.Supported {
@@ -65,7 +77,7 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 		}
 
 		// call to get mint keysets
-		keysetsResponse, err := client.GetAllKeysets(mint)
+		keysetsResponse, err := mintClient.GetAllKeysets(ctx)
 		if err != nil {
 			return 0, err
 		}
@@ -83,7 +95,7 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 
 			var counter uint32 = 0
 
-			keysetKeys, err := GetKeysetKeys(mint, keyset.Id)
+			keysetKeys, err := getKeysetKeys(ctx, mintClient, keyset.Id)
 			if err != nil {
 				return 0, err
 			}
@@ -133,9 +145,9 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 
 				// if response has signatures, unblind them and check proof states
 				restoreRequest := nut09.PostRestoreRequest{Outputs: blindedMessages}
-				restoreResponse, err := client.PostRestore(mint, restoreRequest)
+				restoreResponse, err := mintClient.PostRestore(ctx, restoreRequest)
 				if err != nil {
-					return 0, fmt.Errorf("error restoring signatures from mint '%v': %v", mint, err)
+					return 0, fmt.Errorf("error restoring signatures from mint '%v': %w", mint, err)
 				}
 
 				if len(restoreResponse.Signatures) == 0 {
@@ -183,7 +195,7 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 				}
 
 				proofStateRequest := nut07.PostCheckStateRequest{Ys: Ys}
-				proofStateResponse, err := client.PostCheckProofState(mint, proofStateRequest)
+				proofStateResponse, err := mintClient.PostCheckProofState(ctx, proofStateRequest)
 				if err != nil {
 					return 0, err
 				}
@@ -208,18 +220,18 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 					}
 				}
 				if err := db.SaveProofs(proofsRestored); err != nil {
-					return 0, fmt.Errorf("error saving restored proofs: %v", err)
+					return 0, fmt.Errorf("error saving restored proofs: %w", err)
 				}
 
 				if len(pendingProofs) > 0 {
 					if err := db.AddPendingProofs(pendingProofs); err != nil {
-						return 0, fmt.Errorf("error saving pending proofs: %v", err)
+						return 0, fmt.Errorf("error saving pending proofs: %w", err)
 					}
 				}
 
 				// save wallet keyset with latest counter moving forward for wallet
 				if err := db.IncrementKeysetCounter(keyset.Id, counter); err != nil {
-					return 0, fmt.Errorf("error incrementing keyset counter: %v", err)
+					return 0, fmt.Errorf("error incrementing keyset counter: %w", err)
 				}
 				emptyBatches = 0
 			}
@@ -227,7 +239,7 @@ func Restore(walletPath, mnemonic string, mintsToRestore []string) (uint64, erro
 	}
 
 	if err := db.Close(); err != nil {
-		return proofsRestored.Amount(), fmt.Errorf("could not close db: %v", err)
+		return proofsRestored.Amount(), fmt.Errorf("could not close db: %w", err)
 	}
 
 	return proofsRestored.Amount(), nil
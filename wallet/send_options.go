@@ -1,6 +1,10 @@
 package wallet
 
-import "github.com/Origami74/gonuts-tollgate/cashu"
+import (
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+)
 
 // SendOptions provides configuration for send operations
 type SendOptions struct {
@@ -15,6 +19,13 @@ type SendOptions struct {
 
 	// MaxOverpaymentAbsolute limits overpayment to an absolute amount (0 = no limit)
 	MaxOverpaymentAbsolute uint64
+
+	// Memo is saved with the sent token to identify it
+	Memo string
+
+	// ReclaimAfter is the time after which the sent token is reclaimed if
+	// it has not been claimed (0 = never). See CheckSentTokens.
+	ReclaimAfter time.Duration
 }
 
 // DefaultSendOptions returns the default send options (backwards compatible)
@@ -32,6 +43,9 @@ type SendResult struct {
 	// Proofs are the proofs that were sent
 	Proofs cashu.Proofs
 
+	// SentTokenId is the id of the token tracked in SentTokens
+	SentTokenId string
+
 	// RequestedAmount is the amount that was requested
 	RequestedAmount uint64
 
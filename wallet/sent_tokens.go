@@ -0,0 +1,241 @@
+package wallet
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut07"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+)
+
+var (
+	ErrSentTokenNotFound   = errors.New("sent token not found")
+	ErrSentTokenNotPending = errors.New("sent token was already claimed or reclaimed")
+)
+
+// SentTokens returns the tokens sent by the wallet, newest first
+func (w *Wallet) SentTokens() []storage.SentToken {
+	sentTokens := w.db.GetSentTokens()
+	// ids sort in the order the tokens were sent
+	slices.SortFunc(sentTokens, func(a, b storage.SentToken) int {
+		return strings.Compare(b.Id, a.Id)
+	})
+	return sentTokens
+}
+
+// CheckSentTokens checks with the mints whether the pending sent tokens were claimed.
+// Tokens that were not claimed before their expiry are reclaimed. Tokens
+// from mints that cannot be reached are left pending.
+func (w *Wallet) CheckSentTokens() ([]storage.SentToken, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
+	now := time.Now().Unix()
+	for _, sentToken := range w.db.GetSentTokens() {
+		if sentToken.State != storage.SentTokenPending {
+			continue
+		}
+
+		proofStateRequest := nut07.PostCheckStateRequest{Ys: sentToken.Ys}
+		proofStateResponse, err := w.mintClient(sentToken.Mint).PostCheckProofState(w.ctx, proofStateRequest)
+		if err != nil {
+			if isNetworkError(err) {
+				continue
+			}
+			return nil, err
+		}
+
+		spent := 0
+		for _, state := range proofStateResponse.States {
+			if state.State == nut07.Spent {
+				spent++
+			}
+		}
+
+		if spent == len(sentToken.Ys) {
+			if err := w.db.DeletePendingProofs(sentToken.Ys); err != nil {
+				return nil, fmt.Errorf("error removing pending proofs: %w", err)
+			}
+			if err := w.settleSentToken(sentToken, storage.SentTokenClaimed); err != nil {
+				return nil, err
+			}
+		} else if sentToken.Expiry > 0 && now >= sentToken.Expiry {
+			if _, err := w.reclaimSentToken(sentToken); err != nil {
+				return nil, fmt.Errorf("error reclaiming sent token '%v': %w", sentToken.Id, err)
+			}
+		}
+	}
+
+	return w.SentTokens(), nil
+}
+
+// ReclaimSentToken takes back the proofs of a sent token
+// that have not been claimed and returns the amount reclaimed
+func (w *Wallet) ReclaimSentToken(id string) (uint64, error) {
+	if w.IsLocked() {
+		return 0, ErrWalletLocked
+	}
+
+	sentToken := w.db.GetSentTokenById(id)
+	if sentToken == nil {
+		return 0, ErrSentTokenNotFound
+	}
+	if sentToken.State != storage.SentTokenPending {
+		return 0, ErrSentTokenNotPending
+	}
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.reclaimSentToken(*sentToken)
+}
+
+// MonitorSentTokens checks the pending sent tokens every interval
+// until the context is done, reclaiming the ones that expired
+func (w *Wallet) MonitorSentTokens(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if !w.IsLocked() {
+				w.CheckSentTokens()
+			}
+		}
+	}
+}
+
+// reclaimSentToken takes back the proofs of the token that are unspent and
+// removes the ones claimed by the recipient from pending. The token is left
+// pending if any of its proofs are still pending in the mint.
+func (w *Wallet) reclaimSentToken(sentToken storage.SentToken) (uint64, error) {
+	pendingProofs := slices.DeleteFunc(w.db.GetPendingProofs(), func(proof storage.DBProof) bool {
+		return !slices.Contains(sentToken.Ys, proof.Y)
+	})
+
+	amountReclaimed, reclaimedYs, spentYs, err := w.reclaimProofs(sentToken.Mint, pendingProofs)
+	if err != nil {
+		return 0, err
+	}
+	if err := w.db.DeletePendingProofs(spentYs); err != nil {
+		return 0, fmt.Errorf("error removing pending proofs: %w", err)
+	}
+	if len(reclaimedYs)+len(spentYs) < len(pendingProofs) {
+		return amountReclaimed, nil
+	}
+
+	state := storage.SentTokenPartiallyClaimed
+	if len(reclaimedYs) == 0 {
+		state = storage.SentTokenClaimed
+	} else if len(reclaimedYs) == len(sentToken.Ys) {
+		state = storage.SentTokenReclaimed
+	}
+	if err := w.settleSentToken(sentToken, state); err != nil {
+		return 0, err
+	}
+	return amountReclaimed, nil
+}
+
+// trackSentToken saves the token sent so that it can be checked until
+// it is claimed. If reclaimAfter is not 0, it is reclaimed after that time.
+func (w *Wallet) trackSentToken(
+	mintURL string,
+	proofs cashu.Proofs,
+	memo string,
+	reclaimAfter time.Duration,
+) string {
+	token, err := cashu.NewTokenV4(proofs, mintURL, w.unit, false)
+	if err != nil {
+		fmt.Printf("Warning: could not track sent token: %v\n", err)
+		return ""
+	}
+	token.Memo = memo
+	tokenString, err := token.Serialize()
+	if err != nil {
+		fmt.Printf("Warning: could not track sent token: %v\n", err)
+		return ""
+	}
+
+	Ys := make([]string, len(proofs))
+	for i, proof := range proofs {
+		Y, err := crypto.HashToCurve([]byte(proof.Secret))
+		if err != nil {
+			fmt.Printf("Warning: could not track sent token: %v\n", err)
+			return ""
+		}
+		Ys[i] = hex.EncodeToString(Y.SerializeCompressed())
+	}
+
+	now := time.Now()
+	sentToken := storage.SentToken{
+		Id:        newRecordId(now),
+		Mint:      mintURL,
+		Amount:    proofs.Amount(),
+		Token:     tokenString,
+		Memo:      memo,
+		State:     storage.SentTokenPending,
+		Ys:        Ys,
+		CreatedAt: now.Unix(),
+	}
+	if reclaimAfter > 0 {
+		sentToken.Expiry = now.Add(reclaimAfter).Unix()
+	}
+
+	if err := w.db.SaveSentToken(sentToken); err != nil {
+		fmt.Printf("Warning: could not track sent token: %v\n", err)
+		return ""
+	}
+	return sentToken.Id
+}
+
+// settleSentTokens updates the state of pending sent tokens
+// that have none of their proofs in Ys left as pending
+func (w *Wallet) settleSentTokens(Ys []string, state storage.SentTokenState) error {
+	if len(Ys) == 0 {
+		return nil
+	}
+
+	pendingYs := make(map[string]bool)
+	for _, proof := range w.db.GetPendingProofs() {
+		pendingYs[proof.Y] = true
+	}
+
+	for _, sentToken := range w.db.GetSentTokens() {
+		if sentToken.State != storage.SentTokenPending {
+			continue
+		}
+		settled := slices.ContainsFunc(sentToken.Ys, func(Y string) bool {
+			return slices.Contains(Ys, Y)
+		}) && !slices.ContainsFunc(sentToken.Ys, func(Y string) bool {
+			return pendingYs[Y]
+		})
+		if settled {
+			if err := w.settleSentToken(sentToken, state); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func (w *Wallet) settleSentToken(sentToken storage.SentToken, state storage.SentTokenState) error {
+	sentToken.State = state
+	sentToken.SettledAt = time.Now().Unix()
+	if err := w.db.SaveSentToken(sentToken); err != nil {
+		return fmt.Errorf("error updating sent token: %w", err)
+	}
+	return nil
+}
@@ -22,7 +22,11 @@ const (
 	MELT_QUOTES_BUCKET    = "melt_quotes"
 	INVOICES_BUCKET       = "invoices"
 	SEED_BUCKET           = "seed"
+	HISTORY_BUCKET        = "history"
+	SENT_TOKENS_BUCKET    = "sent_tokens"
+	OPERATIONS_BUCKET     = "pending_operations"
 	MNEMONIC_KEY          = "mnemonic"
+	SEED_SCHEME_KEY       = "seed_scheme"
 )
 
 var (
@@ -89,6 +93,21 @@ func (db *BoltDB) initWalletBuckets() error {
 			return err
 		}
 
+		_, err = tx.CreateBucketIfNotExists([]byte(HISTORY_BUCKET))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists([]byte(SENT_TOKENS_BUCKET))
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.CreateBucketIfNotExists([]byte(OPERATIONS_BUCKET))
+		if err != nil {
+			return err
+		}
+
 		return nil
 	})
 }
@@ -112,6 +131,25 @@ func (db *BoltDB) GetMnemonic() string {
 	return mnemonic
 }
 
+func (db *BoltDB) SaveSeedScheme(scheme SeedScheme) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		seedb := tx.Bucket([]byte(SEED_BUCKET))
+		return seedb.Put([]byte(SEED_SCHEME_KEY), []byte{byte(scheme)})
+	})
+}
+
+func (db *BoltDB) GetSeedScheme() SeedScheme {
+	scheme := SeedSchemeBIP39
+	db.bolt.View(func(tx *bolt.Tx) error {
+		seedb := tx.Bucket([]byte(SEED_BUCKET))
+		if value := seedb.Get([]byte(SEED_SCHEME_KEY)); len(value) == 1 {
+			scheme = SeedScheme(value[0])
+		}
+		return nil
+	})
+	return scheme
+}
+
 func (db *BoltDB) GetSeed() []byte {
 	var seed []byte
 	db.bolt.View(func(tx *bolt.Tx) error {
@@ -640,6 +678,122 @@ func (db *BoltDB) GetMeltQuoteById(id string) *MeltQuote {
 	return quote
 }
 
+func (db *BoltDB) SaveHistoryEntry(entry HistoryEntry) error {
+	jsonEntry, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("invalid history entry: %v", err)
+	}
+
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		historyb := tx.Bucket([]byte(HISTORY_BUCKET))
+		return historyb.Put([]byte(entry.Id), jsonEntry)
+	})
+}
+
+func (db *BoltDB) GetHistory(filter HistoryFilter) []HistoryEntry {
+	entries := []HistoryEntry{}
+
+	db.bolt.View(func(tx *bolt.Tx) error {
+		historyb := tx.Bucket([]byte(HISTORY_BUCKET))
+		c := historyb.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var entry HistoryEntry
+			if err := json.Unmarshal(v, &entry); err != nil {
+				continue
+			}
+			entries = append(entries, entry)
+		}
+		return nil
+	})
+
+	return filterHistory(entries, filter)
+}
+
+func (db *BoltDB) SaveSentToken(sentToken SentToken) error {
+	jsonToken, err := json.Marshal(sentToken)
+	if err != nil {
+		return fmt.Errorf("invalid sent token: %v", err)
+	}
+
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		sentTokensb := tx.Bucket([]byte(SENT_TOKENS_BUCKET))
+		return sentTokensb.Put([]byte(sentToken.Id), jsonToken)
+	})
+}
+
+func (db *BoltDB) GetSentTokens() []SentToken {
+	sentTokens := []SentToken{}
+
+	db.bolt.View(func(tx *bolt.Tx) error {
+		sentTokensb := tx.Bucket([]byte(SENT_TOKENS_BUCKET))
+		c := sentTokensb.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var sentToken SentToken
+			if err := json.Unmarshal(v, &sentToken); err != nil {
+				continue
+			}
+			sentTokens = append(sentTokens, sentToken)
+		}
+		return nil
+	})
+
+	return sentTokens
+}
+
+func (db *BoltDB) GetSentTokenById(id string) *SentToken {
+	var sentToken *SentToken
+	db.bolt.View(func(tx *bolt.Tx) error {
+		sentTokensb := tx.Bucket([]byte(SENT_TOKENS_BUCKET))
+		tokenBytes := sentTokensb.Get([]byte(id))
+		if err := json.Unmarshal(tokenBytes, &sentToken); err != nil {
+			sentToken = nil
+		}
+		return nil
+	})
+	return sentToken
+}
+
+func (db *BoltDB) SavePendingOperation(operation PendingOperation) error {
+	jsonOperation, err := json.Marshal(operation)
+	if err != nil {
+		return fmt.Errorf("invalid pending operation: %v", err)
+	}
+
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		operationsb := tx.Bucket([]byte(OPERATIONS_BUCKET))
+		return operationsb.Put([]byte(operation.Id), jsonOperation)
+	})
+}
+
+func (db *BoltDB) GetPendingOperations() []PendingOperation {
+	operations := []PendingOperation{}
+
+	db.bolt.View(func(tx *bolt.Tx) error {
+		operationsb := tx.Bucket([]byte(OPERATIONS_BUCKET))
+		c := operationsb.Cursor()
+
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			var operation PendingOperation
+			if err := json.Unmarshal(v, &operation); err != nil {
+				continue
+			}
+			operations = append(operations, operation)
+		}
+		return nil
+	})
+
+	return operations
+}
+
+func (db *BoltDB) DeletePendingOperation(id string) error {
+	return db.bolt.Update(func(tx *bolt.Tx) error {
+		operationsb := tx.Bucket([]byte(OPERATIONS_BUCKET))
+		return operationsb.Delete([]byte(id))
+	})
+}
+
 func (db *BoltDB) MigrateInvoicesToQuotes() error {
 	invoices := db.GetInvoices()
 
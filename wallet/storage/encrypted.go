@@ -0,0 +1,591 @@
+package storage
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"sync"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var ErrLocked = errors.New("wallet is locked")
+
+// EncryptedDB wraps a WalletDB and encrypts the seed, mnemonic, proofs
+// and the private keys of mint quotes before they are saved.
+//
+// Proofs are saved with the whole proof encrypted in the C field and the
+// secret replaced by a keyed hash of it, so they can still be found and deleted
+// by secret. Private keys of mint quotes are masked by adding a scalar derived
+// from the key and the quote id. Tokens in the history and sent tokens are encrypted,
+// as are the secrets, blinding factors and input secrets of pending operations.
+// Keysets, counters, melt quotes and the rest of the history are not encrypted.
+//
+// An EncryptedDB starts locked. While locked, proofs and the seed are not
+// returned and saving them fails with ErrLocked.
+type EncryptedDB struct {
+	db WalletDB
+
+	mu sync.RWMutex
+	// key to encrypt the data. nil if locked
+	encryptionKey []byte
+	// key to derive the ids of proofs and the masks of quote private keys
+	lookupKey []byte
+}
+
+func NewEncryptedDB(db WalletDB) *EncryptedDB {
+	return &EncryptedDB{db: db}
+}
+
+// Unlock sets the data key read with ReadDataKey
+func (e *EncryptedDB) Unlock(dataKey []byte) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.encryptionKey = deriveKey(dataKey, "encryption")
+	e.lookupKey = deriveKey(dataKey, "lookup")
+}
+
+func (e *EncryptedDB) Lock() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.encryptionKey = nil
+	e.lookupKey = nil
+}
+
+func (e *EncryptedDB) Locked() bool {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	return e.encryptionKey == nil
+}
+
+func deriveKey(dataKey []byte, label string) []byte {
+	mac := hmac.New(sha256.New, dataKey)
+	mac.Write([]byte(label))
+	return mac.Sum(nil)
+}
+
+// keys returns the keys or ErrLocked if the db is locked
+func (e *EncryptedDB) keys() (encryptionKey, lookupKey []byte, err error) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+	if e.encryptionKey == nil {
+		return nil, nil, ErrLocked
+	}
+	return e.encryptionKey, e.lookupKey, nil
+}
+
+func lookupId(lookupKey []byte, secret string) string {
+	mac := hmac.New(sha256.New, lookupKey)
+	mac.Write([]byte("proof:" + secret))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (e *EncryptedDB) Close() error {
+	e.Lock()
+	return e.db.Close()
+}
+
+func (e *EncryptedDB) SaveMnemonicSeed(mnemonic string, seed []byte) {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return
+	}
+	encryptedMnemonic, err := encrypt(encryptionKey, []byte(mnemonic))
+	if err != nil {
+		return
+	}
+	encryptedSeed, err := encrypt(encryptionKey, seed)
+	if err != nil {
+		return
+	}
+	e.db.SaveMnemonicSeed(base64.StdEncoding.EncodeToString(encryptedMnemonic), encryptedSeed)
+}
+
+func (e *EncryptedDB) GetSeed() []byte {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return nil
+	}
+	encryptedSeed := e.db.GetSeed()
+	if len(encryptedSeed) == 0 {
+		return nil
+	}
+	seed, err := decrypt(encryptionKey, encryptedSeed)
+	if err != nil {
+		return nil
+	}
+	return seed
+}
+
+func (e *EncryptedDB) GetMnemonic() string {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return ""
+	}
+	encryptedMnemonic, err := base64.StdEncoding.DecodeString(e.db.GetMnemonic())
+	if err != nil {
+		return ""
+	}
+	mnemonic, err := decrypt(encryptionKey, encryptedMnemonic)
+	if err != nil {
+		return ""
+	}
+	return string(mnemonic)
+}
+
+func (e *EncryptedDB) SaveSeedScheme(scheme SeedScheme) error {
+	return e.db.SaveSeedScheme(scheme)
+}
+
+func (e *EncryptedDB) GetSeedScheme() SeedScheme {
+	return e.db.GetSeedScheme()
+}
+
+func (e *EncryptedDB) encryptProofs(proofs cashu.Proofs) (cashu.Proofs, error) {
+	encryptionKey, lookupKey, err := e.keys()
+	if err != nil {
+		return nil, err
+	}
+
+	encryptedProofs := make(cashu.Proofs, len(proofs))
+	for i, proof := range proofs {
+		jsonProof, err := json.Marshal(proof)
+		if err != nil {
+			return nil, err
+		}
+		encryptedProof, err := encrypt(encryptionKey, jsonProof)
+		if err != nil {
+			return nil, err
+		}
+		encryptedProofs[i] = cashu.Proof{
+			Amount: proof.Amount,
+			Id:     proof.Id,
+			Secret: lookupId(lookupKey, proof.Secret),
+			C:      base64.StdEncoding.EncodeToString(encryptedProof),
+		}
+	}
+	return encryptedProofs, nil
+}
+
+func decryptProof(encryptionKey []byte, encryptedProof string) (cashu.Proof, error) {
+	ciphertext, err := base64.StdEncoding.DecodeString(encryptedProof)
+	if err != nil {
+		return cashu.Proof{}, err
+	}
+	jsonProof, err := decrypt(encryptionKey, ciphertext)
+	if err != nil {
+		return cashu.Proof{}, err
+	}
+	var proof cashu.Proof
+	if err := json.Unmarshal(jsonProof, &proof); err != nil {
+		return cashu.Proof{}, err
+	}
+	return proof, nil
+}
+
+func (e *EncryptedDB) decryptProofs(encryptedProofs cashu.Proofs) cashu.Proofs {
+	proofs := cashu.Proofs{}
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return proofs
+	}
+
+	for _, encryptedProof := range encryptedProofs {
+		proof, err := decryptProof(encryptionKey, encryptedProof.C)
+		if err != nil {
+			continue
+		}
+		proofs = append(proofs, proof)
+	}
+	return proofs
+}
+
+func (e *EncryptedDB) SaveProofs(proofs cashu.Proofs) error {
+	encryptedProofs, err := e.encryptProofs(proofs)
+	if err != nil {
+		return err
+	}
+	return e.db.SaveProofs(encryptedProofs)
+}
+
+func (e *EncryptedDB) GetProofs() cashu.Proofs {
+	return e.decryptProofs(e.db.GetProofs())
+}
+
+func (e *EncryptedDB) GetProofsByKeysetId(id string) cashu.Proofs {
+	return e.decryptProofs(e.db.GetProofsByKeysetId(id))
+}
+
+func (e *EncryptedDB) DeleteProof(secret string) error {
+	_, lookupKey, err := e.keys()
+	if err != nil {
+		return err
+	}
+	return e.db.DeleteProof(lookupId(lookupKey, secret))
+}
+
+func (e *EncryptedDB) AddPendingProofs(proofs cashu.Proofs) error {
+	encryptedProofs, err := e.encryptProofs(proofs)
+	if err != nil {
+		return err
+	}
+	return e.db.AddPendingProofs(encryptedProofs)
+}
+
+func (e *EncryptedDB) AddPendingProofsByQuoteId(proofs cashu.Proofs, quoteId string) error {
+	encryptedProofs, err := e.encryptProofs(proofs)
+	if err != nil {
+		return err
+	}
+	return e.db.AddPendingProofsByQuoteId(encryptedProofs, quoteId)
+}
+
+// decryptPendingProofs returns the decrypted pending proofs
+// with the Y of the proof as saved in the underlying db
+func (e *EncryptedDB) decryptPendingProofs(encryptedProofs []DBProof) ([]DBProof, []string) {
+	proofs := []DBProof{}
+	dbYs := []string{}
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return proofs, dbYs
+	}
+
+	for _, encryptedProof := range encryptedProofs {
+		proof, err := decryptProof(encryptionKey, encryptedProof.C)
+		if err != nil {
+			continue
+		}
+		Y, err := crypto.HashToCurve([]byte(proof.Secret))
+		if err != nil {
+			continue
+		}
+		proofs = append(proofs, DBProof{
+			Y:           hex.EncodeToString(Y.SerializeCompressed()),
+			Amount:      proof.Amount,
+			Id:          proof.Id,
+			Secret:      proof.Secret,
+			C:           proof.C,
+			DLEQ:        proof.DLEQ,
+			MeltQuoteId: encryptedProof.MeltQuoteId,
+		})
+		dbYs = append(dbYs, encryptedProof.Y)
+	}
+	return proofs, dbYs
+}
+
+func (e *EncryptedDB) GetPendingProofs() []DBProof {
+	proofs, _ := e.decryptPendingProofs(e.db.GetPendingProofs())
+	return proofs
+}
+
+func (e *EncryptedDB) GetPendingProofsByQuoteId(quoteId string) []DBProof {
+	proofs, _ := e.decryptPendingProofs(e.db.GetPendingProofsByQuoteId(quoteId))
+	return proofs
+}
+
+// DeletePendingProofs deletes the pending proofs with the Ys.
+// The Ys in the underlying db are computed from the hashed secrets
+// so they are found by decrypting all the pending proofs.
+func (e *EncryptedDB) DeletePendingProofs(Ys []string) error {
+	if e.Locked() {
+		return ErrLocked
+	}
+
+	proofs, dbYs := e.decryptPendingProofs(e.db.GetPendingProofs())
+	toDelete := make(map[string]bool, len(Ys))
+	for _, Y := range Ys {
+		toDelete[Y] = true
+	}
+
+	dbYsToDelete := []string{}
+	for i, proof := range proofs {
+		if toDelete[proof.Y] {
+			dbYsToDelete = append(dbYsToDelete, dbYs[i])
+		}
+	}
+	return e.db.DeletePendingProofs(dbYsToDelete)
+}
+
+func (e *EncryptedDB) DeletePendingProofsByQuoteId(quoteId string) error {
+	return e.db.DeletePendingProofsByQuoteId(quoteId)
+}
+
+func (e *EncryptedDB) SaveKeyset(keyset *crypto.WalletKeyset) error {
+	return e.db.SaveKeyset(keyset)
+}
+
+func (e *EncryptedDB) GetKeysets() crypto.KeysetsMap {
+	return e.db.GetKeysets()
+}
+
+func (e *EncryptedDB) GetKeyset(keysetId string) *crypto.WalletKeyset {
+	return e.db.GetKeyset(keysetId)
+}
+
+func (e *EncryptedDB) IncrementKeysetCounter(keysetId string, num uint32) error {
+	return e.db.IncrementKeysetCounter(keysetId, num)
+}
+
+func (e *EncryptedDB) GetKeysetCounter(keysetId string) uint32 {
+	return e.db.GetKeysetCounter(keysetId)
+}
+
+func (e *EncryptedDB) UpdateKeysetMintURL(oldURL, newURL string) error {
+	return e.db.UpdateKeysetMintURL(oldURL, newURL)
+}
+
+func quoteKeyMask(lookupKey []byte, quoteId string) *secp256k1.ModNScalar {
+	mac := hmac.New(sha256.New, lookupKey)
+	mac.Write([]byte("mint_quote:" + quoteId))
+	var mask secp256k1.ModNScalar
+	mask.SetByteSlice(mac.Sum(nil))
+	return &mask
+}
+
+func (e *EncryptedDB) SaveMintQuote(quote MintQuote) error {
+	if quote.PrivateKey != nil {
+		_, lookupKey, err := e.keys()
+		if err != nil {
+			return err
+		}
+		var masked secp256k1.ModNScalar
+		masked.Add2(&quote.PrivateKey.Key, quoteKeyMask(lookupKey, quote.QuoteId))
+		quote.PrivateKey = secp256k1.NewPrivateKey(&masked)
+	}
+	return e.db.SaveMintQuote(quote)
+}
+
+// unmaskQuote returns the quote with the private key unmasked
+// or without it if the db is locked
+func (e *EncryptedDB) unmaskQuote(quote MintQuote) MintQuote {
+	if quote.PrivateKey == nil {
+		return quote
+	}
+	_, lookupKey, err := e.keys()
+	if err != nil {
+		quote.PrivateKey = nil
+		return quote
+	}
+	mask := quoteKeyMask(lookupKey, quote.QuoteId)
+	var unmasked secp256k1.ModNScalar
+	unmasked.Add2(&quote.PrivateKey.Key, mask.Negate())
+	quote.PrivateKey = secp256k1.NewPrivateKey(&unmasked)
+	return quote
+}
+
+func (e *EncryptedDB) GetMintQuotes() []MintQuote {
+	quotes := e.db.GetMintQuotes()
+	for i, quote := range quotes {
+		quotes[i] = e.unmaskQuote(quote)
+	}
+	return quotes
+}
+
+func (e *EncryptedDB) GetMintQuoteById(id string) *MintQuote {
+	quote := e.db.GetMintQuoteById(id)
+	if quote == nil {
+		return nil
+	}
+	unmasked := e.unmaskQuote(*quote)
+	return &unmasked
+}
+
+func (e *EncryptedDB) SaveMeltQuote(quote MeltQuote) error {
+	return e.db.SaveMeltQuote(quote)
+}
+
+func (e *EncryptedDB) GetMeltQuotes() []MeltQuote {
+	return e.db.GetMeltQuotes()
+}
+
+func (e *EncryptedDB) GetMeltQuoteById(id string) *MeltQuote {
+	return e.db.GetMeltQuoteById(id)
+}
+
+func (e *EncryptedDB) SaveHistoryEntry(entry HistoryEntry) error {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return err
+	}
+	if len(entry.Token) > 0 {
+		encryptedToken, err := encrypt(encryptionKey, []byte(entry.Token))
+		if err != nil {
+			return err
+		}
+		entry.Token = base64.StdEncoding.EncodeToString(encryptedToken)
+	}
+	return e.db.SaveHistoryEntry(entry)
+}
+
+func (e *EncryptedDB) GetHistory(filter HistoryFilter) []HistoryEntry {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return []HistoryEntry{}
+	}
+
+	entries := e.db.GetHistory(filter)
+	for i, entry := range entries {
+		if len(entry.Token) == 0 {
+			continue
+		}
+		encryptedToken, err := base64.StdEncoding.DecodeString(entry.Token)
+		if err != nil {
+			return []HistoryEntry{}
+		}
+		token, err := decrypt(encryptionKey, encryptedToken)
+		if err != nil {
+			return []HistoryEntry{}
+		}
+		entries[i].Token = string(token)
+	}
+	return entries
+}
+
+func (e *EncryptedDB) SaveSentToken(sentToken SentToken) error {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return err
+	}
+	encryptedToken, err := encrypt(encryptionKey, []byte(sentToken.Token))
+	if err != nil {
+		return err
+	}
+	sentToken.Token = base64.StdEncoding.EncodeToString(encryptedToken)
+	return e.db.SaveSentToken(sentToken)
+}
+
+func (e *EncryptedDB) GetSentTokens() []SentToken {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return []SentToken{}
+	}
+
+	sentTokens := e.db.GetSentTokens()
+	for i := range sentTokens {
+		if err := decryptSentToken(encryptionKey, &sentTokens[i]); err != nil {
+			return []SentToken{}
+		}
+	}
+	return sentTokens
+}
+
+func (e *EncryptedDB) GetSentTokenById(id string) *SentToken {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return nil
+	}
+
+	sentToken := e.db.GetSentTokenById(id)
+	if sentToken == nil {
+		return nil
+	}
+	if err := decryptSentToken(encryptionKey, sentToken); err != nil {
+		return nil
+	}
+	return sentToken
+}
+
+func decryptSentToken(encryptionKey []byte, sentToken *SentToken) error {
+	encryptedToken, err := base64.StdEncoding.DecodeString(sentToken.Token)
+	if err != nil {
+		return err
+	}
+	token, err := decrypt(encryptionKey, encryptedToken)
+	if err != nil {
+		return err
+	}
+	sentToken.Token = string(token)
+	return nil
+}
+
+func (e *EncryptedDB) SavePendingOperation(operation PendingOperation) error {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return err
+	}
+
+	encryptString := func(s string) (string, error) {
+		encrypted, err := encrypt(encryptionKey, []byte(s))
+		if err != nil {
+			return "", err
+		}
+		return base64.StdEncoding.EncodeToString(encrypted), nil
+	}
+
+	inputs := make(cashu.Proofs, len(operation.Inputs))
+	for i, proof := range operation.Inputs {
+		if proof.Secret, err = encryptString(proof.Secret); err != nil {
+			return err
+		}
+		inputs[i] = proof
+	}
+	secrets := make([]string, len(operation.Secrets))
+	for i, secret := range operation.Secrets {
+		if secrets[i], err = encryptString(secret); err != nil {
+			return err
+		}
+	}
+	rs := make([]string, len(operation.Rs))
+	for i, r := range operation.Rs {
+		if rs[i], err = encryptString(r); err != nil {
+			return err
+		}
+	}
+
+	operation.Inputs = inputs
+	operation.Secrets = secrets
+	operation.Rs = rs
+	return e.db.SavePendingOperation(operation)
+}
+
+func (e *EncryptedDB) GetPendingOperations() []PendingOperation {
+	encryptionKey, _, err := e.keys()
+	if err != nil {
+		return []PendingOperation{}
+	}
+
+	decryptString := func(s string) (string, error) {
+		encrypted, err := base64.StdEncoding.DecodeString(s)
+		if err != nil {
+			return "", err
+		}
+		decrypted, err := decrypt(encryptionKey, encrypted)
+		if err != nil {
+			return "", err
+		}
+		return string(decrypted), nil
+	}
+
+	operations := e.db.GetPendingOperations()
+	for i := range operations {
+		operation := &operations[i]
+		for j := range operation.Inputs {
+			if operation.Inputs[j].Secret, err = decryptString(operation.Inputs[j].Secret); err != nil {
+				return []PendingOperation{}
+			}
+		}
+		for j := range operation.Secrets {
+			if operation.Secrets[j], err = decryptString(operation.Secrets[j]); err != nil {
+				return []PendingOperation{}
+			}
+		}
+		for j := range operation.Rs {
+			if operation.Rs[j], err = decryptString(operation.Rs[j]); err != nil {
+				return []PendingOperation{}
+			}
+		}
+	}
+	return operations
+}
+
+func (e *EncryptedDB) DeletePendingOperation(id string) error {
+	if _, _, err := e.keys(); err != nil {
+		return err
+	}
+	return e.db.DeletePendingOperation(id)
+}
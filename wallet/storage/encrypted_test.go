@@ -0,0 +1,180 @@
+package storage
+
+import (
+	"errors"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+func TestKeyFile(t *testing.T) {
+	path := t.TempDir()
+	dataKey := NewDataKey()
+	if err := SaveKeyFile(path, "passphrase", dataKey); err != nil {
+		t.Fatalf("unexpected error saving key file: %v", err)
+	}
+	if !KeyFileExists(path) {
+		t.Fatal("expected key file to exist")
+	}
+
+	if _, err := ReadDataKey(path, "wrong"); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrInvalidPassphrase, err)
+	}
+	key, err := ReadDataKey(path, "passphrase")
+	if err != nil {
+		t.Fatalf("unexpected error reading key file: %v", err)
+	}
+	if !slices.Equal(key, dataKey) {
+		t.Fatal("data key from key file does not match")
+	}
+
+	// changing the passphrase keeps the same data key
+	if err := SaveKeyFile(path, "new passphrase", key); err != nil {
+		t.Fatalf("unexpected error saving key file: %v", err)
+	}
+	if _, err := ReadDataKey(path, "passphrase"); !errors.Is(err, ErrInvalidPassphrase) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrInvalidPassphrase, err)
+	}
+	key, err = ReadDataKey(path, "new passphrase")
+	if err != nil || !slices.Equal(key, dataKey) {
+		t.Fatalf("expected same data key after changing passphrase. err: %v", err)
+	}
+}
+
+func TestEncryptedDB(t *testing.T) {
+	boltdb, err := InitBolt(t.TempDir())
+	if err != nil {
+		t.Fatalf("error setting bolt db: %v", err)
+	}
+	encryptedDB := NewEncryptedDB(boltdb)
+	defer encryptedDB.Close()
+
+	proofs := generateRandomProofs("keysetId12345", 10)
+	if err := encryptedDB.SaveProofs(proofs); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrLocked, err)
+	}
+
+	encryptedDB.Unlock(NewDataKey())
+	encryptedDB.SaveMnemonicSeed("some mnemonic", []byte("seed"))
+	if encryptedDB.GetMnemonic() != "some mnemonic" || string(encryptedDB.GetSeed()) != "seed" {
+		t.Fatal("seed from db does not match saved one")
+	}
+	if strings.Contains(boltdb.GetMnemonic(), "mnemonic") || string(boltdb.GetSeed()) == "seed" {
+		t.Fatal("expected seed to be encrypted in the underlying db")
+	}
+
+	if err := encryptedDB.SaveProofs(proofs); err != nil {
+		t.Fatalf("error saving proofs: %v", err)
+	}
+	proofsFromDb := encryptedDB.GetProofsByKeysetId("keysetId12345")
+	sortProofs(proofs)
+	sortProofs(proofsFromDb)
+	if !slices.Equal(proofs, proofsFromDb) {
+		t.Fatal("proofs from db do not match saved ones")
+	}
+	for _, proof := range boltdb.GetProofs() {
+		if slices.ContainsFunc(proofs, func(p cashu.Proof) bool { return p.Secret == proof.Secret || p.C == proof.C }) {
+			t.Fatal("expected proofs to be encrypted in the underlying db")
+		}
+	}
+	if err := encryptedDB.DeleteProof(proofs[0].Secret); err != nil {
+		t.Fatalf("error deleting proof: %v", err)
+	}
+	if len(encryptedDB.GetProofs()) != 9 {
+		t.Fatalf("expected 9 proofs but got %v", len(encryptedDB.GetProofs()))
+	}
+
+	pendingProofs := generateRandomProofs("keysetId12345", 5)
+	if err := encryptedDB.AddPendingProofsByQuoteId(pendingProofs, "quoteId"); err != nil {
+		t.Fatalf("error saving pending proofs: %v", err)
+	}
+	expectedPending := toDBProofs(pendingProofs, "quoteId")
+	pendingFromDb := encryptedDB.GetPendingProofsByQuoteId("quoteId")
+	sortDBProofs(expectedPending)
+	sortDBProofs(pendingFromDb)
+	if !slices.Equal(expectedPending, pendingFromDb) {
+		t.Fatal("pending proofs from db do not match saved ones")
+	}
+	if err := encryptedDB.DeletePendingProofs([]string{pendingFromDb[0].Y}); err != nil {
+		t.Fatalf("error deleting pending proofs: %v", err)
+	}
+	if len(encryptedDB.GetPendingProofs()) != 4 {
+		t.Fatalf("expected 4 pending proofs but got %v", len(encryptedDB.GetPendingProofs()))
+	}
+
+	quote := generateMintQuote("quoteId", true)
+	if err := encryptedDB.SaveMintQuote(quote); err != nil {
+		t.Fatalf("error saving mint quote: %v", err)
+	}
+	if boltdb.GetMintQuoteById("quoteId").PrivateKey.Key.Equals(&quote.PrivateKey.Key) {
+		t.Fatal("expected private key of quote to be masked in the underlying db")
+	}
+	quoteFromDb := encryptedDB.GetMintQuoteById("quoteId")
+	if !quoteFromDb.PrivateKey.Key.Equals(&quote.PrivateKey.Key) {
+		t.Fatal("private key of quote from db does not match saved one")
+	}
+
+	entry := HistoryEntry{Id: "id", Type: SendHistory, Amount: 21, Token: "cashuBtoken", CreatedAt: 1000}
+	if err := encryptedDB.SaveHistoryEntry(entry); err != nil {
+		t.Fatalf("error saving history entry: %v", err)
+	}
+	if boltdb.GetHistory(HistoryFilter{})[0].Token == entry.Token {
+		t.Fatal("expected token in history to be encrypted in the underlying db")
+	}
+	if !reflect.DeepEqual(encryptedDB.GetHistory(HistoryFilter{}), []HistoryEntry{entry}) {
+		t.Fatal("history from db does not match saved one")
+	}
+
+	sentToken := SentToken{Id: "id", Amount: 21, Token: "cashuBtoken", Ys: []string{"Y"}, CreatedAt: 1000}
+	if err := encryptedDB.SaveSentToken(sentToken); err != nil {
+		t.Fatalf("error saving sent token: %v", err)
+	}
+	if boltdb.GetSentTokenById("id").Token == sentToken.Token {
+		t.Fatal("expected sent token to be encrypted in the underlying db")
+	}
+	if !reflect.DeepEqual(*encryptedDB.GetSentTokenById("id"), sentToken) {
+		t.Fatal("sent token from db does not match saved one")
+	}
+
+	operation := PendingOperation{
+		Id:       "operationId",
+		Type:     SwapOperation,
+		Inputs:   cashu.Proofs{{Amount: 1, Id: "keysetId", Secret: "inputSecret", C: "C"}},
+		Outputs:  cashu.BlindedMessages{{Amount: 1, Id: "keysetId", B_: "B_"}},
+		Secrets:  []string{"secret"},
+		Rs:       []string{"r"},
+		KeysetId: "keysetId",
+	}
+	if err := encryptedDB.SavePendingOperation(operation); err != nil {
+		t.Fatalf("error saving pending operation: %v", err)
+	}
+	savedOperation := boltdb.GetPendingOperations()[0]
+	if savedOperation.Inputs[0].Secret == "inputSecret" || savedOperation.Secrets[0] == "secret" || savedOperation.Rs[0] == "r" {
+		t.Fatal("expected secrets of pending operation to be encrypted in the underlying db")
+	}
+	if !reflect.DeepEqual(encryptedDB.GetPendingOperations(), []PendingOperation{operation}) {
+		t.Fatal("pending operations from db do not match saved ones")
+	}
+
+	encryptedDB.Lock()
+	if len(encryptedDB.GetProofs()) != 0 || encryptedDB.GetSeed() != nil || len(encryptedDB.GetHistory(HistoryFilter{})) != 0 || len(encryptedDB.GetSentTokens()) != 0 {
+		t.Fatal("expected no proofs, seed, history or sent tokens while locked")
+	}
+	if len(encryptedDB.GetPendingOperations()) != 0 {
+		t.Fatal("expected no pending operations while locked")
+	}
+	if err := encryptedDB.DeleteProof(proofs[1].Secret); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrLocked, err)
+	}
+	if encryptedDB.GetMintQuoteById("quoteId").PrivateKey != nil {
+		t.Fatal("expected no private key for quote while locked")
+	}
+	key, _ := secp256k1.GeneratePrivateKey()
+	if err := encryptedDB.SaveMintQuote(MintQuote{QuoteId: "id", PrivateKey: key}); !errors.Is(err, ErrLocked) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrLocked, err)
+	}
+}
@@ -0,0 +1,133 @@
+package storage
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+const KEY_FILENAME = "wallet.key"
+
+// argon2id params used for new key files
+const (
+	argonTime    = 3
+	argonMemory  = 64 * 1024
+	argonThreads = 4
+	saltSize     = 16
+)
+
+var ErrInvalidPassphrase = errors.New("invalid passphrase")
+
+// keyFile holds the key that encrypts the wallet data. The key is
+// encrypted under a key derived from the passphrase so changing the
+// passphrase only needs to rewrite this file.
+type keyFile struct {
+	Salt         []byte `json:"salt"`
+	Time         uint32 `json:"time"`
+	Memory       uint32 `json:"memory"`
+	Threads      uint8  `json:"threads"`
+	EncryptedKey []byte `json:"encrypted_key"`
+}
+
+func KeyFileExists(path string) bool {
+	_, err := os.Stat(filepath.Join(path, KEY_FILENAME))
+	return err == nil
+}
+
+// NewDataKey generates a random key to encrypt the wallet data
+func NewDataKey() []byte {
+	key := make([]byte, chacha20poly1305.KeySize)
+	rand.Read(key)
+	return key
+}
+
+// SaveKeyFile encrypts the data key under the passphrase and writes it to the
+// key file in the path. The file is replaced atomically if it already exists.
+func SaveKeyFile(path, passphrase string, dataKey []byte) error {
+	if len(passphrase) == 0 {
+		return errors.New("passphrase cannot be empty")
+	}
+
+	salt := make([]byte, saltSize)
+	if _, err := rand.Read(salt); err != nil {
+		return err
+	}
+	kf := keyFile{
+		Salt:    salt,
+		Time:    argonTime,
+		Memory:  argonMemory,
+		Threads: argonThreads,
+	}
+
+	encryptedKey, err := encrypt(kf.passphraseKey(passphrase), dataKey)
+	if err != nil {
+		return err
+	}
+	kf.EncryptedKey = encryptedKey
+
+	jsonKeyFile, err := json.Marshal(kf)
+	if err != nil {
+		return err
+	}
+
+	keyPath := filepath.Join(path, KEY_FILENAME)
+	tempPath := keyPath + ".tmp"
+	if err := os.WriteFile(tempPath, jsonKeyFile, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tempPath, keyPath)
+}
+
+// ReadDataKey decrypts the data key in the key file with the passphrase.
+// It returns ErrInvalidPassphrase if the passphrase is wrong.
+func ReadDataKey(path, passphrase string) ([]byte, error) {
+	jsonKeyFile, err := os.ReadFile(filepath.Join(path, KEY_FILENAME))
+	if err != nil {
+		return nil, err
+	}
+	var kf keyFile
+	if err := json.Unmarshal(jsonKeyFile, &kf); err != nil {
+		return nil, fmt.Errorf("invalid key file: %v", err)
+	}
+
+	dataKey, err := decrypt(kf.passphraseKey(passphrase), kf.EncryptedKey)
+	if err != nil {
+		return nil, ErrInvalidPassphrase
+	}
+	return dataKey, nil
+}
+
+func (kf keyFile) passphraseKey(passphrase string) []byte {
+	return argon2.IDKey([]byte(passphrase), kf.Salt, kf.Time, kf.Memory, kf.Threads, chacha20poly1305.KeySize)
+}
+
+// encrypt returns the nonce followed by the ciphertext
+func encrypt(key, plaintext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	nonce := make([]byte, aead.NonceSize(), aead.NonceSize()+len(plaintext)+aead.Overhead())
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, err
+	}
+	return aead.Seal(nonce, nonce, plaintext, nil), nil
+}
+
+func decrypt(key, ciphertext []byte) ([]byte, error) {
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	if len(ciphertext) < aead.NonceSize() {
+		return nil, errors.New("ciphertext too short")
+	}
+	nonce, ciphertext := ciphertext[:aead.NonceSize()], ciphertext[aead.NonceSize():]
+	return aead.Open(nil, nonce, ciphertext, nil)
+}
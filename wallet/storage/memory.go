@@ -0,0 +1,522 @@
+package storage
+
+import (
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+)
+
+// MemoryDB is a WalletDB that keeps the wallet in memory. Its contents
+// can be saved with Snapshot and loaded back with NewMemoryDBFromSnapshot.
+type MemoryDB struct {
+	mu sync.RWMutex
+
+	mnemonic   string
+	seed       []byte
+	seedScheme SeedScheme
+	// proofs by secret
+	proofs map[string]cashu.Proof
+	// pending proofs by Y
+	pendingProofs map[string]DBProof
+	// keysets by mint url and keyset id
+	keysets    map[string]map[string]crypto.WalletKeyset
+	mintQuotes map[string]MintQuote
+	meltQuotes map[string]MeltQuote
+	history    map[string]HistoryEntry
+	sentTokens map[string]SentToken
+	operations map[string]PendingOperation
+}
+
+// memorySnapshot is the serialized form of a MemoryDB. It uses slices
+// because the custom json marshalling of keysets and mint quotes
+// is not applied to map values.
+type memorySnapshot struct {
+	Mnemonic      string                `json:"mnemonic"`
+	Seed          []byte                `json:"seed"`
+	SeedScheme    SeedScheme            `json:"seed_scheme"`
+	Proofs        cashu.Proofs          `json:"proofs"`
+	PendingProofs []DBProof             `json:"pending_proofs"`
+	Keysets       []crypto.WalletKeyset `json:"keysets"`
+	MintQuotes    []MintQuote           `json:"mint_quotes"`
+	MeltQuotes    []MeltQuote           `json:"melt_quotes"`
+	History       []HistoryEntry        `json:"history"`
+	SentTokens    []SentToken           `json:"sent_tokens"`
+	Operations    []PendingOperation    `json:"pending_operations"`
+}
+
+func NewMemoryDB() *MemoryDB {
+	return &MemoryDB{
+		proofs:        make(map[string]cashu.Proof),
+		pendingProofs: make(map[string]DBProof),
+		keysets:       make(map[string]map[string]crypto.WalletKeyset),
+		mintQuotes:    make(map[string]MintQuote),
+		meltQuotes:    make(map[string]MeltQuote),
+		history:       make(map[string]HistoryEntry),
+		sentTokens:    make(map[string]SentToken),
+		operations:    make(map[string]PendingOperation),
+	}
+}
+
+// NewMemoryDBFromSnapshot returns a MemoryDB with the contents of a snapshot
+// previously taken with Snapshot
+func NewMemoryDBFromSnapshot(data []byte) (*MemoryDB, error) {
+	var snapshot memorySnapshot
+	if err := json.Unmarshal(data, &snapshot); err != nil {
+		return nil, fmt.Errorf("invalid snapshot: %v", err)
+	}
+
+	db := NewMemoryDB()
+	db.mnemonic = snapshot.Mnemonic
+	db.seed = snapshot.Seed
+	db.seedScheme = snapshot.SeedScheme
+	for _, proof := range snapshot.Proofs {
+		db.proofs[proof.Secret] = proof
+	}
+	for _, proof := range snapshot.PendingProofs {
+		db.pendingProofs[proof.Y] = proof
+	}
+	for _, keyset := range snapshot.Keysets {
+		db.saveKeyset(keyset)
+	}
+	for _, quote := range snapshot.MintQuotes {
+		db.mintQuotes[quote.QuoteId] = quote
+	}
+	for _, quote := range snapshot.MeltQuotes {
+		db.meltQuotes[quote.QuoteId] = quote
+	}
+	for _, entry := range snapshot.History {
+		db.history[entry.Id] = entry
+	}
+	for _, sentToken := range snapshot.SentTokens {
+		db.sentTokens[sentToken.Id] = sentToken
+	}
+	for _, operation := range snapshot.Operations {
+		db.operations[operation.Id] = operation
+	}
+	return db, nil
+}
+
+// Snapshot serializes the contents of the db
+func (db *MemoryDB) Snapshot() ([]byte, error) {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	snapshot := memorySnapshot{
+		Mnemonic:      db.mnemonic,
+		Seed:          db.seed,
+		SeedScheme:    db.seedScheme,
+		Proofs:        db.getProofs(),
+		PendingProofs: db.getPendingProofs(),
+		Keysets:       []crypto.WalletKeyset{},
+		MintQuotes:    db.getMintQuotes(),
+		MeltQuotes:    db.getMeltQuotes(),
+		History:       db.getHistory(),
+		SentTokens:    db.getSentTokens(),
+		Operations:    db.getPendingOperations(),
+	}
+	for _, mintKeysets := range db.keysets {
+		for _, keyset := range mintKeysets {
+			snapshot.Keysets = append(snapshot.Keysets, keyset)
+		}
+	}
+	return json.Marshal(snapshot)
+}
+
+func (db *MemoryDB) Close() error {
+	return nil
+}
+
+func (db *MemoryDB) SaveMnemonicSeed(mnemonic string, seed []byte) {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.mnemonic = mnemonic
+	db.seed = append([]byte{}, seed...)
+}
+
+func (db *MemoryDB) GetSeed() []byte {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	if len(db.seed) == 0 {
+		return nil
+	}
+	return append([]byte{}, db.seed...)
+}
+
+func (db *MemoryDB) GetMnemonic() string {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.mnemonic
+}
+
+func (db *MemoryDB) SaveSeedScheme(scheme SeedScheme) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.seedScheme = scheme
+	return nil
+}
+
+func (db *MemoryDB) GetSeedScheme() SeedScheme {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.seedScheme
+}
+
+func (db *MemoryDB) SaveProofs(proofs cashu.Proofs) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, proof := range proofs {
+		db.proofs[proof.Secret] = proof
+	}
+	return nil
+}
+
+func (db *MemoryDB) GetProofs() cashu.Proofs {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getProofs()
+}
+
+func (db *MemoryDB) getProofs() cashu.Proofs {
+	proofs := make(cashu.Proofs, 0, len(db.proofs))
+	for _, proof := range db.proofs {
+		proofs = append(proofs, proof)
+	}
+	return proofs
+}
+
+func (db *MemoryDB) GetProofsByKeysetId(id string) cashu.Proofs {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	proofs := cashu.Proofs{}
+	for _, proof := range db.proofs {
+		if proof.Id == id {
+			proofs = append(proofs, proof)
+		}
+	}
+	return proofs
+}
+
+func (db *MemoryDB) DeleteProof(secret string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	if _, ok := db.proofs[secret]; !ok {
+		return ProofNotFound
+	}
+	delete(db.proofs, secret)
+	return nil
+}
+
+func (db *MemoryDB) AddPendingProofs(proofs cashu.Proofs) error {
+	return db.AddPendingProofsByQuoteId(proofs, "")
+}
+
+func (db *MemoryDB) AddPendingProofsByQuoteId(proofs cashu.Proofs, quoteId string) error {
+	dbProofs := make([]DBProof, len(proofs))
+	for i, proof := range proofs {
+		Y, err := crypto.HashToCurve([]byte(proof.Secret))
+		if err != nil {
+			return err
+		}
+		dbProofs[i] = DBProof{
+			Y:           hex.EncodeToString(Y.SerializeCompressed()),
+			Amount:      proof.Amount,
+			Id:          proof.Id,
+			Secret:      proof.Secret,
+			C:           proof.C,
+			DLEQ:        proof.DLEQ,
+			MeltQuoteId: quoteId,
+		}
+	}
+
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, dbProof := range dbProofs {
+		db.pendingProofs[dbProof.Y] = dbProof
+	}
+	return nil
+}
+
+func (db *MemoryDB) GetPendingProofs() []DBProof {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getPendingProofs()
+}
+
+func (db *MemoryDB) getPendingProofs() []DBProof {
+	proofs := make([]DBProof, 0, len(db.pendingProofs))
+	for _, proof := range db.pendingProofs {
+		proofs = append(proofs, proof)
+	}
+	return proofs
+}
+
+func (db *MemoryDB) GetPendingProofsByQuoteId(quoteId string) []DBProof {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	proofs := []DBProof{}
+	for _, proof := range db.pendingProofs {
+		if proof.MeltQuoteId == quoteId {
+			proofs = append(proofs, proof)
+		}
+	}
+	return proofs
+}
+
+func (db *MemoryDB) DeletePendingProofs(Ys []string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for _, y := range Ys {
+		delete(db.pendingProofs, y)
+	}
+	return nil
+}
+
+func (db *MemoryDB) DeletePendingProofsByQuoteId(quoteId string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	for y, proof := range db.pendingProofs {
+		if proof.MeltQuoteId == quoteId {
+			delete(db.pendingProofs, y)
+		}
+	}
+	return nil
+}
+
+func (db *MemoryDB) SaveKeyset(keyset *crypto.WalletKeyset) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.saveKeyset(*keyset)
+	return nil
+}
+
+func (db *MemoryDB) saveKeyset(keyset crypto.WalletKeyset) {
+	mintKeysets, ok := db.keysets[keyset.MintURL]
+	if !ok {
+		mintKeysets = make(map[string]crypto.WalletKeyset)
+		db.keysets[keyset.MintURL] = mintKeysets
+	}
+	mintKeysets[keyset.Id] = keyset
+}
+
+func (db *MemoryDB) GetKeysets() crypto.KeysetsMap {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	keysets := make(crypto.KeysetsMap)
+	for mintURL, mintKeysets := range db.keysets {
+		keysets[mintURL] = make([]crypto.WalletKeyset, 0, len(mintKeysets))
+		for _, keyset := range mintKeysets {
+			keysets[mintURL] = append(keysets[mintURL], keyset)
+		}
+	}
+	return keysets
+}
+
+func (db *MemoryDB) GetKeyset(keysetId string) *crypto.WalletKeyset {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, mintKeysets := range db.keysets {
+		if keyset, ok := mintKeysets[keysetId]; ok {
+			return &keyset
+		}
+	}
+	return nil
+}
+
+func (db *MemoryDB) IncrementKeysetCounter(keysetId string, num uint32) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	for _, mintKeysets := range db.keysets {
+		if keyset, ok := mintKeysets[keysetId]; ok {
+			keyset.Counter += num
+			mintKeysets[keysetId] = keyset
+			return nil
+		}
+	}
+	return errors.New("keyset does not exist")
+}
+
+func (db *MemoryDB) GetKeysetCounter(keysetId string) uint32 {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+
+	for _, mintKeysets := range db.keysets {
+		if keyset, ok := mintKeysets[keysetId]; ok {
+			return keyset.Counter
+		}
+	}
+	return 0
+}
+
+func (db *MemoryDB) UpdateKeysetMintURL(oldURL, newURL string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+
+	mintKeysets, ok := db.keysets[oldURL]
+	if !ok {
+		return KeysetMintURLNotFound
+	}
+	delete(db.keysets, oldURL)
+	for _, keyset := range mintKeysets {
+		keyset.MintURL = newURL
+		db.saveKeyset(keyset)
+	}
+	return nil
+}
+
+func (db *MemoryDB) SaveMintQuote(quote MintQuote) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.mintQuotes[quote.QuoteId] = quote
+	return nil
+}
+
+func (db *MemoryDB) GetMintQuotes() []MintQuote {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getMintQuotes()
+}
+
+func (db *MemoryDB) getMintQuotes() []MintQuote {
+	quotes := make([]MintQuote, 0, len(db.mintQuotes))
+	for _, quote := range db.mintQuotes {
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
+
+func (db *MemoryDB) GetMintQuoteById(id string) *MintQuote {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	quote, ok := db.mintQuotes[id]
+	if !ok {
+		return nil
+	}
+	return &quote
+}
+
+func (db *MemoryDB) SaveMeltQuote(quote MeltQuote) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.meltQuotes[quote.QuoteId] = quote
+	return nil
+}
+
+func (db *MemoryDB) GetMeltQuotes() []MeltQuote {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getMeltQuotes()
+}
+
+func (db *MemoryDB) getMeltQuotes() []MeltQuote {
+	quotes := make([]MeltQuote, 0, len(db.meltQuotes))
+	for _, quote := range db.meltQuotes {
+		quotes = append(quotes, quote)
+	}
+	return quotes
+}
+
+func (db *MemoryDB) GetMeltQuoteById(id string) *MeltQuote {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	quote, ok := db.meltQuotes[id]
+	if !ok {
+		return nil
+	}
+	return &quote
+}
+
+func (db *MemoryDB) SaveHistoryEntry(entry HistoryEntry) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	db.history[entry.Id] = entry
+	return nil
+}
+
+func (db *MemoryDB) GetHistory(filter HistoryFilter) []HistoryEntry {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return filterHistory(db.getHistory(), filter)
+}
+
+func (db *MemoryDB) getHistory() []HistoryEntry {
+	entries := make([]HistoryEntry, 0, len(db.history))
+	for _, entry := range db.history {
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func (db *MemoryDB) SaveSentToken(sentToken SentToken) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	sentToken.Ys = slices.Clone(sentToken.Ys)
+	db.sentTokens[sentToken.Id] = sentToken
+	return nil
+}
+
+func (db *MemoryDB) GetSentTokens() []SentToken {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getSentTokens()
+}
+
+func (db *MemoryDB) getSentTokens() []SentToken {
+	sentTokens := make([]SentToken, 0, len(db.sentTokens))
+	for _, sentToken := range db.sentTokens {
+		sentTokens = append(sentTokens, sentToken)
+	}
+	return sentTokens
+}
+
+func (db *MemoryDB) GetSentTokenById(id string) *SentToken {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	sentToken, ok := db.sentTokens[id]
+	if !ok {
+		return nil
+	}
+	return &sentToken
+}
+
+func (db *MemoryDB) SavePendingOperation(operation PendingOperation) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	operation.Inputs = slices.Clone(operation.Inputs)
+	operation.Outputs = slices.Clone(operation.Outputs)
+	operation.Secrets = slices.Clone(operation.Secrets)
+	operation.Rs = slices.Clone(operation.Rs)
+	db.operations[operation.Id] = operation
+	return nil
+}
+
+func (db *MemoryDB) GetPendingOperations() []PendingOperation {
+	db.mu.RLock()
+	defer db.mu.RUnlock()
+	return db.getPendingOperations()
+}
+
+func (db *MemoryDB) getPendingOperations() []PendingOperation {
+	operations := make([]PendingOperation, 0, len(db.operations))
+	for _, operation := range db.operations {
+		operations = append(operations, operation)
+	}
+	return operations
+}
+
+func (db *MemoryDB) DeletePendingOperation(id string) error {
+	db.mu.Lock()
+	defer db.mu.Unlock()
+	delete(db.operations, id)
+	return nil
+}
@@ -0,0 +1,135 @@
+package storage
+
+import (
+	"reflect"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+)
+
+func TestMemoryDB(t *testing.T) {
+	memoryDB := NewMemoryDB()
+	memoryDB.SaveMnemonicSeed("some mnemonic", []byte("seed"))
+	if err := memoryDB.SaveSeedScheme(SeedSchemeBIP39Passphrase); err != nil {
+		t.Fatalf("error saving seed scheme: %v", err)
+	}
+
+	// proofs can be saved concurrently
+	var wg sync.WaitGroup
+	proofs := cashu.Proofs{}
+	for i := 0; i < 10; i++ {
+		keysetProofs := generateRandomProofs("keysetId12345", 10)
+		proofs = append(proofs, keysetProofs...)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := memoryDB.SaveProofs(keysetProofs); err != nil {
+				t.Errorf("error saving proofs: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	proofsFromDb := memoryDB.GetProofsByKeysetId("keysetId12345")
+	sortProofs(proofs)
+	sortProofs(proofsFromDb)
+	if !slices.Equal(proofs, proofsFromDb) {
+		t.Fatal("proofs from db do not match saved ones")
+	}
+	if err := memoryDB.DeleteProof(proofs[0].Secret); err != nil {
+		t.Fatalf("error deleting proof: %v", err)
+	}
+	if err := memoryDB.DeleteProof(proofs[0].Secret); err != ProofNotFound {
+		t.Fatalf("expected error '%v' but got '%v'", ProofNotFound, err)
+	}
+
+	pendingProofs := generateRandomProofs("keysetId12345", 5)
+	if err := memoryDB.AddPendingProofsByQuoteId(pendingProofs, "quoteId"); err != nil {
+		t.Fatalf("error adding pending proofs: %v", err)
+	}
+	if len(memoryDB.GetPendingProofsByQuoteId("quoteId")) != 5 {
+		t.Fatalf("expected '%v' pending proofs but got '%v'", 5, len(memoryDB.GetPendingProofsByQuoteId("quoteId")))
+	}
+
+	keyset := generateKeyset("http://localhost:3338")
+	if err := memoryDB.SaveKeyset(&keyset); err != nil {
+		t.Fatalf("error saving keyset: %v", err)
+	}
+	if err := memoryDB.IncrementKeysetCounter(keyset.Id, 10); err != nil {
+		t.Fatalf("error incrementing keyset counter: %v", err)
+	}
+	if memoryDB.GetKeysetCounter(keyset.Id) != 10 {
+		t.Fatalf("expected counter '%v' but got '%v'", 10, memoryDB.GetKeysetCounter(keyset.Id))
+	}
+	if err := memoryDB.UpdateKeysetMintURL("http://localhost:3338", "http://localhost:8080"); err != nil {
+		t.Fatalf("error updating keyset mint url: %v", err)
+	}
+	if memoryDB.GetKeyset(keyset.Id).MintURL != "http://localhost:8080" {
+		t.Fatalf("expected keyset mint url to be updated")
+	}
+
+	if err := memoryDB.SaveMintQuote(generateMintQuote("mintQuoteId", true)); err != nil {
+		t.Fatalf("error saving mint quote: %v", err)
+	}
+	if err := memoryDB.SaveMeltQuote(MeltQuote{QuoteId: "meltQuoteId", Amount: 21}); err != nil {
+		t.Fatalf("error saving melt quote: %v", err)
+	}
+
+	sentToken := SentToken{Id: "sentTokenId", Amount: 21, Token: "cashuBtoken", Ys: []string{"Y"}, CreatedAt: 1000}
+	if err := memoryDB.SaveSentToken(sentToken); err != nil {
+		t.Fatalf("error saving sent token: %v", err)
+	}
+	operation := PendingOperation{Id: "operationId", Type: MintOperation, QuoteId: "mintQuoteId", Secrets: []string{"secret"}}
+	if err := memoryDB.SavePendingOperation(operation); err != nil {
+		t.Fatalf("error saving pending operation: %v", err)
+	}
+
+	snapshot, err := memoryDB.Snapshot()
+	if err != nil {
+		t.Fatalf("error taking snapshot: %v", err)
+	}
+	restoredDB, err := NewMemoryDBFromSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("error restoring snapshot: %v", err)
+	}
+
+	if restoredDB.GetMnemonic() != "some mnemonic" || string(restoredDB.GetSeed()) != "seed" ||
+		restoredDB.GetSeedScheme() != SeedSchemeBIP39Passphrase {
+		t.Fatal("seed from snapshot does not match saved one")
+	}
+	restoredProofs := restoredDB.GetProofs()
+	sortProofs(restoredProofs)
+	if !slices.Equal(proofs[1:], restoredProofs) {
+		t.Fatal("proofs from snapshot do not match saved ones")
+	}
+	restoredPendingProofs := restoredDB.GetPendingProofsByQuoteId("quoteId")
+	if len(restoredPendingProofs) != 5 {
+		t.Fatalf("expected '%v' pending proofs from snapshot but got '%v'", 5, len(restoredPendingProofs))
+	}
+	for _, proof := range memoryDB.GetPendingProofs() {
+		if !slices.Contains(restoredPendingProofs, proof) {
+			t.Fatal("pending proofs from snapshot do not match saved ones")
+		}
+	}
+	if !reflect.DeepEqual(memoryDB.GetKeyset(keyset.Id), restoredDB.GetKeyset(keyset.Id)) {
+		t.Fatal("keyset from snapshot does not match saved one")
+	}
+	if !reflect.DeepEqual(memoryDB.GetMintQuoteById("mintQuoteId"), restoredDB.GetMintQuoteById("mintQuoteId")) {
+		t.Fatal("mint quote from snapshot does not match saved one")
+	}
+	if !reflect.DeepEqual(memoryDB.GetMeltQuoteById("meltQuoteId"), restoredDB.GetMeltQuoteById("meltQuoteId")) {
+		t.Fatal("melt quote from snapshot does not match saved one")
+	}
+	if !reflect.DeepEqual(restoredDB.GetSentTokens(), []SentToken{sentToken}) {
+		t.Fatal("sent tokens from snapshot do not match saved ones")
+	}
+	if !reflect.DeepEqual(restoredDB.GetPendingOperations(), []PendingOperation{operation}) {
+		t.Fatal("pending operations from snapshot do not match saved ones")
+	}
+
+	if _, err := NewMemoryDBFromSnapshot([]byte("invalid")); err == nil {
+		t.Fatal("expected error restoring invalid snapshot")
+	}
+}
@@ -0,0 +1,64 @@
+package sqlite
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+)
+
+const (
+	boltFilename         = "wallet.db"
+	migratedBoltFilename = "wallet.db.migrated"
+)
+
+// MigrateFromBolt copies the wallet in the bolt db in the path to a new
+// sqlite db in the same path. The sqlite db is written to a temporary file that
+// is only moved into place once everything was copied. After that, the bolt file
+// is renamed to wallet.db.migrated so the migration runs only once.
+func MigrateFromBolt(path string) error {
+	boltPath := filepath.Join(path, boltFilename)
+	if _, err := os.Stat(boltPath); err != nil {
+		return fmt.Errorf("could not find bolt db to migrate: %v", err)
+	}
+	sqlitePath := filepath.Join(path, DB_FILENAME)
+	if _, err := os.Stat(sqlitePath); err == nil {
+		return errors.New("sqlite db already exists")
+	}
+
+	boltdb, err := storage.InitBolt(path)
+	if err != nil {
+		return err
+	}
+	defer boltdb.Close()
+
+	tempPath := sqlitePath + ".migrating"
+	// remove what is left from a previous migration that did not finish
+	if err := os.Remove(tempPath); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return err
+	}
+	sqlitedb, err := openSQLite(tempPath)
+	if err != nil {
+		return fmt.Errorf("error creating sqlite db: %v", err)
+	}
+
+	if err := storage.CopyWallet(sqlitedb, boltdb); err != nil {
+		sqlitedb.Close()
+		os.Remove(tempPath)
+		return fmt.Errorf("error copying wallet to sqlite db: %v", err)
+	}
+	if err := sqlitedb.Close(); err != nil {
+		os.Remove(tempPath)
+		return err
+	}
+
+	if err := os.Rename(tempPath, sqlitePath); err != nil {
+		return err
+	}
+	if err := boltdb.Close(); err != nil {
+		return err
+	}
+	return os.Rename(boltPath, filepath.Join(path, migratedBoltFilename))
+}
@@ -0,0 +1,9 @@
+DROP TABLE IF EXISTS melt_quotes;
+DROP TABLE IF EXISTS mint_quotes;
+DROP INDEX IF EXISTS idx_pending_proofs_melt_quote_id;
+DROP TABLE IF EXISTS pending_proofs;
+DROP INDEX IF EXISTS idx_proofs_keyset_id;
+DROP TABLE IF EXISTS proofs;
+DROP INDEX IF EXISTS idx_keysets_mint_url;
+DROP TABLE IF EXISTS keysets;
+DROP TABLE IF EXISTS seed;
@@ -0,0 +1,69 @@
+CREATE TABLE IF NOT EXISTS seed (
+	id TEXT NOT NULL PRIMARY KEY,
+	seed TEXT NOT NULL,
+	mnemonic TEXT NOT NULL
+);
+
+CREATE TABLE IF NOT EXISTS keysets (
+	id TEXT NOT NULL PRIMARY KEY,
+	mint_url TEXT NOT NULL,
+	unit TEXT NOT NULL,
+	active BOOLEAN NOT NULL,
+	public_keys TEXT NOT NULL,
+	counter INTEGER NOT NULL DEFAULT 0,
+	input_fee_ppk INTEGER NOT NULL DEFAULT 0
+);
+
+CREATE INDEX IF NOT EXISTS idx_keysets_mint_url ON keysets(mint_url);
+
+CREATE TABLE IF NOT EXISTS proofs (
+	secret TEXT NOT NULL PRIMARY KEY,
+	amount INTEGER NOT NULL,
+	keyset_id TEXT NOT NULL,
+	c TEXT NOT NULL,
+	witness TEXT,
+	dleq TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_proofs_keyset_id ON proofs(keyset_id);
+
+CREATE TABLE IF NOT EXISTS pending_proofs (
+	y TEXT NOT NULL PRIMARY KEY,
+	amount INTEGER NOT NULL,
+	keyset_id TEXT NOT NULL,
+	secret TEXT NOT NULL,
+	c TEXT NOT NULL,
+	dleq TEXT,
+	melt_quote_id TEXT
+);
+
+CREATE INDEX IF NOT EXISTS idx_pending_proofs_melt_quote_id ON pending_proofs(melt_quote_id);
+
+CREATE TABLE IF NOT EXISTS mint_quotes (
+	id TEXT NOT NULL PRIMARY KEY,
+	mint TEXT NOT NULL,
+	method TEXT NOT NULL,
+	state TEXT NOT NULL,
+	unit TEXT NOT NULL,
+	payment_request TEXT NOT NULL,
+	amount INTEGER NOT NULL,
+	created_at INTEGER NOT NULL,
+	settled_at INTEGER NOT NULL,
+	expiry INTEGER NOT NULL,
+	private_key TEXT
+);
+
+CREATE TABLE IF NOT EXISTS melt_quotes (
+	id TEXT NOT NULL PRIMARY KEY,
+	mint TEXT NOT NULL,
+	method TEXT NOT NULL,
+	state TEXT NOT NULL,
+	unit TEXT NOT NULL,
+	payment_request TEXT NOT NULL,
+	amount INTEGER NOT NULL,
+	fee_reserve INTEGER NOT NULL,
+	preimage TEXT,
+	created_at INTEGER NOT NULL,
+	settled_at INTEGER NOT NULL,
+	expiry INTEGER NOT NULL
+);
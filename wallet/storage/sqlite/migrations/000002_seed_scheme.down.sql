@@ -0,0 +1 @@
+ALTER TABLE seed DROP COLUMN scheme;
@@ -0,0 +1 @@
+ALTER TABLE seed ADD COLUMN scheme INTEGER NOT NULL DEFAULT 0;
@@ -0,0 +1,15 @@
+CREATE TABLE IF NOT EXISTS history (
+	id TEXT NOT NULL PRIMARY KEY,
+	type TEXT NOT NULL,
+	mint TEXT NOT NULL,
+	to_mint TEXT NOT NULL DEFAULT '',
+	unit TEXT NOT NULL,
+	amount INTEGER NOT NULL,
+	fee INTEGER NOT NULL DEFAULT 0,
+	token TEXT NOT NULL DEFAULT '',
+	quote_id TEXT NOT NULL DEFAULT '',
+	payment_request TEXT NOT NULL DEFAULT '',
+	created_at INTEGER NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS idx_history_created_at ON history(created_at);
@@ -0,0 +1,12 @@
+CREATE TABLE IF NOT EXISTS sent_tokens (
+	id TEXT NOT NULL PRIMARY KEY,
+	mint TEXT NOT NULL,
+	amount INTEGER NOT NULL,
+	token TEXT NOT NULL,
+	memo TEXT NOT NULL DEFAULT '',
+	state TEXT NOT NULL,
+	ys TEXT NOT NULL,
+	created_at INTEGER NOT NULL,
+	expiry INTEGER NOT NULL DEFAULT 0,
+	settled_at INTEGER NOT NULL DEFAULT 0
+);
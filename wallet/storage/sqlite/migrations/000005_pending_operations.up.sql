@@ -0,0 +1,13 @@
+CREATE TABLE IF NOT EXISTS pending_operations (
+	id TEXT NOT NULL PRIMARY KEY,
+	type TEXT NOT NULL,
+	mint TEXT NOT NULL,
+	quote_id TEXT NOT NULL DEFAULT '',
+	inputs TEXT NOT NULL,
+	outputs TEXT NOT NULL,
+	secrets TEXT NOT NULL,
+	rs TEXT NOT NULL,
+	keyset_id TEXT NOT NULL,
+	counter INTEGER NOT NULL DEFAULT 0,
+	created_at INTEGER NOT NULL
+);
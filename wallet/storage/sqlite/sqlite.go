@@ -0,0 +1,946 @@
+package sqlite
+
+import (
+	"database/sql"
+	"embed"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/golang-migrate/migrate/v4"
+	_ "github.com/golang-migrate/migrate/v4/database/sqlite3"
+	_ "github.com/golang-migrate/migrate/v4/source/file"
+	_ "github.com/mattn/go-sqlite3"
+)
+
+const DB_FILENAME = "wallet.sqlite.db"
+
+//go:embed migrations
+var migrations embed.FS
+
+type SQLiteDB struct {
+	db *sql.DB
+}
+
+// create a temporary directory with the migration files.
+// migration files are embedded with go:embed. These are then read
+// and copied to a temporary directory.
+// This is needed to pass the directory to migrate.New
+func migrationsDir() (string, error) {
+	tempDir, err := os.MkdirTemp("", "migrations")
+	if err != nil {
+		return "", err
+	}
+
+	migrationFiles, err := migrations.ReadDir("migrations")
+	if err != nil {
+		return "", err
+	}
+
+	for _, file := range migrationFiles {
+		filePath := filepath.Join(tempDir, file.Name())
+
+		migrationFilePath := filepath.Join("migrations", file.Name())
+		migrationFile, err := migrations.Open(migrationFilePath)
+		if err != nil {
+			return "", err
+		}
+		defer migrationFile.Close()
+
+		destFile, err := os.Create(filePath)
+		if err != nil {
+			return "", err
+		}
+		defer destFile.Close()
+
+		_, err = io.Copy(destFile, migrationFile)
+		if err != nil {
+			return "", err
+		}
+	}
+
+	return tempDir, nil
+}
+
+func InitSQLite(path string) (*SQLiteDB, error) {
+	return openSQLite(filepath.Join(path, DB_FILENAME))
+}
+
+func openSQLite(dbpath string) (*SQLiteDB, error) {
+	// the db can be opened by more than one process at once (i.e the
+	// tollgate daemon and nutw) so wait for the write lock instead of failing
+	db, err := sql.Open("sqlite3", dbpath+"?_busy_timeout=5000&_txlock=immediate")
+	if err != nil {
+		return nil, err
+	}
+	db.SetMaxOpenConns(1)
+
+	tempMigrationsDir, err := migrationsDir()
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	defer os.RemoveAll(tempMigrationsDir)
+
+	m, err := migrate.New(fmt.Sprintf("file://%s", tempMigrationsDir), fmt.Sprintf("sqlite3://%s", dbpath))
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	defer m.Close()
+
+	if err := m.Up(); err != nil && err != migrate.ErrNoChange {
+		db.Close()
+		return nil, err
+	}
+
+	if err := db.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return &SQLiteDB{db: db}, nil
+}
+
+func (sqlite *SQLiteDB) Close() error {
+	return sqlite.db.Close()
+}
+
+func (sqlite *SQLiteDB) SaveMnemonicSeed(mnemonic string, seed []byte) {
+	sqlite.db.Exec(`
+	INSERT INTO seed (id, seed, mnemonic) VALUES (?, ?, ?)
+	ON CONFLICT(id) DO UPDATE SET seed = excluded.seed, mnemonic = excluded.mnemonic
+	`, "id", hex.EncodeToString(seed), mnemonic)
+}
+
+func (sqlite *SQLiteDB) GetSeed() []byte {
+	var hexSeed string
+	if err := sqlite.db.QueryRow("SELECT seed FROM seed WHERE id = ?", "id").Scan(&hexSeed); err != nil {
+		return nil
+	}
+
+	seed, err := hex.DecodeString(hexSeed)
+	if err != nil {
+		return nil
+	}
+	return seed
+}
+
+func (sqlite *SQLiteDB) GetMnemonic() string {
+	var mnemonic string
+	sqlite.db.QueryRow("SELECT mnemonic FROM seed WHERE id = ?", "id").Scan(&mnemonic)
+	return mnemonic
+}
+
+func (sqlite *SQLiteDB) SaveSeedScheme(scheme storage.SeedScheme) error {
+	result, err := sqlite.db.Exec("UPDATE seed SET scheme = ? WHERE id = ?", scheme, "id")
+	if err != nil {
+		return err
+	}
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count != 1 {
+		return errors.New("seed not found")
+	}
+	return nil
+}
+
+func (sqlite *SQLiteDB) GetSeedScheme() storage.SeedScheme {
+	scheme := storage.SeedSchemeBIP39
+	sqlite.db.QueryRow("SELECT scheme FROM seed WHERE id = ?", "id").Scan(&scheme)
+	return scheme
+}
+
+func (sqlite *SQLiteDB) SaveProofs(proofs cashu.Proofs) error {
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+	INSERT OR REPLACE INTO proofs (secret, amount, keyset_id, c, witness, dleq) VALUES (?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, proof := range proofs {
+		dleq, err := marshalDLEQ(proof.DLEQ)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("invalid proof: %v", err)
+		}
+		witness := sql.NullString{String: proof.Witness, Valid: len(proof.Witness) > 0}
+		if _, err := stmt.Exec(proof.Secret, proof.Amount, proof.Id, proof.C, witness, dleq); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (sqlite *SQLiteDB) GetProofs() cashu.Proofs {
+	return sqlite.getProofs("SELECT amount, keyset_id, secret, c, witness, dleq FROM proofs")
+}
+
+func (sqlite *SQLiteDB) GetProofsByKeysetId(id string) cashu.Proofs {
+	return sqlite.getProofs("SELECT amount, keyset_id, secret, c, witness, dleq FROM proofs WHERE keyset_id = ?", id)
+}
+
+func (sqlite *SQLiteDB) getProofs(query string, args ...any) cashu.Proofs {
+	proofs := cashu.Proofs{}
+
+	rows, err := sqlite.db.Query(query, args...)
+	if err != nil {
+		return proofs
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var proof cashu.Proof
+		var witness sql.NullString
+		var dleq sql.NullString
+
+		if err := rows.Scan(&proof.Amount, &proof.Id, &proof.Secret, &proof.C, &witness, &dleq); err != nil {
+			continue
+		}
+		proof.Witness = witness.String
+		if proof.DLEQ, err = unmarshalDLEQ(dleq); err != nil {
+			continue
+		}
+		proofs = append(proofs, proof)
+	}
+
+	return proofs
+}
+
+func (sqlite *SQLiteDB) DeleteProof(secret string) error {
+	result, err := sqlite.db.Exec("DELETE FROM proofs WHERE secret = ?", secret)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return storage.ProofNotFound
+	}
+	return nil
+}
+
+func (sqlite *SQLiteDB) AddPendingProofs(proofs cashu.Proofs) error {
+	return sqlite.addPendingProofs(proofs, sql.NullString{})
+}
+
+func (sqlite *SQLiteDB) AddPendingProofsByQuoteId(proofs cashu.Proofs, quoteId string) error {
+	return sqlite.addPendingProofs(proofs, sql.NullString{String: quoteId, Valid: true})
+}
+
+func (sqlite *SQLiteDB) addPendingProofs(proofs cashu.Proofs, quoteId sql.NullString) error {
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare(`
+	INSERT OR REPLACE INTO pending_proofs (y, amount, keyset_id, secret, c, dleq, melt_quote_id) VALUES (?, ?, ?, ?, ?, ?, ?)
+	`)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, proof := range proofs {
+		Y, err := crypto.HashToCurve([]byte(proof.Secret))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		Yhex := hex.EncodeToString(Y.SerializeCompressed())
+
+		dleq, err := marshalDLEQ(proof.DLEQ)
+		if err != nil {
+			tx.Rollback()
+			return fmt.Errorf("invalid proof: %v", err)
+		}
+		if _, err := stmt.Exec(Yhex, proof.Amount, proof.Id, proof.Secret, proof.C, dleq, quoteId); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (sqlite *SQLiteDB) GetPendingProofs() []storage.DBProof {
+	return sqlite.getPendingProofs("SELECT y, amount, keyset_id, secret, c, dleq, melt_quote_id FROM pending_proofs")
+}
+
+func (sqlite *SQLiteDB) GetPendingProofsByQuoteId(quoteId string) []storage.DBProof {
+	return sqlite.getPendingProofs(`
+	SELECT y, amount, keyset_id, secret, c, dleq, melt_quote_id FROM pending_proofs WHERE melt_quote_id = ?
+	`, quoteId)
+}
+
+func (sqlite *SQLiteDB) getPendingProofs(query string, args ...any) []storage.DBProof {
+	proofs := []storage.DBProof{}
+
+	rows, err := sqlite.db.Query(query, args...)
+	if err != nil {
+		return proofs
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var proof storage.DBProof
+		var dleq sql.NullString
+		var quoteId sql.NullString
+
+		if err := rows.Scan(&proof.Y, &proof.Amount, &proof.Id, &proof.Secret, &proof.C, &dleq, &quoteId); err != nil {
+			continue
+		}
+		if proof.DLEQ, err = unmarshalDLEQ(dleq); err != nil {
+			continue
+		}
+		proof.MeltQuoteId = quoteId.String
+		proofs = append(proofs, proof)
+	}
+
+	return proofs
+}
+
+func (sqlite *SQLiteDB) DeletePendingProofs(Ys []string) error {
+	tx, err := sqlite.db.Begin()
+	if err != nil {
+		return err
+	}
+
+	stmt, err := tx.Prepare("DELETE FROM pending_proofs WHERE y = ?")
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	defer stmt.Close()
+
+	for _, y := range Ys {
+		if _, err := stmt.Exec(y); err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (sqlite *SQLiteDB) DeletePendingProofsByQuoteId(quoteId string) error {
+	_, err := sqlite.db.Exec("DELETE FROM pending_proofs WHERE melt_quote_id = ?", quoteId)
+	return err
+}
+
+func (sqlite *SQLiteDB) SaveKeyset(keyset *crypto.WalletKeyset) error {
+	publicKeys, err := json.Marshal(crypto.PublicKeys(keyset.PublicKeys))
+	if err != nil {
+		return fmt.Errorf("invalid keyset format: %v", err)
+	}
+
+	_, err = sqlite.db.Exec(`
+	INSERT OR REPLACE INTO keysets (id, mint_url, unit, active, public_keys, counter, input_fee_ppk)
+	VALUES (?, ?, ?, ?, ?, ?, ?)
+	`, keyset.Id, keyset.MintURL, keyset.Unit, keyset.Active, string(publicKeys), keyset.Counter, keyset.InputFeePpk)
+	if err != nil {
+		return fmt.Errorf("error saving keyset: %v", err)
+	}
+	return nil
+}
+
+func (sqlite *SQLiteDB) GetKeysets() crypto.KeysetsMap {
+	keysets := make(crypto.KeysetsMap)
+
+	rows, err := sqlite.db.Query(`
+	SELECT id, mint_url, unit, active, public_keys, counter, input_fee_ppk FROM keysets
+	`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		keyset, err := scanKeyset(rows)
+		if err != nil {
+			return nil
+		}
+		keysets[keyset.MintURL] = append(keysets[keyset.MintURL], *keyset)
+	}
+
+	return keysets
+}
+
+func (sqlite *SQLiteDB) GetKeyset(keysetId string) *crypto.WalletKeyset {
+	row := sqlite.db.QueryRow(`
+	SELECT id, mint_url, unit, active, public_keys, counter, input_fee_ppk FROM keysets WHERE id = ?
+	`, keysetId)
+
+	keyset, err := scanKeyset(row)
+	if err != nil {
+		return nil
+	}
+	return keyset
+}
+
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanKeyset(row scanner) (*crypto.WalletKeyset, error) {
+	var keyset crypto.WalletKeyset
+	var publicKeys string
+
+	if err := row.Scan(
+		&keyset.Id,
+		&keyset.MintURL,
+		&keyset.Unit,
+		&keyset.Active,
+		&publicKeys,
+		&keyset.Counter,
+		&keyset.InputFeePpk,
+	); err != nil {
+		return nil, err
+	}
+
+	keys := make(crypto.PublicKeys)
+	if err := json.Unmarshal([]byte(publicKeys), &keys); err != nil {
+		return nil, err
+	}
+	keyset.PublicKeys = keys
+
+	return &keyset, nil
+}
+
+func (sqlite *SQLiteDB) IncrementKeysetCounter(keysetId string, num uint32) error {
+	result, err := sqlite.db.Exec("UPDATE keysets SET counter = counter + ? WHERE id = ?", num, keysetId)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return errors.New("keyset does not exist")
+	}
+	return nil
+}
+
+func (sqlite *SQLiteDB) GetKeysetCounter(keysetId string) uint32 {
+	var counter uint32
+	sqlite.db.QueryRow("SELECT counter FROM keysets WHERE id = ?", keysetId).Scan(&counter)
+	return counter
+}
+
+func (sqlite *SQLiteDB) UpdateKeysetMintURL(oldURL, newURL string) error {
+	result, err := sqlite.db.Exec("UPDATE keysets SET mint_url = ? WHERE mint_url = ?", newURL, oldURL)
+	if err != nil {
+		return err
+	}
+
+	count, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if count == 0 {
+		return storage.KeysetMintURLNotFound
+	}
+	return nil
+}
+
+func (sqlite *SQLiteDB) SaveMintQuote(quote storage.MintQuote) error {
+	var privateKey sql.NullString
+	if quote.PrivateKey != nil {
+		privateKey = sql.NullString{String: hex.EncodeToString(quote.PrivateKey.Serialize()), Valid: true}
+	}
+
+	_, err := sqlite.db.Exec(`
+	INSERT OR REPLACE INTO mint_quotes
+	(id, mint, method, state, unit, payment_request, amount, created_at, settled_at, expiry, private_key)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		quote.QuoteId,
+		quote.Mint,
+		quote.Method,
+		quote.State.String(),
+		quote.Unit,
+		quote.PaymentRequest,
+		quote.Amount,
+		quote.CreatedAt,
+		quote.SettledAt,
+		quote.QuoteExpiry,
+		privateKey,
+	)
+	return err
+}
+
+func (sqlite *SQLiteDB) GetMintQuotes() []storage.MintQuote {
+	var mintQuotes []storage.MintQuote
+
+	rows, err := sqlite.db.Query(`
+	SELECT id, mint, method, state, unit, payment_request, amount, created_at, settled_at, expiry, private_key
+	FROM mint_quotes
+	`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		quote, err := scanMintQuote(rows)
+		if err != nil {
+			continue
+		}
+		mintQuotes = append(mintQuotes, *quote)
+	}
+
+	return mintQuotes
+}
+
+func (sqlite *SQLiteDB) GetMintQuoteById(id string) *storage.MintQuote {
+	row := sqlite.db.QueryRow(`
+	SELECT id, mint, method, state, unit, payment_request, amount, created_at, settled_at, expiry, private_key
+	FROM mint_quotes WHERE id = ?
+	`, id)
+
+	quote, err := scanMintQuote(row)
+	if err != nil {
+		return nil
+	}
+	return quote
+}
+
+func scanMintQuote(row scanner) (*storage.MintQuote, error) {
+	var quote storage.MintQuote
+	var state string
+	var privateKey sql.NullString
+
+	if err := row.Scan(
+		&quote.QuoteId,
+		&quote.Mint,
+		&quote.Method,
+		&state,
+		&quote.Unit,
+		&quote.PaymentRequest,
+		&quote.Amount,
+		&quote.CreatedAt,
+		&quote.SettledAt,
+		&quote.QuoteExpiry,
+		&privateKey,
+	); err != nil {
+		return nil, err
+	}
+	quote.State = nut04.StringToState(state)
+
+	if privateKey.Valid && len(privateKey.String) > 0 {
+		key, err := hex.DecodeString(privateKey.String)
+		if err != nil {
+			return nil, err
+		}
+		quote.PrivateKey = secp256k1.PrivKeyFromBytes(key)
+	}
+
+	return &quote, nil
+}
+
+func (sqlite *SQLiteDB) SaveMeltQuote(quote storage.MeltQuote) error {
+	_, err := sqlite.db.Exec(`
+	INSERT OR REPLACE INTO melt_quotes
+	(id, mint, method, state, unit, payment_request, amount, fee_reserve, preimage, created_at, settled_at, expiry)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		quote.QuoteId,
+		quote.Mint,
+		quote.Method,
+		quote.State.String(),
+		quote.Unit,
+		quote.PaymentRequest,
+		quote.Amount,
+		quote.FeeReserve,
+		quote.Preimage,
+		quote.CreatedAt,
+		quote.SettledAt,
+		quote.QuoteExpiry,
+	)
+	return err
+}
+
+func (sqlite *SQLiteDB) GetMeltQuotes() []storage.MeltQuote {
+	var meltQuotes []storage.MeltQuote
+
+	rows, err := sqlite.db.Query(`
+	SELECT id, mint, method, state, unit, payment_request, amount, fee_reserve, preimage, created_at, settled_at, expiry
+	FROM melt_quotes
+	`)
+	if err != nil {
+		return nil
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		quote, err := scanMeltQuote(rows)
+		if err != nil {
+			continue
+		}
+		meltQuotes = append(meltQuotes, *quote)
+	}
+
+	return meltQuotes
+}
+
+func (sqlite *SQLiteDB) GetMeltQuoteById(id string) *storage.MeltQuote {
+	row := sqlite.db.QueryRow(`
+	SELECT id, mint, method, state, unit, payment_request, amount, fee_reserve, preimage, created_at, settled_at, expiry
+	FROM melt_quotes WHERE id = ?
+	`, id)
+
+	quote, err := scanMeltQuote(row)
+	if err != nil {
+		return nil
+	}
+	return quote
+}
+
+func scanMeltQuote(row scanner) (*storage.MeltQuote, error) {
+	var quote storage.MeltQuote
+	var state string
+	var preimage sql.NullString
+
+	if err := row.Scan(
+		&quote.QuoteId,
+		&quote.Mint,
+		&quote.Method,
+		&state,
+		&quote.Unit,
+		&quote.PaymentRequest,
+		&quote.Amount,
+		&quote.FeeReserve,
+		&preimage,
+		&quote.CreatedAt,
+		&quote.SettledAt,
+		&quote.QuoteExpiry,
+	); err != nil {
+		return nil, err
+	}
+	quote.State = nut05.StringToState(state)
+	quote.Preimage = preimage.String
+
+	return &quote, nil
+}
+
+func (sqlite *SQLiteDB) SaveHistoryEntry(entry storage.HistoryEntry) error {
+	_, err := sqlite.db.Exec(`
+	INSERT OR REPLACE INTO history
+	(id, type, mint, to_mint, unit, amount, fee, token, quote_id, payment_request, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		entry.Id,
+		entry.Type.String(),
+		entry.Mint,
+		entry.ToMint,
+		entry.Unit,
+		entry.Amount,
+		entry.Fee,
+		entry.Token,
+		entry.QuoteId,
+		entry.PaymentRequest,
+		entry.CreatedAt,
+	)
+	return err
+}
+
+func (sqlite *SQLiteDB) GetHistory(filter storage.HistoryFilter) []storage.HistoryEntry {
+	query := `
+	SELECT id, type, mint, to_mint, unit, amount, fee, token, quote_id, payment_request, created_at
+	FROM history WHERE 1 = 1`
+	args := []any{}
+
+	if len(filter.Types) > 0 {
+		query += " AND type IN (?" + strings.Repeat(", ?", len(filter.Types)-1) + ")"
+		for _, historyType := range filter.Types {
+			args = append(args, historyType.String())
+		}
+	}
+	if len(filter.Mint) > 0 {
+		query += " AND (mint = ? OR to_mint = ?)"
+		args = append(args, filter.Mint, filter.Mint)
+	}
+	if filter.Since > 0 {
+		query += " AND created_at >= ?"
+		args = append(args, filter.Since)
+	}
+	if filter.Until > 0 {
+		query += " AND created_at <= ?"
+		args = append(args, filter.Until)
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 || filter.Offset > 0 {
+		// sqlite needs a limit to use an offset
+		limit := -1
+		if filter.Limit > 0 {
+			limit = filter.Limit
+		}
+		query += " LIMIT ? OFFSET ?"
+		args = append(args, limit, max(filter.Offset, 0))
+	}
+
+	entries := []storage.HistoryEntry{}
+	rows, err := sqlite.db.Query(query, args...)
+	if err != nil {
+		return entries
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var entry storage.HistoryEntry
+		var historyType string
+		if err := rows.Scan(
+			&entry.Id,
+			&historyType,
+			&entry.Mint,
+			&entry.ToMint,
+			&entry.Unit,
+			&entry.Amount,
+			&entry.Fee,
+			&entry.Token,
+			&entry.QuoteId,
+			&entry.PaymentRequest,
+			&entry.CreatedAt,
+		); err != nil {
+			continue
+		}
+		if entry.Type, err = storage.ParseHistoryType(historyType); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries
+}
+
+func (sqlite *SQLiteDB) SaveSentToken(sentToken storage.SentToken) error {
+	ys, err := json.Marshal(sentToken.Ys)
+	if err != nil {
+		return err
+	}
+
+	_, err = sqlite.db.Exec(`
+	INSERT OR REPLACE INTO sent_tokens
+	(id, mint, amount, token, memo, state, ys, created_at, expiry, settled_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		sentToken.Id,
+		sentToken.Mint,
+		sentToken.Amount,
+		sentToken.Token,
+		sentToken.Memo,
+		sentToken.State.String(),
+		string(ys),
+		sentToken.CreatedAt,
+		sentToken.Expiry,
+		sentToken.SettledAt,
+	)
+	return err
+}
+
+func (sqlite *SQLiteDB) GetSentTokens() []storage.SentToken {
+	sentTokens := []storage.SentToken{}
+
+	rows, err := sqlite.db.Query(`
+	SELECT id, mint, amount, token, memo, state, ys, created_at, expiry, settled_at
+	FROM sent_tokens
+	`)
+	if err != nil {
+		return sentTokens
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		sentToken, err := scanSentToken(rows)
+		if err != nil {
+			continue
+		}
+		sentTokens = append(sentTokens, *sentToken)
+	}
+
+	return sentTokens
+}
+
+func (sqlite *SQLiteDB) GetSentTokenById(id string) *storage.SentToken {
+	row := sqlite.db.QueryRow(`
+	SELECT id, mint, amount, token, memo, state, ys, created_at, expiry, settled_at
+	FROM sent_tokens WHERE id = ?
+	`, id)
+
+	sentToken, err := scanSentToken(row)
+	if err != nil {
+		return nil
+	}
+	return sentToken
+}
+
+func scanSentToken(row scanner) (*storage.SentToken, error) {
+	var sentToken storage.SentToken
+	var state, ys string
+
+	if err := row.Scan(
+		&sentToken.Id,
+		&sentToken.Mint,
+		&sentToken.Amount,
+		&sentToken.Token,
+		&sentToken.Memo,
+		&state,
+		&ys,
+		&sentToken.CreatedAt,
+		&sentToken.Expiry,
+		&sentToken.SettledAt,
+	); err != nil {
+		return nil, err
+	}
+	sentToken.State = storage.StringToSentTokenState(state)
+	if err := json.Unmarshal([]byte(ys), &sentToken.Ys); err != nil {
+		return nil, err
+	}
+
+	return &sentToken, nil
+}
+
+func (sqlite *SQLiteDB) SavePendingOperation(operation storage.PendingOperation) error {
+	inputs, err := json.Marshal(operation.Inputs)
+	if err != nil {
+		return err
+	}
+	outputs, err := json.Marshal(operation.Outputs)
+	if err != nil {
+		return err
+	}
+	secrets, err := json.Marshal(operation.Secrets)
+	if err != nil {
+		return err
+	}
+	rs, err := json.Marshal(operation.Rs)
+	if err != nil {
+		return err
+	}
+
+	_, err = sqlite.db.Exec(`
+	INSERT OR REPLACE INTO pending_operations
+	(id, type, mint, quote_id, inputs, outputs, secrets, rs, keyset_id, counter, created_at)
+	VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`,
+		operation.Id,
+		operation.Type.String(),
+		operation.Mint,
+		operation.QuoteId,
+		string(inputs),
+		string(outputs),
+		string(secrets),
+		string(rs),
+		operation.KeysetId,
+		operation.Counter,
+		operation.CreatedAt,
+	)
+	return err
+}
+
+func (sqlite *SQLiteDB) GetPendingOperations() []storage.PendingOperation {
+	operations := []storage.PendingOperation{}
+
+	rows, err := sqlite.db.Query(`
+	SELECT id, type, mint, quote_id, inputs, outputs, secrets, rs, keyset_id, counter, created_at
+	FROM pending_operations
+	`)
+	if err != nil {
+		return operations
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var operation storage.PendingOperation
+		var operationType, inputs, outputs, secrets, rs string
+
+		if err := rows.Scan(
+			&operation.Id,
+			&operationType,
+			&operation.Mint,
+			&operation.QuoteId,
+			&inputs,
+			&outputs,
+			&secrets,
+			&rs,
+			&operation.KeysetId,
+			&operation.Counter,
+			&operation.CreatedAt,
+		); err != nil {
+			continue
+		}
+		operation.Type = storage.StringToOperationType(operationType)
+		if err := json.Unmarshal([]byte(inputs), &operation.Inputs); err != nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(outputs), &operation.Outputs); err != nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(secrets), &operation.Secrets); err != nil {
+			continue
+		}
+		if err := json.Unmarshal([]byte(rs), &operation.Rs); err != nil {
+			continue
+		}
+		operations = append(operations, operation)
+	}
+
+	return operations
+}
+
+func (sqlite *SQLiteDB) DeletePendingOperation(id string) error {
+	_, err := sqlite.db.Exec("DELETE FROM pending_operations WHERE id = ?", id)
+	return err
+}
+
+func marshalDLEQ(dleq *cashu.DLEQProof) (sql.NullString, error) {
+	if dleq == nil {
+		return sql.NullString{}, nil
+	}
+	jsonDLEQ, err := json.Marshal(dleq)
+	if err != nil {
+		return sql.NullString{}, err
+	}
+	return sql.NullString{String: string(jsonDLEQ), Valid: true}, nil
+}
+
+func unmarshalDLEQ(dleq sql.NullString) (*cashu.DLEQProof, error) {
+	if !dleq.Valid || len(dleq.String) == 0 {
+		return nil, nil
+	}
+	var proof cashu.DLEQProof
+	if err := json.Unmarshal([]byte(dleq.String), &proof); err != nil {
+		return nil, err
+	}
+	return &proof, nil
+}
@@ -0,0 +1,420 @@
+package sqlite
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"log"
+	"math"
+	"math/rand/v2"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut05"
+	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage/storagetest"
+	"github.com/btcsuite/btcd/btcec/v2"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+var (
+	db *SQLiteDB
+)
+
+func TestMain(m *testing.M) {
+	code, err := testMain(m)
+	if err != nil {
+		log.Println(err)
+	}
+	os.Exit(code)
+}
+
+func testMain(m *testing.M) (int, error) {
+	dbpath := "./testdbsqlite"
+	err := os.MkdirAll(dbpath, 0750)
+	if err != nil {
+		return 1, err
+	}
+	db, err = InitSQLite(dbpath)
+	if err != nil {
+		return 1, err
+	}
+	defer os.RemoveAll(dbpath)
+
+	return m.Run(), nil
+}
+
+func TestSeed(t *testing.T) {
+	seed := []byte("seed")
+	mnemonic := "some mnemonic"
+	db.SaveMnemonicSeed(mnemonic, seed)
+
+	if !slices.Equal(db.GetSeed(), seed) {
+		t.Fatalf("expected seed '%v' but got '%v'", seed, db.GetSeed())
+	}
+	if db.GetMnemonic() != mnemonic {
+		t.Fatalf("expected mnemonic '%v' but got '%v'", mnemonic, db.GetMnemonic())
+	}
+
+	if db.GetSeedScheme() != storage.SeedSchemeBIP39 {
+		t.Fatalf("expected default seed scheme but got '%v'", db.GetSeedScheme())
+	}
+	if err := db.SaveSeedScheme(storage.SeedSchemeBIP39Passphrase); err != nil {
+		t.Fatalf("error saving seed scheme: %v", err)
+	}
+	// saving the seed again should not reset the scheme
+	db.SaveMnemonicSeed(mnemonic, seed)
+	if db.GetSeedScheme() != storage.SeedSchemeBIP39Passphrase {
+		t.Fatalf("expected seed scheme '%v' but got '%v'", storage.SeedSchemeBIP39Passphrase, db.GetSeedScheme())
+	}
+}
+
+func TestProofs(t *testing.T) {
+	keysetId1 := "keysetId12345"
+	randomProofs1 := generateRandomProofs(keysetId1, 50)
+	randomProofs1[0].Witness = "witness"
+	randomProofs1[1].DLEQ = &cashu.DLEQProof{E: "e", S: "s", R: "r"}
+	if err := db.SaveProofs(randomProofs1); err != nil {
+		t.Fatalf("error saving proofs: %v", err)
+	}
+
+	keysetId2 := "keysetId67890"
+	randomProofs2 := generateRandomProofs(keysetId2, 30)
+	if err := db.SaveProofs(randomProofs2); err != nil {
+		t.Fatalf("error saving proofs: %v", err)
+	}
+
+	proofs := db.GetProofs()
+	if len(proofs) != 80 {
+		t.Fatalf("expected '%v' proofs from db but got '%v'", 80, len(proofs))
+	}
+
+	proofsByKeyset := db.GetProofsByKeysetId(keysetId1)
+	sortProofs(randomProofs1)
+	sortProofs(proofsByKeyset)
+	if !reflect.DeepEqual(randomProofs1, proofsByKeyset) {
+		t.Fatal("proofs from db do not match randomly generated ones saved to db")
+	}
+
+	if err := db.DeleteProof(randomProofs2[0].Secret); err != nil {
+		t.Fatalf("error deleting proof: %v", err)
+	}
+	if len(db.GetProofsByKeysetId(keysetId2)) != 29 {
+		t.Fatalf("expected '%v' proofs from db but got '%v'", 29, len(db.GetProofsByKeysetId(keysetId2)))
+	}
+	if err := db.DeleteProof(randomProofs2[0].Secret); !errors.Is(err, storage.ProofNotFound) {
+		t.Fatalf("expected error '%v' but got '%v'", storage.ProofNotFound, err)
+	}
+}
+
+func TestPendingProofs(t *testing.T) {
+	randomProofs := generateRandomProofs("keysetId12345", 20)
+	if err := db.AddPendingProofs(randomProofs); err != nil {
+		t.Fatalf("error saving pending proofs: %v", err)
+	}
+
+	quoteId := "quoteId12345"
+	proofsForQuote := generateRandomProofs("keysetId12345", 10)
+	if err := db.AddPendingProofsByQuoteId(proofsForQuote, quoteId); err != nil {
+		t.Fatalf("error saving pending proofs by quote id: %v", err)
+	}
+
+	pendingProofs := db.GetPendingProofs()
+	if len(pendingProofs) != 30 {
+		t.Fatalf("expected '%v' pending proofs from db but got '%v'", 30, len(pendingProofs))
+	}
+
+	proofsByQuoteId := db.GetPendingProofsByQuoteId(quoteId)
+	expected := toDBProofs(proofsForQuote, quoteId)
+	sortDBProofs(expected)
+	sortDBProofs(proofsByQuoteId)
+	if !reflect.DeepEqual(expected, proofsByQuoteId) {
+		t.Fatalf("pending proofs for quote id '%v' from db do not match the ones saved to db", quoteId)
+	}
+
+	Ys := []string{toDBProofs(randomProofs, "")[0].Y, toDBProofs(randomProofs, "")[1].Y}
+	if err := db.DeletePendingProofs(Ys); err != nil {
+		t.Fatalf("error deleting pending proofs: %v", err)
+	}
+	if err := db.DeletePendingProofsByQuoteId(quoteId); err != nil {
+		t.Fatalf("error deleting pending proofs by quote id: %v", err)
+	}
+	if len(db.GetPendingProofs()) != 18 {
+		t.Fatalf("expected '%v' pending proofs from db but got '%v'", 18, len(db.GetPendingProofs()))
+	}
+}
+
+func TestKeysets(t *testing.T) {
+	oldURL := "http://localhost:3338"
+	newURL := "http://localhost:3339"
+	keyset1 := generateKeyset(oldURL)
+	keyset2 := generateKeyset(oldURL)
+	keyset3 := generateKeyset("http://localhost:8888")
+
+	for _, keyset := range []crypto.WalletKeyset{keyset1, keyset2, keyset3} {
+		if err := db.SaveKeyset(&keyset); err != nil {
+			t.Fatalf("error saving keyset: %v", err)
+		}
+	}
+
+	if len(db.GetKeysets()) != 2 {
+		t.Fatalf("expected keyset map of length 2 but got %v", len(db.GetKeysets()))
+	}
+
+	keysetFromDb := db.GetKeyset(keyset1.Id)
+	if !reflect.DeepEqual(keyset1, *keysetFromDb) {
+		t.Fatalf("keyset '%v' from db does not match '%v'", *keysetFromDb, keyset1)
+	}
+	if db.GetKeyset("nonexistent") != nil {
+		t.Fatal("expected nil for keyset that does not exist")
+	}
+
+	if err := db.IncrementKeysetCounter(keyset2.Id, 5); err != nil {
+		t.Fatalf("error updating keyset counter: %v", err)
+	}
+	if err := db.IncrementKeysetCounter(keyset2.Id, 3); err != nil {
+		t.Fatalf("error updating keyset counter: %v", err)
+	}
+	if counter := db.GetKeysetCounter(keyset2.Id); counter != 8 {
+		t.Fatalf("expected counter for keyset '%v' to %v but got %v", keyset2.Id, 8, counter)
+	}
+	if err := db.IncrementKeysetCounter("nonexistent", 1); err == nil {
+		t.Fatal("expected error incrementing counter of keyset that does not exist")
+	}
+
+	if err := db.UpdateKeysetMintURL(oldURL, newURL); err != nil {
+		t.Fatalf("error updating mint url: %v", err)
+	}
+	keysets := db.GetKeysets()
+	if _, exists := keysets[oldURL]; exists {
+		t.Fatalf("expected old URL '%v' to be removed from keysets map", oldURL)
+	}
+	if len(keysets[newURL]) != 2 {
+		t.Fatalf("expected 2 keysets for new URL '%v' but got %v", newURL, len(keysets[newURL]))
+	}
+	if err := db.UpdateKeysetMintURL(oldURL, newURL); !errors.Is(err, storage.KeysetMintURLNotFound) {
+		t.Fatalf("expected error '%v' but got '%v'", storage.KeysetMintURLNotFound, err)
+	}
+}
+
+func TestQuotes(t *testing.T) {
+	mintQuote := generateMintQuote("mintquote", false)
+	if err := db.SaveMintQuote(mintQuote); err != nil {
+		t.Fatalf("error saving mint quote: %v", err)
+	}
+	mintQuoteWithKey := generateMintQuote("mintquote-with-privatekey", true)
+	if err := db.SaveMintQuote(mintQuoteWithKey); err != nil {
+		t.Fatalf("error saving mint quote: %v", err)
+	}
+
+	for _, quote := range []storage.MintQuote{mintQuote, mintQuoteWithKey} {
+		quoteById := db.GetMintQuoteById(quote.QuoteId)
+		if quoteById == nil {
+			t.Fatal("expected valid quote but got nil")
+		}
+		if !reflect.DeepEqual(quote, *quoteById) {
+			t.Fatal("mint quote from db does not match generated one")
+		}
+	}
+	if len(db.GetMintQuotes()) != 2 {
+		t.Fatalf("expected '%v' mint quotes but got '%v' ", 2, len(db.GetMintQuotes()))
+	}
+	if db.GetMintQuoteById("nonexistent") != nil {
+		t.Fatal("expected nil for mint quote that does not exist")
+	}
+
+	meltQuote := generateMeltQuote("meltquote")
+	if err := db.SaveMeltQuote(meltQuote); err != nil {
+		t.Fatalf("error saving melt quote: %v", err)
+	}
+	meltQuote.State = nut05.Paid
+	meltQuote.Preimage = "preimage"
+	if err := db.SaveMeltQuote(meltQuote); err != nil {
+		t.Fatalf("error saving melt quote: %v", err)
+	}
+	meltQuoteById := db.GetMeltQuoteById(meltQuote.QuoteId)
+	if meltQuoteById == nil {
+		t.Fatal("expected valid quote but got nil")
+	}
+	if !reflect.DeepEqual(meltQuote, *meltQuoteById) {
+		t.Fatal("melt quote from db does not match generated one")
+	}
+	if len(db.GetMeltQuotes()) != 1 {
+		t.Fatalf("expected '%v' melt quotes but got '%v' ", 1, len(db.GetMeltQuotes()))
+	}
+}
+
+func TestSQLiteWalletDB(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.WalletDB {
+		db, err := InitSQLite(t.TempDir())
+		if err != nil {
+			t.Fatalf("error creating db: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return db
+	})
+}
+
+func TestMigrateFromBolt(t *testing.T) {
+	path := t.TempDir()
+	boltdb, err := storage.InitBolt(path)
+	if err != nil {
+		t.Fatalf("error setting bolt db: %v", err)
+	}
+
+	seed := []byte("seed")
+	boltdb.SaveMnemonicSeed("some mnemonic", seed)
+	keyset := generateKeyset("http://localhost:3338")
+	keyset.Counter = 10
+	boltdb.SaveKeyset(&keyset)
+	proofs := generateRandomProofs(keyset.Id, 10)
+	boltdb.SaveProofs(proofs)
+	pendingProofs := generateRandomProofs(keyset.Id, 5)
+	boltdb.AddPendingProofs(pendingProofs[:2])
+	boltdb.AddPendingProofsByQuoteId(pendingProofs[2:], "meltquote")
+	boltdb.SaveMintQuote(generateMintQuote("mintquote", true))
+	boltdb.SaveMeltQuote(generateMeltQuote("meltquote"))
+	boltdb.Close()
+
+	if err := MigrateFromBolt(path); err != nil {
+		t.Fatalf("unexpected error migrating bolt db: %v", err)
+	}
+	if _, err := os.Stat(filepath.Join(path, boltFilename)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatal("expected bolt db to be renamed after migration")
+	}
+	if err := MigrateFromBolt(path); err == nil {
+		t.Fatal("expected error migrating twice")
+	}
+
+	sqlitedb, err := InitSQLite(path)
+	if err != nil {
+		t.Fatalf("error opening sqlite db: %v", err)
+	}
+	defer sqlitedb.Close()
+
+	if !slices.Equal(sqlitedb.GetSeed(), seed) || sqlitedb.GetMnemonic() != "some mnemonic" {
+		t.Fatal("seed was not migrated")
+	}
+	if !reflect.DeepEqual(*sqlitedb.GetKeyset(keyset.Id), keyset) {
+		t.Fatal("keyset was not migrated")
+	}
+	migratedProofs := sqlitedb.GetProofs()
+	sortProofs(proofs)
+	sortProofs(migratedProofs)
+	if !reflect.DeepEqual(proofs, migratedProofs) {
+		t.Fatal("proofs were not migrated")
+	}
+	if len(sqlitedb.GetPendingProofs()) != 5 || len(sqlitedb.GetPendingProofsByQuoteId("meltquote")) != 3 {
+		t.Fatal("pending proofs were not migrated")
+	}
+	if sqlitedb.GetMintQuoteById("mintquote") == nil || sqlitedb.GetMeltQuoteById("meltquote") == nil {
+		t.Fatal("quotes were not migrated")
+	}
+}
+
+func toDBProofs(proofs cashu.Proofs, quoteId string) []storage.DBProof {
+	dbProofs := make([]storage.DBProof, len(proofs))
+	for i, proof := range proofs {
+		Y, _ := crypto.HashToCurve([]byte(proof.Secret))
+		dbProofs[i] = storage.DBProof{
+			Y:           hex.EncodeToString(Y.SerializeCompressed()),
+			Amount:      proof.Amount,
+			Id:          proof.Id,
+			Secret:      proof.Secret,
+			C:           proof.C,
+			DLEQ:        proof.DLEQ,
+			MeltQuoteId: quoteId,
+		}
+	}
+	return dbProofs
+}
+
+func sortProofs(proofs cashu.Proofs) {
+	slices.SortFunc(proofs, func(a, b cashu.Proof) int {
+		return strings.Compare(a.Secret, b.Secret)
+	})
+}
+
+func sortDBProofs(proofs []storage.DBProof) {
+	slices.SortFunc(proofs, func(a, b storage.DBProof) int {
+		return strings.Compare(a.Secret, b.Secret)
+	})
+}
+
+func generateRandomProofs(keysetId string, num int) cashu.Proofs {
+	proofs := make(cashu.Proofs, num)
+	for i := 0; i < num; i++ {
+		proofs[i] = cashu.Proof{
+			Amount: 21,
+			Id:     keysetId,
+			Secret: generateRandomString(64),
+			C:      generateRandomString(64),
+		}
+	}
+	return proofs
+}
+
+func generateRandomString(length int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = letters[rand.IntN(len(letters))]
+	}
+	return string(b)
+}
+
+func generateKeyset(mint string) crypto.WalletKeyset {
+	keygen := generateRandomString(32)
+	keys := make(map[uint64]*secp256k1.PublicKey, 64)
+	for i := 0; i < 64; i++ {
+		amount := uint64(math.Pow(2, float64(i)))
+		hash := sha256.Sum256([]byte(keygen + strconv.FormatUint(amount, 10)))
+		_, pubKey := btcec.PrivKeyFromBytes(hash[:])
+		keys[amount] = pubKey
+	}
+
+	return crypto.WalletKeyset{
+		Id:          generateRandomString(32),
+		MintURL:     mint,
+		Unit:        cashu.Sat.String(),
+		Active:      true,
+		PublicKeys:  keys,
+		InputFeePpk: 100,
+	}
+}
+
+func generateMintQuote(id string, privateKey bool) storage.MintQuote {
+	mintQuote := storage.MintQuote{
+		QuoteId: id,
+		Mint:    "http://localhost:3338",
+		Method:  "bolt11",
+		State:   nut04.Unpaid,
+		Amount:  21,
+	}
+	if privateKey {
+		pk, err := secp256k1.GeneratePrivateKey()
+		if err != nil {
+			panic(err)
+		}
+		mintQuote.PrivateKey = pk
+	}
+	return mintQuote
+}
+
+func generateMeltQuote(id string) storage.MeltQuote {
+	return storage.MeltQuote{
+		QuoteId: id,
+		Mint:    "http://localhost:3338",
+		Method:  "bolt11",
+		State:   nut05.Unpaid,
+		Amount:  21,
+	}
+}
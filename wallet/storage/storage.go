@@ -1,7 +1,11 @@
 package storage
 
 import (
+	"cmp"
 	"encoding/json"
+	"fmt"
+	"slices"
+	"strings"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
 	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut04"
@@ -28,10 +32,24 @@ func (quote QuoteType) String() string {
 	}
 }
 
+// SeedScheme is how the seed of the wallet was derived from its mnemonic
+type SeedScheme int
+
+const (
+	// seed derived with an empty BIP39 passphrase
+	SeedSchemeBIP39 SeedScheme = iota
+	// seed derived with a BIP39 passphrase. The passphrase
+	// itself is not stored and is needed to restore the wallet
+	SeedSchemeBIP39Passphrase
+)
+
 type WalletDB interface {
 	SaveMnemonicSeed(string, []byte)
 	GetSeed() []byte
 	GetMnemonic() string
+	// SaveSeedScheme has to be called after the seed is saved
+	SaveSeedScheme(SeedScheme) error
+	GetSeedScheme() SeedScheme
 
 	SaveProofs(cashu.Proofs) error
 	GetProofs() cashu.Proofs
@@ -60,6 +78,18 @@ type WalletDB interface {
 	GetMeltQuotes() []MeltQuote
 	GetMeltQuoteById(string) *MeltQuote
 
+	SaveHistoryEntry(HistoryEntry) error
+	// GetHistory returns the entries that match the filter, newest first
+	GetHistory(HistoryFilter) []HistoryEntry
+
+	SaveSentToken(SentToken) error
+	GetSentTokens() []SentToken
+	GetSentTokenById(string) *SentToken
+
+	SavePendingOperation(PendingOperation) error
+	GetPendingOperations() []PendingOperation
+	DeletePendingOperation(string) error
+
 	Close() error
 }
 
@@ -180,3 +210,323 @@ type Invoice struct {
 	SettledAt      int64
 	QuoteExpiry    uint64
 }
+
+type HistoryType int
+
+const (
+	SendHistory HistoryType = iota + 1
+	ReceiveHistory
+	MintHistory
+	MeltHistory
+	// swap of funds between mints
+	SwapHistory
+)
+
+func (t HistoryType) String() string {
+	switch t {
+	case SendHistory:
+		return "send"
+	case ReceiveHistory:
+		return "receive"
+	case MintHistory:
+		return "mint"
+	case MeltHistory:
+		return "melt"
+	case SwapHistory:
+		return "swap"
+	default:
+		return "unknown"
+	}
+}
+
+func ParseHistoryType(s string) (HistoryType, error) {
+	switch s {
+	case "send":
+		return SendHistory, nil
+	case "receive":
+		return ReceiveHistory, nil
+	case "mint":
+		return MintHistory, nil
+	case "melt":
+		return MeltHistory, nil
+	case "swap":
+		return SwapHistory, nil
+	default:
+		return 0, fmt.Errorf("invalid history type '%v'", s)
+	}
+}
+
+func (t HistoryType) MarshalText() ([]byte, error) {
+	return []byte(t.String()), nil
+}
+
+func (t *HistoryType) UnmarshalText(text []byte) error {
+	historyType, err := ParseHistoryType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = historyType
+	return nil
+}
+
+// HistoryEntry records an operation done by the wallet
+type HistoryEntry struct {
+	Id   string      `json:"id"`
+	Type HistoryType `json:"type"`
+	Mint string      `json:"mint"`
+	// mint that received the funds in swaps between mints
+	ToMint string `json:"to_mint,omitempty"`
+	Unit   string `json:"unit"`
+	// amount sent, received, minted, melted or swapped without the fees
+	Amount uint64 `json:"amount"`
+	Fee    uint64 `json:"fee"`
+	// token sent or received
+	Token string `json:"token,omitempty"`
+	// mint or melt quote of the operation
+	QuoteId        string `json:"quote_id,omitempty"`
+	PaymentRequest string `json:"payment_request,omitempty"`
+	CreatedAt      int64  `json:"created_at"`
+}
+
+type HistoryFilter struct {
+	// types of entries to return. All if empty
+	Types []HistoryType
+	// only entries that involve this mint
+	Mint string
+	// unix timestamps. No bound if 0
+	Since int64
+	Until int64
+
+	Offset int
+	// max number of entries to return. No limit if 0
+	Limit int
+}
+
+func (filter HistoryFilter) match(entry HistoryEntry) bool {
+	if len(filter.Types) > 0 && !slices.Contains(filter.Types, entry.Type) {
+		return false
+	}
+	if len(filter.Mint) > 0 && entry.Mint != filter.Mint && entry.ToMint != filter.Mint {
+		return false
+	}
+	if filter.Since > 0 && entry.CreatedAt < filter.Since {
+		return false
+	}
+	if filter.Until > 0 && entry.CreatedAt > filter.Until {
+		return false
+	}
+	return true
+}
+
+// filterHistory returns the page of entries that match the filter, newest first
+func filterHistory(entries []HistoryEntry, filter HistoryFilter) []HistoryEntry {
+	matches := []HistoryEntry{}
+	for _, entry := range entries {
+		if filter.match(entry) {
+			matches = append(matches, entry)
+		}
+	}
+	slices.SortFunc(matches, func(a, b HistoryEntry) int {
+		if a.CreatedAt != b.CreatedAt {
+			return cmp.Compare(b.CreatedAt, a.CreatedAt)
+		}
+		return strings.Compare(b.Id, a.Id)
+	})
+
+	if filter.Offset >= len(matches) {
+		return []HistoryEntry{}
+	}
+	matches = matches[max(filter.Offset, 0):]
+	if filter.Limit > 0 && filter.Limit < len(matches) {
+		matches = matches[:filter.Limit]
+	}
+	return matches
+}
+
+type SentTokenState int
+
+const (
+	// token has not been claimed by the recipient
+	SentTokenPending SentTokenState = iota
+	SentTokenClaimed
+	// token was not claimed and the wallet took the proofs back
+	SentTokenReclaimed
+	// some of the proofs were claimed and the wallet took the rest back
+	SentTokenPartiallyClaimed
+)
+
+func (state SentTokenState) String() string {
+	switch state {
+	case SentTokenPending:
+		return "pending"
+	case SentTokenClaimed:
+		return "claimed"
+	case SentTokenReclaimed:
+		return "reclaimed"
+	case SentTokenPartiallyClaimed:
+		return "partially claimed"
+	default:
+		return "unknown"
+	}
+}
+
+func StringToSentTokenState(state string) SentTokenState {
+	switch state {
+	case "claimed":
+		return SentTokenClaimed
+	case "reclaimed":
+		return SentTokenReclaimed
+	case "partially claimed":
+		return SentTokenPartiallyClaimed
+	default:
+		return SentTokenPending
+	}
+}
+
+// SentToken is a token sent by the wallet that is tracked
+// until the recipient claims it or the wallet reclaims it
+type SentToken struct {
+	Id     string         `json:"id"`
+	Mint   string         `json:"mint"`
+	Amount uint64         `json:"amount"`
+	Token  string         `json:"token"`
+	Memo   string         `json:"memo,omitempty"`
+	State  SentTokenState `json:"state"`
+	// Ys of the proofs in the token
+	Ys        []string `json:"ys"`
+	CreatedAt int64    `json:"created_at"`
+	// unix timestamp after which the token is reclaimed
+	// if it has not been claimed. 0 if it is never reclaimed
+	Expiry int64 `json:"expiry"`
+	// when the token was claimed or reclaimed
+	SettledAt int64 `json:"settled_at"`
+}
+
+type OperationType int
+
+const (
+	SwapOperation OperationType = iota + 1
+	MintOperation
+	MeltOperation
+)
+
+func (t OperationType) String() string {
+	switch t {
+	case SwapOperation:
+		return "swap"
+	case MintOperation:
+		return "mint"
+	case MeltOperation:
+		return "melt"
+	default:
+		return "unknown"
+	}
+}
+
+func StringToOperationType(s string) OperationType {
+	switch s {
+	case "swap":
+		return SwapOperation
+	case "mint":
+		return MintOperation
+	case "melt":
+		return MeltOperation
+	default:
+		return 0
+	}
+}
+
+// PendingOperation is a swap, mint or melt that is saved before the request
+// is sent to the mint and deleted once the proofs from the response are saved.
+// If the wallet stops in between, it is recovered the next time it is loaded.
+type PendingOperation struct {
+	Id   string        `json:"id"`
+	Type OperationType `json:"type"`
+	Mint string        `json:"mint"`
+	// mint or melt quote of the operation
+	QuoteId string       `json:"quote_id,omitempty"`
+	Inputs  cashu.Proofs `json:"inputs,omitempty"`
+	// outputs sent to the mint with the secrets and
+	// blinding factors (hex) needed to unblind the signatures
+	Outputs  cashu.BlindedMessages `json:"outputs"`
+	Secrets  []string              `json:"secrets"`
+	Rs       []string              `json:"rs"`
+	KeysetId string                `json:"keyset_id"`
+	// counter of the keyset after the deterministic outputs were derived
+	Counter   uint32 `json:"counter"`
+	CreatedAt int64  `json:"created_at"`
+}
+
+// CopyWallet copies the seed, keysets, proofs, quotes, history, sent tokens
+// and pending operations from src to dst
+func CopyWallet(dst, src WalletDB) error {
+	if seed := src.GetSeed(); len(seed) > 0 {
+		dst.SaveMnemonicSeed(src.GetMnemonic(), seed)
+		if err := dst.SaveSeedScheme(src.GetSeedScheme()); err != nil {
+			return err
+		}
+	}
+
+	for _, keysets := range src.GetKeysets() {
+		for _, keyset := range keysets {
+			if err := dst.SaveKeyset(&keyset); err != nil {
+				return err
+			}
+		}
+	}
+
+	if err := dst.SaveProofs(src.GetProofs()); err != nil {
+		return err
+	}
+
+	pendingProofs := make(map[string]cashu.Proofs)
+	for _, dbProof := range src.GetPendingProofs() {
+		proof := cashu.Proof{
+			Amount: dbProof.Amount,
+			Id:     dbProof.Id,
+			Secret: dbProof.Secret,
+			C:      dbProof.C,
+			DLEQ:   dbProof.DLEQ,
+		}
+		pendingProofs[dbProof.MeltQuoteId] = append(pendingProofs[dbProof.MeltQuoteId], proof)
+	}
+	for quoteId, proofs := range pendingProofs {
+		var err error
+		if len(quoteId) == 0 {
+			err = dst.AddPendingProofs(proofs)
+		} else {
+			err = dst.AddPendingProofsByQuoteId(proofs, quoteId)
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	for _, quote := range src.GetMintQuotes() {
+		if err := dst.SaveMintQuote(quote); err != nil {
+			return err
+		}
+	}
+	for _, quote := range src.GetMeltQuotes() {
+		if err := dst.SaveMeltQuote(quote); err != nil {
+			return err
+		}
+	}
+	for _, entry := range src.GetHistory(HistoryFilter{}) {
+		if err := dst.SaveHistoryEntry(entry); err != nil {
+			return err
+		}
+	}
+	for _, sentToken := range src.GetSentTokens() {
+		if err := dst.SaveSentToken(sentToken); err != nil {
+			return err
+		}
+	}
+	for _, operation := range src.GetPendingOperations() {
+		if err := dst.SavePendingOperation(operation); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,206 @@
+// Package storagetest has the tests that every implementation
+// of storage.WalletDB is expected to pass.
+package storagetest
+
+import (
+	"math/rand/v2"
+	"reflect"
+	"slices"
+	"strconv"
+	"testing"
+
+	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+)
+
+// NewWalletDB returns an empty db for a test.
+// It should register with t.Cleanup anything needed to close and remove the db.
+type NewWalletDB func(t *testing.T) storage.WalletDB
+
+// Run runs the tests of the suite, each one on a new db from newDB
+func Run(t *testing.T, newDB NewWalletDB) {
+	tests := []struct {
+		name string
+		test func(*testing.T, storage.WalletDB)
+	}{
+		{name: "History", test: testHistory},
+		{name: "SentTokens", test: testSentTokens},
+		{name: "PendingOperations", test: testPendingOperations},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.test(t, newDB(t))
+		})
+	}
+}
+
+func testHistory(t *testing.T, db storage.WalletDB) {
+	types := []storage.HistoryType{storage.SendHistory, storage.ReceiveHistory, storage.MintHistory, storage.MeltHistory}
+	for i := 0; i < 20; i++ {
+		entry := storage.HistoryEntry{
+			Id:        strconv.Itoa(100 + i),
+			Type:      types[i%len(types)],
+			Mint:      "http://localhost:3338",
+			Unit:      cashu.Sat.String(),
+			Amount:    uint64(i),
+			Token:     "cashuB" + generateRandomString(32),
+			CreatedAt: int64(1000 + i),
+		}
+		if err := db.SaveHistoryEntry(entry); err != nil {
+			t.Fatalf("error saving history entry: %v", err)
+		}
+	}
+	swap := storage.HistoryEntry{
+		Id:        "200",
+		Type:      storage.SwapHistory,
+		Mint:      "http://localhost:3338",
+		ToMint:    "http://localhost:3339",
+		Unit:      cashu.Sat.String(),
+		Amount:    21,
+		Fee:       2,
+		CreatedAt: 2000,
+	}
+	if err := db.SaveHistoryEntry(swap); err != nil {
+		t.Fatalf("error saving history entry: %v", err)
+	}
+
+	history := db.GetHistory(storage.HistoryFilter{})
+	if len(history) != 21 {
+		t.Fatalf("expected '%v' history entries but got '%v'", 21, len(history))
+	}
+	if !reflect.DeepEqual(history[0], swap) {
+		t.Fatalf("expected newest entry '%+v' but got '%+v'", swap, history[0])
+	}
+	for i := 1; i < len(history); i++ {
+		if history[i].CreatedAt > history[i-1].CreatedAt {
+			t.Fatal("expected history sorted newest first")
+		}
+	}
+
+	sends := db.GetHistory(storage.HistoryFilter{Types: []storage.HistoryType{storage.SendHistory, storage.SwapHistory}})
+	if len(sends) != 6 {
+		t.Fatalf("expected '%v' history entries but got '%v'", 6, len(sends))
+	}
+	toMint := db.GetHistory(storage.HistoryFilter{Mint: "http://localhost:3339"})
+	if len(toMint) != 1 || toMint[0].Id != swap.Id {
+		t.Fatal("expected swap entry when filtering by the mint it swapped to")
+	}
+	inRange := db.GetHistory(storage.HistoryFilter{Since: 1005, Until: 1009})
+	if len(inRange) != 5 || inRange[0].CreatedAt != 1009 || inRange[4].CreatedAt != 1005 {
+		t.Fatal("unexpected history entries in time range")
+	}
+
+	page := db.GetHistory(storage.HistoryFilter{Offset: 5, Limit: 5})
+	if !reflect.DeepEqual(page, history[5:10]) {
+		t.Fatal("unexpected history page")
+	}
+	if len(db.GetHistory(storage.HistoryFilter{Offset: 30})) != 0 {
+		t.Fatal("expected no entries past the end of the history")
+	}
+}
+
+func testSentTokens(t *testing.T, db storage.WalletDB) {
+	sentToken := storage.SentToken{
+		Id:        "sentTokenId",
+		Mint:      "http://localhost:3338",
+		Amount:    21,
+		Token:     "cashuB" + generateRandomString(32),
+		Memo:      "coffee",
+		State:     storage.SentTokenPending,
+		Ys:        []string{generateRandomString(32), generateRandomString(32)},
+		CreatedAt: 1000,
+		Expiry:    2000,
+	}
+	if err := db.SaveSentToken(sentToken); err != nil {
+		t.Fatalf("error saving sent token: %v", err)
+	}
+	if err := db.SaveSentToken(storage.SentToken{Id: "otherId", Mint: "http://localhost:3338", Ys: []string{}}); err != nil {
+		t.Fatalf("error saving sent token: %v", err)
+	}
+
+	sentTokenFromDb := db.GetSentTokenById(sentToken.Id)
+	if !reflect.DeepEqual(*sentTokenFromDb, sentToken) {
+		t.Fatalf("expected sent token '%+v' but got '%+v'", sentToken, *sentTokenFromDb)
+	}
+	if len(db.GetSentTokens()) != 2 {
+		t.Fatalf("expected '%v' sent tokens but got '%v'", 2, len(db.GetSentTokens()))
+	}
+	if db.GetSentTokenById("nonexistent") != nil {
+		t.Fatal("expected no sent token for nonexistent id")
+	}
+
+	// saving it again updates its state
+	sentToken.State = storage.SentTokenClaimed
+	sentToken.SettledAt = 1500
+	if err := db.SaveSentToken(sentToken); err != nil {
+		t.Fatalf("error updating sent token: %v", err)
+	}
+	sentTokenFromDb = db.GetSentTokenById(sentToken.Id)
+	if !reflect.DeepEqual(*sentTokenFromDb, sentToken) {
+		t.Fatalf("expected sent token '%+v' but got '%+v'", sentToken, *sentTokenFromDb)
+	}
+	if len(db.GetSentTokens()) != 2 {
+		t.Fatalf("expected '%v' sent tokens but got '%v'", 2, len(db.GetSentTokens()))
+	}
+}
+
+func testPendingOperations(t *testing.T, db storage.WalletDB) {
+	operation := storage.PendingOperation{
+		Id:        "operationId",
+		Type:      storage.SwapOperation,
+		Mint:      "http://localhost:3338",
+		Inputs:    generateRandomProofs("keysetId", 2),
+		Outputs:   cashu.BlindedMessages{{Amount: 1, Id: "keysetId", B_: generateRandomString(32)}},
+		Secrets:   []string{generateRandomString(32)},
+		Rs:        []string{generateRandomString(32)},
+		KeysetId:  "keysetId",
+		Counter:   5,
+		CreatedAt: 1000,
+	}
+	if err := db.SavePendingOperation(operation); err != nil {
+		t.Fatalf("error saving pending operation: %v", err)
+	}
+	meltOperation := storage.PendingOperation{Id: "meltOperationId", Type: storage.MeltOperation, QuoteId: "quoteId"}
+	if err := db.SavePendingOperation(meltOperation); err != nil {
+		t.Fatalf("error saving pending operation: %v", err)
+	}
+
+	operations := db.GetPendingOperations()
+	if len(operations) != 2 {
+		t.Fatalf("expected '%v' pending operations but got '%v'", 2, len(operations))
+	}
+	idx := slices.IndexFunc(operations, func(op storage.PendingOperation) bool { return op.Id == operation.Id })
+	if idx < 0 || !reflect.DeepEqual(operations[idx], operation) {
+		t.Fatalf("expected pending operation '%+v' but got '%+v'", operation, operations)
+	}
+
+	if err := db.DeletePendingOperation(operation.Id); err != nil {
+		t.Fatalf("error deleting pending operation: %v", err)
+	}
+	operations = db.GetPendingOperations()
+	if len(operations) != 1 || operations[0].Id != meltOperation.Id || operations[0].Type != storage.MeltOperation {
+		t.Fatalf("expected only melt operation but got '%+v'", operations)
+	}
+}
+
+func generateRandomProofs(keysetId string, num int) cashu.Proofs {
+	proofs := make(cashu.Proofs, num)
+	for i := 0; i < num; i++ {
+		proofs[i] = cashu.Proof{
+			Amount: 21,
+			Id:     keysetId,
+			Secret: generateRandomString(64),
+			C:      generateRandomString(64),
+		}
+	}
+	return proofs
+}
+
+func generateRandomString(length int) string {
+	const letters = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+	b := make([]byte, length)
+	for i := range b {
+		b[i] = letters[rand.IntN(len(letters))]
+	}
+	return string(b)
+}
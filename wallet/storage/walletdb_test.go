@@ -0,0 +1,25 @@
+package storage_test
+
+import (
+	"testing"
+
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage/storagetest"
+)
+
+func TestBoltWalletDB(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.WalletDB {
+		db, err := storage.InitBolt(t.TempDir())
+		if err != nil {
+			t.Fatalf("error creating db: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+		return db
+	})
+}
+
+func TestMemoryWalletDB(t *testing.T) {
+	storagetest.Run(t, func(t *testing.T) storage.WalletDB {
+		return storage.NewMemoryDB()
+	})
+}
@@ -36,8 +36,10 @@ type SubscriptionManager struct {
 	cancel context.CancelFunc
 }
 
-func NewSubscriptionManager(mint string) (*SubscriptionManager, error) {
-	mintInfo, err := client.GetMintInfo(mint)
+// NewSubscriptionManager connects to the websocket of the mint
+// with the proxy and timeout of the client
+func NewSubscriptionManager(ctx context.Context, mintClient *client.Client) (*SubscriptionManager, error) {
+	mintInfo, err := mintClient.GetMintInfo(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("could not get mint info: %v", err)
 	}
@@ -45,7 +47,7 @@ func NewSubscriptionManager(mint string) (*SubscriptionManager, error) {
 		return nil, ErrNUT17NotSupported
 	}
 
-	mintURL, err := url.Parse(mint)
+	mintURL, err := url.Parse(mintClient.MintURL())
 	if err != nil {
 		return nil, fmt.Errorf("invalid mint url: %v", err)
 	}
@@ -55,7 +57,11 @@ func NewSubscriptionManager(mint string) (*SubscriptionManager, error) {
 		scheme = "wss"
 	}
 	wsURL := scheme + "://" + mintURL.Host + mintURL.Path + "/v1/ws"
-	conn, _, err := websocket.DefaultDialer.Dial(wsURL, nil)
+	dialer := websocket.Dialer{
+		Proxy:            mintClient.Proxy(),
+		HandshakeTimeout: mintClient.Timeout(),
+	}
+	conn, _, err := dialer.DialContext(ctx, wsURL, nil)
 	if err != nil {
 		return nil, err
 	}
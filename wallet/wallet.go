@@ -1,6 +1,7 @@
 package wallet
 
 import (
+	"context"
 	"crypto/rand"
 	"crypto/sha256"
 	"encoding/hex"
@@ -9,6 +10,7 @@ import (
 	"math"
 	"net/url"
 	"os"
+	"path/filepath"
 	"slices"
 	"sort"
 	"sync"
@@ -29,11 +31,10 @@ import (
 	"github.com/Origami74/gonuts-tollgate/crypto"
 	"github.com/Origami74/gonuts-tollgate/wallet/client"
 	"github.com/Origami74/gonuts-tollgate/wallet/storage"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage/sqlite"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
-	"github.com/btcsuite/btcd/chaincfg"
 	"github.com/decred/dcrd/dcrec/secp256k1/v4"
-	"github.com/tyler-smith/go-bip39"
 
 	decodepay "github.com/nbd-wtf/ln-decodepay"
 )
@@ -42,6 +43,7 @@ var (
 	ErrMintNotExist            = errors.New("mint does not exist")
 	ErrInsufficientMintBalance = errors.New("not enough funds in selected mint")
 	ErrQuoteNotFound           = errors.New("quote not found")
+	ErrWalletLocked            = storage.ErrLocked
 )
 
 type Wallet struct {
@@ -56,6 +58,17 @@ type Wallet struct {
 	// list of mints that have been trusted
 	mints map[string]walletMint
 
+	// set if the storage of the wallet is encrypted
+	encryptedDB    *storage.EncryptedDB
+	path           string
+	storageBackend StorageBackend
+
+	// client for the REST API of the mints. Requests
+	// are canceled when ctx is done or on Shutdown
+	client *client.Client
+	ctx    context.Context
+	cancel context.CancelFunc
+
 	mu sync.RWMutex
 }
 
@@ -65,14 +78,79 @@ type walletMint struct {
 	inactiveKeysets map[string]crypto.WalletKeyset
 }
 
+type StorageBackend int
+
+const (
+	BoltStorage StorageBackend = iota
+	SQLiteStorage
+)
+
 type Config struct {
 	WalletPath     string
 	CurrentMintURL string
+	// db used to store the wallet. Defaults to bolt
+	StorageBackend StorageBackend
+	// passphrase to unlock the wallet if its storage is encrypted. If the wallet
+	// is encrypted and this is not set, the wallet is loaded locked.
+	// If set when creating a new wallet, its storage is encrypted with it.
+	EncryptionPassphrase string
+	// BIP39 passphrase used with the mnemonic to derive the seed of a new wallet.
+	// It is not stored and has no effect if the wallet already exists.
+	MnemonicPassphrase string
+	// config of the HTTP client used to make requests to the mints.
+	// Zero values are set to the defaults in client.DefaultConfig
+	ClientConfig client.Config
+	// when Context is done, the requests in progress to the mints are canceled
+	// and the operations that made them fail. Defaults to context.Background
+	Context context.Context
+}
+
+func newWalletClient(config Config) (*client.Client, context.Context, context.CancelFunc, error) {
+	mintClient, err := client.NewClient(config.CurrentMintURL, config.ClientConfig)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	ctx := config.Context
+	if ctx == nil {
+		ctx = context.Background()
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	return mintClient, ctx, cancel, nil
+}
+
+// InitStorage opens the wallet db in the path. If sqlite is selected and
+// there is only a bolt db in the path, the bolt db is migrated to sqlite first.
+func InitStorage(path string, backend StorageBackend) (storage.WalletDB, error) {
+	boltExists := fileExists(filepath.Join(path, "wallet.db"))
+	sqliteExists := fileExists(filepath.Join(path, sqlite.DB_FILENAME))
+
+	switch backend {
+	case BoltStorage:
+		if sqliteExists && !boltExists {
+			return nil, errors.New("wallet was migrated to sqlite. Select sqlite as the storage backend")
+		}
+		return storage.InitBolt(path)
+	case SQLiteStorage:
+		if boltExists && !sqliteExists {
+			if err := sqlite.MigrateFromBolt(path); err != nil {
+				return nil, fmt.Errorf("error migrating bolt db to sqlite: %w", err)
+			}
+		}
+		return sqlite.InitSQLite(path)
+	default:
+		return nil, fmt.Errorf("unknown storage backend: %v", backend)
+	}
 }
 
-func InitStorage(path string) (storage.WalletDB, error) {
-	// bolt db atm
-	return storage.InitBolt(path)
+// Exists returns true if there is a wallet db in the path
+func Exists(walletPath string) bool {
+	return fileExists(filepath.Join(walletPath, "wallet.db")) ||
+		fileExists(filepath.Join(walletPath, sqlite.DB_FILENAME))
+}
+
+func fileExists(path string) bool {
+	_, err := os.Stat(path)
+	return err == nil
 }
 
 func LoadWallet(config Config) (*Wallet, error) {
@@ -81,9 +159,13 @@ func LoadWallet(config Config) (*Wallet, error) {
 		return nil, err
 	}
 
-	db, err := InitStorage(path)
+	if err := recoverEncryption(path); err != nil {
+		return nil, fmt.Errorf("error encrypting wallet: %w", err)
+	}
+
+	db, err := InitStorage(path, config.StorageBackend)
 	if err != nil {
-		return nil, fmt.Errorf("InitStorage: %v", err)
+		return nil, fmt.Errorf("InitStorage: %w", err)
 	}
 
 	isErr := true
@@ -94,90 +176,168 @@ func LoadWallet(config Config) (*Wallet, error) {
 		}
 	}()
 
-	seed := db.GetSeed()
-	if len(seed) == 0 {
-		// create and save new seed if none existed previously
-		entropy, err := bip39.NewEntropy(128)
-		if err != nil {
-			return nil, fmt.Errorf("error generating seed: %v", err)
+	mintClient, ctx, cancel, err := newWalletClient(config)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if isErr {
+			cancel()
 		}
+	}()
 
-		mnemonic, err := bip39.NewMnemonic(entropy)
-		if err != nil {
-			return nil, fmt.Errorf("error generating seed: %v", err)
+	wallet := &Wallet{
+		db:             db,
+		unit:           cashu.Sat,
+		path:           path,
+		storageBackend: config.StorageBackend,
+		client:         mintClient,
+		ctx:            ctx,
+		cancel:         cancel,
+	}
+	if storage.KeyFileExists(path) {
+		wallet.encryptedDB = storage.NewEncryptedDB(db)
+		wallet.db = wallet.encryptedDB
+		// if no passphrase, the wallet stays locked until Unlock is called
+		if len(config.EncryptionPassphrase) > 0 {
+			if err := wallet.Unlock(config.EncryptionPassphrase); err != nil {
+				return nil, err
+			}
+		}
+	} else {
+		if len(config.EncryptionPassphrase) > 0 {
+			if len(db.GetSeed()) > 0 {
+				return nil, errors.New("wallet is not encrypted. Use ChangePassphrase to encrypt it")
+			}
+			// new wallet, encrypt it before the seed is created
+			wallet.encryptedDB, err = newEncryptedDB(path, db, config.EncryptionPassphrase)
+			if err != nil {
+				return nil, err
+			}
+			wallet.db = wallet.encryptedDB
 		}
+		if err := wallet.loadKeys(config.MnemonicPassphrase); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := wallet.setupMints(config.CurrentMintURL); err != nil {
+		return nil, err
+	}
+	// a locked wallet recovers its pending operations when it is unlocked
+	if !wallet.IsLocked() {
+		wallet.recoverPendingOperations()
+	}
+
+	isErr = false
+	return wallet, nil
+}
 
-		seed = bip39.NewSeed(mnemonic, "")
-		db.SaveMnemonicSeed(mnemonic, seed)
+// LoadWalletWithDB loads a wallet that uses db as its storage.
+// The wallet path, storage backend and encryption passphrase in the config
+// are not used. The db is closed when the wallet is shut down.
+func LoadWalletWithDB(config Config, db storage.WalletDB) (*Wallet, error) {
+	if db == nil {
+		return nil, errors.New("db cannot be nil")
 	}
 
-	// TODO: what's the point of chain params here?
-	masterKey, err := hdkeychain.NewMaster(seed, &chaincfg.MainNetParams)
+	encryptedDB, encrypted := db.(*storage.EncryptedDB)
+	if encrypted && encryptedDB.Locked() {
+		return nil, ErrWalletLocked
+	}
+	mintClient, ctx, cancel, err := newWalletClient(config)
 	if err != nil {
 		return nil, err
 	}
 
-	privateKey, err := DeriveP2PK(masterKey)
-	if err != nil {
+	wallet := &Wallet{db: db, unit: cashu.Sat, client: mintClient, ctx: ctx, cancel: cancel}
+	if encrypted {
+		wallet.encryptedDB = encryptedDB
+	}
+	if err := wallet.loadKeys(config.MnemonicPassphrase); err != nil {
+		cancel()
+		return nil, err
+	}
+	if err := wallet.setupMints(config.CurrentMintURL); err != nil {
+		cancel()
 		return nil, err
 	}
+	wallet.recoverPendingOperations()
+	return wallet, nil
+}
 
-	wallet := &Wallet{db: db, unit: cashu.Sat, masterKey: masterKey, privateKey: privateKey}
-	wallet.mints, err = wallet.loadWalletMints()
+// setupMints loads the mints trusted by the wallet and adds the current
+// mint if it is new. If the mint cannot be reached, the wallet
+// continues in offline mode with the mints it already has.
+func (w *Wallet) setupMints(currentMintURL string) error {
+	var err error
+	w.mints, err = w.loadWalletMints()
 	if err != nil {
-		return nil, err
+		return err
 	}
-	url, err := url.Parse(config.CurrentMintURL)
+	url, err := url.Parse(currentMintURL)
 	if err != nil {
-		return nil, fmt.Errorf("invalid mint url: %v", err)
+		return fmt.Errorf("invalid mint url: %w", err)
 	}
 	mintURL := url.String()
-	wallet.defaultMint = mintURL
+	w.defaultMint = mintURL
 
-	_, ok := wallet.mints[mintURL]
+	_, ok := w.mints[mintURL]
 	if !ok {
 		// if mint is new, add it
-		_, err := wallet.AddMint(mintURL)
+		_, err := w.AddMint(mintURL)
 		if err != nil {
 			if isNetworkError(err) {
 				// Inform user about network issue but continue with offline mode
 				wrappedErr := wrapNetworkError(err, "adding new mint")
 				fmt.Printf("Warning: %v\nContinuing in offline mode with existing mints only.\n\n", wrappedErr)
-				return wallet, nil
+				return nil
 			}
-			return nil, fmt.Errorf("error adding new mint: %v", err)
+			return fmt.Errorf("error adding new mint: %w", err)
 		}
 	} else {
 		// if mint is known, check if active keyset has changed
-		_, err := wallet.getActiveKeyset(mintURL)
+		_, err := w.getActiveKeyset(mintURL)
 		if err != nil {
 			if isNetworkError(err) {
 				// Inform user about network issue but continue with cached data
 				wrappedErr := wrapNetworkError(err, "checking keyset updates")
 				fmt.Printf("Warning: %v\nContinuing with cached keysets.\n\n", wrappedErr)
-				return wallet, nil
+				return nil
 			}
-			return nil, err
+			return err
 		}
 	}
 
-	isErr = false
-	return wallet, nil
+	return nil
 }
 
+// Shutdown cancels the requests in progress to the mints and closes the db
 func (w *Wallet) Shutdown() error {
+	w.cancel()
 	return w.db.Close()
 }
 
+// mintClient returns the client for the REST API of the mint
+func (w *Wallet) mintClient(mintURL string) *client.Client {
+	return w.client.WithMintURL(mintURL)
+}
+
+// MintClient returns a client for the mint that uses
+// the same HTTP config as the requests of the wallet
+func (w *Wallet) MintClient(mintURL string) *client.Client {
+	return w.mintClient(mintURL)
+}
+
 // AddMint adds the mint to the list of mints trusted by the wallet
 func (w *Wallet) AddMint(mint string) (*walletMint, error) {
 	url, err := url.Parse(mint)
 	if err != nil {
-		return nil, fmt.Errorf("invalid mint url: %v", err)
+		return nil, fmt.Errorf("invalid mint url: %w", err)
 	}
 	mintURL := url.String()
 
-	activeKeyset, err := GetMintActiveKeyset(mintURL, w.unit)
+	activeKeyset, err := getMintActiveKeyset(w.ctx, w.mintClient(mintURL), w.unit)
 	if err != nil {
 		if isNetworkError(err) {
 			return nil, wrapNetworkError(err, "fetching active keyset from mint")
@@ -185,7 +345,7 @@ func (w *Wallet) AddMint(mint string) (*walletMint, error) {
 		return nil, err
 	}
 
-	inactiveKeysets, err := GetMintInactiveKeysets(mintURL, w.unit)
+	inactiveKeysets, err := getMintInactiveKeysets(w.ctx, w.mintClient(mintURL), w.unit)
 	if err != nil {
 		if isNetworkError(err) {
 			return nil, wrapNetworkError(err, "fetching inactive keysets from mint")
@@ -249,6 +409,19 @@ func amount(proofs []storage.DBProof) uint64 {
 
 // RequestMint requests a mint quote to the mint for the specified amount
 func (w *Wallet) RequestMint(amount uint64, mint string) (*nut04.PostMintQuoteBolt11Response, error) {
+	return w.RequestMintWithDescription(amount, mint, "")
+}
+
+// RequestMintWithDescription requests a mint quote with a description
+// that will be included in the invoice
+func (w *Wallet) RequestMintWithDescription(
+	amount uint64,
+	mint, description string,
+) (*nut04.PostMintQuoteBolt11Response, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	selectedMint, ok := w.mints[mint]
 	if !ok {
 		return nil, ErrMintNotExist
@@ -256,22 +429,23 @@ func (w *Wallet) RequestMint(amount uint64, mint string) (*nut04.PostMintQuoteBo
 
 	privateKey, err := secp256k1.GeneratePrivateKey()
 	if err != nil {
-		return nil, fmt.Errorf("could not create key for request: %v", err)
+		return nil, fmt.Errorf("could not create key for request: %w", err)
 	}
 
 	mintRequest := nut04.PostMintQuoteBolt11Request{
-		Amount: amount,
-		Unit:   w.unit.String(),
-		Pubkey: hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
+		Amount:      amount,
+		Unit:        w.unit.String(),
+		Description: description,
+		Pubkey:      hex.EncodeToString(privateKey.PubKey().SerializeCompressed()),
 	}
-	mintResponse, err := client.PostMintQuoteBolt11(selectedMint.mintURL, mintRequest)
+	mintResponse, err := w.mintClient(selectedMint.mintURL).PostMintQuoteBolt11(w.ctx, mintRequest)
 	if err != nil {
 		return nil, err
 	}
 
 	bolt11, err := decodepay.Decodepay(mintResponse.Request)
 	if err != nil {
-		return nil, fmt.Errorf("error decoding bolt11 invoice: %v", err)
+		return nil, fmt.Errorf("error decoding bolt11 invoice: %w", err)
 	}
 
 	quote := storage.MintQuote{
@@ -287,13 +461,17 @@ func (w *Wallet) RequestMint(amount uint64, mint string) (*nut04.PostMintQuoteBo
 		PrivateKey:     privateKey,
 	}
 	if err := w.db.SaveMintQuote(quote); err != nil {
-		return nil, fmt.Errorf("error saving mint quote: %v", err)
+		return nil, fmt.Errorf("error saving mint quote: %w", err)
 	}
 
 	return mintResponse, nil
 }
 
 func (w *Wallet) MintQuoteState(quoteId string) (*nut04.PostMintQuoteBolt11Response, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	quote := w.db.GetMintQuoteById(quoteId)
 	if quote == nil {
 		return nil, ErrQuoteNotFound
@@ -320,7 +498,7 @@ func (w *Wallet) MintQuoteState(quoteId string) (*nut04.PostMintQuoteBolt11Respo
 		}, nil
 	}
 
-	mintQuote, err := client.GetMintQuoteState(mint, quoteId)
+	mintQuote, err := w.mintClient(mint).GetMintQuoteState(w.ctx, quoteId)
 	if err != nil {
 		return nil, err
 	}
@@ -330,7 +508,7 @@ func (w *Wallet) MintQuoteState(quoteId string) (*nut04.PostMintQuoteBolt11Respo
 	}
 
 	if err := w.db.SaveMintQuote(*quote); err != nil {
-		return nil, fmt.Errorf("error saving mint quote: %v", err)
+		return nil, fmt.Errorf("error saving mint quote: %w", err)
 	}
 
 	return mintQuote, nil
@@ -342,6 +520,28 @@ func (w *Wallet) MintQuoteState(quoteId string) (*nut04.PostMintQuoteBolt11Respo
 // If successful, it will unblind the signatures to generate proofs
 // and store the proofs in the db.
 func (w *Wallet) MintTokens(quoteId string) (uint64, error) {
+	if w.IsLocked() {
+		return 0, ErrWalletLocked
+	}
+
+	amount, err := w.mintTokens(quoteId)
+	if err != nil {
+		return 0, err
+	}
+
+	quote := w.db.GetMintQuoteById(quoteId)
+	w.addHistoryEntry(storage.HistoryEntry{
+		Type:           storage.MintHistory,
+		Mint:           quote.Mint,
+		Amount:         amount,
+		QuoteId:        quoteId,
+		PaymentRequest: quote.PaymentRequest,
+	})
+	return amount, nil
+}
+
+// mintTokens mints the proofs for a paid quote without recording it in the history
+func (w *Wallet) mintTokens(quoteId string) (uint64, error) {
 	quote := w.db.GetMintQuoteById(quoteId)
 	if quote == nil {
 		return 0, ErrQuoteNotFound
@@ -366,54 +566,84 @@ func (w *Wallet) MintTokens(quoteId string) (uint64, error) {
 
 	activeKeyset, err := w.getActiveKeyset(mint)
 	if err != nil {
-		return 0, fmt.Errorf("error getting active sat keyset: %v", err)
+		return 0, fmt.Errorf("error getting active sat keyset: %w", err)
 	}
 
 	w.mu.Lock()
 	defer w.mu.Unlock()
+
+	var amount uint64
+	err = w.retryOnCounterDesync(mint, activeKeyset.Id, func() error {
+		amount, err = w.mintProofs(quote, mint, activeKeyset)
+		return err
+	})
+	return amount, err
+}
+
+// mintProofs requests the mint to sign outputs derived
+// from the keyset counter for a paid quote and saves the proofs
+func (w *Wallet) mintProofs(quote *storage.MintQuote, mint string, activeKeyset *crypto.WalletKeyset) (uint64, error) {
 	// get counter for keyset
 	counter := w.counterForKeyset(activeKeyset.Id)
 
 	split := w.splitWalletTarget(quote.Amount, mint)
 	blindedMessages, secrets, rs, err := w.createBlindedMessages(split, activeKeyset.Id, &counter)
 	if err != nil {
-		return 0, fmt.Errorf("error creating blinded messages: %v", err)
+		return 0, fmt.Errorf("error creating blinded messages: %w", err)
 	}
 
 	var signature string
 	if quote.PrivateKey != nil {
-		sig, err := nut20.SignMintQuote(quote.PrivateKey, quoteId, blindedMessages)
+		sig, err := nut20.SignMintQuote(quote.PrivateKey, quote.QuoteId, blindedMessages)
 		if err != nil {
-			return 0, fmt.Errorf("could not sign mint quote: %v", err)
+			return 0, fmt.Errorf("could not sign mint quote: %w", err)
 		}
 		signature = hex.EncodeToString(sig.Serialize())
 	}
 
+	operationId, err := w.savePendingOperation(
+		storage.MintOperation,
+		mint,
+		quote.QuoteId,
+		nil,
+		blindedMessages,
+		secrets,
+		rs,
+		activeKeyset.Id,
+		counter,
+	)
+	if err != nil {
+		return 0, err
+	}
+
 	// request mint to sign the blinded messages
 	postMintRequest := nut04.PostMintBolt11Request{
-		Quote:     quoteId,
+		Quote:     quote.QuoteId,
 		Outputs:   blindedMessages,
 		Signature: signature,
 	}
-	mintResponse, err := client.PostMintBolt11(mint, postMintRequest)
+	mintResponse, err := w.mintClient(mint).PostMintBolt11(w.ctx, postMintRequest)
 	if err != nil {
+		if requestRejected(err) {
+			w.deletePendingOperation(operationId)
+		}
 		return 0, err
 	}
 
 	// unblind the signatures from the promises and build the proofs
 	proofs, err := constructProofs(mintResponse.Signatures, blindedMessages, secrets, rs, activeKeyset)
 	if err != nil {
-		return 0, fmt.Errorf("error constructing proofs: %v", err)
+		return 0, fmt.Errorf("error constructing proofs: %w", err)
 	}
 
 	// store proofs in db
 	if err := w.db.SaveProofs(proofs); err != nil {
-		return 0, fmt.Errorf("error storing proofs: %v", err)
+		return 0, fmt.Errorf("error storing proofs: %w", err)
 	}
 
 	// only increase counter if mint was successful
 	if err := w.db.IncrementKeysetCounter(activeKeyset.Id, uint32(len(blindedMessages))); err != nil {
-		return 0, fmt.Errorf("error incrementing keyset counter: %v", err)
+		return 0, fmt.Errorf("error incrementing keyset counter: %w", err)
 	}
 
 	quote.State = nut04.Issued
@@ -421,12 +651,17 @@ func (w *Wallet) MintTokens(quoteId string) (uint64, error) {
 	if err = w.db.SaveMintQuote(*quote); err != nil {
 		return 0, err
 	}
+	w.deletePendingOperation(operationId)
 
 	return proofs.Amount(), nil
 }
 
 // Send will return proofs for the given amount
 func (w *Wallet) Send(amount uint64, mintURL string, includeFees bool) (cashu.Proofs, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	selectedMint, ok := w.mints[mintURL]
 	if !ok {
 		return nil, ErrMintNotExist
@@ -434,20 +669,27 @@ func (w *Wallet) Send(amount uint64, mintURL string, includeFees bool) (cashu.Pr
 
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	balance := w.mintBalance(mintURL)
 	proofsToSend, err := w.getProofsForAmount(amount, &selectedMint, includeFees)
 	if err != nil {
 		return nil, err
 	}
 
 	if err := w.db.AddPendingProofs(proofsToSend); err != nil {
-		return nil, fmt.Errorf("could not save proofs to pending: %v", err)
+		return nil, fmt.Errorf("could not save proofs to pending: %w", err)
 	}
+	w.addSendEntry(mintURL, proofsToSend, balance)
+	w.trackSentToken(mintURL, proofsToSend, "", 0)
 
 	return proofsToSend, nil
 }
 
 // SendWithOptions provides enhanced send functionality with configurable options
 func (w *Wallet) SendWithOptions(amount uint64, mintURL string, options SendOptions) (*SendResult, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	selectedMint, ok := w.mints[mintURL]
 	if !ok {
 		return nil, ErrMintNotExist
@@ -455,6 +697,7 @@ func (w *Wallet) SendWithOptions(amount uint64, mintURL string, options SendOpti
 
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	balance := w.mintBalance(mintURL)
 
 	// Try to get exact amount first
 	proofsToSend, err := w.getProofsForAmountWithOptions(amount, &selectedMint, options)
@@ -463,12 +706,15 @@ func (w *Wallet) SendWithOptions(amount uint64, mintURL string, options SendOpti
 	}
 
 	if err := w.db.AddPendingProofs(proofsToSend); err != nil {
-		return nil, fmt.Errorf("could not save proofs to pending: %v", err)
+		return nil, fmt.Errorf("could not save proofs to pending: %w", err)
 	}
+	w.addSendEntry(mintURL, proofsToSend, balance)
+	sentTokenId := w.trackSentToken(mintURL, proofsToSend, options.Memo, options.ReclaimAfter)
 
 	actualAmount := proofsToSend.Amount()
 	return &SendResult{
 		Proofs:          proofsToSend,
+		SentTokenId:     sentTokenId,
 		RequestedAmount: amount,
 		ActualAmount:    actualAmount,
 		Overpayment:     actualAmount - amount,
@@ -478,6 +724,10 @@ func (w *Wallet) SendWithOptions(amount uint64, mintURL string, options SendOpti
 
 // SendOffline attempts to send the closest amount above the requested amount when exact change isn't available
 func (w *Wallet) SendOffline(amount uint64, mintURL string, maxOverpayment uint64) (*SendResult, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	options := SendOptions{
 		IncludeFees:            true,
 		AllowOverpayment:       true,
@@ -494,15 +744,19 @@ func (w *Wallet) SendToPubkey(
 	tags *nut11.P2PKTags,
 	includeFees bool,
 ) (cashu.Proofs, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	selectedMint, ok := w.mints[mintURL]
 	if !ok {
 		return nil, ErrMintNotExist
 	}
 
 	// check first if mint supports P2PK NUT
-	mintInfo, err := client.GetMintInfo(mintURL)
+	mintInfo, err := w.mintClient(mintURL).GetMintInfo(w.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting info from mint: %v", err)
+		return nil, fmt.Errorf("error getting info from mint: %w", err)
 	}
 	if !mintInfo.Nuts.Nut11.Supported {
 		return nil, errors.New("mint does not support Pay to Public Key")
@@ -524,10 +778,12 @@ func (w *Wallet) SendToPubkey(
 
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	balance := w.mintBalance(mintURL)
 	lockedProofs, err := w.swapToSend(amount, &selectedMint, &p2pkSpendingCondition, includeFees)
 	if err != nil {
 		return nil, err
 	}
+	w.addSendEntry(mintURL, lockedProofs, balance)
 
 	return lockedProofs, nil
 }
@@ -540,15 +796,19 @@ func (w *Wallet) HTLCLockedProofs(
 	tags *nut11.P2PKTags,
 	includeFees bool,
 ) (cashu.Proofs, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	selectedMint, ok := w.mints[mintURL]
 	if !ok {
 		return nil, ErrMintNotExist
 	}
 
 	// check first if mint supports HTLC NUT
-	mintInfo, err := client.GetMintInfo(mintURL)
+	mintInfo, err := w.mintClient(mintURL).GetMintInfo(w.ctx)
 	if err != nil {
-		return nil, fmt.Errorf("error getting info from mint: %v", err)
+		return nil, fmt.Errorf("error getting info from mint: %w", err)
 	}
 	if !mintInfo.Nuts.Nut14.Supported {
 		return nil, errors.New("mint does not support HTLCs")
@@ -556,7 +816,7 @@ func (w *Wallet) HTLCLockedProofs(
 
 	preimageBytes, err := hex.DecodeString(preimage)
 	if err != nil {
-		return nil, fmt.Errorf("invalid preimage: %v", err)
+		return nil, fmt.Errorf("invalid preimage: %w", err)
 	}
 	hashBytes := sha256.Sum256(preimageBytes)
 	hash := hex.EncodeToString(hashBytes[:])
@@ -573,10 +833,12 @@ func (w *Wallet) HTLCLockedProofs(
 
 	w.mu.Lock()
 	defer w.mu.Unlock()
+	balance := w.mintBalance(mintURL)
 	lockedProofs, err := w.swapToSend(amount, &selectedMint, &htlcSpendingCondition, includeFees)
 	if err != nil {
 		return nil, err
 	}
+	w.addSendEntry(mintURL, lockedProofs, balance)
 
 	return lockedProofs, nil
 }
@@ -584,12 +846,16 @@ func (w *Wallet) HTLCLockedProofs(
 // Receives Cashu token. If swap is true, it will swap the funds to the configured default mint.
 // If false, it will add the proofs from the mint and add that mint to the list of trusted mints.
 func (w *Wallet) Receive(token cashu.Token, swapToTrusted bool) (uint64, error) {
+	if w.IsLocked() {
+		return 0, ErrWalletLocked
+	}
+
 	proofsToSwap := token.Proofs()
 	tokenMint := token.Mint()
 
 	keyset, err := w.getActiveKeyset(tokenMint)
 	if err != nil {
-		return 0, fmt.Errorf("could not get active keyset: %v", err)
+		return 0, fmt.Errorf("could not get active keyset: %w", err)
 	}
 
 	// verify DLEQ in proofs if present
@@ -606,25 +872,29 @@ func (w *Wallet) Receive(token cashu.Token, swapToTrusted bool) (uint64, error)
 		}
 		proofsToSwap, err = nut11.AddSignatureToInputs(proofsToSwap, w.privateKey)
 		if err != nil {
-			return 0, fmt.Errorf("error signing inputs: %v", err)
+			return 0, fmt.Errorf("error signing inputs: %w", err)
 		}
 	}
 
+	w.mu.Lock()
+	defer w.mu.Unlock()
+
 	// if mint in token is already the default mint, do not swap to trusted
 	if _, ok := w.mints[tokenMint]; ok && tokenMint == w.defaultMint {
 		swapToTrusted = false
 	}
 
 	if swapToTrusted {
-		inactiveKeysets, err := GetMintInactiveKeysets(tokenMint, w.unit)
+		inactiveKeysets, err := getMintInactiveKeysets(w.ctx, w.mintClient(tokenMint), w.unit)
 		if err != nil {
 			return 0, err
 		}
 		mint := &walletMint{mintURL: tokenMint, activeKeyset: *keyset, inactiveKeysets: inactiveKeysets}
 		amountSwapped, err := w.swapToTrusted(proofsToSwap, mint)
 		if err != nil {
-			return 0, fmt.Errorf("error swapping token to trusted mint: %v", err)
+			return 0, fmt.Errorf("error swapping token to trusted mint: %w", err)
 		}
+		w.addReceiveEntry(token, w.defaultMint, amountSwapped)
 		return amountSwapped, nil
 	} else {
 		// only add mint if not previously trusted
@@ -637,34 +907,29 @@ func (w *Wallet) Receive(token cashu.Token, swapToTrusted bool) (uint64, error)
 			mint = *newMint
 		}
 
-		req, err := w.createSwapRequest(proofsToSwap, &mint)
-		if err != nil {
-			return 0, fmt.Errorf("could not create swap request: %v", err)
-		}
-
-		//if P2PK locked ecash has `SIG_ALL` flag, sign outputs
-		if nut10Secret.Kind == nut10.P2PK && nut11.IsSigAll(nut10Secret) {
-			req.outputs, err = nut11.AddSignatureToOutputs(req.outputs, w.privateKey)
+		var newProofs cashu.Proofs
+		err := w.retryOnCounterDesync(tokenMint, mint.activeKeyset.Id, func() error {
+			req, err := w.createSwapRequest(proofsToSwap, &mint)
 			if err != nil {
-				return 0, fmt.Errorf("error signing outputs: %v", err)
+				return fmt.Errorf("could not create swap request: %w", err)
 			}
-		}
-
-		newProofs, err := swap(tokenMint, req)
-		if err != nil {
-			return 0, fmt.Errorf("could not swap proofs: %v", err)
-		}
 
-		w.mu.Lock()
-		defer w.mu.Unlock()
+			//if P2PK locked ecash has `SIG_ALL` flag, sign inputs and outputs
+			if nut10Secret.Kind == nut10.P2PK && nut11.IsSigAll(nut10Secret) {
+				req.inputs, err = nut11.AddSigAllSignature(req.inputs, req.outputs, "", w.privateKey)
+				if err != nil {
+					return fmt.Errorf("error signing swap: %w", err)
+				}
+			}
 
-		if err = w.db.IncrementKeysetCounter(req.keyset.Id, uint32(len(req.outputs))); err != nil {
-			return 0, fmt.Errorf("error incrementing keyset counter: %v", err)
+			newProofs, err = w.swap(tokenMint, req)
+			return err
+		})
+		if err != nil {
+			return 0, fmt.Errorf("could not swap proofs: %w", err)
 		}
 
-		if err := w.db.SaveProofs(newProofs); err != nil {
-			return 0, fmt.Errorf("error storing proofs: %v", err)
-		}
+		w.addReceiveEntry(token, "", newProofs.Amount())
 		return newProofs.Amount(), nil
 	}
 }
@@ -673,12 +938,16 @@ func (w *Wallet) Receive(token cashu.Token, swapToTrusted bool) (uint64, error)
 // locked ecash. If successful, it will make a swap and store the new proofs.
 // It will add the mint in the token to the list of trusted mints.
 func (w *Wallet) ReceiveHTLC(token cashu.Token, preimage string) (uint64, error) {
+	if w.IsLocked() {
+		return 0, ErrWalletLocked
+	}
+
 	proofs := token.Proofs()
 	tokenMint := token.Mint()
 
 	keyset, err := w.getActiveKeyset(tokenMint)
 	if err != nil {
-		return 0, fmt.Errorf("could not get active keyset: %v", err)
+		return 0, fmt.Errorf("could not get active keyset: %w", err)
 	}
 	// verify DLEQ in proofs if present
 	if !nut12.VerifyProofsDLEQ(proofs, *keyset) {
@@ -691,7 +960,7 @@ func (w *Wallet) ReceiveHTLC(token cashu.Token, preimage string) (uint64, error)
 	if err == nil && nut10Secret.Kind == nut10.HTLC {
 		proofs, err = nut14.AddWitnessHTLC(proofs, nut10Secret, preimage, w.privateKey)
 		if err != nil {
-			return 0, fmt.Errorf("could not add HTLC witness: %v", err)
+			return 0, fmt.Errorf("could not add HTLC witness: %w", err)
 		}
 
 		// only add mint if not previously trusted
@@ -704,32 +973,29 @@ func (w *Wallet) ReceiveHTLC(token cashu.Token, preimage string) (uint64, error)
 			mint = *newMint
 		}
 
-		req, err := w.createSwapRequest(proofs, &mint)
-		if err != nil {
-			return 0, fmt.Errorf("could not create swap request: %v", err)
-		}
-
-		//if `SIG_ALL` flag, sign outputs
-		if nut11.IsSigAll(nut10Secret) {
-			req.outputs, err = nut14.AddWitnessHTLCToOutputs(req.outputs, preimage, w.privateKey)
+		var newProofs cashu.Proofs
+		err := w.retryOnCounterDesync(tokenMint, mint.activeKeyset.Id, func() error {
+			req, err := w.createSwapRequest(proofs, &mint)
 			if err != nil {
-				return 0, fmt.Errorf("could not add HTLC witness to outputs: %v", err)
+				return fmt.Errorf("could not create swap request: %w", err)
 			}
-		}
 
-		newProofs, err := swap(tokenMint, req)
-		if err != nil {
-			return 0, fmt.Errorf("could not swap proofs: %v", err)
-		}
+			//if `SIG_ALL` flag, sign inputs and outputs
+			if nut11.IsSigAll(nut10Secret) {
+				req.inputs, err = nut11.AddSigAllSignature(req.inputs, req.outputs, "", w.privateKey)
+				if err != nil {
+					return fmt.Errorf("error signing swap: %w", err)
+				}
+			}
 
-		err = w.db.IncrementKeysetCounter(req.keyset.Id, uint32(len(req.outputs)))
+			newProofs, err = w.swap(tokenMint, req)
+			return err
+		})
 		if err != nil {
-			return 0, fmt.Errorf("error incrementing keyset counter: %v", err)
+			return 0, fmt.Errorf("could not swap proofs: %w", err)
 		}
 
-		if err := w.db.SaveProofs(newProofs); err != nil {
-			return 0, fmt.Errorf("error storing proofs: %v", err)
-		}
+		w.addReceiveEntry(token, "", newProofs.Amount())
 		return newProofs.Amount(), nil
 	}
 
@@ -752,7 +1018,7 @@ func (w *Wallet) createSwapRequest(proofs cashu.Proofs, mint *walletMint) (swapR
 	split := w.splitWalletTarget(proofs.Amount()-uint64(fees), mint.mintURL)
 	outputs, secrets, rs, err := w.createBlindedMessages(split, mint.activeKeyset.Id, &keysetCounter)
 	if err != nil {
-		return swapRequestPayload{}, fmt.Errorf("createBlindedMessages: %v", err)
+		return swapRequestPayload{}, fmt.Errorf("createBlindedMessages: %w", err)
 	}
 
 	return swapRequestPayload{
@@ -764,13 +1030,34 @@ func (w *Wallet) createSwapRequest(proofs cashu.Proofs, mint *walletMint) (swapR
 	}, nil
 }
 
-func swap(mint string, swapRequest swapRequestPayload) (cashu.Proofs, error) {
+// swap swaps the inputs for new proofs, increments the counter
+// of the keyset by the outputs used and saves the proofs
+func (w *Wallet) swap(mint string, swapRequest swapRequestPayload) (cashu.Proofs, error) {
+	counter := w.counterForKeyset(swapRequest.keyset.Id) + uint32(len(swapRequest.outputs))
+	operationId, err := w.savePendingOperation(
+		storage.SwapOperation,
+		mint,
+		"",
+		swapRequest.inputs,
+		swapRequest.outputs,
+		swapRequest.secrets,
+		swapRequest.rs,
+		swapRequest.keyset.Id,
+		counter,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	request := nut03.PostSwapRequest{
 		Inputs:  swapRequest.inputs,
 		Outputs: swapRequest.outputs,
 	}
-	swapResponse, err := client.PostSwap(mint, request)
+	swapResponse, err := w.mintClient(mint).PostSwap(w.ctx, request)
 	if err != nil {
+		if requestRejected(err) {
+			w.deletePendingOperation(operationId)
+		}
 		return nil, err
 	}
 
@@ -783,8 +1070,17 @@ func swap(mint string, swapRequest swapRequestPayload) (cashu.Proofs, error) {
 		swapRequest.keyset,
 	)
 	if err != nil {
-		return nil, fmt.Errorf("wallet.ConstructProofs: %v", err)
+		return nil, fmt.Errorf("wallet.ConstructProofs: %w", err)
+	}
+
+	err = w.db.IncrementKeysetCounter(swapRequest.keyset.Id, uint32(len(swapRequest.outputs)))
+	if err != nil {
+		return nil, fmt.Errorf("error incrementing keyset counter: %w", err)
+	}
+	if err := w.db.SaveProofs(proofs); err != nil {
+		return nil, fmt.Errorf("error storing proofs: %w", err)
 	}
+	w.deletePendingOperation(operationId)
 
 	return proofs, nil
 }
@@ -792,29 +1088,8 @@ func swap(mint string, swapRequest swapRequestPayload) (cashu.Proofs, error) {
 // swapToTrusted will swap the proofs from mint
 // to the wallet's configured default mint
 func (w *Wallet) swapToTrusted(proofs cashu.Proofs, mint *walletMint) (uint64, error) {
-	proofsToSwap := proofs
-
-	// if proofs are P2PK locked and sig all, add signatures to swap them first and then melt
-	nut10Secret, err := nut10.DeserializeSecret(proofs[0].Secret)
-	if err == nil && nut10Secret.Kind == nut10.P2PK && nut11.IsSigAll(nut10Secret) {
-		req, err := w.createSwapRequest(proofs, mint)
-		if err != nil {
-			return 0, fmt.Errorf("could not create swap request: %v", err)
-		}
-		req.outputs, err = nut11.AddSignatureToOutputs(req.outputs, w.privateKey)
-		if err != nil {
-			return 0, fmt.Errorf("error signing outputs: %v", err)
-		}
-
-		newProofs, err := swap(mint.mintURL, req)
-		if err != nil {
-			return 0, fmt.Errorf("could not swap proofs: %v", err)
-		}
-		proofsToSwap = newProofs
-	}
-
 	defaultMint := w.mints[w.defaultMint]
-	amountSwapped, err := w.swapProofs(proofsToSwap, mint, &defaultMint)
+	amountSwapped, err := w.swapProofs(proofs, mint, &defaultMint)
 	if err != nil {
 		return 0, err
 	}
@@ -824,18 +1099,40 @@ func (w *Wallet) swapToTrusted(proofs cashu.Proofs, mint *walletMint) (uint64, e
 
 // RequestMeltQuote will request a melt quote to the mint for the specified request
 func (w *Wallet) RequestMeltQuote(request, mint string) (*nut05.PostMeltQuoteBolt11Response, error) {
+	return w.requestMeltQuote(request, mint, nil)
+}
+
+// RequestAmountlessMeltQuote will request a melt quote to the mint
+// to pay the amount (in sats) to an invoice that does not have an amount
+func (w *Wallet) RequestAmountlessMeltQuote(request, mint string, amount uint64) (*nut05.PostMeltQuoteBolt11Response, error) {
+	if amount == 0 {
+		return nil, errors.New("amount has to be greater than 0")
+	}
+	options := &nut05.MeltOptions{
+		Amountless: &nut05.AmountlessOption{AmountMsat: amount * 1000},
+	}
+	return w.requestMeltQuote(request, mint, options)
+}
+
+func (w *Wallet) requestMeltQuote(
+	request, mint string,
+	options *nut05.MeltOptions,
+) (*nut05.PostMeltQuoteBolt11Response, error) {
 	_, ok := w.mints[mint]
 	if !ok {
 		return nil, ErrMintNotExist
 	}
 
-	_, err := decodepay.Decodepay(request)
+	bolt11, err := decodepay.Decodepay(request)
 	if err != nil {
-		return nil, fmt.Errorf("invalid invoice: %v", err)
+		return nil, fmt.Errorf("invalid invoice: %w", err)
+	}
+	if bolt11.MSatoshi == 0 && options == nil {
+		return nil, errors.New("invoice has no amount")
 	}
 
-	meltRequest := nut05.PostMeltQuoteBolt11Request{Request: request, Unit: w.unit.String()}
-	meltQuoteResponse, err := client.PostMeltQuoteBolt11(mint, meltRequest)
+	meltRequest := nut05.PostMeltQuoteBolt11Request{Request: request, Unit: w.unit.String(), Options: options}
+	meltQuoteResponse, err := w.mintClient(mint).PostMeltQuoteBolt11(w.ctx, meltRequest)
 	if err != nil {
 		return nil, err
 	}
@@ -853,19 +1150,23 @@ func (w *Wallet) RequestMeltQuote(request, mint string) (*nut05.PostMeltQuoteBol
 		QuoteExpiry:    meltQuoteResponse.Expiry,
 	}
 	if err := w.db.SaveMeltQuote(quote); err != nil {
-		return nil, fmt.Errorf("error saving melt quote: %v", err)
+		return nil, fmt.Errorf("error saving melt quote: %w", err)
 	}
 
 	return meltQuoteResponse, nil
 }
 
 func (w *Wallet) CheckMeltQuoteState(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	quote := w.db.GetMeltQuoteById(quoteId)
 	if quote == nil {
 		return nil, ErrQuoteNotFound
 	}
 
-	quoteStateResponse, err := client.GetMeltQuoteState(quote.Mint, quoteId)
+	quoteStateResponse, err := w.mintClient(quote.Mint).GetMeltQuoteState(w.ctx, quoteId)
 	if err != nil {
 		return nil, err
 	}
@@ -873,6 +1174,7 @@ func (w *Wallet) CheckMeltQuoteState(quoteId string) (*nut05.PostMeltQuoteBolt11
 	if quote.State != nut05.Paid {
 		// if quote was previously not paid and status has changed, update in db
 		if quoteStateResponse.State == nut05.Paid {
+			quote.State = quoteStateResponse.State
 			quote.Preimage = quoteStateResponse.Preimage
 			quote.SettledAt = time.Now().Unix()
 			if err := w.db.SaveMeltQuote(*quote); err != nil {
@@ -881,19 +1183,24 @@ func (w *Wallet) CheckMeltQuoteState(quoteId string) (*nut05.PostMeltQuoteBolt11
 
 			pendingProofs := w.db.GetPendingProofsByQuoteId(quoteId)
 			var keysetId string
+			var pendingAmount uint64
 			if len(pendingProofs) > 0 {
 				keysetId = pendingProofs[0].Id
 			}
+			for _, proof := range pendingProofs {
+				pendingAmount += proof.Amount
+			}
 			if err := w.db.DeletePendingProofsByQuoteId(quoteId); err != nil {
-				return nil, fmt.Errorf("error removing pending proofs: %v", err)
+				return nil, fmt.Errorf("error removing pending proofs: %w", err)
 			}
 			change := len(quoteStateResponse.Change)
 			// increment the counter if there was change from this quote
 			if change > 0 {
 				if err := w.db.IncrementKeysetCounter(keysetId, uint32(change)); err != nil {
-					return nil, fmt.Errorf("error incrementing keyset counter: %v", err)
+					return nil, fmt.Errorf("error incrementing keyset counter: %w", err)
 				}
 			}
+			w.addMeltEntry(quote, feesFromBalance(pendingAmount, 0, quote.Amount))
 		} else if quoteStateResponse.State == nut05.Unpaid {
 			pendingProofs := w.db.GetPendingProofsByQuoteId(quoteId)
 			// if there were any pending proofs tied to this quote, remove them from pending
@@ -913,10 +1220,10 @@ func (w *Wallet) CheckMeltQuoteState(quoteId string) (*nut05.PostMeltQuoteBolt11
 				}
 
 				if err := w.db.DeletePendingProofsByQuoteId(quoteId); err != nil {
-					return nil, fmt.Errorf("error removing pending proofs: %v", err)
+					return nil, fmt.Errorf("error removing pending proofs: %w", err)
 				}
 				if err := w.db.SaveProofs(proofsToSave); err != nil {
-					return nil, fmt.Errorf("error storing proofs: %v", err)
+					return nil, fmt.Errorf("error storing proofs: %w", err)
 				}
 			}
 
@@ -933,6 +1240,10 @@ func (w *Wallet) CheckMeltQuoteState(quoteId string) (*nut05.PostMeltQuoteBolt11
 // Melt will melt proofs by requesting the mint to pay the
 // payment request from the melt quote passed
 func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	quote := w.db.GetMeltQuoteById(quoteId)
 	if quote == nil {
 		return nil, ErrQuoteNotFound
@@ -944,7 +1255,7 @@ func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error
 		// if quote was previously pending, check if state has changed
 		meltState, err := w.CheckMeltQuoteState(quoteId)
 		if err != nil {
-			return nil, fmt.Errorf("error checking state of quote: %v", err)
+			return nil, fmt.Errorf("error checking state of quote: %w", err)
 		}
 
 		if meltState.State == nut05.Pending {
@@ -964,43 +1275,71 @@ func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error
 
 	// set proofs to pending
 	if err := w.db.AddPendingProofsByQuoteId(proofs, quote.QuoteId); err != nil {
-		return nil, fmt.Errorf("error saving pending proofs: %v", err)
+		return nil, fmt.Errorf("error saving pending proofs: %w", err)
 	}
 
 	activeKeyset, err := w.getActiveKeyset(mint.mintURL)
 	if err != nil {
-		return nil, fmt.Errorf("error getting active sat keyset: %v", err)
-	}
-	counter := w.counterForKeyset(activeKeyset.Id)
+		return nil, fmt.Errorf("error getting active sat keyset: %w", err)
+	}
+	var (
+		outputs            cashu.BlindedMessages
+		outputsSecrets     []string
+		outputsRs          []*secp256k1.PrivateKey
+		operationId        string
+		meltBolt11Response *nut05.PostMeltQuoteBolt11Response
+	)
+	err = w.retryOnCounterDesync(mint.mintURL, activeKeyset.Id, func() error {
+		counter := w.counterForKeyset(activeKeyset.Id)
 
-	// NUT-08 include blank outputs in request for overpaid lightning fees
-	numBlankOutputs := calculateBlankOutputs(quote.FeeReserve)
-	split := make([]uint64, numBlankOutputs)
-	outputs, outputsSecrets, outputsRs, err := w.createBlindedMessages(split, activeKeyset.Id, &counter)
-	if err != nil {
-		return nil, fmt.Errorf("error generating blinded messages for change: %v", err)
-	}
+		// NUT-08 include blank outputs in request for overpaid lightning fees
+		numBlankOutputs := calculateBlankOutputs(quote.FeeReserve)
+		split := make([]uint64, numBlankOutputs)
+		outputs, outputsSecrets, outputsRs, err = w.createBlindedMessages(split, activeKeyset.Id, &counter)
+		if err != nil {
+			return fmt.Errorf("error generating blinded messages for change: %w", err)
+		}
+
+		operationId, err = w.savePendingOperation(
+			storage.MeltOperation,
+			mint.mintURL,
+			quote.QuoteId,
+			proofs,
+			outputs,
+			outputsSecrets,
+			outputsRs,
+			activeKeyset.Id,
+			counter,
+		)
+		if err != nil {
+			return err
+		}
 
-	meltBolt11Request := nut05.PostMeltBolt11Request{
-		Quote:   quote.QuoteId,
-		Inputs:  proofs,
-		Outputs: outputs,
-	}
-	meltBolt11Response, err := client.PostMeltBolt11(mint.mintURL, meltBolt11Request)
+		meltBolt11Request := nut05.PostMeltBolt11Request{
+			Quote:   quote.QuoteId,
+			Inputs:  proofs,
+			Outputs: outputs,
+		}
+		meltBolt11Response, err = w.mintClient(mint.mintURL).PostMeltBolt11(w.ctx, meltBolt11Request)
+		if err != nil && requestRejected(err) {
+			w.deletePendingOperation(operationId)
+		}
+		return err
+	})
 	if err != nil {
-		if cashuErr, ok := err.(cashu.Error); ok && cashuErr.Code == cashu.LightningPaymentErrCode {
+		if errors.Is(err, cashu.LightningPaymentErrCode) {
 			// only remove proofs from pending and save them for use
 			// if got specific error that payment failed
 			if err := w.db.SaveProofs(proofs); err != nil {
-				return nil, fmt.Errorf("error storing proofs: %v", err)
+				return nil, fmt.Errorf("error storing proofs: %w", err)
 			}
 			if err := w.db.DeletePendingProofsByQuoteId(quote.QuoteId); err != nil {
-				return nil, fmt.Errorf("error removing pending proofs: %v", err)
+				return nil, fmt.Errorf("error removing pending proofs: %w", err)
 			}
 			return nil, err
 		} else {
 			// for any other errors leave proofs as pending
-			return nil, fmt.Errorf("error doing melt request: %v. Proofs are pending", err)
+			return nil, fmt.Errorf("error doing melt request: %w. Proofs are pending", err)
 		}
 	}
 
@@ -1009,21 +1348,22 @@ func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error
 		// if quote is unpaid, remove proofs from pending and add them
 		// to proofs available
 		if err := w.db.SaveProofs(proofs); err != nil {
-			return nil, fmt.Errorf("error storing proofs: %v", err)
+			return nil, fmt.Errorf("error storing proofs: %w", err)
 		}
 		if err := w.db.DeletePendingProofsByQuoteId(quote.QuoteId); err != nil {
-			return nil, fmt.Errorf("error removing pending proofs: %v", err)
+			return nil, fmt.Errorf("error removing pending proofs: %w", err)
 		}
+		w.deletePendingOperation(operationId)
 	case nut05.Pending:
 		quote.State = nut05.Pending
 		if err := w.db.SaveMeltQuote(*quote); err != nil {
-			return nil, fmt.Errorf("error updating melt quote: %v", err)
+			return nil, fmt.Errorf("error updating melt quote: %w", err)
 		}
 
 	case nut05.Paid:
 		// payment succeeded so remove proofs from pending
 		if err := w.db.DeletePendingProofsByQuoteId(quote.QuoteId); err != nil {
-			return nil, fmt.Errorf("error removing pending proofs: %v", err)
+			return nil, fmt.Errorf("error removing pending proofs: %w", err)
 		}
 
 		quote.Preimage = meltBolt11Response.Preimage
@@ -1033,10 +1373,16 @@ func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error
 			return nil, err
 		}
 
+		// increment keyset counter in db by all the blank outputs, which is the
+		// counter recorded in the pending operation, even if the mint signed fewer
+		if err := w.db.IncrementKeysetCounter(activeKeyset.Id, uint32(len(outputs))); err != nil {
+			return nil, fmt.Errorf("error incrementing keyset counter: %w", err)
+		}
+
+		var changeAmount uint64
 		change := len(meltBolt11Response.Change)
-		// if mint provided blind signtures for any overpaid lightning fees:
-		// - unblind them and save the proofs in the db
-		// - increment keyset counter in db (by the number of blind sigs provided by mint)
+		// if mint provided blind signtures for any overpaid lightning fees,
+		// unblind them and save the proofs in the db
 		if change > 0 {
 			changeProofs, err := constructProofs(
 				meltBolt11Response.Change,
@@ -1046,15 +1392,15 @@ func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error
 				activeKeyset,
 			)
 			if err != nil {
-				return nil, fmt.Errorf("error unblinding signature from change: %v", err)
+				return nil, fmt.Errorf("error unblinding signature from change: %w", err)
 			}
 			if err := w.db.SaveProofs(changeProofs); err != nil {
-				return nil, fmt.Errorf("error storing change proofs: %v", err)
-			}
-			if err := w.db.IncrementKeysetCounter(activeKeyset.Id, uint32(change)); err != nil {
-				return nil, fmt.Errorf("error incrementing keyset counter: %v", err)
+				return nil, fmt.Errorf("error storing change proofs: %w", err)
 			}
+			changeAmount = changeProofs.Amount()
 		}
+		w.deletePendingOperation(operationId)
+		w.addMeltEntry(quote, feesFromBalance(proofs.Amount(), changeAmount, quote.Amount))
 	}
 	return meltBolt11Response, err
 }
@@ -1062,6 +1408,10 @@ func (w *Wallet) Melt(quoteId string) (*nut05.PostMeltQuoteBolt11Response, error
 // MultiMintPayment tries an MPP according to NUT-15. The split is a map where the
 // key is the mint and the uint64 is the amount in msat.
 func (w *Wallet) MultiMintPayment(request string, split map[string]uint64) ([]nut05.PostMeltQuoteBolt11Response, error) {
+	if w.IsLocked() {
+		return nil, ErrWalletLocked
+	}
+
 	splitLen := len(split)
 	if splitLen < 2 {
 		return nil, nut15.ErrSplitTooShort
@@ -1069,7 +1419,7 @@ func (w *Wallet) MultiMintPayment(request string, split map[string]uint64) ([]nu
 
 	bolt11, err := decodepay.Decodepay(request)
 	if err != nil {
-		return nil, fmt.Errorf("invalid invoice: %v", err)
+		return nil, fmt.Errorf("invalid invoice: %w", err)
 	}
 
 	balanceByMint := w.GetBalanceByMints()
@@ -1084,11 +1434,14 @@ func (w *Wallet) MultiMintPayment(request string, split map[string]uint64) ([]nu
 			return nil, ErrMintNotExist
 		}
 
-		supported, err := nut15.IsMppSupported(mint, w.unit)
+		mintInfo, err := w.mintClient(mint).GetMintInfo(w.ctx)
 		if err != nil {
-			return nil, err
+			if isNetworkError(err) {
+				return nil, wrapNetworkError(err, "getting info from mint")
+			}
+			return nil, fmt.Errorf("error getting info from mint: %w", err)
 		}
-		if !supported {
+		if !nut15.IsMppSupported(*mintInfo, w.unit) {
 			return nil, fmt.Errorf("mint '%v' does not support multimint payments", mint)
 		}
 
@@ -1121,9 +1474,9 @@ func (w *Wallet) MultiMintPayment(request string, split map[string]uint64) ([]nu
 				meltRequest := nut05.PostMeltQuoteBolt11Request{
 					Request: invoice,
 					Unit:    w.unit.String(),
-					Options: map[string]nut05.MppOption{"mpp": {AmountMsat: amount}},
+					Options: &nut05.MeltOptions{Mpp: &nut05.MppOption{AmountMsat: amount}},
 				}
-				meltQuoteResponse, err := client.PostMeltQuoteBolt11(mint, meltRequest)
+				meltQuoteResponse, err := w.mintClient(mint).PostMeltQuoteBolt11(w.ctx, meltRequest)
 				if err != nil {
 					results[j] = result{response: nil, err: err}
 					return
@@ -1142,7 +1495,7 @@ func (w *Wallet) MultiMintPayment(request string, split map[string]uint64) ([]nu
 					QuoteExpiry:    meltQuoteResponse.Expiry,
 				}
 				if err := w.db.SaveMeltQuote(quote); err != nil {
-					results[j] = result{response: nil, err: fmt.Errorf("unable to save melt quote: %v", err)}
+					results[j] = result{response: nil, err: fmt.Errorf("unable to save melt quote: %w", err)}
 					return
 				}
 				results[j] = result{response: meltQuoteResponse, err: nil}
@@ -1186,6 +1539,10 @@ func (w *Wallet) MultiMintPayment(request string, split map[string]uint64) ([]nu
 
 // MintSwap will swap the amount from to the specified mint
 func (w *Wallet) MintSwap(amount uint64, from, to string) (uint64, error) {
+	if w.IsLocked() {
+		return 0, ErrWalletLocked
+	}
+
 	// check both mints are in list of trusted mints
 	fromMint, fromOk := w.mints[from]
 	toMint, toOk := w.mints[to]
@@ -1198,6 +1555,7 @@ func (w *Wallet) MintSwap(amount uint64, from, to string) (uint64, error) {
 		return 0, ErrInsufficientMintBalance
 	}
 
+	balance := balanceByMints[from]
 	proofsToSwap, err := w.getProofsForAmount(amount, &fromMint, true)
 	if err != nil {
 		return 0, err
@@ -1207,6 +1565,13 @@ func (w *Wallet) MintSwap(amount uint64, from, to string) (uint64, error) {
 	if err != nil {
 		return 0, err
 	}
+	w.addHistoryEntry(storage.HistoryEntry{
+		Type:   storage.SwapHistory,
+		Mint:   from,
+		ToMint: to,
+		Amount: amountSwapped,
+		Fee:    feesFromBalance(balance, w.mintBalance(from), amountSwapped),
+	})
 
 	return amountSwapped, nil
 }
@@ -1226,15 +1591,15 @@ func (w *Wallet) swapProofs(proofs cashu.Proofs, from, to *walletMint) (uint64,
 		var err error
 		mintResponse, err = w.RequestMint(mintAmountRequest, to.mintURL)
 		if err != nil {
-			return 0, fmt.Errorf("error requesting mint quote: %v", err)
+			return 0, fmt.Errorf("error requesting mint quote: %w", err)
 		}
 
 		// request melt quote from the 'from' mint
 		// this melt will pay the invoice generated from the previous mint quote request
 		meltRequest := nut05.PostMeltQuoteBolt11Request{Request: mintResponse.Request, Unit: cashu.Sat.String()}
-		meltQuoteResponse, err = client.PostMeltQuoteBolt11(from.mintURL, meltRequest)
+		meltQuoteResponse, err = w.mintClient(from.mintURL).PostMeltQuoteBolt11(w.ctx, meltRequest)
 		if err != nil {
-			return 0, fmt.Errorf("error with melt request: %v", err)
+			return 0, fmt.Errorf("error with melt request: %w", err)
 		}
 
 		// if amount in proofs is less than amount asked from mint in melt request,
@@ -1247,19 +1612,28 @@ func (w *Wallet) swapProofs(proofs cashu.Proofs, from, to *walletMint) (uint64,
 		}
 	}
 
+	// if proofs are locked with SIG_ALL flag, sign the melt request
+	if nut11.ProofsSigAll(proofs) {
+		var err error
+		proofs, err = nut11.AddSigAllSignature(proofs, nil, meltQuoteResponse.Quote, w.privateKey)
+		if err != nil {
+			return 0, fmt.Errorf("error signing melt request: %w", err)
+		}
+	}
+
 	// request from mint to pay invoice from the mint quote request
 	meltBolt11Request := nut05.PostMeltBolt11Request{Quote: meltQuoteResponse.Quote, Inputs: proofs}
-	meltBolt11Response, err := client.PostMeltBolt11(from.mintURL, meltBolt11Request)
+	meltBolt11Response, err := w.mintClient(from.mintURL).PostMeltBolt11(w.ctx, meltBolt11Request)
 	if err != nil {
-		return 0, fmt.Errorf("error melting token: %v", err)
+		return 0, fmt.Errorf("error melting token: %w", err)
 	}
 
 	// if melt request was successful and invoice got paid,
 	// make mint request to get valid proofs
 	if meltBolt11Response.State == nut05.Paid {
-		mintedAmount, err := w.MintTokens(mintResponse.Quote)
+		mintedAmount, err := w.mintTokens(mintResponse.Quote)
 		if err != nil {
-			return 0, fmt.Errorf("error minting tokens: %v", err)
+			return 0, fmt.Errorf("error minting tokens: %w", err)
 		}
 		return mintedAmount, nil
 	} else {
@@ -1425,9 +1799,24 @@ func (w *Wallet) swapToSend(
 ) (cashu.Proofs, error) {
 	activeSatKeyset, err := w.getActiveKeyset(mint.mintURL)
 	if err != nil {
-		return nil, fmt.Errorf("error getting active sat keyset: %v", err)
+		return nil, fmt.Errorf("error getting active sat keyset: %w", err)
 	}
 
+	var proofsToSend cashu.Proofs
+	err = w.retryOnCounterDesync(mint.mintURL, activeSatKeyset.Id, func() error {
+		proofsToSend, err = w.swapProofsToSend(amount, mint, activeSatKeyset, spendingCondition, includeFees)
+		return err
+	})
+	return proofsToSend, err
+}
+
+func (w *Wallet) swapProofsToSend(
+	amount uint64,
+	mint *walletMint,
+	activeSatKeyset *crypto.WalletKeyset,
+	spendingCondition *nut10.SpendingCondition,
+	includeFees bool,
+) (cashu.Proofs, error) {
 	splitForSendAmount := cashu.AmountSplit(amount)
 	var feesToReceive uint = 0
 	if includeFees {
@@ -1485,10 +1874,28 @@ func (w *Wallet) swapToSend(
 
 	cashu.SortBlindedMessages(blindedMessages, secrets, rs)
 
+	operationId, err := w.savePendingOperation(
+		storage.SwapOperation,
+		mint.mintURL,
+		"",
+		proofsToSwap,
+		blindedMessages,
+		secrets,
+		rs,
+		activeSatKeyset.Id,
+		w.counterForKeyset(activeSatKeyset.Id)+incrementCounterBy,
+	)
+	if err != nil {
+		return nil, err
+	}
+
 	// call swap endpoint
 	swapRequest := nut03.PostSwapRequest{Inputs: proofsToSwap, Outputs: blindedMessages}
-	swapResponse, err := client.PostSwap(mint.mintURL, swapRequest)
+	swapResponse, err := w.mintClient(mint.mintURL).PostSwap(w.ctx, swapRequest)
 	if err != nil {
+		if requestRejected(err) {
+			w.deletePendingOperation(operationId)
+		}
 		return nil, err
 	}
 
@@ -1498,7 +1905,7 @@ func (w *Wallet) swapToSend(
 
 	proofsFromSwap, err := constructProofs(swapResponse.Signatures, blindedMessages, secrets, rs, activeSatKeyset)
 	if err != nil {
-		return nil, fmt.Errorf("wallet.ConstructProofs: %v", err)
+		return nil, fmt.Errorf("wallet.ConstructProofs: %w", err)
 	}
 
 	proofsToSend := make(cashu.Proofs, len(send))
@@ -1514,13 +1921,14 @@ func (w *Wallet) swapToSend(
 
 	// remaining proofs are change proofs to save to db
 	if err := w.db.SaveProofs(proofsFromSwap); err != nil {
-		return nil, fmt.Errorf("error storing proofs: %v", err)
+		return nil, fmt.Errorf("error storing proofs: %w", err)
 	}
 
 	err = w.db.IncrementKeysetCounter(activeSatKeyset.Id, incrementCounterBy)
 	if err != nil {
-		return nil, fmt.Errorf("error incrementing keyset counter: %v", err)
+		return nil, fmt.Errorf("error incrementing keyset counter: %w", err)
 	}
+	w.deletePendingOperation(operationId)
 
 	return proofsToSend, nil
 }
@@ -1584,7 +1992,7 @@ func (w *Wallet) getProofsForAmountWithOptions(
 		return selectedProofs, nil
 	} else {
 		wrappedErr := wrapNetworkError(err, "creating exact change (proof swapping)")
-		return nil, fmt.Errorf("cannot create exact change offline. %v\nConsider using SendOffline() to allow overpayment", wrappedErr)
+		return nil, fmt.Errorf("cannot create exact change offline. %w\nConsider using SendOffline() to allow overpayment", wrappedErr)
 	}
 }
 
@@ -1620,7 +2028,7 @@ func (w *Wallet) getProofsForAmount(
 	if err != nil {
 		if isNetworkError(err) {
 			wrappedErr := wrapNetworkError(err, "creating exact change (proof swapping)")
-			return nil, fmt.Errorf("cannot create exact change offline. %v\nConsider using SendOffline() to allow overpayment", wrappedErr)
+			return nil, fmt.Errorf("cannot create exact change offline. %w\nConsider using SendOffline() to allow overpayment", wrappedErr)
 		}
 		return nil, err
 	}
@@ -1930,7 +2338,7 @@ func (w *Wallet) loadWalletMints() (map[string]walletMint, error) {
 			}
 
 			if len(keyset.PublicKeys) == 0 {
-				publicKeys, err := GetKeysetKeys(keyset.MintURL, keyset.Id)
+				publicKeys, err := getKeysetKeys(w.ctx, w.mintClient(keyset.MintURL), keyset.Id)
 				if err != nil {
 					if isNetworkError(err) {
 						// Inform user about network issue and skip this keyset
@@ -1986,7 +2394,7 @@ func (w *Wallet) UpdateMintURL(oldURL, newURL string) error {
 	}
 
 	if err := w.db.UpdateKeysetMintURL(oldURL, newURL); err != nil {
-		return fmt.Errorf("error updating mint URL in database: %v", err)
+		return fmt.Errorf("error updating mint URL in database: %w", err)
 	}
 
 	mint.mintURL = newURL
@@ -2006,8 +2414,11 @@ func (w *Wallet) UpdateMintURL(oldURL, newURL string) error {
 }
 
 // GetReceivePubkey retrieves public key to which
-// the wallet can receive locked ecash
+// the wallet can receive locked ecash. It returns nil if the wallet is locked
 func (w *Wallet) GetReceivePubkey() *btcec.PublicKey {
+	if w.privateKey == nil {
+		return nil
+	}
 	return w.privateKey.PubKey()
 }
 
@@ -2015,6 +2426,12 @@ func (w *Wallet) Mnemonic() string {
 	return w.db.GetMnemonic()
 }
 
+// HasMnemonicPassphrase returns true if the seed of the wallet was derived
+// with a BIP39 passphrase. The mnemonic alone is then not enough to restore it.
+func (w *Wallet) HasMnemonicPassphrase() bool {
+	return w.db.GetSeedScheme() == storage.SeedSchemeBIP39Passphrase
+}
+
 func (w *Wallet) pendingProofsByMint() map[string][]storage.DBProof {
 	proofsByKeysetId := make(map[string][]storage.DBProof)
 	for _, proof := range w.db.GetPendingProofs() {
@@ -2043,6 +2460,10 @@ func (w *Wallet) pendingProofsByMint() map[string][]storage.DBProof {
 // RemoveSpentProofs will check the state of pending proofs
 // and remove the ones in spent state
 func (w *Wallet) RemoveSpentProofs() error {
+	if w.IsLocked() {
+		return ErrWalletLocked
+	}
+
 	pendingProofs := w.pendingProofsByMint()
 
 	for mint, proofs := range pendingProofs {
@@ -2052,7 +2473,7 @@ func (w *Wallet) RemoveSpentProofs() error {
 		}
 
 		proofStateRequest := nut07.PostCheckStateRequest{Ys: Ys}
-		proofStateResponse, err := client.PostCheckProofState(mint, proofStateRequest)
+		proofStateResponse, err := w.mintClient(mint).PostCheckProofState(w.ctx, proofStateRequest)
 		if err != nil {
 			return err
 		}
@@ -2065,7 +2486,10 @@ func (w *Wallet) RemoveSpentProofs() error {
 		}
 
 		if err := w.db.DeletePendingProofs(YsToDelete); err != nil {
-			return fmt.Errorf("error removing pending proofs: %v", err)
+			return fmt.Errorf("error removing pending proofs: %w", err)
+		}
+		if err := w.settleSentTokens(YsToDelete, storage.SentTokenClaimed); err != nil {
+			return err
 		}
 	}
 
@@ -2075,67 +2499,92 @@ func (w *Wallet) RemoveSpentProofs() error {
 // ReclaimUnspentProofs will check the state of pending proofs
 // and try to reclaim proofs that are in a unspent state
 func (w *Wallet) ReclaimUnspentProofs() (uint64, error) {
+	if w.IsLocked() {
+		return 0, ErrWalletLocked
+	}
+
 	pendingProofs := w.pendingProofsByMint()
 
 	var amountReclaimed uint64
 	for mintURL, proofs := range pendingProofs {
-		var Ys []string
-		for _, proof := range proofs {
-			Ys = append(Ys, proof.Y)
-		}
-
-		proofStateRequest := nut07.PostCheckStateRequest{Ys: Ys}
-		proofStateResponse, err := client.PostCheckProofState(mintURL, proofStateRequest)
+		amount, reclaimedYs, _, err := w.reclaimProofs(mintURL, proofs)
 		if err != nil {
 			return 0, err
 		}
+		if err := w.settleSentTokens(reclaimedYs, storage.SentTokenReclaimed); err != nil {
+			return 0, err
+		}
+		amountReclaimed += amount
+	}
 
-		var proofsToReclaim cashu.Proofs
-		var pendingYsToDelete []string
-		for _, state := range proofStateResponse.States {
-			if state.State == nut07.Unspent {
-				for _, proof := range proofs {
-					if proof.Y == state.Y {
-						proofToReclaim := cashu.Proof{
-							Amount: proof.Amount,
-							Id:     proof.Id,
-							Secret: proof.Secret,
-							C:      proof.C,
-						}
-						proofsToReclaim = append(proofsToReclaim, proofToReclaim)
-						pendingYsToDelete = append(pendingYsToDelete, proof.Y)
-						break
+	return amountReclaimed, nil
+}
+
+// reclaimProofs checks the state of the pending proofs from the mint, swaps
+// the ones that are unspent for new proofs and returns the amount reclaimed,
+// the Ys of the pending proofs that were reclaimed and the Ys of the ones that are spent
+func (w *Wallet) reclaimProofs(mintURL string, proofs []storage.DBProof) (uint64, []string, []string, error) {
+	if len(proofs) == 0 {
+		return 0, nil, nil, nil
+	}
+
+	var Ys []string
+	for _, proof := range proofs {
+		Ys = append(Ys, proof.Y)
+	}
+
+	proofStateRequest := nut07.PostCheckStateRequest{Ys: Ys}
+	proofStateResponse, err := w.mintClient(mintURL).PostCheckProofState(w.ctx, proofStateRequest)
+	if err != nil {
+		return 0, nil, nil, err
+	}
+
+	var proofsToReclaim cashu.Proofs
+	var pendingYsToDelete []string
+	var spentYs []string
+	for _, state := range proofStateResponse.States {
+		switch state.State {
+		case nut07.Spent:
+			spentYs = append(spentYs, state.Y)
+		case nut07.Unspent:
+			for _, proof := range proofs {
+				if proof.Y == state.Y {
+					proofToReclaim := cashu.Proof{
+						Amount: proof.Amount,
+						Id:     proof.Id,
+						Secret: proof.Secret,
+						C:      proof.C,
 					}
+					proofsToReclaim = append(proofsToReclaim, proofToReclaim)
+					pendingYsToDelete = append(pendingYsToDelete, proof.Y)
+					break
 				}
 			}
 		}
+	}
 
-		if len(proofsToReclaim) > 0 {
-			mint := w.mints[mintURL]
-			req, err := w.createSwapRequest(proofsToReclaim, &mint)
-			if err != nil {
-				return 0, fmt.Errorf("could not create swap request: %v", err)
-			}
-			newProofs, err := swap(mintURL, req)
-			if err != nil {
-				return 0, fmt.Errorf("could not swap proofs: %v", err)
-			}
-			err = w.db.IncrementKeysetCounter(req.keyset.Id, uint32(len(req.outputs)))
-			if err != nil {
-				return 0, fmt.Errorf("error incrementing keyset counter: %v", err)
-			}
-			if err := w.db.SaveProofs(newProofs); err != nil {
-				return 0, fmt.Errorf("error storing proofs: %v", err)
-			}
-			if err := w.db.DeletePendingProofs(pendingYsToDelete); err != nil {
-				return 0, fmt.Errorf("error removing pending proofs: %v", err)
-			}
+	if len(proofsToReclaim) == 0 {
+		return 0, nil, spentYs, nil
+	}
 
-			amountReclaimed = newProofs.Amount()
+	mint := w.mints[mintURL]
+	var newProofs cashu.Proofs
+	err = w.retryOnCounterDesync(mintURL, mint.activeKeyset.Id, func() error {
+		req, err := w.createSwapRequest(proofsToReclaim, &mint)
+		if err != nil {
+			return fmt.Errorf("could not create swap request: %w", err)
 		}
+		newProofs, err = w.swap(mintURL, req)
+		return err
+	})
+	if err != nil {
+		return 0, nil, nil, fmt.Errorf("could not swap proofs: %w", err)
+	}
+	if err := w.db.DeletePendingProofs(pendingYsToDelete); err != nil {
+		return 0, nil, nil, fmt.Errorf("error removing pending proofs: %w", err)
 	}
 
-	return amountReclaimed, nil
+	return newProofs.Amount(), pendingYsToDelete, spentYs, nil
 }
 
 // GetPendingMeltQuotes return a list of pending quote ids
@@ -2166,7 +2615,7 @@ func (w *Wallet) GetMintQuoteById(id string) *storage.MintQuote {
 func (w *Wallet) GetMintQuoteByPaymentRequest(request string) (*storage.MintQuote, error) {
 	_, err := decodepay.Decodepay(request)
 	if err != nil {
-		return nil, fmt.Errorf("invalid payment request: %v", err)
+		return nil, fmt.Errorf("invalid payment request: %w", err)
 	}
 
 	quotes := w.db.GetMintQuotes()
@@ -23,6 +23,7 @@ import (
 	"github.com/Origami74/gonuts-tollgate/mint/lightning"
 	"github.com/Origami74/gonuts-tollgate/testutils"
 	"github.com/Origami74/gonuts-tollgate/wallet"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
 	"github.com/btcsuite/btcd/btcec/v2"
 	btcdocker "github.com/elnosh/btc-docker-test"
 	"github.com/elnosh/btc-docker-test/lnd"
@@ -431,6 +432,321 @@ func TestMintSwap(t *testing.T) {
 	}
 }
 
+func TestHistory(t *testing.T) {
+	testWalletPath := filepath.Join(".", "/testhistorywallet")
+	testWallet, err := testutils.CreateTestWallet(testWalletPath, mintURL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testWalletPath)
+
+	receiverWalletPath := filepath.Join(".", "/testhistoryreceiverwallet")
+	receiverWallet, err := testutils.CreateTestWallet(receiverWalletPath, mintURL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(receiverWalletPath)
+
+	if err := testutils.FundCashuWallet(ctx, testWallet, nil, 10000); err != nil {
+		t.Fatalf("error funding wallet: %v", err)
+	}
+
+	proofsToSend, err := testWallet.Send(2100, testWallet.CurrentMint(), true)
+	if err != nil {
+		t.Fatalf("unexpected error in send: %v", err)
+	}
+	token, _ := cashu.NewTokenV4(proofsToSend, testWallet.CurrentMint(), cashu.Sat, false)
+	if _, err := receiverWallet.Receive(token, false); err != nil {
+		t.Fatalf("unexpected error receiving: %v", err)
+	}
+
+	bolt11, _, _, _ := lightning.CreateFakeInvoice(1000, false)
+	meltQuote, err := testWallet.RequestMeltQuote(bolt11, testWallet.CurrentMint())
+	if err != nil {
+		t.Fatalf("unexpected error requesting melt quote: %v", err)
+	}
+	if _, err := testWallet.Melt(meltQuote.Quote); err != nil {
+		t.Fatalf("unexpected error in melt: %v", err)
+	}
+
+	history := testWallet.History(storage.HistoryFilter{})
+	expectedTypes := []storage.HistoryType{storage.MeltHistory, storage.SendHistory, storage.MintHistory}
+	if len(history) != len(expectedTypes) {
+		t.Fatalf("expected '%v' history entries but got '%v'", len(expectedTypes), len(history))
+	}
+	for i, entry := range history {
+		if entry.Type != expectedTypes[i] {
+			t.Fatalf("expected entry of type '%v' but got '%v'", expectedTypes[i], entry.Type)
+		}
+	}
+	if history[1].Amount != 2100 || len(history[1].Token) == 0 {
+		t.Fatalf("expected send entry with amount '%v' and token but got '%+v'", 2100, history[1])
+	}
+	if history[0].Amount != 1000 || history[0].QuoteId != meltQuote.Quote {
+		t.Fatalf("unexpected melt entry '%+v'", history[0])
+	}
+
+	page := testWallet.History(storage.HistoryFilter{Offset: 1, Limit: 1})
+	if len(page) != 1 || page[0].Id != history[1].Id {
+		t.Fatal("unexpected history page")
+	}
+	mints := testWallet.History(storage.HistoryFilter{Types: []storage.HistoryType{storage.MintHistory}})
+	if len(mints) != 1 || mints[0].Amount != 10000 {
+		t.Fatalf("expected mint entry with amount '%v'", 10000)
+	}
+
+	received := receiverWallet.History(storage.HistoryFilter{})
+	if len(received) != 1 || received[0].Type != storage.ReceiveHistory || received[0].Amount != 2100 {
+		t.Fatalf("expected receive entry with amount '%v' but got '%+v'", 2100, received)
+	}
+}
+
+func TestSentTokens(t *testing.T) {
+	testWalletPath := filepath.Join(".", "/testsenttokenswallet")
+	testWallet, err := testutils.CreateTestWallet(testWalletPath, mintURL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(testWalletPath)
+
+	receiverWalletPath := filepath.Join(".", "/testsenttokensreceiverwallet")
+	receiverWallet, err := testutils.CreateTestWallet(receiverWalletPath, mintURL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(receiverWalletPath)
+
+	if err := testutils.FundCashuWallet(ctx, testWallet, nil, 10000); err != nil {
+		t.Fatalf("error funding wallet: %v", err)
+	}
+
+	options := wallet.SendOptions{IncludeFees: true, Memo: "claimed"}
+	claimedResult, err := testWallet.SendWithOptions(2100, testWallet.CurrentMint(), options)
+	if err != nil {
+		t.Fatalf("unexpected error in send: %v", err)
+	}
+	options = wallet.SendOptions{IncludeFees: true, Memo: "expired", ReclaimAfter: time.Second}
+	expiredResult, err := testWallet.SendWithOptions(1000, testWallet.CurrentMint(), options)
+	if err != nil {
+		t.Fatalf("unexpected error in send: %v", err)
+	}
+
+	sentTokens, err := testWallet.CheckSentTokens()
+	if err != nil {
+		t.Fatalf("unexpected error checking sent tokens: %v", err)
+	}
+	if len(sentTokens) != 2 {
+		t.Fatalf("expected '%v' sent tokens but got '%v'", 2, len(sentTokens))
+	}
+	for _, sentToken := range sentTokens {
+		if sentToken.State != storage.SentTokenPending {
+			t.Fatalf("expected sent token '%v' to be pending but got '%v'", sentToken.Memo, sentToken.State)
+		}
+	}
+	if sentTokens[0].Id != expiredResult.SentTokenId || sentTokens[0].Amount != 1000 {
+		t.Fatalf("unexpected sent token '%+v'", sentTokens[0])
+	}
+
+	token, _ := cashu.NewTokenV4(claimedResult.Proofs, testWallet.CurrentMint(), cashu.Sat, false)
+	if _, err := receiverWallet.Receive(token, false); err != nil {
+		t.Fatalf("unexpected error receiving: %v", err)
+	}
+
+	balanceBeforeReclaim := testWallet.GetBalance()
+	time.Sleep(time.Millisecond * 1100)
+	sentTokens, err = testWallet.CheckSentTokens()
+	if err != nil {
+		t.Fatalf("unexpected error checking sent tokens: %v", err)
+	}
+	if sentTokens[1].Id != claimedResult.SentTokenId || sentTokens[1].State != storage.SentTokenClaimed {
+		t.Fatalf("expected claimed sent token but got '%+v'", sentTokens[1])
+	}
+	if sentTokens[0].State != storage.SentTokenReclaimed {
+		t.Fatalf("expected reclaimed sent token but got '%+v'", sentTokens[0])
+	}
+	if testWallet.GetBalance() != balanceBeforeReclaim+1000 {
+		t.Fatalf("expected balance of '%v' but got '%v'", balanceBeforeReclaim+1000, testWallet.GetBalance())
+	}
+	if testWallet.PendingBalance() != 0 {
+		t.Fatalf("expected no pending balance but got '%v'", testWallet.PendingBalance())
+	}
+
+	if _, err := testWallet.ReclaimSentToken(claimedResult.SentTokenId); !errors.Is(err, wallet.ErrSentTokenNotPending) {
+		t.Fatalf("expected error '%v' but got '%v'", wallet.ErrSentTokenNotPending, err)
+	}
+
+	// only the proofs not claimed are reclaimed from a partially claimed token
+	options = wallet.SendOptions{IncludeFees: true, Memo: "partially claimed"}
+	partialResult, err := testWallet.SendWithOptions(1000, testWallet.CurrentMint(), options)
+	if err != nil {
+		t.Fatalf("unexpected error in send: %v", err)
+	}
+	claimedProof := partialResult.Proofs[:1]
+	token, _ = cashu.NewTokenV4(claimedProof, testWallet.CurrentMint(), cashu.Sat, false)
+	if _, err := receiverWallet.Receive(token, false); err != nil {
+		t.Fatalf("unexpected error receiving: %v", err)
+	}
+
+	balanceBeforeReclaim = testWallet.GetBalance()
+	amountReclaimed, err := testWallet.ReclaimSentToken(partialResult.SentTokenId)
+	if err != nil {
+		t.Fatalf("unexpected error reclaiming sent token: %v", err)
+	}
+	expectedAmount := partialResult.Proofs.Amount() - claimedProof.Amount()
+	if amountReclaimed != expectedAmount {
+		t.Fatalf("expected to reclaim '%v' but got '%v'", expectedAmount, amountReclaimed)
+	}
+	if testWallet.GetBalance() != balanceBeforeReclaim+expectedAmount {
+		t.Fatalf("expected balance of '%v' but got '%v'", balanceBeforeReclaim+expectedAmount, testWallet.GetBalance())
+	}
+	if testWallet.PendingBalance() != 0 {
+		t.Fatalf("expected no pending balance but got '%v'", testWallet.PendingBalance())
+	}
+	sentTokens = testWallet.SentTokens()
+	if sentTokens[0].Id != partialResult.SentTokenId || sentTokens[0].State != storage.SentTokenPartiallyClaimed {
+		t.Fatalf("expected partially claimed sent token but got '%+v'", sentTokens[0])
+	}
+}
+
+// stoppedDB fails to save proofs to simulate the wallet
+// stopping after a request was sent to the mint
+type stoppedDB struct {
+	storage.WalletDB
+	stopped bool
+}
+
+func (db *stoppedDB) SaveProofs(proofs cashu.Proofs) error {
+	if db.stopped {
+		return errors.New("wallet stopped")
+	}
+	return db.WalletDB.SaveProofs(proofs)
+}
+
+func TestRecoverPendingOperations(t *testing.T) {
+	memoryDB := storage.NewMemoryDB()
+	db := &stoppedDB{WalletDB: memoryDB, stopped: true}
+	config := wallet.Config{CurrentMintURL: mintURL1}
+	testWallet, err := wallet.LoadWalletWithDB(config, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// mint is interrupted before the proofs are saved
+	if err := testutils.FundCashuWallet(ctx, testWallet, nil, 10000); err == nil {
+		t.Fatal("expected error minting with stopped wallet")
+	}
+	if testWallet.GetBalance() != 0 {
+		t.Fatalf("expected balance of '%v' but got '%v'", 0, testWallet.GetBalance())
+	}
+	if len(memoryDB.GetPendingOperations()) != 1 {
+		t.Fatalf("expected '%v' pending operation but got '%v'", 1, len(memoryDB.GetPendingOperations()))
+	}
+
+	testWallet, err = wallet.LoadWalletWithDB(config, memoryDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testWallet.GetBalance() != 10000 {
+		t.Fatalf("expected balance of '%v' after recovery but got '%v'", 10000, testWallet.GetBalance())
+	}
+	if len(memoryDB.GetPendingOperations()) != 0 {
+		t.Fatalf("expected no pending operations but got '%v'", len(memoryDB.GetPendingOperations()))
+	}
+
+	senderWalletPath := filepath.Join(".", "/testrecoversenderwallet")
+	senderWallet, err := testutils.CreateTestWallet(senderWalletPath, mintURL1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(senderWalletPath)
+	if err := testutils.FundCashuWallet(ctx, senderWallet, nil, 10000); err != nil {
+		t.Fatalf("error funding wallet: %v", err)
+	}
+	proofs, err := senderWallet.Send(2100, senderWallet.CurrentMint(), false)
+	if err != nil {
+		t.Fatalf("unexpected error in send: %v", err)
+	}
+	token, _ := cashu.NewTokenV4(proofs, senderWallet.CurrentMint(), cashu.Sat, false)
+
+	// swap of received token is interrupted before the proofs are saved
+	db = &stoppedDB{WalletDB: memoryDB, stopped: true}
+	testWallet, err = wallet.LoadWalletWithDB(config, db)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := testWallet.Receive(token, false); err == nil {
+		t.Fatal("expected error receiving with stopped wallet")
+	}
+
+	testWallet, err = wallet.LoadWalletWithDB(config, memoryDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if testWallet.GetBalance() != 12100 {
+		t.Fatalf("expected balance of '%v' after recovery but got '%v'", 12100, testWallet.GetBalance())
+	}
+	if len(memoryDB.GetPendingOperations()) != 0 {
+		t.Fatalf("expected no pending operations but got '%v'", len(memoryDB.GetPendingOperations()))
+	}
+
+	// recovered proofs can be spent
+	if _, err := testWallet.Send(12100, testWallet.CurrentMint(), false); err != nil {
+		t.Fatalf("unexpected error sending recovered proofs: %v", err)
+	}
+}
+
+func TestKeysetCounterSync(t *testing.T) {
+	memoryDB := storage.NewMemoryDB()
+	config := wallet.Config{CurrentMintURL: mintURL1}
+	testWallet, err := wallet.LoadWalletWithDB(config, memoryDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutils.FundCashuWallet(ctx, testWallet, nil, 10000); err != nil {
+		t.Fatalf("error funding wallet: %v", err)
+	}
+
+	backup, err := memoryDB.Snapshot()
+	if err != nil {
+		t.Fatalf("error taking snapshot: %v", err)
+	}
+	if err := testutils.FundCashuWallet(ctx, testWallet, nil, 2100); err != nil {
+		t.Fatalf("error funding wallet: %v", err)
+	}
+
+	// wallet restored from the backup has its counter behind the mint
+	restoredDB, err := storage.NewMemoryDBFromSnapshot(backup)
+	if err != nil {
+		t.Fatalf("error restoring snapshot: %v", err)
+	}
+	restoredWallet, err := wallet.LoadWalletWithDB(config, restoredDB)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := testutils.FundCashuWallet(ctx, restoredWallet, nil, 1000); err != nil {
+		t.Fatalf("expected mint to succeed after syncing counter but got: %v", err)
+	}
+
+	var keysetId string
+	for _, keyset := range memoryDB.GetKeysets()[mintURL1] {
+		if keyset.Active {
+			keysetId = keyset.Id
+		}
+	}
+	if restoredDB.GetKeysetCounter(keysetId) <= memoryDB.GetKeysetCounter(keysetId) {
+		t.Fatalf("expected counter to be past '%v' but got '%v'",
+			memoryDB.GetKeysetCounter(keysetId), restoredDB.GetKeysetCounter(keysetId))
+	}
+
+	// proofs minted after the backup were recovered
+	restoredProofs := restoredDB.GetProofs()
+	for _, proof := range memoryDB.GetProofs() {
+		if !slices.ContainsFunc(restoredProofs, func(p cashu.Proof) bool { return p.Secret == proof.Secret }) {
+			t.Fatalf("expected proof '%v' to be recovered", proof.Secret)
+		}
+	}
+}
+
 // check balance is correct after certain operations
 func TestWalletBalance(t *testing.T) {
 	testWalletPath := filepath.Join(".", "/testwalletbalance")
@@ -917,7 +1233,7 @@ func testWalletRestore(
 	// delete wallet db to restore
 	os.RemoveAll(filepath.Join(restorePath, "wallet.db"))
 
-	amountRestored, err := wallet.Restore(restorePath, mnemonic, []string{mintURL})
+	amountRestored, err := wallet.Restore(wallet.Config{WalletPath: restorePath}, mnemonic, []string{mintURL})
 	if err != nil {
 		t.Fatalf("error restoring wallet: %v\n", err)
 	}
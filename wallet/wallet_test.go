@@ -5,6 +5,7 @@ package wallet
 import (
 	"crypto/sha256"
 	"encoding/hex"
+	"errors"
 	"math"
 	"os"
 	"reflect"
@@ -12,7 +13,9 @@ import (
 	"testing"
 
 	"github.com/Origami74/gonuts-tollgate/cashu"
+	"github.com/Origami74/gonuts-tollgate/cashu/nuts/nut13"
 	"github.com/Origami74/gonuts-tollgate/crypto"
+	"github.com/Origami74/gonuts-tollgate/wallet/storage"
 	"github.com/btcsuite/btcd/btcec/v2"
 	"github.com/btcsuite/btcd/btcutil/hdkeychain"
 	"github.com/btcsuite/btcd/chaincfg"
@@ -203,7 +206,7 @@ func TestUpdateMintURL(t *testing.T) {
 	}
 	defer os.RemoveAll(dbpath)
 
-	db, err := InitStorage(dbpath)
+	db, err := InitStorage(dbpath, BoltStorage)
 	if err != nil {
 		t.Fatalf("InitStorage: %v", err)
 	}
@@ -259,3 +262,264 @@ func generateWalletKeyset(seed, derivationPath string, active bool, mintURL stri
 		PublicKeys: keys,
 	}
 }
+
+func TestInitStorageMigratesToSQLite(t *testing.T) {
+	dbpath := t.TempDir()
+
+	boltdb, err := InitStorage(dbpath, BoltStorage)
+	if err != nil {
+		t.Fatalf("InitStorage: %v", err)
+	}
+	proofs := cashu.Proofs{{Amount: 1, Id: "keysetid", Secret: "secret", C: "C"}}
+	if err := boltdb.SaveProofs(proofs); err != nil {
+		t.Fatalf("unexpected error saving proofs: %v", err)
+	}
+	boltdb.Close()
+
+	sqlitedb, err := InitStorage(dbpath, SQLiteStorage)
+	if err != nil {
+		t.Fatalf("InitStorage: %v", err)
+	}
+	if !reflect.DeepEqual(sqlitedb.GetProofs(), proofs) {
+		t.Fatalf("expected proofs '%v' in sqlite db but got '%v'", proofs, sqlitedb.GetProofs())
+	}
+	sqlitedb.Close()
+
+	// opening the migrated wallet with bolt would create an empty wallet
+	if _, err := InitStorage(dbpath, BoltStorage); err == nil {
+		t.Fatal("expected error opening migrated wallet with bolt")
+	}
+}
+
+func TestLoadWalletOffline(t *testing.T) {
+	config := Config{
+		WalletPath: t.TempDir(),
+		// no mint running
+		CurrentMintURL: "http://127.0.0.1:1",
+	}
+
+	// mint not known by the wallet
+	wallet, err := LoadWallet(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	if wallet.ctx.Err() != nil {
+		t.Fatal("expected context of wallet loaded offline to not be canceled")
+	}
+	privateKey, _ := secp256k1.GeneratePrivateKey()
+	keyset := crypto.WalletKeyset{
+		Id:         "00a1b2c3d4e5f607",
+		MintURL:    config.CurrentMintURL,
+		Unit:       cashu.Sat.String(),
+		Active:     true,
+		PublicKeys: map[uint64]*secp256k1.PublicKey{1: privateKey.PubKey()},
+	}
+	if err := wallet.db.SaveKeyset(&keyset); err != nil {
+		t.Fatalf("expected db of wallet loaded offline to be open but got error: %v", err)
+	}
+	wallet.Shutdown()
+
+	// mint known by the wallet
+	wallet, err = LoadWallet(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	defer wallet.Shutdown()
+	if wallet.ctx.Err() != nil {
+		t.Fatal("expected context of wallet loaded offline to not be canceled")
+	}
+	if _, ok := wallet.mints[config.CurrentMintURL]; !ok {
+		t.Fatal("expected wallet to load mint from cached keysets")
+	}
+	proofs := cashu.Proofs{{Amount: 1, Id: keyset.Id, Secret: "secret", C: "C"}}
+	if err := wallet.db.SaveProofs(proofs); err != nil {
+		t.Fatalf("expected db of wallet loaded offline to be open but got error: %v", err)
+	}
+}
+
+func TestEncryptWallet(t *testing.T) {
+	config := Config{
+		WalletPath: t.TempDir(),
+		// no mint running, the wallet loads in offline mode
+		CurrentMintURL: "http://127.0.0.1:1",
+	}
+	wallet, err := LoadWallet(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	mnemonic := wallet.Mnemonic()
+	proofs := cashu.Proofs{{Amount: 1, Id: "keysetid", Secret: "secret", C: "C"}}
+	if err := wallet.db.SaveProofs(proofs); err != nil {
+		t.Fatalf("unexpected error saving proofs: %v", err)
+	}
+
+	if err := wallet.Unlock("passphrase"); !errors.Is(err, ErrWalletNotEncrypted) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrWalletNotEncrypted, err)
+	}
+	if err := wallet.ChangePassphrase("", "passphrase"); err != nil {
+		t.Fatalf("unexpected error encrypting wallet: %v", err)
+	}
+	if !IsEncrypted(config.WalletPath) {
+		t.Fatal("expected wallet to be encrypted")
+	}
+	if wallet.Mnemonic() != mnemonic || !reflect.DeepEqual(wallet.db.GetProofs(), proofs) {
+		t.Fatal("expected wallet data to be kept after encrypting it")
+	}
+
+	if err := wallet.Lock(); err != nil {
+		t.Fatalf("unexpected error locking wallet: %v", err)
+	}
+	if !wallet.IsLocked() || wallet.GetReceivePubkey() != nil || wallet.Mnemonic() != "" {
+		t.Fatal("expected wallet to be locked")
+	}
+	if _, err := wallet.Send(1, config.CurrentMintURL, false); !errors.Is(err, ErrWalletLocked) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrWalletLocked, err)
+	}
+	if err := wallet.Unlock("wrong"); !errors.Is(err, storage.ErrInvalidPassphrase) {
+		t.Fatalf("expected error '%v' but got '%v'", storage.ErrInvalidPassphrase, err)
+	}
+	if err := wallet.Unlock("passphrase"); err != nil {
+		t.Fatalf("unexpected error unlocking wallet: %v", err)
+	}
+	if wallet.Mnemonic() != mnemonic || wallet.GetReceivePubkey() == nil {
+		t.Fatal("expected wallet to be unlocked")
+	}
+
+	if err := wallet.ChangePassphrase("wrong", "new passphrase"); !errors.Is(err, storage.ErrInvalidPassphrase) {
+		t.Fatalf("expected error '%v' but got '%v'", storage.ErrInvalidPassphrase, err)
+	}
+	if err := wallet.ChangePassphrase("passphrase", "new passphrase"); err != nil {
+		t.Fatalf("unexpected error changing passphrase: %v", err)
+	}
+	wallet.Shutdown()
+
+	config.EncryptionPassphrase = "passphrase"
+	if _, err := LoadWallet(config); !errors.Is(err, storage.ErrInvalidPassphrase) {
+		t.Fatalf("expected error '%v' but got '%v'", storage.ErrInvalidPassphrase, err)
+	}
+	config.EncryptionPassphrase = "new passphrase"
+	wallet, err = LoadWallet(config)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	defer wallet.Shutdown()
+	if wallet.Mnemonic() != mnemonic || !reflect.DeepEqual(wallet.db.GetProofs(), proofs) {
+		t.Fatal("expected wallet data to be kept after changing passphrase")
+	}
+}
+
+func TestMnemonicPassphrase(t *testing.T) {
+	// NUT-13 test vectors
+	mnemonic := "half depart obvious quality work element tank gorilla view sugar picture humble"
+	keysetId := "009a1f293253e41e"
+	expectedSecrets := []string{
+		"485875df74771877439ac06339e284c3acfcd9be7abf3bc20b516faeadfe77ae",
+		"8f2b39e8e594a4056eb1e6dbb4b0c38ef13b1b2c751f64f810ec04ee35b77270",
+		"bc628c79accd2364fd31511216a0fab62afd4a18ff77a20deded7b858c9860c8",
+		"59284fd1650ea9fa17db2b3acf59ecd0f2d52ec3261dd4152785813ff27a33bf",
+		"576c23393a8b31cc8da6688d9c9a96394ec74b40fdaf1f693a6bb84284334ea0",
+	}
+
+	restoreSecrets := func(passphrase string) (*Wallet, []string) {
+		config := Config{
+			WalletPath:         t.TempDir(),
+			CurrentMintURL:     "http://127.0.0.1:1",
+			MnemonicPassphrase: passphrase,
+		}
+		if _, err := Restore(config, mnemonic, nil); err != nil {
+			t.Fatalf("unexpected error restoring wallet: %v", err)
+		}
+		// passphrase is only used when the seed is created
+		config.MnemonicPassphrase = ""
+		wallet, err := LoadWallet(config)
+		if err != nil {
+			t.Fatalf("unexpected error loading wallet: %v", err)
+		}
+		t.Cleanup(func() { wallet.Shutdown() })
+
+		keysetPath, err := nut13.DeriveKeysetPath(wallet.masterKey, keysetId)
+		if err != nil {
+			t.Fatalf("could not derive keyset path: %v", err)
+		}
+		secrets := make([]string, len(expectedSecrets))
+		for i := range secrets {
+			secret, err := nut13.DeriveSecret(keysetPath, uint32(i))
+			if err != nil {
+				t.Fatalf("error deriving secret: %v", err)
+			}
+			secrets[i] = secret
+		}
+		return wallet, secrets
+	}
+
+	wallet, secrets := restoreSecrets("")
+	if wallet.HasMnemonicPassphrase() {
+		t.Fatal("expected wallet without BIP39 passphrase")
+	}
+	if !reflect.DeepEqual(secrets, expectedSecrets) {
+		t.Fatalf("expected secrets '%v' but got '%v'", expectedSecrets, secrets)
+	}
+
+	wallet, secrets = restoreSecrets("TREZOR")
+	if !wallet.HasMnemonicPassphrase() {
+		t.Fatal("expected wallet with BIP39 passphrase")
+	}
+	if wallet.Mnemonic() != mnemonic {
+		t.Fatalf("expected mnemonic '%v' but got '%v'", mnemonic, wallet.Mnemonic())
+	}
+	for i := range secrets {
+		if secrets[i] == expectedSecrets[i] {
+			t.Fatalf("expected secret %v derived with passphrase to differ from '%v'", i, expectedSecrets[i])
+		}
+	}
+}
+
+func TestLoadWalletWithDB(t *testing.T) {
+	db := storage.NewMemoryDB()
+	config := Config{CurrentMintURL: "http://127.0.0.1:1"}
+	wallet, err := LoadWalletWithDB(config, db)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	if len(db.GetSeed()) == 0 || wallet.GetReceivePubkey() == nil {
+		t.Fatal("expected new seed to be saved in the db")
+	}
+	pubkey := wallet.GetReceivePubkey()
+
+	snapshot, err := db.Snapshot()
+	if err != nil {
+		t.Fatalf("unexpected error taking snapshot: %v", err)
+	}
+	restoredDB, err := storage.NewMemoryDBFromSnapshot(snapshot)
+	if err != nil {
+		t.Fatalf("unexpected error restoring snapshot: %v", err)
+	}
+	wallet, err = LoadWalletWithDB(config, restoredDB)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	if !wallet.GetReceivePubkey().IsEqual(pubkey) {
+		t.Fatal("expected wallet loaded from snapshot to have the same keys")
+	}
+	if err := wallet.ChangePassphrase("", "passphrase"); err == nil {
+		t.Fatal("expected error encrypting storage provided by the caller")
+	}
+
+	encryptedDB := storage.NewEncryptedDB(storage.NewMemoryDB())
+	if _, err := LoadWalletWithDB(config, encryptedDB); !errors.Is(err, ErrWalletLocked) {
+		t.Fatalf("expected error '%v' but got '%v'", ErrWalletLocked, err)
+	}
+
+	// wallet with an unlocked encrypted db can be locked
+	encryptedDB.Unlock(make([]byte, 32))
+	wallet, err = LoadWalletWithDB(config, encryptedDB)
+	if err != nil {
+		t.Fatalf("unexpected error loading wallet: %v", err)
+	}
+	if err := wallet.Lock(); err != nil {
+		t.Fatalf("unexpected error locking wallet: %v", err)
+	}
+	if !wallet.IsLocked() || !encryptedDB.Locked() {
+		t.Fatal("expected wallet and its storage to be locked")
+	}
+}